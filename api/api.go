@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/render"
 	"io/fs"
 	"net/http"
+	"os"
 	"path"
 	"strings"
 
@@ -15,10 +16,13 @@ import (
 	"github.com/frain-dev/convoy/api/handlers"
 	"github.com/frain-dev/convoy/api/policies"
 	"github.com/frain-dev/convoy/api/types"
+	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/log"
 	redisqueue "github.com/frain-dev/convoy/queue/redis"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
@@ -57,6 +61,9 @@ type ApplicationHandler struct {
 	rm     *requestmigrations.RequestMigration
 	A      *types.APIOptions
 	cfg    config.Configuration
+
+	// dispatcher fetches the jwks of source verifiers.
+	dispatcher *net.Dispatcher
 }
 
 func NewApplicationHandler(a *types.APIOptions) (*ApplicationHandler, error) {
@@ -69,6 +76,27 @@ func NewApplicationHandler(a *types.APIOptions) (*ApplicationHandler, error) {
 
 	appHandler.cfg = cfg
 
+	// jwks urls come from source configs, so they are fetched through the
+	// dispatcher to apply the same network rules as event deliveries.
+	lo, ok := a.Logger.(*log.Logger)
+	if !ok {
+		lo = log.NewLogger(os.Stdout)
+	}
+
+	dispatcher, err := net.NewDispatcher(
+		a.Licenser,
+		a.FFlag,
+		net.LoggerOption(lo),
+		net.ProxyOption(cfg.Server.HTTP.HttpProxy),
+		net.AllowListOption(cfg.Dispatcher.AllowList),
+		net.BlockListOption(cfg.Dispatcher.BlockList),
+		net.InsecureSkipVerifyOption(cfg.Dispatcher.InsecureSkipVerify),
+	)
+	if err != nil {
+		return nil, err
+	}
+	appHandler.dispatcher = dispatcher
+
 	az, err := authz.NewAuthz(&authz.AuthzOpts{
 		AuthCtxKey: authz.AuthCtxType(middleware.AuthUserCtx),
 	})
@@ -145,11 +173,12 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 				projectRouter.Post("/", handler.CreateProject)
 
 				projectRouter.Route("/{projectID}", func(projectSubRouter chi.Router) {
-					projectSubRouter.Get("/", handler.GetProject)
+					projectSubRouter.With(handler.RequirePermission(auth.PermissionProjectsRead)).Get("/", handler.GetProject)
 					projectSubRouter.With(handler.RequireEnabledProject()).Put("/", handler.UpdateProject)
 					projectSubRouter.Delete("/", handler.DeleteProject)
 
 					projectSubRouter.Route("/endpoints", func(endpointSubRouter chi.Router) {
+						endpointSubRouter.Use(handler.RequireResourcePermission(auth.PermissionEndpointsRead, auth.PermissionEndpointsWrite))
 						endpointSubRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateEndpoint)
 						endpointSubRouter.With(middleware.Pagination).Get("/", handler.GetEndpoints)
 
@@ -162,37 +191,43 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 							e.With(handler.RequireEnabledProject()).Delete("/", handler.DeleteEndpoint)
 							e.With(handler.RequireEnabledProject()).Put("/expire_secret", handler.ExpireSecret)
 							e.With(handler.RequireEnabledProject()).Put("/pause", handler.PauseEndpoint)
+							e.With(handler.RequireEnabledProject()).Post("/verify", handler.VerifyEndpoint)
+							e.Get("/probes", handler.GetEndpointProbes)
 						})
 					})
 
 					// TODO(subomi): left this here temporarily till the data plane is stable.
 					projectSubRouter.Route("/events", func(eventRouter chi.Router) {
 						eventRouter.Route("/", func(writeEventRouter chi.Router) {
-							eventRouter.With(middleware.Pagination).Get("/", handler.GetEventsPaged)
-							eventRouter.Get("/countbatchreplayevents", handler.CountAffectedEvents)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsRead), middleware.Pagination).Get("/", handler.GetEventsPaged)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsRead)).Get("/countbatchreplayevents", handler.CountAffectedEvents)
 
 							// TODO(all): should the InstrumentPath change?
-							eventRouter.With(handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/", handler.CreateEndpointEvent)
-							eventRouter.With(handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/fanout", handler.CreateEndpointFanoutEvent)
-							eventRouter.With(handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/broadcast", handler.CreateBroadcastEvent)
-							eventRouter.With(handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/dynamic", handler.CreateDynamicEvent)
-							eventRouter.With(handler.RequireEnabledProject()).Post("/batchreplay", handler.BatchReplayEvents)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/", handler.CreateEndpointEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/fanout", handler.CreateEndpointFanoutEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/broadcast", handler.CreateBroadcastEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject(), middleware.InstrumentPath(a.A.Licenser)).Post("/dynamic", handler.CreateDynamicEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry), handler.RequireEnabledProject()).Post("/batchreplay", handler.BatchReplayEvents)
 
 							eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
-								eventSubRouter.With(handler.RequireEnabledProject()).Put("/replay", handler.ReplayEndpointEvent)
-								eventSubRouter.Get("/", handler.GetEndpointEvent)
+								eventSubRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry), handler.RequireEnabledProject()).Put("/replay", handler.ReplayEndpointEvent)
+								eventSubRouter.With(handler.RequirePermission(auth.PermissionEventsRead)).Get("/", handler.GetEndpointEvent)
 							})
 						})
 					})
 
 					projectSubRouter.Route("/event-types", func(eventTypesRouter chi.Router) {
+						eventTypesRouter.Use(handler.RequireResourcePermission(auth.PermissionEventTypesRead, auth.PermissionEventTypesWrite))
 						eventTypesRouter.Get("/", handler.GetEventTypes)
+						eventTypesRouter.Get("/{eventTypeId}/schemas", handler.GetEventTypeSchemas)
+						eventTypesRouter.Get("/export", handler.ExportEventTypes)
 						eventTypesRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateEventType)
 						eventTypesRouter.With(handler.RequireEnabledProject()).Put("/{eventTypeId}", handler.UpdateEventType)
 						eventTypesRouter.With(handler.RequireEnabledProject()).Post("/{eventTypeId}/deprecate", handler.DeprecateEventType)
 					})
 
 					projectSubRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
+						eventDeliveryRouter.Use(handler.RequireResourcePermission(auth.PermissionDeliveriesRead, auth.PermissionDeliveriesRetry))
 						eventDeliveryRouter.With(middleware.Pagination).Get("/", handler.GetEventDeliveriesPaged)
 						eventDeliveryRouter.With(handler.RequireEnabledProject()).Post("/forceresend", handler.ForceResendEventDeliveries)
 						eventDeliveryRouter.With(handler.RequireEnabledProject()).Post("/batchretry", handler.BatchRetryEventDelivery)
@@ -209,6 +244,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 					})
 
 					projectSubRouter.Route("/subscriptions", func(subscriptionRouter chi.Router) {
+						subscriptionRouter.Use(handler.RequireResourcePermission(auth.PermissionSubscriptionsRead, auth.PermissionSubscriptionsWrite))
 						subscriptionRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateSubscription)
 						subscriptionRouter.Post("/test_filter", handler.TestSubscriptionFilter)
 						subscriptionRouter.Post("/test_function", handler.TestSubscriptionFunction)
@@ -220,6 +256,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 					})
 
 					projectSubRouter.Route("/sources", func(sourceRouter chi.Router) {
+						sourceRouter.Use(handler.RequireResourcePermission(auth.PermissionSourcesRead, auth.PermissionSourcesWrite))
 						sourceRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateSource)
 						sourceRouter.Get("/{sourceID}", handler.GetSource)
 						sourceRouter.With(middleware.Pagination).Get("/", handler.LoadSourcesPaged)
@@ -230,6 +267,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 
 					projectSubRouter.Route("/portal-links", func(portalLinkRouter chi.Router) {
 						portalLinkRouter.Use(middleware.RequireValidPortalLinksLicense(handler.A.Licenser))
+						portalLinkRouter.Use(handler.RequireResourcePermission(auth.PermissionPortalLinksRead, auth.PermissionPortalLinksWrite))
 						portalLinkRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreatePortalLink)
 						portalLinkRouter.Get("/{portalLinkID}", handler.GetPortalLink)
 						portalLinkRouter.With(middleware.Pagination).Get("/", handler.LoadPortalLinksPaged)
@@ -238,6 +276,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 					})
 
 					projectSubRouter.Route("/meta-events", func(metaEventRouter chi.Router) {
+						metaEventRouter.Use(handler.RequireResourcePermission(auth.PermissionMetaEventsRead, auth.PermissionDeliveriesRetry))
 						metaEventRouter.With(middleware.Pagination).Get("/", handler.GetMetaEventsPaged)
 
 						metaEventRouter.Route("/{metaEventID}", func(metaEventSubRouter chi.Router) {
@@ -245,11 +284,54 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 							metaEventSubRouter.With(handler.RequireEnabledProject()).Put("/resend", handler.ResendMetaEvent)
 						})
 					})
+
+					projectSubRouter.Route("/dead-letters", func(deadLetterRouter chi.Router) {
+						deadLetterRouter.Use(handler.RequireResourcePermission(auth.PermissionDeadLettersRead, auth.PermissionDeliveriesRetry))
+						deadLetterRouter.With(middleware.Pagination).Get("/", handler.GetDeadLettersPaged)
+						deadLetterRouter.With(handler.RequireEnabledProject()).Post("/redrive", handler.RedriveDeadLetters)
+						deadLetterRouter.Get("/{deadLetterID}", handler.GetDeadLetter)
+					})
+
+					projectSubRouter.Route("/audit-logs", func(auditLogRouter chi.Router) {
+						auditLogRouter.Use(handler.RequirePermission(auth.PermissionAuditLogsRead))
+						auditLogRouter.With(middleware.Pagination).Get("/", handler.GetAuditLogsPaged)
+						auditLogRouter.Get("/export", handler.ExportAuditLogs)
+					})
+
+					projectSubRouter.Route("/scheduled-events", func(scheduledEventRouter chi.Router) {
+						scheduledEventRouter.Use(handler.RequireResourcePermission(auth.PermissionScheduledEventsRead, auth.PermissionScheduledEventsWrite))
+						scheduledEventRouter.With(middleware.Pagination).Get("/", handler.GetScheduledEventsPaged)
+
+						scheduledEventRouter.Route("/{scheduledEventID}", func(scheduledEventSubRouter chi.Router) {
+							scheduledEventSubRouter.Get("/", handler.GetScheduledEvent)
+							scheduledEventSubRouter.With(handler.RequireEnabledProject()).Put("/cancel", handler.CancelScheduledEvent)
+							scheduledEventSubRouter.With(handler.RequireEnabledProject()).Put("/reschedule", handler.RescheduleEvent)
+						})
+					})
 				})
 			})
 		})
 	})
 
+	// SCIM API.
+	if a.A.Cfg.Auth.SCIM.Enabled {
+		router.Route("/scim/v2/organisations/{orgID}", func(scimRouter chi.Router) {
+			scimRouter.Use(middleware.RequireAuth())
+			scimRouter.Use(middleware.RequirePersonalAccessToken())
+
+			scimRouter.Get("/ServiceProviderConfig", handler.GetSCIMServiceProviderConfig)
+
+			scimRouter.Route("/Users", func(userRouter chi.Router) {
+				userRouter.Get("/", handler.GetSCIMUsers)
+				userRouter.Post("/", handler.CreateSCIMUser)
+				userRouter.Get("/{userID}", handler.GetSCIMUser)
+				userRouter.Put("/{userID}", handler.ReplaceSCIMUser)
+				userRouter.Patch("/{userID}", handler.PatchSCIMUser)
+				userRouter.Delete("/{userID}", handler.DeleteSCIMUser)
+			})
+		})
+	}
+
 	// Dashboard API.
 	router.Route("/ui", func(uiRouter chi.Router) {
 		uiRouter.Use(middleware.JsonResponse)
@@ -266,6 +348,8 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 
 		uiRouter.Route("/auth", func(authRouter chi.Router) {
 			authRouter.With(middleware.RequireValidEnterpriseSSOLicense(handler.A.Licenser)).Get("/sso", handler.InitSSO)
+			authRouter.Get("/oidc/login", handler.InitOIDC)
+			authRouter.Get("/oidc/callback", handler.RedeemOIDCCallback)
 			authRouter.Post("/login", handler.LoginUser)
 			authRouter.Post("/register", handler.RegisterUser)
 			authRouter.Post("/token/refresh", handler.RefreshToken)
@@ -317,21 +401,28 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 					})
 				})
 
+				orgSubRouter.Route("/audit-logs", func(auditLogRouter chi.Router) {
+					auditLogRouter.With(middleware.Pagination).Get("/", handler.GetOrganisationAuditLogsPaged)
+					auditLogRouter.Get("/export", handler.ExportOrganisationAuditLogs)
+				})
+
 				orgSubRouter.Route("/projects", func(projectRouter chi.Router) {
 					projectRouter.Get("/", handler.GetProjects)
 					projectRouter.Post("/", handler.CreateProject)
 
 					projectRouter.Route("/{projectID}", func(projectSubRouter chi.Router) {
-						projectSubRouter.Get("/", handler.GetProject)
+						projectSubRouter.With(handler.RequirePermission(auth.PermissionProjectsRead)).Get("/", handler.GetProject)
 						projectSubRouter.With(handler.RequireEnabledProject()).Put("/", handler.UpdateProject)
 						projectSubRouter.With(handler.RequireEnabledProject()).Delete("/", handler.DeleteProject)
-						projectSubRouter.Get("/stats", handler.GetProjectStatistics)
+						projectSubRouter.With(handler.RequirePermission(auth.PermissionProjectsRead)).Get("/stats", handler.GetProjectStatistics)
 
 						projectSubRouter.Route("/security/keys", func(projectKeySubRouter chi.Router) {
 							projectKeySubRouter.With(handler.RequireEnabledProject()).Put("/regenerate", handler.RegenerateProjectAPIKey)
+							projectKeySubRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateProjectAPIKey)
 						})
 
 						projectSubRouter.Route("/endpoints", func(endpointSubRouter chi.Router) {
+							endpointSubRouter.Use(handler.RequireResourcePermission(auth.PermissionEndpointsRead, auth.PermissionEndpointsWrite))
 							endpointSubRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateEndpoint)
 							endpointSubRouter.With(middleware.Pagination).Get("/", handler.GetEndpoints)
 
@@ -344,36 +435,42 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 								e.With(handler.RequireEnabledProject()).Delete("/", handler.DeleteEndpoint)
 								e.With(handler.RequireEnabledProject()).Put("/expire_secret", handler.ExpireSecret)
 								e.With(handler.RequireEnabledProject()).Put("/pause", handler.PauseEndpoint)
+								e.With(handler.RequireEnabledProject()).Post("/verify", handler.VerifyEndpoint)
+								e.Get("/probes", handler.GetEndpointProbes)
 								e.With(handler.RequireEnabledProject()).Post("/activate", handler.ActivateEndpoint)
 							})
 						})
 
 						// TODO(subomi): left this here temporarily till the data plane is stable.
 						projectSubRouter.Route("/events", func(eventRouter chi.Router) {
-							eventRouter.With(middleware.Pagination).Get("/", handler.GetEventsPaged)
-							eventRouter.Get("/countbatchreplayevents", handler.CountAffectedEvents)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsRead), middleware.Pagination).Get("/", handler.GetEventsPaged)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsRead)).Get("/countbatchreplayevents", handler.CountAffectedEvents)
 
 							// TODO(all): should the InstrumentPath change?
-							eventRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateEndpointEvent)
-							eventRouter.With(handler.RequireEnabledProject()).Post("/fanout", handler.CreateEndpointFanoutEvent)
-							eventRouter.With(handler.RequireEnabledProject()).Post("/broadcast", handler.CreateBroadcastEvent)
-							eventRouter.With(handler.RequireEnabledProject()).Post("/dynamic", handler.CreateDynamicEvent)
-							eventRouter.With(handler.RequireEnabledProject()).Post("/batchreplay", handler.BatchReplayEvents)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject()).Post("/", handler.CreateEndpointEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject()).Post("/fanout", handler.CreateEndpointFanoutEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject()).Post("/broadcast", handler.CreateBroadcastEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), handler.RequireEnabledProject()).Post("/dynamic", handler.CreateDynamicEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry), handler.RequireEnabledProject()).Post("/batchreplay", handler.BatchReplayEvents)
 
 							eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
-								eventSubRouter.With(handler.RequireEnabledProject()).Put("/replay", handler.ReplayEndpointEvent)
-								eventSubRouter.Get("/", handler.GetEndpointEvent)
+								eventSubRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry), handler.RequireEnabledProject()).Put("/replay", handler.ReplayEndpointEvent)
+								eventSubRouter.With(handler.RequirePermission(auth.PermissionEventsRead)).Get("/", handler.GetEndpointEvent)
 							})
 						})
 
 						projectSubRouter.Route("/event-types", func(eventTypesRouter chi.Router) {
+							eventTypesRouter.Use(handler.RequireResourcePermission(auth.PermissionEventTypesRead, auth.PermissionEventTypesWrite))
 							eventTypesRouter.Get("/", handler.GetEventTypes)
+							eventTypesRouter.Get("/{eventTypeId}/schemas", handler.GetEventTypeSchemas)
+							eventTypesRouter.Get("/export", handler.ExportEventTypes)
 							eventTypesRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateEventType)
 							eventTypesRouter.With(handler.RequireEnabledProject()).Put("/{eventTypeId}", handler.UpdateEventType)
 							eventTypesRouter.With(handler.RequireEnabledProject()).Post("/{eventTypeId}/deprecate", handler.DeprecateEventType)
 						})
 
 						projectSubRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
+							eventDeliveryRouter.Use(handler.RequireResourcePermission(auth.PermissionDeliveriesRead, auth.PermissionDeliveriesRetry))
 							eventDeliveryRouter.With(middleware.Pagination).Get("/", handler.GetEventDeliveriesPaged)
 							eventDeliveryRouter.With(handler.RequireEnabledProject()).Post("/forceresend", handler.ForceResendEventDeliveries)
 							eventDeliveryRouter.With(handler.RequireEnabledProject()).Post("/batchretry", handler.BatchRetryEventDelivery)
@@ -391,6 +488,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 						})
 
 						projectSubRouter.Route("/subscriptions", func(subscriptionRouter chi.Router) {
+							subscriptionRouter.Use(handler.RequireResourcePermission(auth.PermissionSubscriptionsRead, auth.PermissionSubscriptionsWrite))
 							subscriptionRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateSubscription)
 							subscriptionRouter.Post("/test_filter", handler.TestSubscriptionFilter)
 							subscriptionRouter.Post("/test_function", handler.TestSubscriptionFunction)
@@ -401,6 +499,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 						})
 
 						projectSubRouter.Route("/sources", func(sourceRouter chi.Router) {
+							sourceRouter.Use(handler.RequireResourcePermission(auth.PermissionSourcesRead, auth.PermissionSourcesWrite))
 							sourceRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateSource)
 							sourceRouter.Get("/{sourceID}", handler.GetSource)
 							sourceRouter.With(middleware.Pagination).Get("/", handler.LoadSourcesPaged)
@@ -410,6 +509,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 						})
 
 						projectSubRouter.Route("/meta-events", func(metaEventRouter chi.Router) {
+							metaEventRouter.Use(handler.RequireResourcePermission(auth.PermissionMetaEventsRead, auth.PermissionDeliveriesRetry))
 							metaEventRouter.With(middleware.Pagination).Get("/", handler.GetMetaEventsPaged)
 
 							metaEventRouter.Route("/{metaEventID}", func(metaEventSubRouter chi.Router) {
@@ -418,8 +518,33 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 							})
 						})
 
+						projectSubRouter.Route("/dead-letters", func(deadLetterRouter chi.Router) {
+							deadLetterRouter.Use(handler.RequireResourcePermission(auth.PermissionDeadLettersRead, auth.PermissionDeliveriesRetry))
+							deadLetterRouter.With(middleware.Pagination).Get("/", handler.GetDeadLettersPaged)
+							deadLetterRouter.With(handler.RequireEnabledProject()).Post("/redrive", handler.RedriveDeadLetters)
+							deadLetterRouter.Get("/{deadLetterID}", handler.GetDeadLetter)
+						})
+
+						projectSubRouter.Route("/audit-logs", func(auditLogRouter chi.Router) {
+							auditLogRouter.Use(handler.RequirePermission(auth.PermissionAuditLogsRead))
+							auditLogRouter.With(middleware.Pagination).Get("/", handler.GetAuditLogsPaged)
+							auditLogRouter.Get("/export", handler.ExportAuditLogs)
+						})
+
+						projectSubRouter.Route("/scheduled-events", func(scheduledEventRouter chi.Router) {
+							scheduledEventRouter.Use(handler.RequireResourcePermission(auth.PermissionScheduledEventsRead, auth.PermissionScheduledEventsWrite))
+							scheduledEventRouter.With(middleware.Pagination).Get("/", handler.GetScheduledEventsPaged)
+
+							scheduledEventRouter.Route("/{scheduledEventID}", func(scheduledEventSubRouter chi.Router) {
+								scheduledEventSubRouter.Get("/", handler.GetScheduledEvent)
+								scheduledEventSubRouter.With(handler.RequireEnabledProject()).Put("/cancel", handler.CancelScheduledEvent)
+								scheduledEventSubRouter.With(handler.RequireEnabledProject()).Put("/reschedule", handler.RescheduleEvent)
+							})
+						})
+
 						projectSubRouter.Route("/portal-links", func(portalLinkRouter chi.Router) {
 							portalLinkRouter.Use(middleware.RequireValidPortalLinksLicense(handler.A.Licenser))
+							portalLinkRouter.Use(handler.RequireResourcePermission(auth.PermissionPortalLinksRead, auth.PermissionPortalLinksWrite))
 							portalLinkRouter.Post("/", handler.CreatePortalLink)
 							portalLinkRouter.Get("/{portalLinkID}", handler.GetPortalLink)
 							portalLinkRouter.With(middleware.Pagination).Get("/", handler.LoadPortalLinksPaged)
@@ -428,6 +553,7 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 						})
 
 						projectSubRouter.Route("/dashboard", func(dashboardRouter chi.Router) {
+							dashboardRouter.Use(handler.RequirePermission(auth.PermissionProjectsRead))
 							dashboardRouter.Get("/summary", handler.GetDashboardSummary)
 						})
 					})
@@ -438,6 +564,8 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 		uiRouter.Route("/configuration", func(configRouter chi.Router) {
 			configRouter.Get("/", handler.GetConfiguration)
 			configRouter.Get("/is_signup_enabled", handler.IsSignUpEnabled)
+			configRouter.With(handler.RequireInstanceAdmin()).Get("/key_rotations/latest", handler.GetLatestKeyRotation)
+			configRouter.With(handler.RequireInstanceAdmin()).Get("/key_rotations/{jobID}", handler.GetKeyRotation)
 		})
 	})
 
@@ -459,6 +587,8 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 			endpointRouter.With(handler.CanManageEndpoint()).Put("/{endpointID}", handler.UpdateEndpoint)
 			endpointRouter.With(handler.CanManageEndpoint()).Delete("/{endpointID}", handler.DeleteEndpoint)
 			endpointRouter.With(handler.CanManageEndpoint()).Put("/{endpointID}/pause", handler.PauseEndpoint)
+			endpointRouter.With(handler.CanManageEndpoint()).Post("/{endpointID}/verify", handler.VerifyEndpoint)
+			endpointRouter.Get("/{endpointID}/probes", handler.GetEndpointProbes)
 			endpointRouter.With(handler.CanManageEndpoint()).Put("/{endpointID}/expire_secret", handler.ExpireSecret)
 		})
 
@@ -477,6 +607,8 @@ func (a *ApplicationHandler) BuildControlPlaneRoutes() *chi.Mux {
 
 		portalLinkRouter.Route("/event-types", func(eventTypesRouter chi.Router) {
 			eventTypesRouter.Get("/", handler.GetEventTypes)
+			eventTypesRouter.Get("/{eventTypeId}/schemas", handler.GetEventTypeSchemas)
+			eventTypesRouter.Get("/export", handler.ExportEventTypes)
 			eventTypesRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateEventType)
 			eventTypesRouter.With(handler.RequireEnabledProject()).Put("/{eventTypeId}", handler.UpdateEventType)
 			eventTypesRouter.With(handler.RequireEnabledProject()).Post("/{eventTypeId}/deprecate", handler.DeprecateEventType)
@@ -562,20 +694,21 @@ func (a *ApplicationHandler) BuildDataPlaneRoutes() *chi.Mux {
 				projectRouter.Use(middleware.RateLimiterHandler(a.A.Rate, a.cfg.ApiRateLimit))
 				projectRouter.Route("/{projectID}", func(projectSubRouter chi.Router) {
 					projectSubRouter.Route("/events", func(eventRouter chi.Router) {
-						eventRouter.With(middleware.InstrumentPath(a.A.Licenser)).Post("/", handler.CreateEndpointEvent)
-						eventRouter.With(middleware.InstrumentPath(a.A.Licenser)).Post("/fanout", handler.CreateEndpointFanoutEvent)
-						eventRouter.With(middleware.InstrumentPath(a.A.Licenser)).Post("/broadcast", handler.CreateBroadcastEvent)
-						eventRouter.With(middleware.InstrumentPath(a.A.Licenser)).Post("/dynamic", handler.CreateDynamicEvent)
-						eventRouter.With(middleware.Pagination).Get("/", handler.GetEventsPaged)
-						eventRouter.Post("/batchreplay", handler.BatchReplayEvents)
+						eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), middleware.InstrumentPath(a.A.Licenser)).Post("/", handler.CreateEndpointEvent)
+						eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), middleware.InstrumentPath(a.A.Licenser)).Post("/fanout", handler.CreateEndpointFanoutEvent)
+						eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), middleware.InstrumentPath(a.A.Licenser)).Post("/broadcast", handler.CreateBroadcastEvent)
+						eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite), middleware.InstrumentPath(a.A.Licenser)).Post("/dynamic", handler.CreateDynamicEvent)
+						eventRouter.With(handler.RequirePermission(auth.PermissionEventsRead), middleware.Pagination).Get("/", handler.GetEventsPaged)
+						eventRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry)).Post("/batchreplay", handler.BatchReplayEvents)
 
 						eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
-							eventSubRouter.Get("/", handler.GetEndpointEvent)
-							eventSubRouter.Put("/replay", handler.ReplayEndpointEvent)
+							eventSubRouter.With(handler.RequirePermission(auth.PermissionEventsRead)).Get("/", handler.GetEndpointEvent)
+							eventSubRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry)).Put("/replay", handler.ReplayEndpointEvent)
 						})
 					})
 
 					projectSubRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
+						eventDeliveryRouter.Use(handler.RequireResourcePermission(auth.PermissionDeliveriesRead, auth.PermissionDeliveriesRetry))
 						eventDeliveryRouter.With(middleware.Pagination).Get("/", handler.GetEventDeliveriesPaged)
 						eventDeliveryRouter.Post("/forceresend", handler.ForceResendEventDeliveries)
 						eventDeliveryRouter.Post("/batchretry", handler.BatchRetryEventDelivery)
@@ -604,6 +737,8 @@ func (a *ApplicationHandler) BuildDataPlaneRoutes() *chi.Mux {
 		// What should we do in the future?
 		uiRouter.Route("/auth", func(authRouter chi.Router) {
 			authRouter.With(middleware.RequireValidEnterpriseSSOLicense(handler.A.Licenser)).Get("/sso", handler.InitSSO)
+			authRouter.Get("/oidc/login", handler.InitOIDC)
+			authRouter.Get("/oidc/callback", handler.RedeemOIDCCallback)
 			authRouter.Post("/login", handler.LoginUser)
 			authRouter.Post("/register", handler.RegisterUser)
 			authRouter.Post("/token/refresh", handler.RefreshToken)
@@ -621,19 +756,20 @@ func (a *ApplicationHandler) BuildDataPlaneRoutes() *chi.Mux {
 				orgSubRouter.Route("/projects", func(projectRouter chi.Router) {
 					projectRouter.Route("/{projectID}", func(projectSubRouter chi.Router) {
 						projectSubRouter.Route("/events", func(eventRouter chi.Router) {
-							eventRouter.Post("/", handler.CreateEndpointEvent)
-							eventRouter.Post("/fanout", handler.CreateEndpointFanoutEvent)
-							eventRouter.With(middleware.Pagination).Get("/", handler.GetEventsPaged)
-							eventRouter.Post("/batchreplay", handler.BatchReplayEvents)
-							eventRouter.Get("/countbatchreplayevents", handler.CountAffectedEvents)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite)).Post("/", handler.CreateEndpointEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsWrite)).Post("/fanout", handler.CreateEndpointFanoutEvent)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsRead), middleware.Pagination).Get("/", handler.GetEventsPaged)
+							eventRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry)).Post("/batchreplay", handler.BatchReplayEvents)
+							eventRouter.With(handler.RequirePermission(auth.PermissionEventsRead)).Get("/countbatchreplayevents", handler.CountAffectedEvents)
 
 							eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
-								eventSubRouter.Get("/", handler.GetEndpointEvent)
-								eventSubRouter.Put("/replay", handler.ReplayEndpointEvent)
+								eventSubRouter.With(handler.RequirePermission(auth.PermissionEventsRead)).Get("/", handler.GetEndpointEvent)
+								eventSubRouter.With(handler.RequirePermission(auth.PermissionDeliveriesRetry)).Put("/replay", handler.ReplayEndpointEvent)
 							})
 						})
 
 						projectSubRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
+							eventDeliveryRouter.Use(handler.RequireResourcePermission(auth.PermissionDeliveriesRead, auth.PermissionDeliveriesRetry))
 							eventDeliveryRouter.With(middleware.Pagination).Get("/", handler.GetEventDeliveriesPaged)
 							eventDeliveryRouter.Post("/forceresend", handler.ForceResendEventDeliveries)
 							eventDeliveryRouter.Post("/batchretry", handler.BatchRetryEventDelivery)
@@ -717,24 +853,15 @@ func (a *ApplicationHandler) RegisterPolicy() error {
 		return err
 	}
 
-	err = a.A.Authz.RegisterPolicy(func() authz.Policy {
-		po := &policies.ProjectPolicy{
-			BasePolicy:             authz.NewBasePolicy(),
-			Licenser:               a.A.Licenser,
-			OrganisationRepo:       postgres.NewOrgRepo(a.A.DB),
-			OrganisationMemberRepo: postgres.NewOrgMemberRepo(a.A.DB),
-		}
-
-		po.SetRule("manage", authz.RuleFunc(po.Manage))
-
-		return po
-	}())
+	err = a.A.Authz.RegisterPolicy(policies.NewProjectPolicy(a.A.Licenser, postgres.NewOrgRepo(a.A.DB), postgres.NewOrgMemberRepo(a.A.DB)))
 
 	return err
 }
 
 var guestRoutes = []string{
 	"/auth/sso",
+	"/auth/oidc/login",
+	"/auth/oidc/callback",
 	"/saml/login",
 	"/saml/register",
 	"/auth/login",
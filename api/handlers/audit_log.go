@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/render"
+)
+
+// GetAuditLogsPaged
+//
+//	@Summary		List all audit logs
+//	@Description	This endpoint fetches the audit logs of the management actions taken on a project
+//	@Id				GetAuditLogsPaged
+//	@Tags			Audit Logs
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			request		query		models.QueryListAuditLog	false	"Query Params"
+//	@Success		200			{object}	util.ServerResponse{data=models.PagedResponse{content=[]models.AuditLogResponse}}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/audit-logs [get]
+func (h *Handler) GetAuditLogsPaged(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListAuditLog
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	data.Filter.ProjectID = project.UID
+	h.renderAuditLogs(w, r, project.OrganisationID, data)
+}
+
+// ExportAuditLogs
+//
+//	@Summary		Export audit logs
+//	@Description	This endpoint downloads the audit logs of a project as a json array, oldest first
+//	@Id				ExportAuditLogs
+//	@Tags			Audit Logs
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			request		query		models.QueryListAuditLog	false	"Query Params"
+//	@Success		200			{array}		models.AuditLogResponse
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/audit-logs/export [get]
+func (h *Handler) ExportAuditLogs(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListAuditLog
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	data.Filter.ProjectID = project.UID
+	h.exportAuditLogs(w, r, project.OrganisationID, data.Filter)
+}
+
+func (h *Handler) GetOrganisationAuditLogsPaged(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListAuditLog
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	org, err := h.retrieveOrganisation(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	if err = h.A.Authz.Authorize(r.Context(), "organisation.manage", org); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Unauthorized", http.StatusForbidden))
+		return
+	}
+
+	h.renderAuditLogs(w, r, org.UID, data)
+}
+
+func (h *Handler) ExportOrganisationAuditLogs(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListAuditLog
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	org, err := h.retrieveOrganisation(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	if err = h.A.Authz.Authorize(r.Context(), "organisation.manage", org); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Unauthorized", http.StatusForbidden))
+		return
+	}
+
+	h.exportAuditLogs(w, r, org.UID, data.Filter)
+}
+
+func (h *Handler) renderAuditLogs(w http.ResponseWriter, r *http.Request, orgID string, data *models.QueryListAuditLogResponse) {
+	auditLogs, paginationData, err := postgres.NewAuditLogRepo(h.A.DB).LoadAuditLogsPaged(r.Context(), orgID, data.Filter, data.Pageable)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to fetch audit logs")
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching audit logs", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(auditLogs, func(auditLog datastore.AuditLog) models.AuditLogResponse {
+		return models.AuditLogResponse{AuditLog: &auditLog}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Audit logs fetched successfully",
+		models.PagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
+
+func (h *Handler) exportAuditLogs(w http.ResponseWriter, r *http.Request, orgID string, filter *datastore.AuditLogFilter) {
+	filename := fmt.Sprintf("audit-logs-%s-%s.json", orgID, time.Now().UTC().Format("20060102150405"))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// the response has already started, a failure midway can only be logged
+	_, err := postgres.NewAuditLogRepo(h.A.DB).ExportAuditLogs(r.Context(), orgID, filter, w)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to export audit logs")
+	}
+}
+
+// recordAuditLog records a management action taken by the caller of r, failing
+// to record it doesn't fail the action since it has already been carried out.
+func (h *Handler) recordAuditLog(r *http.Request, auditLog *datastore.AuditLog, before, after interface{}) {
+	actor, err := h.retrieveAuditActor(r)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to find audit log actor")
+		return
+	}
+
+	auditLog.Actor = *actor
+	auditLog.IPAddress = clientIP(r)
+	auditLog.UserAgent = r.UserAgent()
+
+	ra := services.RecordAuditLogService{
+		AuditLogRepo: postgres.NewAuditLogRepo(h.A.DB),
+		AuditLog:     auditLog,
+		Before:       before,
+		After:        after,
+	}
+
+	if err = ra.Run(r.Context()); err != nil {
+		log.FromContext(r.Context()).WithError(err).Errorf("failed to record %s %s audit log", auditLog.ResourceType, auditLog.Action)
+	}
+}
+
+func (h *Handler) retrieveAuditActor(r *http.Request) (*datastore.AuditActor, error) {
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+
+	switch {
+	case h.IsReqWithJWT(authUser), h.IsReqWithPersonalAccessToken(authUser):
+		user, err := h.retrieveUser(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return &datastore.AuditActor{Type: datastore.UserAuditActor, ID: user.UID, Name: user.Email}, nil
+	case h.IsReqWithProjectAPIKey(authUser):
+		apiKey, ok := authUser.APIKey.(*datastore.APIKey)
+		if !ok {
+			return nil, errors.New("invalid auth object")
+		}
+
+		return &datastore.AuditActor{Type: datastore.APIKeyAuditActor, ID: apiKey.UID, Name: apiKey.Name}, nil
+	case h.IsReqWithPortalLinkToken(authUser):
+		pLink, err := h.retrievePortalLinkFromToken(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return &datastore.AuditActor{Type: datastore.PortalLinkAuditActor, ID: pLink.UID, Name: pLink.Name}, nil
+	default:
+		return nil, errors.New("auth: auth object was not recognized")
+	}
+}
+
+// clientIP returns the address of the client that sent r, the first
+// address in X-Forwarded-For is used when the server is behind a proxy.
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(ip)
+	}
+
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
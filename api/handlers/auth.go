@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"github.com/frain-dev/convoy/datastore"
 	"net/http"
 
@@ -111,6 +112,61 @@ func (h *Handler) redeemSSOToken(w http.ResponseWriter, r *http.Request, intent
 	_ = render.Render(w, r, util.NewServerResponse("Login successful", u, http.StatusOK))
 }
 
+func (h *Handler) InitOIDC(w http.ResponseWriter, r *http.Request) {
+	if !h.A.Cfg.Auth.OIDC.Enabled {
+		_ = render.Render(w, r, util.NewErrorResponse("oidc login is not enabled", http.StatusNotFound))
+		return
+	}
+
+	resp, err := h.oidcLoginService().AuthURL(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Get Redirect successful", resp, http.StatusOK))
+}
+
+func (h *Handler) RedeemOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	if !h.A.Cfg.Auth.OIDC.Enabled {
+		_ = render.Render(w, r, util.NewErrorResponse("oidc login is not enabled", http.StatusNotFound))
+		return
+	}
+
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		_ = render.Render(w, r, util.NewErrorResponse(fmt.Sprintf("oidc login failed: %s", e), http.StatusUnauthorized))
+		return
+	}
+
+	user, token, err := h.oidcLoginService().Login(r.Context(), q.Get("code"), q.Get("state"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	u := &models.LoginUserResponse{
+		User:  user,
+		Token: models.Token{AccessToken: token.AccessToken, RefreshToken: token.RefreshToken},
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Login successful", u, http.StatusOK))
+}
+
+func (h *Handler) oidcLoginService() *services.LoginUserOIDCService {
+	configuration := h.A.Cfg
+
+	return &services.LoginUserOIDCService{
+		UserRepo:      postgres.NewUserRepo(h.A.DB),
+		OrgRepo:       postgres.NewOrgRepo(h.A.DB),
+		OrgMemberRepo: postgres.NewOrgMemberRepo(h.A.DB),
+		JWT:           jwt.NewJwt(&configuration.Auth.Jwt, h.A.Cache),
+		Cache:         h.A.Cache,
+		Licenser:      h.A.Licenser,
+		Config:        &configuration.Auth.OIDC,
+	}
+}
+
 func (h *Handler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	var newUser models.LoginUser
 	if err := util.ReadJSON(r, &newUser); err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 )
 
@@ -116,3 +117,39 @@ func (h *Handler) IsSignUpEnabled(w http.ResponseWriter, r *http.Request) {
 
 	_ = render.Render(w, r, util.NewServerResponse("Configuration loaded successfully", cfg.Auth.IsSignupEnabled, http.StatusOK))
 }
+
+// GetLatestKeyRotation returns the progress of the last encryption key rotation.
+func (h *Handler) GetLatestKeyRotation(w http.ResponseWriter, r *http.Request) {
+	job, err := postgres.NewJobRepo(h.A.DB).FetchLatestJobByType(r.Context(), datastore.JobTypeKeyRotation, "")
+	if err != nil {
+		h.renderKeyRotationError(w, r, err)
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Key rotation fetched successfully", models.NewKeyRotationResponse(job), http.StatusOK))
+}
+
+// GetKeyRotation returns the progress of an encryption key rotation.
+func (h *Handler) GetKeyRotation(w http.ResponseWriter, r *http.Request) {
+	job, err := postgres.NewJobRepo(h.A.DB).FetchJobById(r.Context(), chi.URLParam(r, "jobID"), "")
+	if err == nil && job.Type != datastore.JobTypeKeyRotation {
+		err = datastore.ErrJobNotFound
+	}
+
+	if err != nil {
+		h.renderKeyRotationError(w, r, err)
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Key rotation fetched successfully", models.NewKeyRotationResponse(job), http.StatusOK))
+}
+
+func (h *Handler) renderKeyRotationError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, datastore.ErrJobNotFound) {
+		_ = render.Render(w, r, util.NewErrorResponse("key rotation not found", http.StatusNotFound))
+		return
+	}
+
+	log.FromContext(r.Context()).WithError(err).Error("failed to fetch key rotation")
+	_ = render.Render(w, r, util.NewErrorResponse("failed to fetch key rotation", http.StatusBadRequest))
+}
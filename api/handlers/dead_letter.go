@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// GetDeadLettersPaged
+//
+//	@Summary		List all dead letters
+//	@Description	This endpoint fetches event deliveries that exhausted their retries, with the last response their endpoint sent
+//	@Id				GetDeadLettersPaged
+//	@Tags			Dead Letters
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			request		query		models.QueryListDeadLetter	false	"Query Params"
+//	@Success		200			{object}	util.ServerResponse{data=models.PagedResponse{content=[]models.DeadLetterResponse}}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/dead-letters [get]
+func (h *Handler) GetDeadLettersPaged(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListDeadLetter
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	deadLetters, paginationData, err := postgres.NewDeadLetterRepo(h.A.DB).LoadDeadLettersPaged(r.Context(), project.UID, data.Filter, data.Pageable)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching dead letters", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(deadLetters, func(deadLetter datastore.DeadLetter) models.DeadLetterResponse {
+		return models.DeadLetterResponse{DeadLetter: &deadLetter}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Dead letters fetched successfully",
+		models.PagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetDeadLetter
+//
+//	@Summary		Retrieve a dead letter
+//	@Description	This endpoint retrieves a dead letter
+//	@Id				GetDeadLetter
+//	@Tags			Dead Letters
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string	true	"Project ID"
+//	@Param			deadLetterID	path		string	true	"dead letter id"
+//	@Success		200				{object}	util.ServerResponse{data=models.DeadLetterResponse}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/dead-letters/{deadLetterID} [get]
+func (h *Handler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	deadLetter, err := postgres.NewDeadLetterRepo(h.A.DB).FindDeadLetterByID(r.Context(), project.UID, chi.URLParam(r, "deadLetterID"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	resp := &models.DeadLetterResponse{DeadLetter: deadLetter}
+	_ = render.Render(w, r, util.NewServerResponse("Dead letter fetched successfully", resp, http.StatusOK))
+}
+
+// RedriveDeadLetters
+//
+//	@Summary		Redrive dead letters
+//	@Description	This endpoint sends dead-lettered event deliveries again, optionally to a different endpoint
+//	@Id				RedriveDeadLetters
+//	@Tags			Dead Letters
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			redrive		body		models.RedriveDeadLetters	true	"Redrive Details"
+//	@Success		200			{object}	util.ServerResponse{data=models.RedriveDeadLettersResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/dead-letters/redrive [post]
+func (h *Handler) RedriveDeadLetters(w http.ResponseWriter, r *http.Request) {
+	var redrive models.RedriveDeadLetters
+	err := util.ReadJSON(r, &redrive)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = redrive.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	rs := services.RedriveDeadLettersService{
+		DeadLetterRepo:    postgres.NewDeadLetterRepo(h.A.DB),
+		EventDeliveryRepo: postgres.NewEventDeliveryRepo(h.A.DB),
+		EndpointRepo:      postgres.NewEndpointRepo(h.A.DB),
+		Queue:             h.A.Queue,
+		Project:           project,
+		Redrive:           &redrive,
+	}
+
+	resp, err := rs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Dead letters redriven successfully", resp, http.StatusOK))
+}
@@ -23,6 +23,9 @@ import (
 	"github.com/go-chi/render"
 )
 
+// endpointProbesLimit covers the last few hours of five minute health checks.
+const endpointProbesLimit = 50
+
 // CreateEndpoint
 //
 //	@Summary		Create an endpoint
@@ -82,6 +85,7 @@ func (h *Handler) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
 		ProjectRepo:    postgres.NewProjectRepo(h.A.DB),
 		PortalLinkRepo: postgres.NewPortalLinkRepo(h.A.DB),
 		Licenser:       h.A.Licenser,
+		Queue:          h.A.Queue,
 		E:              e,
 		ProjectID:      project.UID,
 	}
@@ -92,7 +96,15 @@ func (h *Handler) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.EndpointAuditResource,
+		ResourceID:     endpoint.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, endpoint)
+
+	resp := models.NewEndpointResponse(endpoint, project)
 	serverResponse := util.NewServerResponse(
 		"Endpoint created successfully",
 		resp, http.StatusCreated)
@@ -140,7 +152,7 @@ func (h *Handler) GetEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	resp := models.NewEndpointResponse(endpoint, project)
 	serverResponse := util.NewServerResponse(
 		"Endpoint fetched successfully", resp, http.StatusOK)
 
@@ -243,7 +255,7 @@ func (h *Handler) GetEndpoints(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := models.NewListResponse(endpoints, func(endpoint datastore.Endpoint) models.EndpointResponse {
-		return models.EndpointResponse{Endpoint: &endpoint}
+		return *models.NewEndpointResponse(&endpoint, project)
 	})
 
 	serverResponse := util.NewServerResponse(
@@ -314,11 +326,15 @@ func (h *Handler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the endpoint is updated in place
+	before := services.AuditSnapshot(endpoint)
+
 	ce := services.UpdateEndpointService{
 		Cache:        h.A.Cache,
 		EndpointRepo: postgres.NewEndpointRepo(h.A.DB),
 		ProjectRepo:  postgres.NewProjectRepo(h.A.DB),
 		Licenser:     h.A.Licenser,
+		Queue:        h.A.Queue,
 		E:            e,
 		Endpoint:     endpoint,
 		Project:      project,
@@ -330,7 +346,15 @@ func (h *Handler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.EndpointAuditResource,
+		ResourceID:     endpoint.UID,
+		Action:         datastore.AuditActionUpdated,
+	}, before, endpoint)
+
+	resp := models.NewEndpointResponse(endpoint, project)
 	serverResponse := util.NewServerResponse("Endpoint updated successfully", resp, http.StatusAccepted)
 
 	rb, err := json.Marshal(serverResponse)
@@ -383,6 +407,14 @@ func (h *Handler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.EndpointAuditResource,
+		ResourceID:     endpoint.UID,
+		Action:         datastore.AuditActionDeleted,
+	}, endpoint, nil)
+
 	_ = render.Render(w, r, util.NewServerResponse("Endpoint deleted successfully", nil, http.StatusOK))
 }
 
@@ -422,6 +454,9 @@ func (h *Handler) ExpireSecret(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the endpoint is updated in place
+	before := services.AuditSnapshot(endpoint)
+
 	xs := services.ExpireSecretService{
 		Queuer:       h.A.Queue,
 		Cache:        h.A.Cache,
@@ -438,7 +473,15 @@ func (h *Handler) ExpireSecret(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.EndpointAuditResource,
+		ResourceID:     endpoint.UID,
+		Action:         datastore.AuditActionSecretRolled,
+	}, before, endpoint)
+
+	resp := models.NewEndpointResponse(endpoint, project)
 	_ = render.Render(w, r, util.NewServerResponse("endpoint secret expired successfully",
 		resp, http.StatusOK))
 }
@@ -476,7 +519,21 @@ func (h *Handler) PauseEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	// pausing toggles the status, so the endpoint was in the other one before
+	action, previous := datastore.AuditActionPaused, datastore.ActiveEndpointStatus
+	if endpoint.Status == datastore.ActiveEndpointStatus {
+		action, previous = datastore.AuditActionActivated, datastore.PausedEndpointStatus
+	}
+
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.EndpointAuditResource,
+		ResourceID:     endpoint.UID,
+		Action:         action,
+	}, map[string]interface{}{"status": previous}, map[string]interface{}{"status": endpoint.Status})
+
+	resp := models.NewEndpointResponse(endpoint, project)
 	serverResponse := util.NewServerResponse("endpoint status updated successfully", resp, http.StatusAccepted)
 
 	rb, err := json.Marshal(serverResponse)
@@ -532,6 +589,14 @@ func (h *Handler) ActivateEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.EndpointAuditResource,
+		ResourceID:     endpoint.UID,
+		Action:         datastore.AuditActionActivated,
+	}, map[string]interface{}{"status": datastore.InactiveEndpointStatus}, map[string]interface{}{"status": datastore.ActiveEndpointStatus})
+
 	cbs, err := h.A.Redis.Get(r.Context(), fmt.Sprintf("breaker:%s", endpoint.UID)).Result()
 	if err != nil {
 		h.A.Logger.WithError(err).Error("failed to find circuit breaker")
@@ -551,7 +616,7 @@ func (h *Handler) ActivateEndpoint(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	resp := models.NewEndpointResponse(endpoint, project)
 	serverResponse := util.NewServerResponse("endpoint status successfully activated", resp, http.StatusAccepted)
 
 	rb, err := json.Marshal(serverResponse)
@@ -569,6 +634,99 @@ func (h *Handler) ActivateEndpoint(w http.ResponseWriter, r *http.Request) {
 	util.WriteResponse(w, r, resBytes, http.StatusAccepted)
 }
 
+// VerifyEndpoint
+//
+//	@Summary		Verify endpoint
+//	@Description	Sends the verification challenge again to an endpoint that is awaiting verification
+//	@Id				VerifyEndpoint
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			endpointID	path		string	true	"Endpoint ID"
+//	@Success		202			{object}	util.ServerResponse{data=models.EndpointResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/verify [post]
+func (h *Handler) VerifyEndpoint(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	vs := services.VerifyEndpointService{
+		EndpointRepo: postgres.NewEndpointRepo(h.A.DB),
+		Queue:        h.A.Queue,
+		ProjectID:    project.UID,
+		EndpointId:   chi.URLParam(r, "endpointID"),
+	}
+
+	endpoint, err := vs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := models.NewEndpointResponse(endpoint, project)
+	serverResponse := util.NewServerResponse("endpoint verification queued successfully", resp, http.StatusAccepted)
+
+	rb, err := json.Marshal(serverResponse)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resBytes, err := h.RM.VersionResponse(r, rb, "UpdateEndpoint")
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	util.WriteResponse(w, r, resBytes, http.StatusAccepted)
+}
+
+// GetEndpointProbes
+//
+//	@Summary		List endpoint health probes
+//	@Description	This endpoint fetches the results of the most recent health checks sent to an endpoint
+//	@Id				GetEndpointProbes
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			endpointID	path		string	true	"Endpoint ID"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.EndpointProbeResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/probes [get]
+func (h *Handler) GetEndpointProbes(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	endpoint, err := h.retrieveEndpoint(r.Context(), chi.URLParam(r, "endpointID"), project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	probes, err := postgres.NewEndpointProbeRepo(h.A.DB).LoadEndpointProbes(r.Context(), project.UID, endpoint.UID, endpointProbesLimit)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching endpoint probes", http.StatusInternalServerError))
+		return
+	}
+
+	resp := make([]models.EndpointProbeResponse, len(probes))
+	for i := range probes {
+		resp[i] = models.EndpointProbeResponse{EndpointProbe: &probes[i]}
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Endpoint probes fetched successfully", resp, http.StatusOK))
+}
+
 func (h *Handler) retrieveEndpoint(ctx context.Context, endpointID, projectID string) (*datastore.Endpoint, error) {
 	endpointRepo := postgres.NewEndpointRepo(h.A.DB)
 	return endpointRepo.FindEndpointByID(ctx, endpointID, projectID)
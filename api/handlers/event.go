@@ -1,14 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
-	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/internal/pkg/dedup"
+	"github.com/frain-dev/convoy/internal/pkg/eventschema"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/pkg/msgpack"
-	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/worker/task"
 	"github.com/oklog/ulid/v2"
 
@@ -26,14 +28,15 @@ import (
 // CreateEndpointEvent
 //
 //	@Summary		Create an event
-//	@Description	This endpoint creates an endpoint event
+//	@Description	This endpoint creates an endpoint event, synchronous events wait for the endpoint to respond and return its response
 //	@Tags			Events
 //	@Id				CreateEndpointEvent
 //	@Accept			json
 //	@Produce		json
 //	@Param			projectID	path		string				true	"Project ID"
 //	@Param			event		body		models.CreateEvent	true	"Event Details"
-//	@Success		201			{object}	util.ServerResponse{data=Stub}
+//	@Success		201			{object}	util.ServerResponse{data=models.ScheduledEventResponse}
+//	@Success		200,202		{object}	util.ServerResponse{data=models.SyncEventResponse}
 //	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
 //	@Security		ApiKeyAuth
 //	@Router			/v1/projects/{projectID}/events [post]
@@ -51,6 +54,17 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	deliverAt, err := newMessage.DeliverTime(time.Now())
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if newMessage.Synchronous && !h.A.Licenser.SynchronousWebhooks() {
+		_ = render.Render(w, r, util.NewErrorResponse("your instance does not have access to synchronous webhooks, upgrade to access this feature", http.StatusBadRequest))
+		return
+	}
+
 	var projectID string
 	authUser := middleware.GetAuthUserFromContext(r.Context())
 	if h.IsReqWithPortalLinkToken(authUser) {
@@ -70,6 +84,18 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	schemaStatus, err := h.checkEventSchema(r, projectID, newMessage.EventType, newMessage.Data)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	isDuplicate, err := h.checkDuplicate(r, projectID, newMessage.IdempotencyKey, newMessage.DedupWindow)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	e := task.CreateEvent{
 		Params: task.CreateEventTaskParams{
 			UID:            ulid.Make().String(),
@@ -80,6 +106,8 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 			CustomHeaders:  newMessage.CustomHeaders,
 			IdempotencyKey: newMessage.IdempotencyKey,
 			AcknowledgedAt: time.Now(),
+			SchemaStatus:   schemaStatus,
+			IsDuplicate:    isDuplicate,
 		},
 		CreateSubscription: !util.IsStringEmpty(newMessage.EndpointID),
 	}
@@ -90,16 +118,57 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobId := fmt.Sprintf("single:%s:%s", e.Params.ProjectID, e.Params.UID)
-	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
-		Delay:   0,
+	scheduledEvent := &datastore.ScheduledEvent{
+		ProjectID: e.Params.ProjectID,
+		EventID:   e.Params.UID,
+		EventType: e.Params.EventType,
+		Kind:      datastore.SingleScheduledEvent,
+		Payload:   eventByte,
+		DeliverAt: deliverAt,
 	}
 
-	err = h.A.Queue.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
+	err = services.QueueEventCreation(r.Context(), h.A.Queue, postgres.NewScheduledEventRepo(h.A.DB), scheduledEvent)
 	if err != nil {
 		log.FromContext(r.Context()).Errorf("Error occurred sending new event to the queue %s", err)
+		h.releaseDuplicateClaim(r, projectID, newMessage.IdempotencyKey, isDuplicate)
+
+		if newMessage.Synchronous {
+			_ = render.Render(w, r, util.NewErrorResponse("failed to queue event", http.StatusBadRequest))
+			return
+		}
+	}
+
+	if err == nil && !deliverAt.IsZero() {
+		_ = render.Render(w, r, util.NewServerResponse("Event scheduled successfully",
+			&models.ScheduledEventResponse{ScheduledEvent: scheduledEvent}, http.StatusCreated))
+		return
+	}
+
+	if newMessage.Synchronous {
+		waiter := services.WaitForDeliveryService{
+			EventDeliveryRepo: postgres.NewEventDeliveryRepo(h.A.DB),
+			AttemptsRepo:      postgres.NewDeliveryAttemptRepo(h.A.DB),
+			ProjectID:         projectID,
+			EventID:           e.Params.UID,
+			Timeout:           newMessage.SyncTimeoutDuration(),
+		}
+
+		eventDelivery, attempt, err := waiter.Run(r.Context())
+		if err != nil {
+			if errors.Is(err, services.ErrSyncDeliveryTimeout) {
+				// the delivery carries on asynchronously
+				_ = render.Render(w, r, util.NewServerResponse("Event queued successfully, the endpoint did not respond in time",
+					models.SyncEventResponse{EventID: e.Params.UID}, http.StatusAccepted))
+				return
+			}
+
+			_ = render.Render(w, r, util.NewServiceErrResponse(err))
+			return
+		}
+
+		_ = render.Render(w, r, util.NewServerResponse("Event sent successfully",
+			models.NewSyncEventResponse(e.Params.UID, eventDelivery, attempt), http.StatusOK))
+		return
 	}
 
 	_ = render.Render(w, r, util.NewServerResponse("Event queued successfully", 200, http.StatusCreated))
@@ -115,7 +184,7 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Param			projectID	path		string					true	"Project ID"
 //	@Param			event		body		models.BroadcastEvent	true	"Broadcast Event Details"
-//	@Success		201			{object}	util.ServerResponse{data=models.EventResponse}
+//	@Success		201			{object}	util.ServerResponse{data=models.ScheduledEventResponse}
 //	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
 //	@Security		ApiKeyAuth
 //	@Router			/v1/projects/{projectID}/events/broadcast [post]
@@ -139,18 +208,38 @@ func (h *Handler) CreateBroadcastEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	newMessage.SchemaStatus, err = h.checkEventSchema(r, project.UID, newMessage.EventType, newMessage.Data)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	newMessage.IsDuplicate, err = h.checkDuplicate(r, project.UID, newMessage.IdempotencyKey, newMessage.DedupWindow)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	cbe := services.CreateBroadcastEventService{
-		Queue:          h.A.Queue,
-		BroadcastEvent: &newMessage,
-		Project:        project,
+		ScheduledEventRepo: postgres.NewScheduledEventRepo(h.A.DB),
+		Queue:              h.A.Queue,
+		BroadcastEvent:     &newMessage,
+		Project:            project,
 	}
 
-	err = cbe.Run(r.Context())
+	scheduledEvent, err := cbe.Run(r.Context())
 	if err != nil {
+		h.releaseDuplicateClaim(r, project.UID, newMessage.IdempotencyKey, newMessage.IsDuplicate)
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
 		return
 	}
 
+	if scheduledEvent != nil {
+		_ = render.Render(w, r, util.NewServerResponse("Broadcast event scheduled successfully",
+			&models.ScheduledEventResponse{ScheduledEvent: scheduledEvent}, http.StatusCreated))
+		return
+	}
+
 	_ = render.Render(w, r, util.NewServerResponse("Broadcast event created successfully", nil, http.StatusCreated))
 }
 
@@ -164,7 +253,7 @@ func (h *Handler) CreateBroadcastEvent(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Param			projectID	path		string				true	"Project ID"
 //	@Param			event		body		models.FanoutEvent	true	"Event Details"
-//	@Success		201			{object}	util.ServerResponse{data=Stub}
+//	@Success		201			{object}	util.ServerResponse{data=models.ScheduledEventResponse}
 //	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
 //	@Security		ApiKeyAuth
 //	@Router			/v1/projects/{projectID}/events/fanout [post]
@@ -188,21 +277,41 @@ func (h *Handler) CreateEndpointFanoutEvent(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	newMessage.SchemaStatus, err = h.checkEventSchema(r, project.UID, newMessage.EventType, newMessage.Data)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	newMessage.IsDuplicate, err = h.checkDuplicate(r, project.UID, newMessage.IdempotencyKey, newMessage.DedupWindow)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	cf := services.CreateFanoutEventService{
-		EndpointRepo:   postgres.NewEndpointRepo(h.A.DB),
-		EventRepo:      postgres.NewEventRepo(h.A.DB),
-		PortalLinkRepo: postgres.NewPortalLinkRepo(h.A.DB),
-		Queue:          h.A.Queue,
-		NewMessage:     &newMessage,
-		Project:        project,
+		EndpointRepo:       postgres.NewEndpointRepo(h.A.DB),
+		EventRepo:          postgres.NewEventRepo(h.A.DB),
+		PortalLinkRepo:     postgres.NewPortalLinkRepo(h.A.DB),
+		ScheduledEventRepo: postgres.NewScheduledEventRepo(h.A.DB),
+		Queue:              h.A.Queue,
+		NewMessage:         &newMessage,
+		Project:            project,
 	}
 
-	event, err := cf.Run(r.Context())
+	event, scheduledEvent, err := cf.Run(r.Context())
 	if err != nil {
+		h.releaseDuplicateClaim(r, project.UID, newMessage.IdempotencyKey, newMessage.IsDuplicate)
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
 		return
 	}
 
+	if scheduledEvent != nil {
+		_ = render.Render(w, r, util.NewServerResponse("Endpoint fanout event scheduled successfully",
+			&models.ScheduledEventResponse{ScheduledEvent: scheduledEvent}, http.StatusCreated))
+		return
+	}
+
 	if event.IsDuplicateEvent {
 		_ = render.Render(w, r, util.NewServerResponse("Duplicate event received, but will not be sent", nil, http.StatusCreated))
 	} else {
@@ -220,7 +329,7 @@ func (h *Handler) CreateEndpointFanoutEvent(w http.ResponseWriter, r *http.Reque
 //	@Produce		json
 //	@Param			projectID	path		string				true	"Project ID"
 //	@Param			event		body		models.DynamicEvent	true	"Event Details"
-//	@Success		201			{object}	Stub
+//	@Success		201			{object}	util.ServerResponse{data=models.ScheduledEventResponse}
 //	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
 //	@Security		ApiKeyAuth
 //	@Router			/v1/projects/{projectID}/events/dynamic [post]
@@ -244,18 +353,38 @@ func (h *Handler) CreateDynamicEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	newMessage.SchemaStatus, err = h.checkEventSchema(r, project.UID, newMessage.EventType, newMessage.Data)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	newMessage.IsDuplicate, err = h.checkDuplicate(r, project.UID, newMessage.IdempotencyKey, newMessage.DedupWindow)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	cde := services.CreateDynamicEventService{
-		Queue:        h.A.Queue,
-		DynamicEvent: &newMessage,
-		Project:      project,
+		ScheduledEventRepo: postgres.NewScheduledEventRepo(h.A.DB),
+		Queue:              h.A.Queue,
+		DynamicEvent:       &newMessage,
+		Project:            project,
 	}
 
-	err = cde.Run(r.Context())
+	scheduledEvent, err := cde.Run(r.Context())
 	if err != nil {
+		h.releaseDuplicateClaim(r, project.UID, newMessage.IdempotencyKey, newMessage.IsDuplicate)
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
 		return
 	}
 
+	if scheduledEvent != nil {
+		_ = render.Render(w, r, util.NewServerResponse("Dynamic event scheduled successfully",
+			&models.ScheduledEventResponse{ScheduledEvent: scheduledEvent}, http.StatusCreated))
+		return
+	}
+
 	_ = render.Render(w, r, util.NewServerResponse("Dynamic event created successfully", nil, http.StatusCreated))
 }
 
@@ -489,6 +618,49 @@ func (h *Handler) CountAffectedEvents(w http.ResponseWriter, r *http.Request) {
 	_ = render.Render(w, r, util.NewServerResponse("events count successful", map[string]interface{}{"num": count}, http.StatusOK))
 }
 
+// checkEventSchema validates the data against the schema of the event type,
+// the returned status is saved with the event.
+func (h *Handler) checkEventSchema(r *http.Request, projectID, eventType string, data []byte) (datastore.EventSchemaStatus, error) {
+	validator := eventschema.NewValidator(postgres.NewEventTypesRepo(h.A.DB))
+	return validator.Check(r.Context(), projectID, eventType, data)
+}
+
+// checkDuplicate checks the idempotency key against the event's dedup window,
+// it returns nil for events without a window so the key is looked up in the
+// events table instead. A failing dedup store is a server error.
+func (h *Handler) checkDuplicate(r *http.Request, projectID, idempotencyKey, dedupWindow string) (*bool, error) {
+	window, err := dedup.ParseWindow(dedupWindow)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if util.IsStringEmpty(idempotencyKey) || window == 0 {
+		return nil, nil
+	}
+
+	w := dedup.NewWindow(dedup.NewStore(h.A.Cfg.Dedup, h.A.Redis), metrics.GetDPInstance(h.A.Licenser))
+	isDuplicate, err := w.IsDuplicate(r.Context(), projectID, idempotencyKey, window)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to check the idempotency key")
+		return nil, util.NewServiceError(http.StatusInternalServerError, errors.New("failed to check the idempotency key"))
+	}
+
+	return &isDuplicate, nil
+}
+
+// releaseDuplicateClaim gives up the idempotency key claimed by checkDuplicate
+// when the event wasn't queued, so the retry isn't taken for a duplicate.
+func (h *Handler) releaseDuplicateClaim(r *http.Request, projectID, idempotencyKey string, isDuplicate *bool) {
+	if isDuplicate == nil || *isDuplicate {
+		return
+	}
+
+	w := dedup.NewWindow(dedup.NewStore(h.A.Cfg.Dedup, h.A.Redis), metrics.GetDPInstance(h.A.Licenser))
+	if err := w.Release(r.Context(), projectID, idempotencyKey); err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to release the idempotency key")
+	}
+}
+
 func (h *Handler) retrieveEvent(r *http.Request) (*datastore.Event, error) {
 	project, err := h.retrieveProject(r)
 	if err != nil {
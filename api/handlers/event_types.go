@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/eventcatalog"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -77,11 +81,17 @@ func (h *Handler) CreateEventType(w http.ResponseWriter, r *http.Request) {
 	}
 
 	pe := &datastore.ProjectEventType{
-		ProjectId:   project.UID,
-		Name:        newEventType.Name,
-		UID:         ulid.Make().String(),
-		Category:    newEventType.Category,
-		Description: newEventType.Description,
+		ProjectId:        project.UID,
+		Name:             newEventType.Name,
+		UID:              ulid.Make().String(),
+		Category:         newEventType.Category,
+		Description:      newEventType.Description,
+		SchemaValidation: newEventType.SchemaValidation,
+	}
+
+	if len(newEventType.JSONSchema) > 0 && string(newEventType.JSONSchema) != "null" {
+		pe.JSONSchema = compactSchema(newEventType.JSONSchema)
+		pe.SchemaVersion = 1
 	}
 
 	eventTypeRepo := postgres.NewEventTypesRepo(h.A.DB)
@@ -125,6 +135,12 @@ func (h *Handler) UpdateEventType(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = ue.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
 	eventTypeRepo := postgres.NewEventTypesRepo(h.A.DB)
 	pe, err := eventTypeRepo.FetchEventTypeById(r.Context(), eventTypeId, project.UID)
 	if err != nil {
@@ -140,6 +156,22 @@ func (h *Handler) UpdateEventType(w http.ResponseWriter, r *http.Request) {
 		pe.Category = ue.Category
 	}
 
+	if ue.SchemaValidation != "" {
+		pe.SchemaValidation = ue.SchemaValidation
+	}
+
+	// an explicit null removes the schema, its versions are kept
+	switch {
+	case string(ue.JSONSchema) == "null":
+		pe.JSONSchema = nil
+	case len(ue.JSONSchema) > 0:
+		schema := compactSchema(ue.JSONSchema)
+		if !bytes.Equal(schema, pe.JSONSchema) {
+			pe.JSONSchema = schema
+			pe.SchemaVersion++
+		}
+	}
+
 	err = eventTypeRepo.UpdateEventType(r.Context(), pe)
 	if err != nil {
 		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
@@ -150,6 +182,108 @@ func (h *Handler) UpdateEventType(w http.ResponseWriter, r *http.Request) {
 	_ = render.Render(w, r, util.NewServerResponse("Event type created successfully", resp, http.StatusAccepted))
 }
 
+// GetEventTypeSchemas
+//
+//	@Summary		Retrieves the schema versions of an event type
+//	@Description	This endpoint fetches every version of an event type's JSON Schema, newest first
+//	@Id				GetEventTypeSchemas
+//	@Tags			EventTypes
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			eventTypeId	path		string	true	"Event Type ID"
+//	@Success		200			{object}	util.ServerResponse{data=models.EventTypeSchemaListResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/event-types/{eventTypeId}/schemas [get]
+func (h *Handler) GetEventTypeSchemas(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	eventTypeId := chi.URLParam(r, "eventTypeId")
+	eventTypeRepo := postgres.NewEventTypesRepo(h.A.DB)
+	schemas, err := eventTypeRepo.FetchEventTypeSchemas(r.Context(), eventTypeId, project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	resp := &models.EventTypeSchemaListResponse{Schemas: schemas}
+	_ = render.Render(w, r, util.NewServerResponse("Event type schemas fetched successfully", resp, http.StatusOK))
+}
+
+// ExportEventTypes
+//
+//	@Summary		Exports a project's event types
+//	@Description	This endpoint renders the project's event types and their schemas as an AsyncAPI document or the webhooks of an OpenAPI document
+//	@Id				ExportEventTypes
+//	@Tags			EventTypes
+//	@Produce		json,yaml
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			spec		query		string	false	"Document spec"		Enums(asyncapi-3.0, asyncapi-2.6, openapi-3.1)
+//	@Param			format		query		string	false	"Document format"	Enums(json, yaml)
+//	@Param			version		query		string	false	"Document version, it defaults to 1.0.0"
+//	@Success		200			{object}	map[string]interface{}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/event-types/export [get]
+func (h *Handler) ExportEventTypes(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	spec, err := eventcatalog.ParseSpec(r.URL.Query().Get("spec"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	format, err := eventcatalog.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	eventTypeRepo := postgres.NewEventTypesRepo(h.A.DB)
+	eventTypes, err := eventTypeRepo.FetchAllEventTypes(r.Context(), project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	doc, err := eventcatalog.Generate(spec, project, eventTypes, eventcatalog.Options{Version: r.URL.Query().Get("version")})
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	b, contentType, err := eventcatalog.Encode(doc, format)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to encode the document", http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
+}
+
+// compactSchema strips the whitespace in a schema, so schemas that only
+// differ in formatting are the same version. The schema has been validated.
+func compactSchema(schema json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, schema); err != nil {
+		return schema
+	}
+
+	return buf.Bytes()
+}
+
 // DeprecateEventType
 //
 //	@Summary		Deprecates an event type
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/render"
 )
@@ -28,3 +31,66 @@ func (h *Handler) RequireEnabledProject() func(next http.Handler) http.Handler {
 		})
 	}
 }
+
+// RequirePermission rejects requests from callers that aren't granted perm
+// on the project in the request.
+func (h *Handler) RequirePermission(perm auth.Permission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authUser := middleware.GetAuthUserFromContext(r.Context())
+
+			// portal links are limited to their endpoints by the portal handlers
+			if h.IsReqWithPortalLinkToken(authUser) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			p, err := h.retrieveProject(r)
+			if err != nil {
+				_ = render.Render(w, r, util.NewErrorResponse("failed to retrieve project", http.StatusBadRequest))
+				return
+			}
+
+			if err = h.A.Authz.Authorize(r.Context(), "project."+perm.String(), p); err != nil {
+				_ = render.Render(w, r, util.NewErrorResponse(fmt.Sprintf("%s permission is required", perm), http.StatusForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireResourcePermission requires read for requests that don't change
+// the resource and write for the rest.
+func (h *Handler) RequireResourcePermission(read, write auth.Permission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		readHandler := h.RequirePermission(read)(next)
+		writeHandler := h.RequirePermission(write)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				readHandler.ServeHTTP(w, r)
+			default:
+				writeHandler.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// RequireInstanceAdmin rejects requests from callers that aren't users
+// configured as instance admins.
+func (h *Handler) RequireInstanceAdmin() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := h.retrieveUser(r)
+			if err != nil || !h.A.Cfg.Auth.IsInstanceAdmin(user.Email) {
+				_ = render.Render(w, r, util.NewErrorResponse("instance admin access is required", http.StatusForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
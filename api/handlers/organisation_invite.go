@@ -60,6 +60,13 @@ func (h *Handler) InviteUserToOrganisation(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: org.UID,
+		ResourceType:   datastore.OrganisationInviteAuditResource,
+		ResourceID:     iv.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, iv)
+
 	res := models.UserInviteTokenResponse{Token: iv, User: user}
 	_ = render.Render(w, r, util.NewServerResponse("invite created successfully", res, http.StatusCreated))
 }
@@ -205,5 +212,12 @@ func (h *Handler) CancelOrganizationInvite(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: org.UID,
+		ResourceType:   datastore.OrganisationInviteAuditResource,
+		ResourceID:     iv.UID,
+		Action:         datastore.AuditActionRevoked,
+	}, nil, map[string]interface{}{"status": iv.Status})
+
 	_ = render.Render(w, r, util.NewServerResponse("invite cancelled successfully", iv, http.StatusOK))
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/chi/v5"
@@ -87,6 +88,9 @@ func (h *Handler) UpdateOrganisationMember(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// the member is updated in place
+	before := services.AuditSnapshot(member)
+
 	orgMemberService := createOrganisationMemberService(h)
 	organisationMember, err := orgMemberService.UpdateOrganisationMember(r.Context(), member, &roleUpdate.Role)
 	if err != nil {
@@ -94,6 +98,13 @@ func (h *Handler) UpdateOrganisationMember(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: org.UID,
+		ResourceType:   datastore.OrganisationMemberAuditResource,
+		ResourceID:     organisationMember.UID,
+		Action:         datastore.AuditActionUpdated,
+	}, before, organisationMember)
+
 	_ = render.Render(w, r, util.NewServerResponse("Organisation member updated successfully", organisationMember, http.StatusAccepted))
 }
 
@@ -110,6 +121,13 @@ func (h *Handler) DeleteOrganisationMember(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	member, err := postgres.NewOrgMemberRepo(h.A.DB).FetchOrganisationMemberByID(r.Context(), memberID, org.UID)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to find organisation member by id")
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	orgMemberService := createOrganisationMemberService(h)
 	err = orgMemberService.DeleteOrganisationMember(r.Context(), memberID, org)
 	if err != nil {
@@ -117,5 +135,12 @@ func (h *Handler) DeleteOrganisationMember(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: org.UID,
+		ResourceType:   datastore.OrganisationMemberAuditResource,
+		ResourceID:     member.UID,
+		Action:         datastore.AuditActionDeleted,
+	}, member, nil)
+
 	_ = render.Render(w, r, util.NewServerResponse("Organisation member deleted successfully", nil, http.StatusOK))
 }
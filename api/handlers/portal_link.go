@@ -58,6 +58,14 @@ func (h *Handler) CreatePortalLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.PortalLinkAuditResource,
+		ResourceID:     portalLink.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, portalLink)
+
 	baseUrl, err := h.retrieveHost()
 	if err != nil {
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
@@ -161,6 +169,9 @@ func (h *Handler) UpdatePortalLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the portal link is updated in place
+	before := services.AuditSnapshot(portalLink)
+
 	upl := services.UpdatePortalLinkService{
 		PortalLinkRepo: postgres.NewPortalLinkRepo(h.A.DB),
 		EndpointRepo:   postgres.NewEndpointRepo(h.A.DB),
@@ -175,6 +186,14 @@ func (h *Handler) UpdatePortalLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.PortalLinkAuditResource,
+		ResourceID:     portalLink.UID,
+		Action:         datastore.AuditActionUpdated,
+	}, before, portalLink)
+
 	baseUrl, err := h.retrieveHost()
 	if err != nil {
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
@@ -225,6 +244,14 @@ func (h *Handler) RevokePortalLink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.PortalLinkAuditResource,
+		ResourceID:     portalLink.UID,
+		Action:         datastore.AuditActionRevoked,
+	}, portalLink, nil)
+
 	_ = render.Render(w, r, util.NewServerResponse("Portal link revoked successfully", nil, http.StatusOK))
 }
 
@@ -81,6 +81,14 @@ func (h *Handler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 
 	h.A.Licenser.RemoveEnabledProject(project.UID)
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.ProjectAuditResource,
+		ResourceID:     project.UID,
+		Action:         datastore.AuditActionDeleted,
+	}, project, nil)
+
 	_ = render.Render(w, r, util.NewServerResponse("Project deleted successfully",
 		nil, http.StatusOK))
 }
@@ -127,6 +135,22 @@ func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: org.UID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.ProjectAuditResource,
+		ResourceID:     project.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, project)
+
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: org.UID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.APIKeyAuditResource,
+		ResourceID:     apiKey.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, apiKey)
+
 	resp := &models.CreateProjectResponse{
 		APIKey:  apiKey,
 		Project: &models.ProjectResponse{Project: project},
@@ -165,12 +189,23 @@ func (h *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the project is updated in place
+	before := services.AuditSnapshot(p)
+
 	project, err := projectService.UpdateProject(r.Context(), p, &update)
 	if err != nil {
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.ProjectAuditResource,
+		ResourceID:     project.UID,
+		Action:         datastore.AuditActionUpdated,
+	}, before, project)
+
 	resp := &models.ProjectResponse{Project: project}
 	_ = render.Render(w, r, util.NewServerResponse("Project updated successfully", resp, http.StatusAccepted))
 }
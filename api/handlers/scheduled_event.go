@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// GetScheduledEventsPaged
+//
+//	@Summary		List all scheduled events
+//	@Description	This endpoint fetches events that were created with a deliver_at or delay
+//	@Id				GetScheduledEventsPaged
+//	@Tags			Scheduled Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string							true	"Project ID"
+//	@Param			request		query		models.QueryListScheduledEvent	false	"Query Params"
+//	@Success		200			{object}	util.ServerResponse{data=models.PagedResponse{content=[]models.ScheduledEventResponse}}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/scheduled-events [get]
+func (h *Handler) GetScheduledEventsPaged(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListScheduledEvent
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	scheduledEvents, paginationData, err := postgres.NewScheduledEventRepo(h.A.DB).LoadScheduledEventsPaged(r.Context(), project.UID, data.Status, data.Filter)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching scheduled events", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(scheduledEvents, func(scheduledEvent datastore.ScheduledEvent) models.ScheduledEventResponse {
+		return models.ScheduledEventResponse{ScheduledEvent: &scheduledEvent}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Scheduled events fetched successfully",
+		models.PagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetScheduledEvent
+//
+//	@Summary		Retrieve a scheduled event
+//	@Description	This endpoint retrieves a scheduled event
+//	@Id				GetScheduledEvent
+//	@Tags			Scheduled Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID			path		string	true	"Project ID"
+//	@Param			scheduledEventID	path		string	true	"scheduled event id"
+//	@Success		200					{object}	util.ServerResponse{data=models.ScheduledEventResponse}
+//	@Failure		400,401,404			{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/scheduled-events/{scheduledEventID} [get]
+func (h *Handler) GetScheduledEvent(w http.ResponseWriter, r *http.Request) {
+	scheduledEvent, err := h.retrieveScheduledEvent(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	resp := &models.ScheduledEventResponse{ScheduledEvent: scheduledEvent}
+	_ = render.Render(w, r, util.NewServerResponse("Scheduled event fetched successfully", resp, http.StatusOK))
+}
+
+// CancelScheduledEvent
+//
+//	@Summary		Cancel a scheduled event
+//	@Description	This endpoint cancels a scheduled event that has not been sent yet
+//	@Id				CancelScheduledEvent
+//	@Tags			Scheduled Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID			path		string	true	"Project ID"
+//	@Param			scheduledEventID	path		string	true	"scheduled event id"
+//	@Success		200					{object}	util.ServerResponse{data=models.ScheduledEventResponse}
+//	@Failure		400,401,404			{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/scheduled-events/{scheduledEventID}/cancel [put]
+func (h *Handler) CancelScheduledEvent(w http.ResponseWriter, r *http.Request) {
+	scheduledEvent, err := h.retrieveScheduledEvent(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	cs := services.CancelScheduledEventService{
+		ScheduledEventRepo: postgres.NewScheduledEventRepo(h.A.DB),
+		ScheduledEvent:     scheduledEvent,
+	}
+
+	err = cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.ScheduledEventResponse{ScheduledEvent: scheduledEvent}
+	_ = render.Render(w, r, util.NewServerResponse("Scheduled event cancelled successfully", resp, http.StatusOK))
+}
+
+// RescheduleEvent
+//
+//	@Summary		Reschedule a scheduled event
+//	@Description	This endpoint changes when a scheduled event that has not been sent yet is sent
+//	@Id				RescheduleEvent
+//	@Tags			Scheduled Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID			path		string					true	"Project ID"
+//	@Param			scheduledEventID	path		string					true	"scheduled event id"
+//	@Param			schedule			body		models.RescheduleEvent	true	"Schedule Details"
+//	@Success		200					{object}	util.ServerResponse{data=models.ScheduledEventResponse}
+//	@Failure		400,401,404			{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/scheduled-events/{scheduledEventID}/reschedule [put]
+func (h *Handler) RescheduleEvent(w http.ResponseWriter, r *http.Request) {
+	var update models.RescheduleEvent
+	err := util.ReadJSON(r, &update)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = update.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	scheduledEvent, err := h.retrieveScheduledEvent(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	rs := services.RescheduleEventService{
+		ScheduledEventRepo: postgres.NewScheduledEventRepo(h.A.DB),
+		Queue:              h.A.Queue,
+		ScheduledEvent:     scheduledEvent,
+		Update:             &update,
+	}
+
+	err = rs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.ScheduledEventResponse{ScheduledEvent: scheduledEvent}
+	_ = render.Render(w, r, util.NewServerResponse("Scheduled event rescheduled successfully", resp, http.StatusOK))
+}
+
+func (h *Handler) retrieveScheduledEvent(r *http.Request) (*datastore.ScheduledEvent, error) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		return &datastore.ScheduledEvent{}, err
+	}
+
+	scheduledEventID := chi.URLParam(r, "scheduledEventID")
+	scheduledEventRepo := postgres.NewScheduledEventRepo(h.A.DB)
+	return scheduledEventRepo.FindScheduledEventByID(r.Context(), project.UID, scheduledEventID)
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const scimMaxResults = 100
+
+var scimFilterRegex = regexp.MustCompile(`^(\S+)\s+(?i:eq)\s+"([^"]*)"$`)
+
+func createSCIMService(h *Handler) *services.SCIMService {
+	return &services.SCIMService{
+		UserRepo:      postgres.NewUserRepo(h.A.DB),
+		OrgMemberRepo: postgres.NewOrgMemberRepo(h.A.DB),
+		SCIMUserRepo:  postgres.NewSCIMUserRepo(h.A.DB),
+		Licenser:      h.A.Licenser,
+		GroupRoles:    h.A.Cfg.Auth.OIDC.GroupRoles,
+	}
+}
+
+func (h *Handler) GetSCIMServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeSCIM(w, http.StatusOK, &models.SCIMServiceProviderConfig{
+		Schemas: []string{models.SCIMServiceProviderConfigSchema},
+		Patch:   models.SCIMSupported{Supported: true},
+		Filter:  models.SCIMFilterSupported{Supported: true, MaxResults: scimMaxResults},
+		AuthenticationSchemes: []models.SCIMAuthentication{{
+			Type:        "oauthbearertoken",
+			Name:        "Personal Access Token",
+			Description: "A personal access token of a super user of the organisation",
+			Primary:     true,
+		}},
+	})
+}
+
+func (h *Handler) GetSCIMUsers(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.scimOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := &datastore.SCIMUserFilter{Offset: 0, Limit: scimMaxResults}
+
+	if f := q.Get("filter"); f != "" {
+		matches := scimFilterRegex.FindStringSubmatch(strings.TrimSpace(f))
+		if matches == nil {
+			writeSCIMError(w, http.StatusBadRequest, "invalidFilter", "only eq filters on userName, externalId and emails.value are supported")
+			return
+		}
+
+		switch strings.ToLower(matches[1]) {
+		case "username", "emails.value", `emails[type eq "work"].value`:
+			filter.Email = strings.ToLower(matches[2])
+		case "externalid":
+			filter.ExternalID = matches[2]
+		default:
+			writeSCIMError(w, http.StatusBadRequest, "invalidFilter", fmt.Sprintf("filtering on %s is not supported", matches[1]))
+			return
+		}
+	}
+
+	startIndex := 1
+	if v, err := strconv.Atoi(q.Get("startIndex")); err == nil && v > 1 {
+		startIndex = v
+	}
+	filter.Offset = startIndex - 1
+
+	if v, err := strconv.Atoi(q.Get("count")); err == nil && v >= 0 && v <= scimMaxResults {
+		filter.Limit = v
+	}
+
+	users, count, err := createSCIMService(h).LoadUsers(r.Context(), org, filter)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	resources := make([]*models.SCIMUser, 0, len(users))
+	for i := range users {
+		resources = append(resources, models.NewSCIMUser(&users[i], scimUserLocation(&users[i])))
+	}
+
+	writeSCIM(w, http.StatusOK, &models.SCIMListResponse{
+		Schemas:      []string{models.SCIMListResponseSchema},
+		TotalResults: count,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) CreateSCIMUser(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.scimOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	var newUser models.SCIMUser
+	if err := json.NewDecoder(r.Body).Decode(&newUser); err != nil {
+		writeSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	user, err := createSCIMService(h).CreateUser(r.Context(), org, &newUser)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusCreated, models.NewSCIMUser(user, scimUserLocation(user)))
+}
+
+func (h *Handler) GetSCIMUser(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.scimOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := createSCIMService(h).FindUser(r.Context(), org, chi.URLParam(r, "userID"))
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, models.NewSCIMUser(user, scimUserLocation(user)))
+}
+
+func (h *Handler) ReplaceSCIMUser(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.scimOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	var update models.SCIMUser
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	user, err := createSCIMService(h).ReplaceUser(r.Context(), org, chi.URLParam(r, "userID"), &update)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, models.NewSCIMUser(user, scimUserLocation(user)))
+}
+
+func (h *Handler) PatchSCIMUser(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.scimOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	var patch models.SCIMPatchOp
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+
+	user, err := createSCIMService(h).PatchUser(r.Context(), org, chi.URLParam(r, "userID"), &patch)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	writeSCIM(w, http.StatusOK, models.NewSCIMUser(user, scimUserLocation(user)))
+}
+
+func (h *Handler) DeleteSCIMUser(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.scimOrganisation(w, r)
+	if !ok {
+		return
+	}
+
+	err := createSCIMService(h).DeleteUser(r.Context(), org, chi.URLParam(r, "userID"))
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// scimOrganisation returns the organisation in the url when the caller
+// can manage it.
+func (h *Handler) scimOrganisation(w http.ResponseWriter, r *http.Request) (*datastore.Organisation, bool) {
+	org, err := h.retrieveOrganisation(r)
+	if err != nil {
+		writeSCIMError(w, http.StatusNotFound, "", "organisation not found")
+		return nil, false
+	}
+
+	if err = h.A.Authz.Authorize(r.Context(), "organisation.manage", org); err != nil {
+		writeSCIMError(w, http.StatusForbidden, "", "Unauthorized")
+		return nil, false
+	}
+
+	return org, true
+}
+
+func scimUserLocation(user *datastore.SCIMUser) string {
+	return fmt.Sprintf("/scim/v2/organisations/%s/Users/%s", user.OrganisationID, user.UID)
+}
+
+func writeSCIMServiceError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+
+	var serviceErr *util.ServiceError
+	if errors.As(err, &serviceErr) {
+		status = serviceErr.ErrCode()
+	}
+
+	scimType := ""
+	switch {
+	case status == http.StatusConflict:
+		scimType = "uniqueness"
+	case errors.Is(err, models.ErrSCIMInvalidPatch):
+		scimType = "invalidValue"
+	}
+
+	writeSCIMError(w, status, scimType, err.Error())
+}
+
+func writeSCIMError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeSCIM(w, status, models.NewSCIMError(status, scimType, detail))
+}
+
+func writeSCIM(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/frain-dev/convoy/pkg/log"
 
 	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/api/policies"
+	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/services"
@@ -50,8 +54,9 @@ func (h *Handler) CreatePersonalAPIKey(w http.ResponseWriter, r *http.Request) {
 		APIKey: models.APIKey{
 			Name: apiKey.Name,
 			Role: models.Role{
-				Type:    apiKey.Role.Type,
-				Project: apiKey.Role.Project,
+				Type:        apiKey.Role.Type,
+				Project:     apiKey.Role.Project,
+				Permissions: apiKey.Role.Permissions,
 			},
 			Type:      apiKey.Type,
 			ExpiresAt: apiKey.ExpiresAt,
@@ -121,12 +126,21 @@ func (h *Handler) RegenerateProjectAPIKey(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.APIKeyAuditResource,
+		ResourceID:     apiKey.UID,
+		Action:         datastore.AuditActionRegenerated,
+	}, nil, apiKey)
+
 	resp := &models.APIKeyResponse{
 		APIKey: models.APIKey{
 			Name: apiKey.Name,
 			Role: models.Role{
-				Type:    apiKey.Role.Type,
-				Project: apiKey.Role.Project,
+				Type:        apiKey.Role.Type,
+				Project:     apiKey.Role.Project,
+				Permissions: apiKey.Role.Permissions,
 			},
 			Type:      apiKey.Type,
 			ExpiresAt: apiKey.ExpiresAt,
@@ -139,6 +153,114 @@ func (h *Handler) RegenerateProjectAPIKey(w http.ResponseWriter, r *http.Request
 	_ = render.Render(w, r, util.NewServerResponse("api key regenerated successfully", resp, http.StatusOK))
 }
 
+// CreateProjectAPIKey creates an additional api key for the project, the
+// key can be limited to a subset of permissions and given an expiry.
+func (h *Handler) CreateProjectAPIKey(w http.ResponseWriter, r *http.Request) {
+	var newApiKey models.ProjectAPIKey
+	err := json.NewDecoder(r.Body).Decode(&newApiKey)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Request is invalid", http.StatusBadRequest))
+		return
+	}
+
+	if err = newApiKey.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	member, err := h.retrieveMembership(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	if err = h.A.Authz.Authorize(r.Context(), "project.manage", project); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Unauthorized", http.StatusForbidden))
+		return
+	}
+
+	role := models.Role{Type: auth.RoleAdmin, Project: project.UID}
+	if len(newApiKey.Permissions) > 0 {
+		role.Type = auth.RoleCustom
+		role.Permissions = newApiKey.Permissions
+	}
+
+	// a key can't be granted permissions the caller doesn't have, keys
+	// without permissions are granted every permission
+	requested := newApiKey.Permissions
+	if len(requested) == 0 {
+		requested = auth.AllPermissions
+	}
+
+	policy := policies.NewProjectPolicy(h.A.Licenser, postgres.NewOrgRepo(h.A.DB), postgres.NewOrgMemberRepo(h.A.DB))
+	granted, err := policy.Permissions(r.Context(), project)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Unauthorized", http.StatusForbidden))
+		return
+	}
+
+	if excess := requested.Difference(granted); len(excess) > 0 {
+		names := make([]string, len(excess))
+		for i, perm := range excess {
+			names[i] = perm.String()
+		}
+
+		msg := fmt.Sprintf("you can't grant permissions you don't have: %s", strings.Join(names, ", "))
+		_ = render.Render(w, r, util.NewErrorResponse(msg, http.StatusForbidden))
+		return
+	}
+
+	cak := &services.CreateAPIKeyService{
+		ProjectRepo: postgres.NewProjectRepo(h.A.DB),
+		APIKeyRepo:  postgres.NewAPIKeyRepo(h.A.DB),
+		Member:      member,
+		NewApiKey: &models.APIKey{
+			Name:      newApiKey.Name,
+			Role:      role,
+			Type:      datastore.ProjectKey,
+			ExpiresAt: newApiKey.ExpiresAt,
+		},
+	}
+
+	apiKey, keyString, err := cak.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.APIKeyAuditResource,
+		ResourceID:     apiKey.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, apiKey)
+
+	resp := &models.APIKeyResponse{
+		APIKey: models.APIKey{
+			Name: apiKey.Name,
+			Role: models.Role{
+				Type:        apiKey.Role.Type,
+				Project:     apiKey.Role.Project,
+				Permissions: apiKey.Role.Permissions,
+			},
+			Type:      apiKey.Type,
+			ExpiresAt: apiKey.ExpiresAt,
+		},
+		UID:       apiKey.UID,
+		CreatedAt: apiKey.CreatedAt,
+		Key:       keyString,
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("api key created successfully", resp, http.StatusCreated))
+}
+
 func (h *Handler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
 	pageable := m.GetPageableFromContext(r.Context())
 
@@ -14,6 +14,7 @@ import (
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/changestream"
 	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/chi/v5"
@@ -64,6 +65,14 @@ func (h *Handler) CreateSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.SourceAuditResource,
+		ResourceID:     source.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, source)
+
 	org, err := postgres.NewOrgRepo(h.A.DB).FetchOrganisationByID(r.Context(), project.OrganisationID)
 	if err != nil {
 		log.FromContext(r.Context()).WithError(err).Error("failed to find organisation by id")
@@ -179,8 +188,12 @@ func (h *Handler) UpdateSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the source is updated in place
+	before := services.AuditSnapshot(source)
+
 	us := services.UpdateSourceService{
 		SourceRepo:   postgres.NewSourceRepo(h.A.DB),
+		SubRepo:      postgres.NewSubscriptionRepo(h.A.DB),
 		Project:      project,
 		SourceUpdate: &sourceUpdate,
 		Source:       source,
@@ -192,6 +205,14 @@ func (h *Handler) UpdateSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.SourceAuditResource,
+		ResourceID:     source.UID,
+		Action:         datastore.AuditActionUpdated,
+	}, before, source)
+
 	org, err := postgres.NewOrgRepo(h.A.DB).FetchOrganisationByID(r.Context(), project.OrganisationID)
 	if err != nil {
 		log.FromContext(r.Context()).WithError(err).Error("failed to find organisation by id")
@@ -251,6 +272,21 @@ func (h *Handler) DeleteSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if source.Type == datastore.DBChangeStream && source.ChangeStream != nil {
+		err = changestream.DropSlot(r.Context(), source.ChangeStream)
+		if err != nil {
+			log.FromContext(r.Context()).WithError(err).Errorf("failed to drop replication slot %s of source %s", source.ChangeStream.SlotName, source.UID)
+		}
+	}
+
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.SourceAuditResource,
+		ResourceID:     source.UID,
+		Action:         datastore.AuditActionDeleted,
+	}, source, nil)
+
 	_ = render.Render(w, r, util.NewServerResponse("Source deleted successfully", nil, http.StatusOK))
 }
 
@@ -233,6 +233,14 @@ func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.SubscriptionAuditResource,
+		ResourceID:     subscription.UID,
+		Action:         datastore.AuditActionCreated,
+	}, nil, subscription)
+
 	resp := models.SubscriptionResponse{Subscription: subscription}
 	_ = render.Render(w, r, util.NewServerResponse("Subscription created successfully", resp, http.StatusCreated))
 }
@@ -296,6 +304,14 @@ func (h *Handler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.SubscriptionAuditResource,
+		ResourceID:     sub.UID,
+		Action:         datastore.AuditActionDeleted,
+	}, sub, nil)
+
 	_ = render.Render(w, r, util.NewServerResponse("Subscription deleted successfully", nil, http.StatusOK))
 }
 
@@ -335,6 +351,17 @@ func (h *Handler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	before, err := postgres.NewSubscriptionRepo(h.A.DB).FindSubscriptionByID(r.Context(), project.UID, chi.URLParam(r, "subscriptionID"))
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to find subscription")
+		if errors.Is(err, datastore.ErrSubscriptionNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse("failed to find subscription", http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	authUser := middleware.GetAuthUserFromContext(r.Context())
 
 	if h.IsReqWithPortalLinkToken(authUser) {
@@ -350,18 +377,7 @@ func (h *Handler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		sub, err := postgres.NewSubscriptionRepo(h.A.DB).FindSubscriptionByID(r.Context(), project.UID, chi.URLParam(r, "subscriptionID"))
-		if err != nil {
-			log.FromContext(r.Context()).WithError(err).Error("failed to find subscription")
-			if errors.Is(err, datastore.ErrSubscriptionNotFound) {
-				_ = render.Render(w, r, util.NewErrorResponse("failed to find subscription", http.StatusNotFound))
-				return
-			}
-			_ = render.Render(w, r, util.NewServiceErrResponse(err))
-			return
-		}
-
-		if !util.StringSliceContains(endpointIDs, sub.EndpointID) {
+		if !util.StringSliceContains(endpointIDs, before.EndpointID) {
 			_ = render.Render(w, r, util.NewErrorResponse("unauthorized", http.StatusUnauthorized))
 			return
 		}
@@ -383,6 +399,14 @@ func (h *Handler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.recordAuditLog(r, &datastore.AuditLog{
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		ResourceType:   datastore.SubscriptionAuditResource,
+		ResourceID:     sub.UID,
+		Action:         datastore.AuditActionUpdated,
+	}, before, sub)
+
 	resp := models.SubscriptionResponse{Subscription: sub}
 	_ = render.Render(w, r, util.NewServerResponse("Subscription updated successfully", resp, http.StatusAccepted))
 }
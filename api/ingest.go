@@ -1,20 +1,28 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy/api/handlers"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/services"
 
 	"github.com/frain-dev/convoy/pkg/msgpack"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/frain-dev/convoy/internal/pkg/dedup"
+	"github.com/frain-dev/convoy/internal/pkg/eventschema"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/go-chi/chi/v5"
 	"github.com/oklog/ulid/v2"
 
@@ -25,6 +33,7 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/crc"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/verifier"
+	"github.com/frain-dev/convoy/pkg/xmljson"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/util"
 	"github.com/frain-dev/convoy/worker/task"
@@ -79,6 +88,14 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 			v = verifier.NewTwitterVerifier(verifierConfig.HMac.Secret)
 		case datastore.ShopifySourceProvider:
 			v = verifier.NewShopifyVerifier(verifierConfig.HMac.Secret)
+		case datastore.StripeSourceProvider:
+			v = verifier.NewStripeVerifier(verifierConfig.HMac.Secret, time.Duration(verifierConfig.HMac.Tolerance)*time.Second)
+		case datastore.SlackSourceProvider:
+			v = verifier.NewSlackVerifier(verifierConfig.HMac.Secret, time.Duration(verifierConfig.HMac.Tolerance)*time.Second)
+		case datastore.TwilioSourceProvider:
+			v = verifier.NewTwilioVerifier(verifierConfig.HMac.Secret)
+		case datastore.StandardWebhooksSourceProvider:
+			v = verifier.NewStandardWebhooksVerifier(verifierConfig.HMac.Secret, time.Duration(verifierConfig.HMac.Tolerance)*time.Second)
 		default:
 			_ = render.Render(w, r, util.NewErrorResponse("Provider type undefined",
 				http.StatusBadRequest))
@@ -92,6 +109,9 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 				Hash:     verifierConfig.HMac.Hash,
 				Secret:   verifierConfig.HMac.Secret,
 				Encoding: string(verifierConfig.HMac.Encoding),
+
+				TimestampHeader: verifierConfig.HMac.TimestampHeader,
+				Tolerance:       time.Duration(verifierConfig.HMac.Tolerance) * time.Second,
 			}
 			v = verifier.NewHmacVerifier(opts)
 
@@ -105,6 +125,14 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 				verifierConfig.ApiKey.HeaderValue,
 				verifierConfig.ApiKey.HeaderName,
 			)
+		case datastore.JWTVerifier:
+			v = verifier.NewJWTVerifier(&verifier.JWTOptions{
+				Header:     verifierConfig.JWT.Header,
+				JWKSURL:    verifierConfig.JWT.JWKSURL,
+				PublicKeys: verifierConfig.JWT.PublicKeys,
+				Issuer:     verifierConfig.JWT.Issuer,
+				Audience:   verifierConfig.JWT.Audience,
+			}, a.dispatcher)
 		default:
 			v = &verifier.NoopVerifier{}
 		}
@@ -135,63 +163,129 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// 3.1 On Failure
+	// Return 400 Bad Request.
+	payload, err := extractPayloadFromIngestEventReq(r, maxIngestSize, source)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	dedupWindow, err := dedup.ParseWindow(source.DedupWindow)
+	if err != nil {
+		a.A.Logger.WithError(err).Errorf("source %s has an invalid dedup window", source.UID)
+	}
+
 	var checksum string
 	var isDuplicate bool
 	if len(source.IdempotencyKeys) > 0 {
 		duper := dedup.NewDeDuper(r.Context(), r, postgres.NewEventRepo(a.A.DB))
-		exists, err := duper.Exists(source.Name, source.ProjectID, source.IdempotencyKeys)
+
+		// keys within a dedup window are checked once the request is accepted
+		if dedupWindow == 0 {
+			isDuplicate, err = duper.Exists(source.Name, source.ProjectID, source.IdempotencyKeys)
+			if err != nil {
+				_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+				return
+			}
+		}
+
+		checksum, err = duper.GenerateChecksum(source.Name, source.IdempotencyKeys)
 		if err != nil {
 			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
 			return
 		}
+	}
 
-		isDuplicate = exists
+	// signatures are computed over the body as it was sent
+	if err = v.VerifyRequest(r, payload.raw); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
 
-		checksum, err = duper.GenerateChecksum(source.Name, source.IdempotencyKeys)
+	var replayGuard *verifier.ReplayGuard
+	if project.Config != nil && project.Config.ReplayAttacks {
+		replayGuard = verifier.NewReplayGuard(a.A.Cache)
+		err = replayGuard.Check(r.Context(), source.UID, v, r, payload.raw)
 		if err != nil {
-			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+			if errors.Is(err, verifier.ErrReplayedRequest) {
+				_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+				return
+			}
+
+			a.A.Logger.WithError(err).Error("failed to check for a replayed request")
+			_ = render.Render(w, r, util.NewErrorResponse("failed to check for a replayed request", http.StatusInternalServerError))
 			return
 		}
 	}
 
-	// 3.1 On Failure
-	// Return 400 Bad Request.
-	payload, err := extractPayloadFromIngestEventReq(r, maxIngestSize)
+	if len(payload.data) == 0 {
+		payload.data = []byte("{}")
+	}
+
+	// events from http sources are typed with the source's mask id, so
+	// that is the event type whose schema the payload is validated against
+	schemaStatus, err := eventschema.NewValidator(postgres.NewEventTypesRepo(a.A.DB)).Check(r.Context(), source.ProjectID, maskID, payload.data)
 	if err != nil {
 		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
 		return
 	}
 
-	if err = v.VerifyRequest(r, payload); err != nil {
-		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
-		return
+	// the key is claimed for the window here, so rejected requests don't use it up
+	var dedupChecked bool
+	var window *dedup.Window
+	if !util.IsStringEmpty(checksum) && dedupWindow > 0 {
+		window = dedup.NewWindow(dedup.NewStore(a.A.Cfg.Dedup, a.A.Redis), metrics.GetDPInstance(a.A.Licenser))
+		isDuplicate, err = window.IsDuplicate(r.Context(), source.ProjectID, checksum, dedupWindow)
+		if err != nil {
+			a.A.Logger.WithError(err).Error("failed to check the idempotency key")
+			_ = render.Render(w, r, util.NewErrorResponse("failed to check the idempotency key", http.StatusInternalServerError))
+			return
+		}
+		dedupChecked = true
 	}
 
-	if len(payload) == 0 {
-		payload = []byte("{}")
+	// the key is given up when the event isn't queued, so the retry isn't a duplicate
+	var queued bool
+	if dedupChecked && !isDuplicate {
+		defer func() {
+			if queued {
+				return
+			}
+
+			if err := window.Release(r.Context(), source.ProjectID, checksum); err != nil {
+				a.A.Logger.WithError(err).Error("failed to release the idempotency key")
+			}
+		}()
 	}
 
 	// 3.2 On success
 	// Attach Source to Event.
 	// Write Event to the Ingestion Queue.
+	original := datastore.NewOriginalBody(payload.contentType, payload.raw)
 	event := &datastore.Event{
 		UID:              ulid.Make().String(),
 		EventType:        datastore.EventType(maskID),
 		SourceID:         source.UID,
 		ProjectID:        source.ProjectID,
-		Raw:              string(payload),
-		Data:             payload,
+		Raw:              string(payload.data),
+		ContentType:      original.ContentType,
+		OriginalRaw:      original.Data,
+		RawEncoding:      original.Encoding,
+		Data:             payload.data,
 		IsDuplicateEvent: isDuplicate,
 		URLQueryParams:   r.URL.RawQuery,
 		IdempotencyKey:   checksum,
 		Headers:          httpheader.HTTPHeader(r.Header),
 		AcknowledgedAt:   null.TimeFrom(time.Now()),
+		SchemaStatus:     schemaStatus,
 	}
 
 	event.Headers["X-Convoy-Source-Id"] = []string{source.MaskID}
 
 	createEvent := task.CreateEvent{
-		Event: event,
+		Event:        event,
+		DedupChecked: dedupChecked,
 	}
 
 	eventByte, err := msgpack.EncodeMsgPack(createEvent)
@@ -213,6 +307,36 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
 		return
 	}
+	queued = true
+
+	// the request is only recorded once it's accepted, so a provider's retry
+	// of a request that failed isn't taken for a replay
+	if replayGuard != nil {
+		if err = replayGuard.Record(r.Context(), source.UID, v, r, payload.raw); err != nil {
+			a.A.Logger.WithError(err).Error("failed to record the request for replay checks")
+		}
+	}
+
+	if source.Synchronous && a.A.Licenser.SynchronousWebhooks() && a.hasSingleSubscription(r, source) {
+		waiter := services.WaitForDeliveryService{
+			EventDeliveryRepo: postgres.NewEventDeliveryRepo(a.A.DB),
+			AttemptsRepo:      postgres.NewDeliveryAttemptRepo(a.A.DB),
+			ProjectID:         source.ProjectID,
+			EventID:           event.UID,
+			Timeout:           models.DefaultSyncTimeout,
+		}
+
+		_, attempt, err := waiter.Run(r.Context())
+		if err == nil {
+			writeReceiverResponse(w, r, models.NewReceiverResponse(attempt))
+			return
+		}
+
+		// the delivery carries on asynchronously, so reply as usual
+		if !errors.Is(err, services.ErrSyncDeliveryTimeout) {
+			a.A.Logger.WithError(err).Error("failed to wait for the event delivery attempt")
+		}
+	}
 
 	// 4. Return 200
 	if !util.IsStringEmpty(source.CustomResponse.Body) {
@@ -231,10 +355,47 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 	}
 
 	if event.IsDuplicateEvent {
-		_ = render.Render(w, r, util.NewServerResponse("Duplicate event received, but will not be sent", len(payload), http.StatusOK))
+		_ = render.Render(w, r, util.NewServerResponse("Duplicate event received, but will not be sent", len(payload.data), http.StatusOK))
 	} else {
-		_ = render.Render(w, r, util.NewServerResponse("Event received", len(payload), http.StatusOK))
+		_ = render.Render(w, r, util.NewServerResponse("Event received", len(payload.data), http.StatusOK))
+	}
+}
+
+// hasSingleSubscription reports whether the source's events are delivered to
+// one endpoint, a source that fans out has no single response to relay.
+func (a *ApplicationHandler) hasSingleSubscription(r *http.Request, source *datastore.Source) bool {
+	subscriptions, err := postgres.NewSubscriptionRepo(a.A.DB).FindSubscriptionsBySourceID(r.Context(), source.ProjectID, source.UID)
+	if err != nil {
+		a.A.Logger.WithError(err).Error("failed to find the source's subscriptions")
+		return false
 	}
+
+	return len(subscriptions) == 1
+}
+
+// hopByHopHeaders aren't relayed from the endpoint's response.
+var hopByHopHeaders = map[string]bool{
+	"Connection":        true,
+	"Content-Length":    true,
+	"Keep-Alive":        true,
+	"Transfer-Encoding": true,
+}
+
+// writeReceiverResponse relays the endpoint's response to the ingest request.
+func writeReceiverResponse(w http.ResponseWriter, r *http.Request, resp *models.ReceiverResponse) {
+	if resp.StatusCode == 0 {
+		_ = render.Render(w, r, util.NewErrorResponse(fmt.Sprintf("endpoint could not be reached: %s", resp.Error), http.StatusBadGateway))
+		return
+	}
+
+	for k, v := range resp.Headers {
+		if !hopByHopHeaders[http.CanonicalHeaderKey(k)] {
+			w.Header().Set(k, v)
+		}
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write([]byte(resp.Body))
 }
 
 const (
@@ -243,45 +404,89 @@ const (
 	urlEncodedContentType        = "application/x-www-form-urlencoded"
 )
 
-func extractPayloadFromIngestEventReq(r *http.Request, maxIngestSize uint64) ([]byte, error) {
-	var contentType string
-	rawContentType := strings.ToLower(
-		strings.TrimSpace(
-			r.Header.Get("Content-Type"),
-		),
-	)
-
-	// We split the rawContentType using the first semicolon as the delimiter because go-chi has a weird way
-	// of handling the form-data content type. It adds a semicolon after the boundary and we need to remove it.
-	// Example: multipart/form-data; boundary=--------------------------879783787191406952783600
-	if contentTypeSlice := strings.SplitN(rawContentType, ";", 2); len(contentTypeSlice) == 0 {
+// ingestPayload is the body of an ingest request as it was received and the
+// JSON event data derived from it.
+type ingestPayload struct {
+	raw         []byte
+	contentType string
+	data        []byte
+}
+
+// extractPayloadFromIngestEventReq reads the request body and converts it to
+// the event data using the source's converters. Bodies that are valid JSON are
+// used as is, whatever their content type, and other bodies are kept as a JSON
+// string, base64 encoded when they aren't text. The body is left readable.
+func extractPayloadFromIngestEventReq(r *http.Request, maxIngestSize uint64, source *datastore.Source) (*ingestPayload, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, int64(maxIngestSize)))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	p := &ingestPayload{raw: body, contentType: strings.TrimSpace(r.Header.Get("Content-Type"))}
+	if util.IsStringEmpty(p.contentType) {
 		// always default to json if no content type is specified
-		contentType = applicationJsonContentType
-	} else {
-		contentType = strings.TrimSpace(contentTypeSlice[0])
+		p.contentType = applicationJsonContentType
+	}
+
+	mediaType, params, err := mime.ParseMediaType(p.contentType)
+	if err != nil {
+		// To avoid introducing a breaking change, we are keeping the old behaviour of assuming
+		// the content type is JSON if the content type is not specified/unsupported.
+		mediaType = applicationJsonContentType
 	}
 
-	switch contentType {
-	case multipartFormDataContentType:
-		if err := r.ParseMultipartForm(int64(maxIngestSize)); err != nil {
+	switch {
+	case mediaType == multipartFormDataContentType && source.Converts(datastore.FormPayloadConverter):
+		form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(int64(maxIngestSize))
+		if err != nil {
 			return nil, err
 		}
-		return convertRequestFormToJSON(r)
-	case urlEncodedContentType:
-		if err := r.ParseForm(); err != nil {
+		defer func() { _ = form.RemoveAll() }()
+
+		p.data, err = convertRequestFormToJSON(r, form.Value)
+		return p, err
+	case mediaType == urlEncodedContentType && source.Converts(datastore.FormPayloadConverter):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
 			return nil, err
 		}
-		return convertRequestFormToJSON(r)
-	default:
-		// To avoid introducing a breaking change, we are keeping the old behaviour of assuming
-		// the content type is JSON if the content type is not specified/unsupported.
-		return io.ReadAll(io.LimitReader(r.Body, int64(maxIngestSize)))
+
+		p.data, err = convertRequestFormToJSON(r, values)
+		return p, err
+	case isXMLContentType(mediaType) && source.Converts(datastore.XMLPayloadConverter):
+		p.data, err = xmljson.Convert(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert xml body: %v", err)
+		}
+		return p, nil
+	}
+
+	if len(body) == 0 || json.Valid(body) {
+		p.data = body
+		return p, nil
 	}
+
+	original := datastore.NewOriginalBody(p.contentType, body)
+	p.data, err = json.Marshal(original.Data)
+	return p, err
+}
+
+func isXMLContentType(mediaType string) bool {
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
 }
 
-func convertRequestFormToJSON(r *http.Request) ([]byte, error) {
+// convertRequestFormToJSON flattens the form values and the url query params,
+// the form values take precedence like they do in http.Request.Form.
+func convertRequestFormToJSON(r *http.Request, form map[string][]string) ([]byte, error) {
 	data := make(map[string]string)
-	for k, v := range r.Form {
+	for k, v := range r.URL.Query() {
+		if len(v) > 0 {
+			data[k] = v[0]
+		}
+	}
+
+	for k, v := range form {
 		// Golang handles the form data and returns it as a map[string][]string.
 		// we only need the first value in the slice, so we take the first element in the slice.
 		// We also skip empty values.
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,9 +23,9 @@ func Test_extractPayloadFromIngestEventReq(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonBody))
 		req.Header.Set("Content-Type", applicationJsonContentType)
 
-		payload, err := extractPayloadFromIngestEventReq(req, 1024)
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, &datastore.Source{})
 		require.NoError(t, err)
-		require.Equal(t, jsonBody, payload)
+		require.Equal(t, jsonBody, payload.data)
 	})
 
 	t.Run("multipart/form-data content type", func(t *testing.T) {
@@ -35,11 +38,11 @@ func Test_extractPayloadFromIngestEventReq(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/", body)
 		req.Header.Set("Content-Type", fmt.Sprintf("%s; boundary=%s", multipartFormDataContentType, writer.Boundary()))
 
-		payload, err := extractPayloadFromIngestEventReq(req, 1024)
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, &datastore.Source{})
 		require.NoError(t, err)
 
 		var form map[string]string
-		require.NoError(t, json.Unmarshal(payload, &form))
+		require.NoError(t, json.Unmarshal(payload.data, &form))
 
 		require.Equal(t, "value1", form["key1"])
 		require.Equal(t, "value2", form["key2"])
@@ -50,9 +53,9 @@ func Test_extractPayloadFromIngestEventReq(t *testing.T) {
 
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonBody))
 
-		payload, err := extractPayloadFromIngestEventReq(req, 1024)
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, &datastore.Source{})
 		require.NoError(t, err)
-		require.Equal(t, jsonBody, payload)
+		require.Equal(t, jsonBody, payload.data)
 	})
 
 	t.Run("urlencoded content type", func(t *testing.T) {
@@ -61,9 +64,10 @@ func Test_extractPayloadFromIngestEventReq(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/", body)
 		req.Header.Set("Content-Type", urlEncodedContentType)
 
-		payload, err := extractPayloadFromIngestEventReq(req, 1024)
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, &datastore.Source{})
 		require.NoError(t, err)
-		require.Equal(t, []byte(`{"key1":"value1","key2":"value2"}`), payload)
+		require.Equal(t, []byte(`{"key1":"value1","key2":"value2"}`), payload.data)
+		require.Equal(t, []byte("key1=value1&key2=value2"), payload.raw)
 	})
 
 	t.Run("unsupported content type", func(t *testing.T) {
@@ -72,8 +76,93 @@ func Test_extractPayloadFromIngestEventReq(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(jsonBody))
 		req.Header.Set("Content-Type", "text/html")
 
-		payload, err := extractPayloadFromIngestEventReq(req, 1024)
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, &datastore.Source{})
 		require.NoError(t, err)
-		require.Equal(t, jsonBody, payload)
+		require.Equal(t, jsonBody, payload.data)
+	})
+
+	t.Run("xml body converted to json", func(t *testing.T) {
+		xmlBody := []byte(`<order id="1"><item>a</item><item>b</item></order>`)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(xmlBody))
+		req.Header.Set("Content-Type", "application/soap+xml; charset=utf-8")
+
+		source := &datastore.Source{Converters: []string{"xml"}}
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, source)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"order":{"@id":"1","item":["a","b"]}}`, string(payload.data))
+		require.Equal(t, xmlBody, payload.raw)
+		require.Equal(t, "application/soap+xml; charset=utf-8", payload.contentType)
+	})
+
+	t.Run("invalid xml body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("<order>"))
+		req.Header.Set("Content-Type", "text/xml")
+
+		source := &datastore.Source{Converters: []string{"xml"}}
+		_, err := extractPayloadFromIngestEventReq(req, 1024, source)
+		require.Error(t, err)
+	})
+
+	t.Run("xml body without converter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("<id>1</id>"))
+		req.Header.Set("Content-Type", "text/xml")
+
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, &datastore.Source{})
+		require.NoError(t, err)
+		require.Equal(t, []byte(`"\u003cid\u003e1\u003c/id\u003e"`), payload.data)
+	})
+
+	t.Run("urlencoded body with form converter disabled", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("key1=value1"))
+		req.Header.Set("Content-Type", urlEncodedContentType)
+
+		source := &datastore.Source{Converters: []string{}}
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, source)
+		require.NoError(t, err)
+		require.Equal(t, []byte(`"key1=value1"`), payload.data)
+	})
+
+	t.Run("binary body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte{0xff, 0x00, 0x01}))
+		req.Header.Set("Content-Type", "application/octet-stream")
+
+		payload, err := extractPayloadFromIngestEventReq(req, 1024, &datastore.Source{})
+		require.NoError(t, err)
+		require.Equal(t, []byte(`"/wAB"`), payload.data)
+		require.Equal(t, []byte{0xff, 0x00, 0x01}, payload.raw)
+
+		// the body can be read again, e.g. to dedupe the request
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, payload.raw, body)
+	})
+}
+
+func Test_writeReceiverResponse(t *testing.T) {
+	t.Run("relays the endpoint's response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+		writeReceiverResponse(w, req, &models.ReceiverResponse{
+			StatusCode: http.StatusForbidden,
+			Headers:    datastore.HttpHeader{"Content-Type": "application/json", "Content-Length": "99", "X-Decision": "deny"},
+			Body:       `{"allowed":false}`,
+		})
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Equal(t, "deny", w.Header().Get("X-Decision"))
+		require.Empty(t, w.Header().Get("Content-Length"))
+		require.Equal(t, `{"allowed":false}`, w.Body.String())
+	})
+
+	t.Run("unreachable endpoint", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+		writeReceiverResponse(w, req, &models.ReceiverResponse{Error: "connection refused"})
+
+		require.Equal(t, http.StatusBadGateway, w.Code)
+		require.Contains(t, w.Body.String(), "connection refused")
 	})
 }
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+)
+
+type QueryListAuditLog struct {
+	// The project to filter by, only used for organisation audit logs
+	ProjectID string `json:"projectId"`
+
+	// The user, api key or portal link that took the action
+	ActorID string `json:"actorId"`
+
+	// The resource type to filter by, e.g. endpoint or api_key
+	ResourceType string `json:"resourceType"`
+
+	// The resource to filter by
+	ResourceID string `json:"resourceId"`
+
+	// The action to filter by, e.g. created or deleted
+	Action string `json:"action"`
+
+	SearchParams
+	Pageable
+}
+
+type QueryListAuditLogResponse struct {
+	Filter   *datastore.AuditLogFilter
+	Pageable datastore.Pageable
+}
+
+func (ql *QueryListAuditLog) Transform(r *http.Request) (*QueryListAuditLogResponse, error) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryListAuditLogResponse{
+		Filter: &datastore.AuditLogFilter{
+			ProjectID:    r.URL.Query().Get("projectId"),
+			ActorID:      r.URL.Query().Get("actorId"),
+			ResourceType: datastore.AuditResourceType(r.URL.Query().Get("resourceType")),
+			ResourceID:   r.URL.Query().Get("resourceId"),
+			Action:       datastore.AuditAction(r.URL.Query().Get("action")),
+			SearchParams: searchParams,
+		},
+		Pageable: m.GetPageableFromContext(r.Context()),
+	}, nil
+}
+
+type AuditLogResponse struct {
+	*datastore.AuditLog
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/util"
 	"gopkg.in/guregu/null.v4"
@@ -45,6 +47,46 @@ type ConfigurationResponse struct {
 	ApiVersion string `json:"api_version"`
 }
 
+// KeyRotationResponse is the progress of an encryption key rotation, the
+// versions of the keys aren't returned.
+type KeyRotationResponse struct {
+	UID    string              `json:"uid"`
+	Status datastore.JobStatus `json:"status"`
+
+	// Number of encrypted rows
+	Total int64 `json:"total"`
+
+	// Number of encrypted rows re-encrypted with the new key
+	Processed int64  `json:"processed"`
+	Error     string `json:"error,omitempty"`
+
+	StartedAt   null.Time `json:"started_at,omitempty" swaggertype:"string"`
+	FailedAt    null.Time `json:"failed_at,omitempty" swaggertype:"string"`
+	CompletedAt null.Time `json:"completed_at,omitempty" swaggertype:"string"`
+	CreatedAt   time.Time `json:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty" swaggertype:"string"`
+}
+
+func NewKeyRotationResponse(job *datastore.Job) *KeyRotationResponse {
+	k := &KeyRotationResponse{
+		UID:         job.UID,
+		Status:      job.Status,
+		StartedAt:   job.StartedAt,
+		FailedAt:    job.FailedAt,
+		CompletedAt: job.CompletedAt,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+
+	if job.Progress != nil {
+		k.Total = job.Progress.Total
+		k.Processed = job.Progress.Processed
+		k.Error = job.Progress.Error
+	}
+
+	return k
+}
+
 type StoragePolicyConfiguration struct {
 	// Storage policy type e.g on_prem or s3
 	Type datastore.StorageType `json:"type,omitempty" valid:"supported_storage~please provide a valid storage type,required"`
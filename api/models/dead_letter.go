@@ -0,0 +1,143 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+)
+
+const (
+	defaultRedriveRate  = 10
+	maxRedriveRate      = 1000
+	defaultRedriveLimit = 1000
+	maxRedriveLimit     = 10000
+)
+
+type QueryListDeadLetter struct {
+	// A list of endpoint IDs to filter by
+	EndpointIDs []string `json:"endpointId"`
+
+	// EventType to filter by
+	EventType string `json:"eventType"`
+
+	// The status to filter by, one of pending or redriven
+	Status string `json:"status"`
+
+	SearchParams
+	Pageable
+}
+
+type QueryListDeadLetterResponse struct {
+	Filter   *datastore.DeadLetterFilter
+	Pageable datastore.Pageable
+}
+
+func (ql *QueryListDeadLetter) Transform(r *http.Request) (*QueryListDeadLetterResponse, error) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	status := datastore.DeadLetterStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", datastore.PendingDeadLetterStatus, datastore.RedrivenDeadLetterStatus:
+	default:
+		return nil, errors.New("status must be one of pending or redriven")
+	}
+
+	return &QueryListDeadLetterResponse{
+		Filter: &datastore.DeadLetterFilter{
+			EndpointIDs:  getEndpointIDs(r),
+			EventType:    r.URL.Query().Get("eventType"),
+			Status:       status,
+			SearchParams: searchParams,
+		},
+		Pageable: m.GetPageableFromContext(r.Context()),
+	}, nil
+}
+
+type RedriveDeadLetters struct {
+	// IDs of the dead letters to redrive, every pending dead letter
+	// matching the other filters is redriven when it is empty
+	IDs []string `json:"ids"`
+
+	// A list of endpoint IDs to filter by
+	EndpointIDs []string `json:"endpoint_ids"`
+
+	// EventType to filter by
+	EventType string `json:"event_type"`
+
+	// Only redrive deliveries dead-lettered at or after this time
+	StartDate *time.Time `json:"start_date,omitempty"`
+
+	// Only redrive deliveries dead-lettered at or before this time
+	EndDate *time.Time `json:"end_date,omitempty"`
+
+	// Send the deliveries to this endpoint instead of the one they were dead-lettered from
+	TargetEndpointID string `json:"target_endpoint_id"`
+
+	// The number of deliveries sent per second, it defaults to 10
+	Rate int `json:"rate"`
+
+	// The maximum number of dead letters to redrive, it defaults to 1000
+	Limit int `json:"limit"`
+}
+
+func (rd *RedriveDeadLetters) Validate() error {
+	if rd.Rate < 0 || rd.Rate > maxRedriveRate {
+		return errors.New("rate must be between 1 and 1000")
+	}
+
+	if rd.Limit < 0 || rd.Limit > maxRedriveLimit {
+		return errors.New("limit must be between 1 and 10000")
+	}
+
+	if rd.StartDate != nil && rd.EndDate != nil {
+		if err := m.EnsurePeriod(*rd.StartDate, *rd.EndDate); err != nil {
+			return err
+		}
+	}
+
+	if rd.Rate == 0 {
+		rd.Rate = defaultRedriveRate
+	}
+
+	if rd.Limit == 0 {
+		rd.Limit = defaultRedriveLimit
+	}
+
+	return nil
+}
+
+func (rd *RedriveDeadLetters) Filter() *datastore.DeadLetterFilter {
+	filter := &datastore.DeadLetterFilter{
+		IDs:         rd.IDs,
+		EndpointIDs: rd.EndpointIDs,
+		EventType:   rd.EventType,
+	}
+
+	if rd.StartDate != nil || rd.EndDate != nil {
+		filter.SearchParams.CreatedAtEnd = time.Now().Unix()
+		if rd.StartDate != nil {
+			filter.SearchParams.CreatedAtStart = rd.StartDate.Unix()
+		}
+
+		if rd.EndDate != nil {
+			filter.SearchParams.CreatedAtEnd = rd.EndDate.Unix()
+		}
+	}
+
+	return filter
+}
+
+type RedriveDeadLettersResponse struct {
+	Redriven int `json:"redriven"`
+	Failed   int `json:"failed"`
+}
+
+type DeadLetterResponse struct {
+	*datastore.DeadLetter
+}
@@ -1,11 +1,14 @@
 package models
 
 import (
+	"fmt"
+	"mime"
 	"net/http"
 	"strings"
 
 	"github.com/frain-dev/convoy/datastore"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/pkg/signature"
 	"github.com/frain-dev/convoy/util"
 )
 
@@ -58,11 +61,71 @@ type CreateEndpoint struct {
 	// the internet.
 	Authentication *EndpointAuthentication `json:"authentication"`
 
+	// StatusCodePolicies define what happens to an event delivery when the endpoint
+	// responds with specific status codes. They take precedence over the project's
+	// retry strategy status code policies.
+	StatusCodePolicies StatusCodePolicies `json:"status_code_policies"`
+
+	// HttpMethod is the http method event deliveries are sent with. Supported values
+	// are POST, PUT and PATCH. Defaults to POST.
+	HttpMethod string `json:"http_method" valid:"optional,in(POST|PUT|PATCH)~unsupported http method"`
+
+	// ContentType is the Content-Type header of event deliveries. If left unspecified,
+	// it is derived from the body encoding.
+	ContentType string `json:"content_type"`
+
+	// BodyEncoding controls how the event payload is written into the request body.
+	// Supported values are json, form, cloudevents, raw and original. Defaults to json.
+	// original sends events with the body and content type they were ingested with.
+	BodyEncoding string `json:"body_encoding" valid:"optional,in(json|form|cloudevents|raw|original)~unsupported body encoding"`
+
+	// OrderedDelivery sends events to the endpoint one at a time in the order they
+	// were created. A failing delivery blocks the deliveries behind it until it
+	// succeeds, runs out of retries or is discarded.
+	OrderedDelivery *bool `json:"ordered_delivery"`
+
+	// OrderingKey scopes ordered delivery so that only deliveries with the same key
+	// block each other. It is a path into the event payload e.g. data.account_id,
+	// or a header name prefixed with header. e.g. header.X-Account-Id
+	OrderingKey string `json:"ordering_key"`
+
+	// BatchDelivery coalesces deliveries to the endpoint into a single request
+	// whose body is a JSON array of the event payloads.
+	BatchDelivery *bool `json:"batch_delivery"`
+
+	// BatchSize is the maximum number of events sent in one batch, defaults to 100.
+	BatchSize uint64 `json:"batch_size"`
+
+	// BatchWindow is how long in milliseconds a delivery waits for the batch to
+	// fill up before it is sent, defaults to 1000.
+	BatchWindow uint64 `json:"batch_window"`
+
+	// MtlsClientCert is the client certificate presented to the endpoint when
+	// it requires mutual TLS.
+	MtlsClientCert *MtlsClientCert `json:"mtls_client_cert"`
+
+	// VerificationRequired keeps the endpoint pending until it answers a signed
+	// challenge. The challenge is POSTed to the endpoint's url as
+	// {"type": "endpoint.verification", "endpoint_id": "...", "challenge": "...", "timestamp": 0}
+	// and the endpoint responds with the hex encoded HMAC-SHA256 of the challenge
+	// keyed with its secret, either as the whole body or in the challenge_response
+	// field of a json object. It is repeated when the url changes.
+	VerificationRequired *bool `json:"verification_required"`
+
+	// HealthCheck sends the endpoint a signed endpoint.health_check request every
+	// five minutes. An inactive endpoint is activated when it responds with a 2xx
+	// status code, and an active endpoint is deactivated after three failed checks in a row.
+	HealthCheck *bool `json:"health_check"`
+
 	// Deprecated but necessary for backward compatibility
 	AppID string
 }
 
 func (cE *CreateEndpoint) Validate() error {
+	if err := validateContentType(cE.ContentType); err != nil {
+		return err
+	}
+
 	return util.Validate(cE)
 }
 
@@ -115,12 +178,89 @@ type UpdateEndpoint struct {
 	// shouldn't be needed often because webhook endpoints usually should be exposed to
 	// the internet.
 	Authentication *EndpointAuthentication `json:"authentication"`
+
+	// StatusCodePolicies define what happens to an event delivery when the endpoint
+	// responds with specific status codes. They take precedence over the project's
+	// retry strategy status code policies.
+	StatusCodePolicies StatusCodePolicies `json:"status_code_policies"`
+
+	// HttpMethod is the http method event deliveries are sent with. Supported values
+	// are POST, PUT and PATCH. Defaults to POST.
+	HttpMethod string `json:"http_method" valid:"optional,in(POST|PUT|PATCH)~unsupported http method"`
+
+	// ContentType is the Content-Type header of event deliveries. An empty string
+	// clears it so that it is derived from the body encoding, it is also cleared
+	// when the body encoding changes and it isn't set.
+	ContentType *string `json:"content_type"`
+
+	// BodyEncoding controls how the event payload is written into the request body.
+	// Supported values are json, form, cloudevents, raw and original. Defaults to json.
+	// original sends events with the body and content type they were ingested with.
+	BodyEncoding string `json:"body_encoding" valid:"optional,in(json|form|cloudevents|raw|original)~unsupported body encoding"`
+
+	// OrderedDelivery sends events to the endpoint one at a time in the order they
+	// were created. A failing delivery blocks the deliveries behind it until it
+	// succeeds, runs out of retries or is discarded.
+	OrderedDelivery *bool `json:"ordered_delivery"`
+
+	// OrderingKey scopes ordered delivery so that only deliveries with the same key
+	// block each other. It is a path into the event payload e.g. data.account_id,
+	// or a header name prefixed with header. e.g. header.X-Account-Id
+	OrderingKey string `json:"ordering_key"`
+
+	// BatchDelivery coalesces deliveries to the endpoint into a single request
+	// whose body is a JSON array of the event payloads.
+	BatchDelivery *bool `json:"batch_delivery"`
+
+	// BatchSize is the maximum number of events sent in one batch, defaults to 100.
+	BatchSize uint64 `json:"batch_size"`
+
+	// BatchWindow is how long in milliseconds a delivery waits for the batch to
+	// fill up before it is sent, defaults to 1000.
+	BatchWindow uint64 `json:"batch_window"`
+
+	// MtlsClientCert is the client certificate presented to the endpoint when
+	// it requires mutual TLS.
+	MtlsClientCert *MtlsClientCert `json:"mtls_client_cert"`
+
+	// VerificationRequired keeps the endpoint pending until it answers a signed
+	// challenge. The challenge is POSTed to the endpoint's url as
+	// {"type": "endpoint.verification", "endpoint_id": "...", "challenge": "...", "timestamp": 0}
+	// and the endpoint responds with the hex encoded HMAC-SHA256 of the challenge
+	// keyed with its secret, either as the whole body or in the challenge_response
+	// field of a json object. It is repeated when the url changes.
+	VerificationRequired *bool `json:"verification_required"`
+
+	// HealthCheck sends the endpoint a signed endpoint.health_check request every
+	// five minutes. An inactive endpoint is activated when it responds with a 2xx
+	// status code, and an active endpoint is deactivated after three failed checks in a row.
+	HealthCheck *bool `json:"health_check"`
 }
 
 func (uE *UpdateEndpoint) Validate() error {
+	if uE.ContentType != nil {
+		if err := validateContentType(*uE.ContentType); err != nil {
+			return err
+		}
+	}
+
 	return util.Validate(uE)
 }
 
+// validateContentType checks a content type can be sent as a Content-Type
+// header, an empty one is derived from the body encoding.
+func validateContentType(contentType string) error {
+	if util.IsStringEmpty(contentType) {
+		return nil
+	}
+
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return fmt.Errorf("invalid content_type: %v", err)
+	}
+
+	return nil
+}
+
 type QueryListEndpoint struct {
 	// The name of the endpoint
 	Name string `json:"q" example:"endpoint-1"`
@@ -145,8 +285,9 @@ func (q *QueryListEndpoint) Transform(r *http.Request) *QueryListEndpointRespons
 }
 
 type EndpointAuthentication struct {
-	Type   datastore.EndpointAuthenticationType `json:"type,omitempty" valid:"optional,in(api_key)~unsupported authentication type"`
+	Type   datastore.EndpointAuthenticationType `json:"type,omitempty" valid:"optional,in(api_key|oauth2)~unsupported authentication type"`
 	ApiKey *ApiKey                              `json:"api_key"`
+	OAuth2 *OAuth2                              `json:"oauth2"`
 }
 
 func (ea *EndpointAuthentication) Transform() *datastore.EndpointAuthentication {
@@ -157,9 +298,77 @@ func (ea *EndpointAuthentication) Transform() *datastore.EndpointAuthentication
 	return &datastore.EndpointAuthentication{
 		Type:   ea.Type,
 		ApiKey: ea.ApiKey.transform(),
+		OAuth2: ea.OAuth2.transform(),
+	}
+}
+
+type OAuth2 struct {
+	// TokenURL is where access tokens are requested with the client credentials grant
+	TokenURL string `json:"token_url" valid:"required"`
+
+	ClientID     string `json:"client_id" valid:"required"`
+	ClientSecret string `json:"client_secret" valid:"required"`
+
+	// Scopes requested for the access token
+	Scopes []string `json:"scopes"`
+
+	// Audience is sent with the token request, some identity providers require it
+	Audience string `json:"audience"`
+}
+
+func (o *OAuth2) transform() *datastore.OAuth2 {
+	if o == nil {
+		return nil
+	}
+
+	return &datastore.OAuth2{
+		TokenURL:     o.TokenURL,
+		ClientID:     o.ClientID,
+		ClientSecret: o.ClientSecret,
+		Scopes:       o.Scopes,
+		Audience:     o.Audience,
+	}
+}
+
+type MtlsClientCert struct {
+	// ClientCert is the PEM encoded client certificate
+	ClientCert string `json:"client_cert" valid:"required~please provide the client certificate"`
+
+	// ClientKey is the PEM encoded private key of the client certificate
+	ClientKey string `json:"client_key" valid:"required~please provide the client certificate key"`
+
+	// CACert is an optional PEM encoded CA bundle used to verify the endpoint's certificate
+	CACert string `json:"ca_cert"`
+}
+
+func (mc *MtlsClientCert) Transform() *datastore.MtlsClientCert {
+	if mc == nil {
+		return nil
+	}
+
+	return &datastore.MtlsClientCert{
+		ClientCert: mc.ClientCert,
+		ClientKey:  mc.ClientKey,
+		CACert:     mc.CACert,
 	}
 }
 
 type EndpointResponse struct {
 	*datastore.Endpoint
 }
+
+// NewEndpointResponse returns the endpoint along with the whsec_ form of its
+// secrets when the project signs its webhooks using Standard Webhooks.
+func NewEndpointResponse(endpoint *datastore.Endpoint, project *datastore.Project) *EndpointResponse {
+	if project != nil && project.Config != nil && project.Config.Signature.IsStandardWebhooks() {
+		for i := range endpoint.Secrets {
+			endpoint.Secrets[i].StandardWebhooksValue = signature.StandardWebhooksSecret(endpoint.Secrets[i].Value)
+		}
+	}
+
+	return &EndpointResponse{Endpoint: endpoint}
+}
+
+type EndpointProbeResponse struct {
+	*datastore.EndpointProbe
+}
@@ -3,14 +3,50 @@ package models
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/dedup"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/util"
 )
 
+// EventSchedule holds an event back until it is due, only one
+// of DeliverAt and Delay can be set.
+type EventSchedule struct {
+	// Specifies when the event should be sent to your endpoints
+	DeliverAt *time.Time `json:"deliver_at,omitempty"`
+
+	// Specifies the number of seconds to wait before the event is sent to your endpoints
+	Delay uint64 `json:"delay,omitempty"`
+}
+
+// DeliverTime returns when the event is due, a zero time means
+// the event should be sent immediately.
+func (es *EventSchedule) DeliverTime(now time.Time) (time.Time, error) {
+	if es.DeliverAt != nil && es.Delay > 0 {
+		return time.Time{}, errors.New("please provide either deliver_at or delay, not both")
+	}
+
+	if es.Delay > 0 {
+		return now.Add(time.Duration(es.Delay) * time.Second), nil
+	}
+
+	if es.DeliverAt != nil {
+		if !es.DeliverAt.After(now) {
+			return time.Time{}, errors.New("deliver_at must be in the future")
+		}
+
+		return *es.DeliverAt, nil
+	}
+
+	return time.Time{}, nil
+}
+
 type CreateEvent struct {
 	UID string `json:"uid" swaggerignore:"true"`
 
@@ -32,12 +68,61 @@ type CreateEvent struct {
 
 	// Specify a key for event deduplication
 	IdempotencyKey string `json:"idempotency_key"`
+
+	// DedupWindow is how long the idempotency key is remembered e.g. 24h, it
+	// can't be more than 720h. Without it, the key is compared with every
+	// earlier event of the project.
+	DedupWindow string `json:"dedup_window"`
+
+	// Synchronous waits for the first delivery attempt and returns the
+	// endpoint's response, it requires an endpoint id
+	Synchronous bool `json:"synchronous"`
+
+	// Specifies how long in seconds to wait for the endpoint's response, it
+	// defaults to 10 seconds and can't be more than 20 seconds
+	SyncTimeout int `json:"sync_timeout"`
+
+	EventSchedule
 }
 
+const (
+	DefaultSyncTimeout = 10 * time.Second
+
+	// MaxSyncTimeout is kept below the server's write timeout.
+	MaxSyncTimeout = 20 * time.Second
+)
+
 func (e *CreateEvent) Validate() error {
+	if e.Synchronous {
+		if util.IsStringEmpty(e.EndpointID) {
+			return errors.New("please provide an endpoint id for synchronous events")
+		}
+
+		if e.DeliverAt != nil || e.Delay > 0 {
+			return errors.New("synchronous events cannot be scheduled")
+		}
+
+		if e.SyncTimeout < 0 || time.Duration(e.SyncTimeout)*time.Second > MaxSyncTimeout {
+			return fmt.Errorf("sync_timeout must be between 0 and %d seconds", int(MaxSyncTimeout.Seconds()))
+		}
+	}
+
+	if _, err := dedup.ParseWindow(e.DedupWindow); err != nil {
+		return err
+	}
+
 	return util.Validate(e)
 }
 
+// SyncTimeoutDuration returns how long to wait for the endpoint's response.
+func (e *CreateEvent) SyncTimeoutDuration() time.Duration {
+	if e.SyncTimeout == 0 {
+		return DefaultSyncTimeout
+	}
+
+	return time.Duration(e.SyncTimeout) * time.Second
+}
+
 type DynamicEvent struct {
 	JobID string `json:"jid" swaggerignore:"true"`
 
@@ -68,10 +153,27 @@ type DynamicEvent struct {
 	// Specify a key for event deduplication
 	IdempotencyKey string `json:"idempotency_key"`
 
+	// DedupWindow is how long the idempotency key is remembered e.g. 24h, it
+	// can't be more than 720h. Without it, the key is compared with every
+	// earlier event of the project.
+	DedupWindow string `json:"dedup_window"`
+
+	EventSchedule
+
 	AcknowledgedAt time.Time `json:"acknowledged_at,omitempty"`
+
+	// SchemaStatus is set when the data is validated against the event type's schema
+	SchemaStatus datastore.EventSchemaStatus `json:"schema_status" swaggerignore:"true"`
+
+	// IsDuplicate is set when the idempotency key is checked against the dedup window
+	IsDuplicate *bool `json:"is_duplicate,omitempty" swaggerignore:"true"`
 }
 
 func (de *DynamicEvent) Validate() error {
+	if _, err := dedup.ParseWindow(de.DedupWindow); err != nil {
+		return err
+	}
+
 	return util.Validate(de)
 }
 
@@ -154,10 +256,27 @@ type BroadcastEvent struct {
 	// Specify a key for event deduplication
 	IdempotencyKey string `json:"idempotency_key"`
 
+	// DedupWindow is how long the idempotency key is remembered e.g. 24h, it
+	// can't be more than 720h. Without it, the key is compared with every
+	// earlier event of the project.
+	DedupWindow string `json:"dedup_window"`
+
+	EventSchedule
+
 	AcknowledgedAt time.Time `json:"acknowledged_at,omitempty"`
+
+	// SchemaStatus is set when the data is validated against the event type's schema
+	SchemaStatus datastore.EventSchemaStatus `json:"schema_status" swaggerignore:"true"`
+
+	// IsDuplicate is set when the idempotency key is checked against the dedup window
+	IsDuplicate *bool `json:"is_duplicate,omitempty" swaggerignore:"true"`
 }
 
 func (bs *BroadcastEvent) Validate() error {
+	if _, err := dedup.ParseWindow(bs.DedupWindow); err != nil {
+		return err
+	}
+
 	return util.Validate(bs)
 }
 
@@ -177,9 +296,26 @@ type FanoutEvent struct {
 
 	// Specify a key for event deduplication
 	IdempotencyKey string `json:"idempotency_key"`
+
+	// DedupWindow is how long the idempotency key is remembered e.g. 24h, it
+	// can't be more than 720h. Without it, the key is compared with every
+	// earlier event of the project.
+	DedupWindow string `json:"dedup_window"`
+
+	EventSchedule
+
+	// SchemaStatus is set when the data is validated against the event type's schema
+	SchemaStatus datastore.EventSchemaStatus `json:"schema_status" swaggerignore:"true"`
+
+	// IsDuplicate is set when the idempotency key is checked against the dedup window
+	IsDuplicate *bool `json:"is_duplicate,omitempty" swaggerignore:"true"`
 }
 
 func (fe *FanoutEvent) Validate() error {
+	if _, err := dedup.ParseWindow(fe.DedupWindow); err != nil {
+		return err
+	}
+
 	return util.Validate(fe)
 }
 
@@ -187,6 +323,44 @@ type EventResponse struct {
 	*datastore.Event
 }
 
+// SyncEventResponse is returned for synchronous events, Response holds
+// the endpoint's reply to the first delivery attempt.
+type SyncEventResponse struct {
+	EventID         string            `json:"event_id"`
+	EventDeliveryID string            `json:"event_delivery_id"`
+	Response        *ReceiverResponse `json:"response"`
+}
+
+type ReceiverResponse struct {
+	StatusCode int                  `json:"status_code"`
+	Headers    datastore.HttpHeader `json:"headers"`
+	Body       string               `json:"body"`
+	Error      string               `json:"error,omitempty"`
+}
+
+func NewSyncEventResponse(eventID string, eventDelivery *datastore.EventDelivery, attempt *datastore.DeliveryAttempt) *SyncEventResponse {
+	return &SyncEventResponse{
+		EventID:         eventID,
+		EventDeliveryID: eventDelivery.UID,
+		Response:        NewReceiverResponse(attempt),
+	}
+}
+
+// NewReceiverResponse returns the endpoint's response to the attempt, the
+// status code is zero when the endpoint couldn't be reached.
+func NewReceiverResponse(attempt *datastore.DeliveryAttempt) *ReceiverResponse {
+	// http_status is stored as the status line e.g. 200 OK
+	code, _, _ := strings.Cut(attempt.HttpResponseCode, " ")
+	statusCode, _ := strconv.Atoi(code)
+
+	return &ReceiverResponse{
+		StatusCode: statusCode,
+		Headers:    attempt.ResponseHeader,
+		Body:       string(attempt.ResponseData),
+		Error:      attempt.Error,
+	}
+}
+
 type QueryCountAffectedEvents struct {
 	SourceID   string `json:"sourceId"`
 	EndpointID string `json:"endpointId"`
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEvent_Validate_Synchronous(t *testing.T) {
+	deliverAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		event   CreateEvent
+		wantErr string
+	}{
+		{
+			name:  "valid_synchronous_event",
+			event: CreateEvent{EndpointID: "endpoint-1", Synchronous: true, SyncTimeout: 5},
+		},
+		{
+			name:    "missing_endpoint_id",
+			event:   CreateEvent{Synchronous: true},
+			wantErr: "please provide an endpoint id for synchronous events",
+		},
+		{
+			name:    "scheduled_synchronous_event",
+			event:   CreateEvent{EndpointID: "endpoint-1", Synchronous: true, EventSchedule: EventSchedule{DeliverAt: &deliverAt}},
+			wantErr: "synchronous events cannot be scheduled",
+		},
+		{
+			name:    "timeout_too_long",
+			event:   CreateEvent{EndpointID: "endpoint-1", Synchronous: true, SyncTimeout: 60},
+			wantErr: "sync_timeout must be between 0 and 20 seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.EventType = "invoice.paid"
+			tt.event.Data = json.RawMessage(`{}`)
+
+			err := tt.event.Validate()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewReceiverResponse(t *testing.T) {
+	resp := NewReceiverResponse(&datastore.DeliveryAttempt{
+		HttpResponseCode: "403 Forbidden",
+		ResponseHeader:   datastore.HttpHeader{"Content-Type": "application/json"},
+		ResponseData:     []byte(`{"allowed":false}`),
+	})
+	require.Equal(t, 403, resp.StatusCode)
+	require.Equal(t, `{"allowed":false}`, resp.Body)
+
+	resp = NewReceiverResponse(&datastore.DeliveryAttempt{Error: "connection refused"})
+	require.Equal(t, 0, resp.StatusCode)
+	require.Equal(t, "connection refused", resp.Error)
+}
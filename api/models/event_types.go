@@ -1,7 +1,11 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/eventschema"
 	"github.com/frain-dev/convoy/util"
 )
 
@@ -14,10 +18,21 @@ type CreateEventType struct {
 
 	// Description is used to describe what the event type does
 	Description string `json:"description"`
+
+	// JSONSchema is the JSON Schema the event type's payloads are validated against
+	JSONSchema json.RawMessage `json:"json_schema" swaggertype:"object"`
+
+	// SchemaValidation is what happens to payloads that don't match JSONSchema,
+	// they are either rejected or flagged, it defaults to reject
+	SchemaValidation datastore.SchemaValidation `json:"schema_validation"`
 }
 
 func (ce *CreateEventType) Validate() error {
-	return util.Validate(ce)
+	if err := util.Validate(ce); err != nil {
+		return err
+	}
+
+	return validateSchema(ce.JSONSchema, ce.SchemaValidation)
 }
 
 type EventTypeResponse struct {
@@ -28,10 +43,39 @@ type EventTypeListResponse struct {
 	EventTypes []datastore.ProjectEventType `json:"event_types"`
 }
 
+type EventTypeSchemaListResponse struct {
+	Schemas []datastore.EventTypeSchema `json:"schemas"`
+}
+
 type UpdateEventType struct {
 	// Category is a product-specific grouping for the event type
 	Category string `json:"category"`
 
 	// Description is used to describe what the event type does
 	Description string `json:"description"`
+
+	// JSONSchema replaces the event type's schema, the schema version is
+	// bumped when it changes
+	JSONSchema json.RawMessage `json:"json_schema" swaggertype:"object"`
+
+	// SchemaValidation is what happens to payloads that don't match JSONSchema,
+	// they are either rejected or flagged
+	SchemaValidation datastore.SchemaValidation `json:"schema_validation"`
+}
+
+func (ue *UpdateEventType) Validate() error {
+	return validateSchema(ue.JSONSchema, ue.SchemaValidation)
+}
+
+func validateSchema(schema json.RawMessage, validation datastore.SchemaValidation) error {
+	if validation != "" && !validation.IsValid() {
+		return errors.New("schema_validation must be one of reject or flag")
+	}
+
+	if len(schema) == 0 || string(schema) == "null" {
+		return nil
+	}
+
+	_, err := eventschema.Compile(schema)
+	return err
 }
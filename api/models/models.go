@@ -2,10 +2,12 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
 	"gopkg.in/guregu/null.v4"
 )
 
@@ -31,15 +33,33 @@ type APIKey struct {
 	ExpiresAt null.Time         `json:"expires_at"`
 }
 
+// ProjectAPIKey is a project api key limited to Permissions, keys
+// without permissions are granted every permission on the project.
+type ProjectAPIKey struct {
+	Name        string           `json:"name" valid:"required~please provide a name for the api key"`
+	Permissions auth.Permissions `json:"permissions"`
+	ExpiresAt   null.Time        `json:"expires_at"`
+}
+
+func (pk *ProjectAPIKey) Validate() error {
+	if pk.ExpiresAt.Valid && !pk.ExpiresAt.Time.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+
+	return util.Validate(pk)
+}
+
 type PersonalAPIKey struct {
-	Name       string `json:"name"`
-	Expiration int    `json:"expiration"`
+	Name        string           `json:"name"`
+	Expiration  int              `json:"expiration"`
+	Permissions auth.Permissions `json:"permissions,omitempty"`
 }
 
 type Role struct {
-	Type    auth.RoleType `json:"type"`
-	Project string        `json:"project"`
-	App     string        `json:"app,omitempty"`
+	Type        auth.RoleType    `json:"type"`
+	Project     string           `json:"project"`
+	App         string           `json:"app,omitempty"`
+	Permissions auth.Permissions `json:"permissions,omitempty"`
 }
 
 type UpdateOrganisationMember struct {
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestProjectAPIKey_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     *ProjectAPIKey
+		wantErr string
+	}{
+		{
+			name: "should_pass_validation",
+			key:  &ProjectAPIKey{Name: "ci", ExpiresAt: null.TimeFrom(time.Now().Add(time.Hour))},
+		},
+		{
+			name: "should_pass_validation_without_expiry",
+			key:  &ProjectAPIKey{Name: "ci"},
+		},
+		{
+			name:    "should_error_for_expiry_in_the_past",
+			key:     &ProjectAPIKey{Name: "ci", ExpiresAt: null.TimeFrom(time.Now().Add(-time.Hour))},
+			wantErr: "expires_at must be in the future",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Validate()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEndpoint_Validate_ContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		wantErr     bool
+	}{
+		{name: "should_allow_empty_content_type", contentType: ""},
+		{name: "should_allow_content_type", contentType: "application/json"},
+		{name: "should_allow_content_type_with_params", contentType: "application/x-www-form-urlencoded; charset=utf-8"},
+		{name: "should_error_for_malformed_content_type", contentType: "application/json;;", wantErr: true},
+		{name: "should_error_for_content_type_without_subtype", contentType: "application/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := "endpoint"
+			ce := &CreateEndpoint{Name: name, URL: "https://example.com", ContentType: tt.contentType}
+			ue := &UpdateEndpoint{Name: &name, URL: "https://example.com", ContentType: &tt.contentType}
+
+			for _, err := range []error{ce.Validate(), ue.Validate()} {
+				if tt.wantErr {
+					require.ErrorContains(t, err, "invalid content_type")
+					continue
+				}
+
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -127,6 +127,10 @@ type StrategyConfiguration struct {
 	Type       string `json:"type" valid:"optional~please provide a valid strategy type, in(linear|exponential)~unsupported strategy type"`
 	Duration   uint64 `json:"duration" valid:"optional~please provide a valid duration in seconds,int"`
 	RetryCount uint64 `json:"retry_count" valid:"optional~please provide a valid retry count,int"`
+
+	// StatusCodePolicies define what happens to an event delivery when an endpoint
+	// responds with specific status codes, e.g. discard on 410 or fail on 400 and 422
+	StatusCodePolicies StatusCodePolicies `json:"status_code_policies"`
 }
 
 func (sc *StrategyConfiguration) transform() *datastore.StrategyConfiguration {
@@ -135,15 +139,51 @@ func (sc *StrategyConfiguration) transform() *datastore.StrategyConfiguration {
 	}
 
 	return &datastore.StrategyConfiguration{
-		Type:       datastore.StrategyProvider(sc.Type),
-		Duration:   sc.Duration,
-		RetryCount: sc.RetryCount,
+		Type:               datastore.StrategyProvider(sc.Type),
+		Duration:           sc.Duration,
+		RetryCount:         sc.RetryCount,
+		StatusCodePolicies: sc.StatusCodePolicies.Transform(),
 	}
 }
 
+type StatusCodePolicy struct {
+	// Endpoint response status codes this policy applies to
+	StatusCodes []int `json:"status_codes"`
+
+	// Action is one of retry, discard or fail. Discarded and failed deliveries
+	// are not retried
+	Action string `json:"action" valid:"required~please provide a status code action,in(retry|discard|fail)~unsupported status code action"`
+}
+
+type StatusCodePolicies []StatusCodePolicy
+
+func (sp StatusCodePolicies) Transform() datastore.StatusCodePolicies {
+	if sp == nil {
+		return nil
+	}
+
+	p := make(datastore.StatusCodePolicies, 0, len(sp))
+	for _, policy := range sp {
+		p = append(p, datastore.StatusCodePolicy{
+			StatusCodes: policy.StatusCodes,
+			Action:      datastore.StatusCodeAction(policy.Action),
+		})
+	}
+
+	return p
+}
+
 type SignatureConfiguration struct {
 	Header   config.SignatureHeaderProvider `json:"header,omitempty" valid:"required~please provide a valid signature header"`
 	Versions []SignatureVersion             `json:"versions"`
+
+	// Scheme is the signature format sent to endpoints, supported values are
+	// `convoy` and `standard_webhooks`. Defaults to convoy.
+	Scheme string `json:"scheme" valid:"optional,in(convoy|standard_webhooks)~unsupported signature scheme"`
+
+	// Asymmetric makes standard webhooks signatures use Ed25519 instead of
+	// HMAC-SHA256, receivers then only hold a public key.
+	Asymmetric bool `json:"asymmetric"`
 }
 
 func (sc *SignatureConfiguration) transform() *datastore.SignatureConfiguration {
@@ -151,7 +191,16 @@ func (sc *SignatureConfiguration) transform() *datastore.SignatureConfiguration
 		return nil
 	}
 
-	s := &datastore.SignatureConfiguration{Header: sc.Header}
+	s := &datastore.SignatureConfiguration{
+		Header:     sc.Header,
+		Scheme:     datastore.SignatureScheme(sc.Scheme),
+		Asymmetric: sc.Asymmetric,
+	}
+
+	if util.IsStringEmpty(sc.Scheme) {
+		s.Scheme = datastore.ConvoySignatureScheme
+	}
+
 	for _, version := range sc.Versions {
 		s.Versions = append(s.Versions, datastore.SignatureVersion{
 			UID:       version.UID,
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+)
+
+type QueryListScheduledEvent struct {
+	// The status to filter by, one of Scheduled, Dispatched or Cancelled
+	Status string `json:"status"`
+
+	SearchParams
+	Pageable
+}
+
+type QueryListScheduledEventResponse struct {
+	*datastore.Filter
+	Status datastore.ScheduleStatus
+}
+
+func (ql *QueryListScheduledEvent) Transform(r *http.Request) (*QueryListScheduledEventResponse, error) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	status := datastore.ScheduleStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", datastore.ScheduleActiveStatus, datastore.ScheduleDispatchedStatus, datastore.ScheduleCancelledStatus:
+	default:
+		return nil, errors.New("status must be one of Scheduled, Dispatched or Cancelled")
+	}
+
+	return &QueryListScheduledEventResponse{
+		Filter: &datastore.Filter{
+			SearchParams: searchParams,
+			Pageable:     m.GetPageableFromContext(r.Context()),
+		},
+		Status: status,
+	}, nil
+}
+
+type RescheduleEvent struct {
+	EventSchedule
+}
+
+func (re *RescheduleEvent) Validate() error {
+	if re.DeliverAt == nil && re.Delay == 0 {
+		return errors.New("please provide either deliver_at or delay")
+	}
+
+	return nil
+}
+
+type ScheduledEventResponse struct {
+	*datastore.ScheduledEvent
+}
@@ -0,0 +1,296 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+const (
+	SCIMUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SCIMPatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+var (
+	ErrSCIMMissingUserName = errors.New("userName is required")
+	ErrSCIMInvalidPatch    = errors.New("invalid patch operation")
+)
+
+// SCIMUser is the SCIM 2.0 core user resource, only the attributes convoy
+// stores are read.
+type SCIMUser struct {
+	Schemas    []string        `json:"schemas"`
+	ID         string          `json:"id,omitempty"`
+	ExternalID string          `json:"externalId,omitempty"`
+	UserName   string          `json:"userName"`
+	Name       SCIMName        `json:"name"`
+	Emails     []SCIMAttribute `json:"emails,omitempty"`
+	Active     *bool           `json:"active,omitempty"`
+	Roles      []SCIMAttribute `json:"roles,omitempty"`
+	Meta       *SCIMMeta       `json:"meta,omitempty"`
+}
+
+type SCIMName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMAttribute is an entry of a multi-valued attribute like emails.
+type SCIMAttribute struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type SCIMMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+func (u *SCIMUser) Validate() error {
+	if strings.TrimSpace(u.UserName) == "" {
+		return ErrSCIMMissingUserName
+	}
+
+	return nil
+}
+
+// Email returns the user's primary email, identity providers that don't
+// send emails use the email as the userName.
+func (u *SCIMUser) Email() string {
+	email := u.UserName
+	for i, e := range u.Emails {
+		if e.Primary || i == 0 {
+			email = e.Value
+		}
+
+		if e.Primary {
+			break
+		}
+	}
+
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsActive reports whether the user should be a member of the organisation,
+// users are active unless the identity provider says otherwise.
+func (u *SCIMUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// RoleValues returns the values of the user's roles.
+func (u *SCIMUser) RoleValues() []string {
+	values := make([]string, 0, len(u.Roles))
+	for _, r := range u.Roles {
+		values = append(values, r.Value)
+	}
+
+	return values
+}
+
+func NewSCIMUser(s *datastore.SCIMUser, location string) *SCIMUser {
+	active := s.Active
+
+	return &SCIMUser{
+		Schemas:    []string{SCIMUserSchema},
+		ID:         s.UID,
+		ExternalID: s.ExternalID,
+		UserName:   s.UserMetadata.Email,
+		Name: SCIMName{
+			GivenName:  s.UserMetadata.FirstName,
+			FamilyName: s.UserMetadata.LastName,
+		},
+		Emails: []SCIMAttribute{{Value: s.UserMetadata.Email, Type: "work", Primary: true}},
+		Active: &active,
+		Meta: &SCIMMeta{
+			ResourceType: "User",
+			Created:      s.CreatedAt,
+			LastModified: s.UpdatedAt,
+			Location:     location,
+		},
+	}
+}
+
+type SCIMListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    []*SCIMUser `json:"Resources"`
+}
+
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+func NewSCIMError(status int, scimType, detail string) *SCIMError {
+	return &SCIMError{
+		Schemas:  []string{SCIMErrorSchema},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	}
+}
+
+type SCIMPatchOp struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies the patch operations to u. Operations without a path carry
+// an object of attributes, identity providers differ in the case of the op
+// and in sending booleans as strings, both are accepted.
+func (p *SCIMPatchOp) Apply(u *SCIMUser) error {
+	for _, op := range p.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			if op.Path != "" {
+				if err := u.set(op.Path, op.Value); err != nil {
+					return err
+				}
+				continue
+			}
+
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return fmt.Errorf("%w: value must be an object when path is empty", ErrSCIMInvalidPatch)
+			}
+
+			for path, value := range attrs {
+				if err := u.set(path, value); err != nil {
+					return err
+				}
+			}
+		case "remove":
+			if err := u.remove(op.Path); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: unsupported op %q", ErrSCIMInvalidPatch, op.Op)
+		}
+	}
+
+	return nil
+}
+
+func (u *SCIMUser) set(path string, value json.RawMessage) error {
+	var err error
+
+	switch normalisePath(path) {
+	case "active":
+		var active bool
+		active, err = parseBool(value)
+		u.Active = &active
+	case "username":
+		err = json.Unmarshal(value, &u.UserName)
+	case "externalid":
+		err = json.Unmarshal(value, &u.ExternalID)
+	case "name":
+		err = json.Unmarshal(value, &u.Name)
+	case "name.givenname":
+		err = json.Unmarshal(value, &u.Name.GivenName)
+	case "name.familyname":
+		err = json.Unmarshal(value, &u.Name.FamilyName)
+	case "emails":
+		err = json.Unmarshal(value, &u.Emails)
+	case `emails[type eq "work"].value`, "emails.value":
+		var email string
+		err = json.Unmarshal(value, &email)
+		u.Emails = []SCIMAttribute{{Value: email, Type: "work", Primary: true}}
+	case "roles":
+		err = json.Unmarshal(value, &u.Roles)
+	case "displayname", "schemas":
+		// not stored
+	default:
+		return fmt.Errorf("%w: unsupported path %q", ErrSCIMInvalidPatch, path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: invalid value for %q", ErrSCIMInvalidPatch, path)
+	}
+
+	return nil
+}
+
+func (u *SCIMUser) remove(path string) error {
+	switch normalisePath(path) {
+	case "externalid":
+		u.ExternalID = ""
+	case "roles":
+		u.Roles = []SCIMAttribute{}
+	case "name.givenname":
+		u.Name.GivenName = ""
+	case "name.familyname":
+		u.Name.FamilyName = ""
+	case "displayname":
+	default:
+		return fmt.Errorf("%w: unsupported path %q", ErrSCIMInvalidPatch, path)
+	}
+
+	return nil
+}
+
+func normalisePath(path string) string {
+	path = strings.TrimPrefix(path, SCIMUserSchema+":")
+	return strings.ToLower(strings.TrimSpace(path))
+}
+
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+
+	return strconv.ParseBool(strings.ToLower(s))
+}
+
+type SCIMServiceProviderConfig struct {
+	Schemas               []string             `json:"schemas"`
+	Patch                 SCIMSupported        `json:"patch"`
+	Bulk                  SCIMSupported        `json:"bulk"`
+	Filter                SCIMFilterSupported  `json:"filter"`
+	ChangePassword        SCIMSupported        `json:"changePassword"`
+	Sort                  SCIMSupported        `json:"sort"`
+	Etag                  SCIMSupported        `json:"etag"`
+	AuthenticationSchemes []SCIMAuthentication `json:"authenticationSchemes"`
+}
+
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type SCIMFilterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type SCIMAuthentication struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
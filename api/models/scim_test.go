@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIMPatchOp_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		want    *SCIMUser
+		wantErr bool
+	}{
+		{
+			name:  "should_apply_okta_deactivation",
+			patch: `{"Operations":[{"op":"replace","value":{"active":false}}]}`,
+			want: &SCIMUser{
+				UserName: "jane@example.com",
+				Name:     SCIMName{GivenName: "Jane", FamilyName: "Doe"},
+				Active:   boolPtr(false),
+			},
+		},
+		{
+			name:  "should_apply_azure_operations",
+			patch: `{"Operations":[{"op":"Replace","path":"active","value":"False"},{"op":"Add","path":"name.givenName","value":"Janet"},{"op":"Replace","path":"emails[type eq \"work\"].value","value":"janet@example.com"}]}`,
+			want: &SCIMUser{
+				UserName: "jane@example.com",
+				Name:     SCIMName{GivenName: "Janet", FamilyName: "Doe"},
+				Emails:   []SCIMAttribute{{Value: "janet@example.com", Type: "work", Primary: true}},
+				Active:   boolPtr(false),
+			},
+		},
+		{
+			name:  "should_apply_attributes_with_schema_prefix",
+			patch: `{"Operations":[{"op":"replace","value":{"urn:ietf:params:scim:schemas:core:2.0:User:externalId":"okta-2","name.familyName":"Smith"}}]}`,
+			want: &SCIMUser{
+				UserName:   "jane@example.com",
+				ExternalID: "okta-2",
+				Name:       SCIMName{GivenName: "Jane", FamilyName: "Smith"},
+				Active:     boolPtr(true),
+			},
+		},
+		{
+			name:    "should_error_for_unsupported_path",
+			patch:   `{"Operations":[{"op":"replace","path":"title","value":"CTO"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "should_error_for_unsupported_op",
+			patch:   `{"Operations":[{"op":"move","path":"active","value":true}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch SCIMPatchOp
+			require.NoError(t, json.Unmarshal([]byte(tt.patch), &patch))
+
+			u := &SCIMUser{
+				UserName: "jane@example.com",
+				Name:     SCIMName{GivenName: "Jane", FamilyName: "Doe"},
+				Active:   boolPtr(true),
+			}
+
+			err := patch.Apply(u)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrSCIMInvalidPatch)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, u)
+		})
+	}
+}
+
+func TestSCIMUser_Email(t *testing.T) {
+	u := &SCIMUser{UserName: "jane"}
+	require.Equal(t, "jane", u.Email())
+
+	u.Emails = []SCIMAttribute{{Value: "home@example.com"}, {Value: "Work@Example.com", Primary: true}}
+	require.Equal(t, "work@example.com", u.Email())
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -7,7 +7,9 @@ import (
 	"strings"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/dedup"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/pkg/verifier"
 	"github.com/frain-dev/convoy/util"
 )
 
@@ -34,10 +36,28 @@ type CreateSource struct {
 	// webhooks projects.
 	PubSub PubSubConfig `json:"pub_sub"`
 
+	// ChangeStream is used to stream row changes from a Postgres database,
+	// you only need to specify this when the source type is `db_change_stream`.
+	ChangeStream *ChangeStreamConfig `json:"change_stream"`
+
+	// RestApi is used to poll a REST API for new items, you only need to
+	// specify this when the source type is `rest_api`.
+	RestApi *RestApiConfig `json:"rest_api"`
+
 	// IdempotencyKeys are used to specify parts of a webhook request to uniquely
 	// identify the event in an incoming webhooks project.
 	IdempotencyKeys []string `json:"idempotency_keys"`
 
+	// DedupWindow is how long idempotency keys are remembered e.g. 24h, it
+	// can't be more than 720h. Without it, the keys are compared with every
+	// earlier event of the source.
+	DedupWindow string `json:"dedup_window"`
+
+	// Converters turn request bodies into JSON event data, supported values
+	// are form and xml. Sources without converters convert form bodies, set
+	// an empty list to keep every body as it was sent.
+	Converters []string `json:"converters"`
+
 	// Function is a javascript function used to mutate the payload
 	// immediately after ingesting an event
 	BodyFunction *string `json:"body_function"`
@@ -45,6 +65,11 @@ type CreateSource struct {
 	// Function is a javascript function used to mutate the headers
 	// immediately after ingesting an event
 	HeaderFunction *string `json:"header_function"`
+
+	// Synchronous makes ingest requests wait for the first delivery attempt
+	// and reply with the endpoint's response, a synchronous source can only
+	// have one subscription.
+	Synchronous bool `json:"synchronous"`
 }
 
 func (cs *CreateSource) Validate() error {
@@ -66,6 +91,14 @@ func (cs *CreateSource) Validate() error {
 		return err
 	}
 
+	if err := validateConverters(cs.Converters); err != nil {
+		return err
+	}
+
+	if _, err := dedup.ParseWindow(cs.DedupWindow); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -74,6 +107,10 @@ func validateSourceVerifier(cfg VerifierConfig) error {
 		return errors.New("invalid verifier config for hmac")
 	}
 
+	if cfg.HMac != nil && cfg.HMac.Tolerance < 0 {
+		return errors.New("invalid verifier config for hmac, tolerance cannot be negative")
+	}
+
 	if cfg.Type == datastore.APIKeyVerifier && cfg.ApiKey == nil {
 		return errors.New("invalid verifier config for api key")
 	}
@@ -82,6 +119,28 @@ func validateSourceVerifier(cfg VerifierConfig) error {
 		return errors.New("invalid verifier config for basic auth")
 	}
 
+	if cfg.Type == datastore.JWTVerifier {
+		if cfg.JWT == nil || (util.IsStringEmpty(cfg.JWT.JWKSURL) && util.IsStringEmpty(cfg.JWT.PublicKeys)) {
+			return errors.New("invalid verifier config for jwt, a jwks url or public keys are required")
+		}
+
+		if !util.IsStringEmpty(cfg.JWT.PublicKeys) {
+			if _, err := verifier.ParsePublicKeys(cfg.JWT.PublicKeys); err != nil {
+				return errors.New("invalid verifier config for jwt, the public keys are not valid PEM encoded keys")
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateConverters(converters []string) error {
+	for _, c := range converters {
+		if !datastore.PayloadConverter(c).IsValid() {
+			return fmt.Errorf("unsupported converter %s, supported converters are form and xml", c)
+		}
+	}
+
 	return nil
 }
 
@@ -120,7 +179,11 @@ func validateSourceForProvider(newSource *CreateSource) error {
 	switch newSource.Provider {
 	case datastore.GithubSourceProvider,
 		datastore.ShopifySourceProvider,
-		datastore.TwitterSourceProvider:
+		datastore.TwitterSourceProvider,
+		datastore.StripeSourceProvider,
+		datastore.SlackSourceProvider,
+		datastore.TwilioSourceProvider,
+		datastore.StandardWebhooksSourceProvider:
 		verifierConfig := newSource.Verifier
 		if verifierConfig.HMac == nil || verifierConfig.HMac.Secret == "" {
 			return fmt.Errorf("hmac secret is required for %s source", newSource.Provider)
@@ -156,10 +219,28 @@ type UpdateSource struct {
 	// webhooks projects, you only need to specify this when the source type is `pub_sub`.
 	PubSub *PubSubConfig `json:"pub_sub"`
 
+	// ChangeStream is used to stream row changes from a Postgres database,
+	// you only need to specify this when the source type is `db_change_stream`.
+	ChangeStream *ChangeStreamConfig `json:"change_stream"`
+
+	// RestApi is used to poll a REST API for new items, you only need to
+	// specify this when the source type is `rest_api`.
+	RestApi *RestApiConfig `json:"rest_api"`
+
 	// IdempotencyKeys are used to specify parts of a webhook request to uniquely
 	// identify the event in an incoming webhooks project.
 	IdempotencyKeys []string `json:"idempotency_keys"`
 
+	// DedupWindow is how long idempotency keys are remembered e.g. 24h, it
+	// can't be more than 720h. Set it to an empty string to compare the keys
+	// with every earlier event of the source.
+	DedupWindow *string `json:"dedup_window"`
+
+	// Converters turn request bodies into JSON event data, supported values
+	// are form and xml. Sources without converters convert form bodies, set
+	// an empty list to keep every body as it was sent.
+	Converters []string `json:"converters"`
+
 	// Function is a javascript function used to mutate the payload
 	// immediately after ingesting an event
 	BodyFunction *string `json:"body_function"`
@@ -167,6 +248,11 @@ type UpdateSource struct {
 	// Function is a javascript function used to mutate the headers
 	// immediately after ingesting an event
 	HeaderFunction *string `json:"header_function"`
+
+	// Synchronous makes ingest requests wait for the first delivery attempt
+	// and reply with the endpoint's response, a synchronous source can only
+	// have one subscription.
+	Synchronous *bool `json:"synchronous"`
 }
 
 func (us *UpdateSource) Validate() error {
@@ -182,6 +268,16 @@ func (us *UpdateSource) Validate() error {
 		return err
 	}
 
+	if err := validateConverters(us.Converters); err != nil {
+		return err
+	}
+
+	if us.DedupWindow != nil {
+		if _, err := dedup.ParseWindow(*us.DedupWindow); err != nil {
+			return err
+		}
+	}
+
 	return util.Validate(us)
 }
 
@@ -240,6 +336,7 @@ type VerifierConfig struct {
 	HMac      *HMac                  `json:"hmac" validate:"optional"`
 	BasicAuth *BasicAuth             `json:"basic_auth" validate:"optional"`
 	ApiKey    *ApiKey                `json:"api_key" validate:"optional"`
+	JWT       *JWTAuth               `json:"jwt" validate:"optional"`
 }
 
 func (vc *VerifierConfig) Transform() *datastore.VerifierConfig {
@@ -252,6 +349,7 @@ func (vc *VerifierConfig) Transform() *datastore.VerifierConfig {
 		HMac:      vc.HMac.transform(),
 		BasicAuth: vc.BasicAuth.transform(),
 		ApiKey:    vc.ApiKey.transform(),
+		JWT:       vc.JWT.transform(),
 	}
 }
 
@@ -260,6 +358,13 @@ type HMac struct {
 	Hash     string                 `json:"hash" valid:"supported_hash,required" validate:"required"`
 	Secret   string                 `json:"secret" valid:"required" validate:"required"`
 	Encoding datastore.EncodingType `json:"encoding" valid:"supported_encoding~please provide a valid encoding type,required" validate:"required"`
+
+	// The header the signed timestamp is sent in, it can be the signature
+	// header in the t=<timestamp>,v1=<signature> format
+	TimestampHeader string `json:"timestamp_header"`
+
+	// How old the signed timestamp can be in seconds, it defaults to 300
+	Tolerance int `json:"tolerance"`
 }
 
 func (hm *HMac) transform() *datastore.HMac {
@@ -268,10 +373,12 @@ func (hm *HMac) transform() *datastore.HMac {
 	}
 
 	return &datastore.HMac{
-		Header:   hm.Header,
-		Hash:     hm.Hash,
-		Secret:   hm.Secret,
-		Encoding: hm.Encoding,
+		Header:          hm.Header,
+		Hash:            hm.Hash,
+		Secret:          hm.Secret,
+		Encoding:        hm.Encoding,
+		TimestampHeader: hm.TimestampHeader,
+		Tolerance:       hm.Tolerance,
 	}
 }
 
@@ -307,6 +414,38 @@ func (ak *ApiKey) transform() *datastore.ApiKey {
 	}
 }
 
+type JWTAuth struct {
+	// Header the token is read from, defaults to the Authorization
+	// header with a Bearer token.
+	Header string `json:"header"`
+
+	// JWKSURL is the url of the key set used to verify the token.
+	JWKSURL string `json:"jwks_url" valid:"optional,url~please provide a valid jwks url"`
+
+	// PublicKeys are PEM encoded public keys or certificates used to verify
+	// the token, either this or the jwks url is required.
+	PublicKeys string `json:"public_keys"`
+
+	// Issuer and Audience are checked against the token's iss
+	// and aud claims when set.
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"`
+}
+
+func (ja *JWTAuth) transform() *datastore.JWTAuth {
+	if ja == nil {
+		return nil
+	}
+
+	return &datastore.JWTAuth{
+		Header:     ja.Header,
+		JWKSURL:    ja.JWKSURL,
+		PublicKeys: ja.PublicKeys,
+		Issuer:     ja.Issuer,
+		Audience:   ja.Audience,
+	}
+}
+
 type PubSubConfig struct {
 	Type    datastore.PubSubType `json:"type"`
 	Workers int                  `json:"workers"`
@@ -331,6 +470,86 @@ func (pc *PubSubConfig) Transform() *datastore.PubSubConfig {
 	}
 }
 
+type ChangeStreamConfig struct {
+	// DSN is the connection string of the database, the user needs
+	// the REPLICATION attribute.
+	DSN string `json:"dsn"`
+
+	// SlotName is the logical replication slot the changes are read from,
+	// it defaults to convoy_<source id>.
+	SlotName string `json:"slot_name"`
+
+	// Tables to stream changes from e.g. public.orders. Inserts, updates and
+	// deletes are ingested as <table>.created, <table>.updated and
+	// <table>.deleted events.
+	Tables []string `json:"tables"`
+
+	// PollInterval is how often changes are read in seconds, defaults to 1.
+	PollInterval int `json:"poll_interval"`
+}
+
+func (cs *ChangeStreamConfig) Transform() *datastore.ChangeStreamConfig {
+	if cs == nil {
+		return nil
+	}
+
+	return &datastore.ChangeStreamConfig{
+		DSN:          cs.DSN,
+		SlotName:     cs.SlotName,
+		Tables:       cs.Tables,
+		PollInterval: cs.PollInterval,
+	}
+}
+
+type RestApiConfig struct {
+	// URL is polled with a GET request.
+	URL string `json:"url"`
+
+	// Headers are sent with every request.
+	Headers map[string]string `json:"headers"`
+
+	// Authentication is used to authenticate the requests with an api key
+	// header or an oauth2 access token.
+	Authentication *EndpointAuthentication `json:"authentication"`
+
+	// ItemsPath is the JSONPath of the items array in the response e.g.
+	// $.data, it defaults to the root of the response.
+	ItemsPath string `json:"items_path"`
+
+	// IDPath is the JSONPath of the unique id in an item e.g. $.id, items
+	// that have been seen are skipped.
+	IDPath string `json:"id_path"`
+
+	// EventType is the event type of the events created from the items.
+	EventType string `json:"event_type"`
+
+	// CursorPath is the JSONPath of the next page cursor in the response,
+	// it is sent in the CursorParam query param of the next request.
+	CursorPath  string `json:"cursor_path"`
+	CursorParam string `json:"cursor_param"`
+
+	// PollInterval is how often the url is polled in seconds, defaults to 300.
+	PollInterval int `json:"poll_interval"`
+}
+
+func (rc *RestApiConfig) Transform() *datastore.RestApiConfig {
+	if rc == nil {
+		return nil
+	}
+
+	return &datastore.RestApiConfig{
+		URL:            rc.URL,
+		Headers:        rc.Headers,
+		Authentication: rc.Authentication.Transform(),
+		ItemsPath:      rc.ItemsPath,
+		IDPath:         rc.IDPath,
+		EventType:      rc.EventType,
+		CursorPath:     rc.CursorPath,
+		CursorParam:    rc.CursorParam,
+		PollInterval:   rc.PollInterval,
+	}
+}
+
 type SQSPubSubConfig struct {
 	AccessKeyID   string `json:"access_key_id"`
 	SecretKey     string `json:"secret_key"`
@@ -89,6 +89,55 @@ func TestCreateSource_Validate(t *testing.T) {
 			wantErr: true,
 		},
 
+		{
+			name: "should_error_for_stripe_without_hmac_secret",
+			source: &CreateSource{
+				Name:     "Convoy-Prod",
+				Type:     datastore.HTTPSource,
+				Provider: datastore.StripeSourceProvider,
+				Verifier: VerifierConfig{HMac: nil},
+			},
+			wantErr: true,
+		},
+
+		{
+			name: "should_pass_validation_for_jwt_verifier",
+			source: &CreateSource{
+				Name: "Convoy-Prod",
+				Type: datastore.HTTPSource,
+				Verifier: VerifierConfig{
+					Type: datastore.JWTVerifier,
+					JWT:  &JWTAuth{JWKSURL: "https://auth.example.com/.well-known/jwks.json"},
+				},
+			},
+		},
+
+		{
+			name: "should_error_for_jwt_verifier_without_keys",
+			source: &CreateSource{
+				Name: "Convoy-Prod",
+				Type: datastore.HTTPSource,
+				Verifier: VerifierConfig{
+					Type: datastore.JWTVerifier,
+					JWT:  &JWTAuth{Issuer: "https://auth.example.com"},
+				},
+			},
+			wantErr: true,
+		},
+
+		{
+			name: "should_error_for_jwt_verifier_with_invalid_public_keys",
+			source: &CreateSource{
+				Name: "Convoy-Prod",
+				Type: datastore.HTTPSource,
+				Verifier: VerifierConfig{
+					Type: datastore.JWTVerifier,
+					JWT:  &JWTAuth{PublicKeys: "not a pem"},
+				},
+			},
+			wantErr: true,
+		},
+
 		{
 			name: "should_fail_invalid_source_configuration",
 			source: &CreateSource{
@@ -27,6 +27,11 @@ func (op *OrganisationPolicy) Manage(ctx context.Context, res interface{}) error
 		return errors.New("Wrong organisation type")
 	}
 
+	// personal access tokens limited to a set of project permissions can't manage the organisation
+	if apiKey, ok := authCtx.APIKey.(*datastore.APIKey); ok && apiKey.Role.Permissions != nil {
+		return ErrNotAllowed
+	}
+
 	member, err := op.OrganisationMemberRepo.FetchOrganisationMemberByUserID(ctx, user.UID, org.UID)
 	if err != nil {
 		return ErrNotAllowed
@@ -69,6 +69,25 @@ func Test_OrganisationPolicy_Manage(t *testing.T) {
 				},
 			},
 		},
+		"personal_api_key": {
+			{
+				basetest: basetest{
+					name: "should_fail_when_key_is_limited_to_permissions",
+					authCtx: &auth.AuthenticatedUser{
+						User: &datastore.User{UID: "randomstring"},
+						APIKey: &datastore.APIKey{
+							Type: datastore.PersonalKey,
+							Role: auth.Role{Permissions: auth.Permissions{auth.PermissionEventsRead}},
+						},
+					},
+					assertion:     require.Error,
+					expectedError: ErrNotAllowed,
+				},
+				organisation: &datastore.Organisation{
+					UID: "randomstring",
+				},
+			},
+		},
 	}
 
 	for name, test := range testmatrix {
@@ -18,58 +18,133 @@ type ProjectPolicy struct {
 	Licenser               license.Licenser
 }
 
+// NewProjectPolicy returns the project policy with its rules set. Routes are
+// authorised and api key permissions are checked against the same policy.
+func NewProjectPolicy(licenser license.Licenser, orgRepo datastore.OrganisationRepository, orgMemberRepo datastore.OrganisationMemberRepository) *ProjectPolicy {
+	po := &ProjectPolicy{
+		BasePolicy:             authz.NewBasePolicy(),
+		Licenser:               licenser,
+		OrganisationRepo:       orgRepo,
+		OrganisationMemberRepo: orgMemberRepo,
+	}
+
+	po.SetRule("view", authz.RuleFunc(po.View))
+	po.SetRule("manage", authz.RuleFunc(po.Manage))
+
+	for _, perm := range auth.AllPermissions {
+		po.SetRule(perm.String(), po.Permission(perm))
+	}
+
+	return po
+}
+
+// Manage allows callers that can change the project's settings.
 func (pp *ProjectPolicy) Manage(ctx context.Context, res interface{}) error {
+	return pp.Permission(auth.PermissionProjectsWrite)(ctx, res)
+}
+
+// View allows callers granted any permission on the project.
+func (pp *ProjectPolicy) View(ctx context.Context, res interface{}) error {
+	perms, err := pp.Permissions(ctx, res)
+	if err != nil {
+		return err
+	}
+
+	if len(perms) == 0 {
+		return ErrNotAllowed
+	}
+
+	return nil
+}
+
+// Permission returns a rule allowing callers granted perm on the project.
+func (pp *ProjectPolicy) Permission(perm auth.Permission) authz.RuleFunc {
+	return func(ctx context.Context, res interface{}) error {
+		perms, err := pp.Permissions(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		if !perms.Has(perm) {
+			return ErrNotAllowed
+		}
+
+		return nil
+	}
+}
+
+// Permissions returns the permissions the caller is granted on the project.
+func (pp *ProjectPolicy) Permissions(ctx context.Context, res interface{}) (auth.Permissions, error) {
 	authCtx := ctx.Value(AuthUserCtx).(*auth.AuthenticatedUser)
 
 	project, ok := res.(*datastore.Project)
 	if !ok {
-		return errors.New("Wrong project type")
+		return nil, errors.New("Wrong project type")
 	}
 
 	org, err := pp.OrganisationRepo.FetchOrganisationByID(ctx, project.OrganisationID)
 	if err != nil {
-		return ErrNotAllowed
+		return nil, ErrNotAllowed
 	}
 
 	// Dashboard Access or Personal Access Token
 	if authCtx.User != nil {
 		user, ok := authCtx.User.(*datastore.User)
 		if !ok {
-			return ErrNotAllowed
+			return nil, ErrNotAllowed
 		}
 		member, err := pp.OrganisationMemberRepo.FetchOrganisationMemberByUserID(ctx, user.UID, org.UID)
 		if err != nil {
-			return ErrNotAllowed
+			return nil, ErrNotAllowed
 		}
 
-		// to allow admin roles, MultiPlayerMode must be enabled
-		adminAllowed := isAdmin(member) && pp.Licenser.MultiPlayerMode()
+		perms := pp.memberPermissions(member, project)
 
-		if isSuperAdmin(member) || adminAllowed {
-			return nil
+		// personal access tokens may be limited to a subset of the member's permissions
+		apiKey, ok := authCtx.APIKey.(*datastore.APIKey)
+		if ok && apiKey.Role.Permissions != nil {
+			perms = perms.Intersect(apiKey.Role.Permissions)
 		}
 
-		return ErrNotAllowed
+		return perms, nil
 	}
 
 	// API Key Access.
 	apiKey, ok := authCtx.APIKey.(*datastore.APIKey)
 	if !ok {
-		return ErrNotAllowed
+		return nil, ErrNotAllowed
 	}
 
 	if apiKey.Role.Project != project.UID {
-		return ErrNotAllowed
+		return nil, ErrNotAllowed
 	}
-	return nil
+
+	return apiKey.Role.Grants(), nil
 }
 
-func (pp *ProjectPolicy) GetName() string {
-	return "project"
+func (pp *ProjectPolicy) memberPermissions(member *datastore.OrganisationMember, project *datastore.Project) auth.Permissions {
+	switch member.Role.Type {
+	case auth.RoleSuperUser:
+		return member.Role.Grants()
+	case auth.RoleAdmin, auth.RoleCustom:
+		// to allow admin and custom roles, MultiPlayerMode must be enabled
+		if !pp.Licenser.MultiPlayerMode() {
+			return auth.Permissions{}
+		}
+
+		// custom roles scoped to a project only apply to that project
+		if member.Role.Type == auth.RoleCustom && member.Role.Project != "" && member.Role.Project != project.UID {
+			return auth.Permissions{}
+		}
+
+		return member.Role.Grants()
+	default:
+		return auth.Permissions{}
+	}
 }
 
-func isAdmin(m *datastore.OrganisationMember) bool {
-	return m.Role.Type == auth.RoleAdmin
+func (pp *ProjectPolicy) GetName() string {
+	return "project"
 }
 
 func isSuperAdmin(m *datastore.OrganisationMember) bool {
@@ -271,3 +271,192 @@ func Test_ProjectPolicy_Manage(t *testing.T) {
 		})
 	}
 }
+
+func Test_ProjectPolicy_Permission(t *testing.T) {
+	project := &datastore.Project{UID: "project-1", OrganisationID: "org-1"}
+
+	memberStore := func(role auth.Role, multiPlayer bool) func(*ProjectPolicy) {
+		return func(pp *ProjectPolicy) {
+			orgMemberRepo := pp.OrganisationMemberRepo.(*mocks.MockOrganisationMemberRepository)
+			orgMemberRepo.EXPECT().
+				FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").
+				Return(&datastore.OrganisationMember{UID: "member-1", Role: role}, nil)
+
+			licenser := pp.Licenser.(*mocks.MockLicenser)
+			licenser.EXPECT().MultiPlayerMode().Return(multiPlayer)
+		}
+	}
+
+	tests := []struct {
+		basetest
+		rule    string
+		storeFn func(*ProjectPolicy)
+	}{
+		{
+			basetest: basetest{
+				name: "should_allow_retry_only_api_key_to_retry",
+				authCtx: &auth.AuthenticatedUser{
+					APIKey: &datastore.APIKey{Role: auth.Role{
+						Type:        auth.RoleCustom,
+						Project:     "project-1",
+						Permissions: auth.Permissions{auth.PermissionDeliveriesRetry},
+					}},
+				},
+				assertion: require.NoError,
+			},
+			rule: "project.deliveries:retry",
+		},
+		{
+			basetest: basetest{
+				name: "should_reject_retry_only_api_key_reading_events",
+				authCtx: &auth.AuthenticatedUser{
+					APIKey: &datastore.APIKey{Role: auth.Role{
+						Type:        auth.RoleCustom,
+						Project:     "project-1",
+						Permissions: auth.Permissions{auth.PermissionDeliveriesRetry},
+					}},
+				},
+				assertion: require.Error,
+			},
+			rule: "project.events:read",
+		},
+		{
+			basetest: basetest{
+				name: "should_allow_scoped_api_key_to_view_project",
+				authCtx: &auth.AuthenticatedUser{
+					APIKey: &datastore.APIKey{Role: auth.Role{
+						Type:        auth.RoleCustom,
+						Project:     "project-1",
+						Permissions: auth.Permissions{auth.PermissionEventsRead},
+					}},
+				},
+				assertion: require.NoError,
+			},
+			rule: "project.view",
+		},
+		{
+			basetest: basetest{
+				name: "should_reject_read_only_api_key_managing_project",
+				authCtx: &auth.AuthenticatedUser{
+					APIKey: &datastore.APIKey{Role: auth.Role{
+						Type:        auth.RoleAdmin,
+						Project:     "project-1",
+						Permissions: auth.Permissions{auth.PermissionProjectsRead, auth.PermissionEventsRead},
+					}},
+				},
+				assertion: require.Error,
+			},
+			rule: "project.manage",
+		},
+		{
+			basetest: basetest{
+				name:      "should_allow_custom_role_member",
+				authCtx:   &auth.AuthenticatedUser{User: &datastore.User{UID: "user-1"}},
+				assertion: require.NoError,
+			},
+			rule: "project.endpoints:read",
+			storeFn: memberStore(auth.Role{
+				Type:        auth.RoleCustom,
+				Permissions: auth.Permissions{auth.PermissionEndpointsRead},
+			}, true),
+		},
+		{
+			basetest: basetest{
+				name:      "should_reject_custom_role_member_of_another_project",
+				authCtx:   &auth.AuthenticatedUser{User: &datastore.User{UID: "user-1"}},
+				assertion: require.Error,
+			},
+			rule: "project.endpoints:read",
+			storeFn: memberStore(auth.Role{
+				Type:        auth.RoleCustom,
+				Project:     "project-2",
+				Permissions: auth.Permissions{auth.PermissionEndpointsRead},
+			}, true),
+		},
+		{
+			basetest: basetest{
+				name:      "should_reject_custom_role_member_without_multiplayer_mode",
+				authCtx:   &auth.AuthenticatedUser{User: &datastore.User{UID: "user-1"}},
+				assertion: require.Error,
+			},
+			rule: "project.endpoints:read",
+			storeFn: memberStore(auth.Role{
+				Type:        auth.RoleCustom,
+				Permissions: auth.Permissions{auth.PermissionEndpointsRead},
+			}, false),
+		},
+		{
+			basetest: basetest{
+				name: "should_limit_personal_api_key_to_its_permissions",
+				authCtx: &auth.AuthenticatedUser{
+					User: &datastore.User{UID: "user-1"},
+					APIKey: &datastore.APIKey{
+						Type: datastore.PersonalKey,
+						Role: auth.Role{Permissions: auth.Permissions{auth.PermissionEventsRead}},
+					},
+				},
+				assertion: require.Error,
+			},
+			rule:    "project.events:write",
+			storeFn: memberStore(auth.Role{Type: auth.RoleAdmin}, true),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange.
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			policy := &ProjectPolicy{
+				BasePolicy:             authz.NewBasePolicy(),
+				OrganisationRepo:       mocks.NewMockOrganisationRepository(ctrl),
+				Licenser:               mocks.NewMockLicenser(ctrl),
+				OrganisationMemberRepo: mocks.NewMockOrganisationMemberRepository(ctrl),
+			}
+
+			policy.SetRule("view", authz.RuleFunc(policy.View))
+			policy.SetRule("manage", authz.RuleFunc(policy.Manage))
+			for _, perm := range auth.AllPermissions {
+				policy.SetRule(perm.String(), policy.Permission(perm))
+			}
+
+			orgRepo := policy.OrganisationRepo.(*mocks.MockOrganisationRepository)
+			orgRepo.EXPECT().
+				FetchOrganisationByID(gomock.Any(), "org-1").
+				Return(&datastore.Organisation{UID: "org-1"}, nil)
+
+			if tc.storeFn != nil {
+				tc.storeFn(policy)
+			}
+
+			ctx := context.WithValue(context.Background(), AuthUserCtx, tc.authCtx)
+
+			az, _ := authz.NewAuthz(&authz.AuthzOpts{})
+			_ = az.RegisterPolicy(policy)
+
+			// Act.
+			err := az.Authorize(ctx, tc.rule, project)
+
+			// Assert.
+			tc.assertion(t, err)
+		})
+	}
+}
+
+func Test_NewProjectPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := NewProjectPolicy(mocks.NewMockLicenser(ctrl), mocks.NewMockOrganisationRepository(ctrl), mocks.NewMockOrganisationMemberRepository(ctrl))
+
+	for _, name := range []string{"view", "manage"} {
+		_, err := policy.GetRule(name)
+		require.NoError(t, err, name)
+	}
+
+	for _, perm := range auth.AllPermissions {
+		_, err := policy.GetRule(perm.String())
+		require.NoError(t, err, perm.String())
+	}
+}
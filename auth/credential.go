@@ -40,6 +40,7 @@ const (
 const (
 	NativeRealmName = "native_realm"
 	JWTRealmName    = "jwt"
+	OIDCRealmName   = "oidc_realm"
 	FileRealmName   = "file_realm"
 	NoopRealmName   = "noop_realm"
 )
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Permission grants a single action on the resources of a project,
+// permissions are written as <resource>:<action>.
+type Permission string
+
+const (
+	PermissionProjectsRead         = Permission("projects:read")
+	PermissionProjectsWrite        = Permission("projects:write")
+	PermissionEndpointsRead        = Permission("endpoints:read")
+	PermissionEndpointsWrite       = Permission("endpoints:write")
+	PermissionEventsRead           = Permission("events:read")
+	PermissionEventsWrite          = Permission("events:write")
+	PermissionEventTypesRead       = Permission("event_types:read")
+	PermissionEventTypesWrite      = Permission("event_types:write")
+	PermissionDeliveriesRead       = Permission("deliveries:read")
+	PermissionDeliveriesRetry      = Permission("deliveries:retry")
+	PermissionSubscriptionsRead    = Permission("subscriptions:read")
+	PermissionSubscriptionsWrite   = Permission("subscriptions:write")
+	PermissionSourcesRead          = Permission("sources:read")
+	PermissionSourcesWrite         = Permission("sources:write")
+	PermissionPortalLinksRead      = Permission("portal_links:read")
+	PermissionPortalLinksWrite     = Permission("portal_links:write")
+	PermissionMetaEventsRead       = Permission("meta_events:read")
+	PermissionDeadLettersRead      = Permission("dead_letters:read")
+	PermissionScheduledEventsRead  = Permission("scheduled_events:read")
+	PermissionScheduledEventsWrite = Permission("scheduled_events:write")
+	PermissionAuditLogsRead        = Permission("audit_logs:read")
+)
+
+// AllPermissions is every permission that can be granted on a project.
+var AllPermissions = Permissions{
+	PermissionProjectsRead,
+	PermissionProjectsWrite,
+	PermissionEndpointsRead,
+	PermissionEndpointsWrite,
+	PermissionEventsRead,
+	PermissionEventsWrite,
+	PermissionEventTypesRead,
+	PermissionEventTypesWrite,
+	PermissionDeliveriesRead,
+	PermissionDeliveriesRetry,
+	PermissionSubscriptionsRead,
+	PermissionSubscriptionsWrite,
+	PermissionSourcesRead,
+	PermissionSourcesWrite,
+	PermissionPortalLinksRead,
+	PermissionPortalLinksWrite,
+	PermissionMetaEventsRead,
+	PermissionDeadLettersRead,
+	PermissionScheduledEventsRead,
+	PermissionScheduledEventsWrite,
+	PermissionAuditLogsRead,
+}
+
+func (p Permission) IsValid() bool {
+	for _, v := range AllPermissions {
+		if v == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+type Permissions []Permission
+
+func (p Permissions) Has(perm Permission) bool {
+	for _, v := range p {
+		if v == perm {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Permissions) Validate() error {
+	for _, v := range p {
+		if !v.IsValid() {
+			return fmt.Errorf("invalid permission: %s", v)
+		}
+	}
+
+	return nil
+}
+
+// Intersect returns the permissions present in both p and o.
+func (p Permissions) Intersect(o Permissions) Permissions {
+	perms := Permissions{}
+	for _, v := range p {
+		if o.Has(v) && !perms.Has(v) {
+			perms = append(perms, v)
+		}
+	}
+
+	sort.Slice(perms, func(i, j int) bool { return perms[i] < perms[j] })
+	return perms
+}
+
+// Difference returns the permissions in p that aren't in o.
+func (p Permissions) Difference(o Permissions) Permissions {
+	perms := Permissions{}
+	for _, v := range p {
+		if !o.Has(v) && !perms.Has(v) {
+			perms = append(perms, v)
+		}
+	}
+
+	sort.Slice(perms, func(i, j int) bool { return perms[i] < perms[j] })
+	return perms
+}
+
+func (p *Permissions) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	if string(b) == "null" {
+		*p = nil
+		return nil
+	}
+
+	return json.Unmarshal(b, p)
+}
+
+func (p Permissions) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
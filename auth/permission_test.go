@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Grants(t *testing.T) {
+	tests := []struct {
+		name string
+		role Role
+		want Permissions
+	}{
+		{
+			name: "should_grant_every_permission_to_admins",
+			role: Role{Type: RoleAdmin, Project: "project-1"},
+			want: AllPermissions,
+		},
+		{
+			name: "should_limit_admins_to_their_permissions",
+			role: Role{Type: RoleAdmin, Permissions: Permissions{PermissionEventsRead, PermissionDeliveriesRead}},
+			want: Permissions{PermissionDeliveriesRead, PermissionEventsRead},
+		},
+		{
+			name: "should_grant_custom_roles_their_permissions",
+			role: Role{Type: RoleCustom, Permissions: Permissions{PermissionDeliveriesRetry}},
+			want: Permissions{PermissionDeliveriesRetry},
+		},
+		{
+			name: "should_grant_members_nothing",
+			role: Role{Type: RoleMember, Permissions: Permissions{PermissionDeliveriesRetry}},
+			want: Permissions{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.role.Grants())
+		})
+	}
+}
+
+func TestRole_Validate(t *testing.T) {
+	require.NoError(t, (&Role{Type: RoleCustom, Permissions: Permissions{PermissionEventsRead}}).Validate("api key"))
+	require.EqualError(t, (&Role{Type: RoleCustom}).Validate("api key"), "api key with a custom role must have at least one permission")
+	require.EqualError(t, (&Role{Type: RoleAdmin, Permissions: Permissions{"events:delete"}}).Validate("api key"), "invalid permission: events:delete")
+}
+
+func TestPermissions_Scan(t *testing.T) {
+	var p Permissions
+	require.NoError(t, p.Scan(nil))
+	require.Nil(t, p)
+
+	require.NoError(t, p.Scan([]byte(`["events:read","deliveries:retry"]`)))
+	require.Equal(t, Permissions{PermissionEventsRead, PermissionDeliveriesRetry}, p)
+
+	v, err := Permissions(nil).Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestPermissions_Difference(t *testing.T) {
+	requested := Permissions{PermissionEventsRead, PermissionDeliveriesRetry, PermissionEventsRead}
+
+	require.Equal(t, Permissions{PermissionDeliveriesRetry}, requested.Difference(Permissions{PermissionEventsRead}))
+	require.Equal(t, Permissions{}, requested.Difference(AllPermissions))
+	require.Equal(t, Permissions{PermissionDeliveriesRetry, PermissionEventsRead}, requested.Difference(nil))
+}
@@ -0,0 +1,301 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/pkg/verifier"
+)
+
+const (
+	DefaultGroupsClaim = "groups"
+
+	discoveryCacheTTL = time.Hour
+)
+
+var (
+	DefaultScopes = []string{"openid", "email", "profile"}
+
+	ErrInvalidToken     = errors.New("invalid id token")
+	ErrMissingEmail     = errors.New("id token has no email claim")
+	ErrEmailNotVerified = errors.New("the identity provider has not verified the user's email")
+	ErrNoRole           = errors.New("user is not a member of any group that has been given a role")
+
+	discovery = &discoveryCache{
+		docs:   map[string]*discoveryDocument{},
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+)
+
+// Claims are the claims of an ID token users are identified by.
+type Claims struct {
+	Issuer     string
+	Subject    string
+	Email      string
+	GivenName  string
+	FamilyName string
+	Groups     []string
+	Nonce      string
+}
+
+// Provider signs users in with an OpenID Connect identity provider using
+// the authorization code flow.
+type Provider struct {
+	opts   *config.OIDCRealmOptions
+	client *http.Client
+}
+
+func NewProvider(opts *config.OIDCRealmOptions) *Provider {
+	return &Provider{opts: opts, client: discovery.client}
+}
+
+// AuthCodeURL returns the url of the identity provider's login page, the
+// user is sent back to the redirect url with state and a code to exchange
+// for an ID token that carries nonce.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	doc, err := discovery.get(ctx, p.opts.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.opts.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.opts.ClientID)
+	q.Set("redirect_uri", p.opts.RedirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+
+	sep := "?"
+	if strings.Contains(doc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return doc.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange redeems the authorization code for the user's ID token, see
+// https://openid.net/specs/openid-connect-core-1_0.html#TokenEndpoint
+func (p *Provider) Exchange(ctx context.Context, code string) (string, error) {
+	doc, err := discovery.get(ctx, p.opts.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.opts.RedirectURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.SetBasicAuth(url.QueryEscape(p.opts.ClientID), url.QueryEscape(p.opts.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange oidc code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("oidc token endpoint responded with status code %d: %s", resp.StatusCode, body)
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+
+	err = json.Unmarshal(body, &token)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode oidc token response: %w", err)
+	}
+
+	if len(token.IDToken) == 0 {
+		return "", errors.New("oidc token endpoint did not return an id token")
+	}
+
+	return token.IDToken, nil
+}
+
+// VerifyIDToken verifies the token was issued to the client by the identity
+// provider and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, token string) (*Claims, error) {
+	doc, err := discovery.get(ctx, p.opts.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	// the key set url is discovered from the issuer operators configure
+	v := verifier.NewJWTVerifier(&verifier.JWTOptions{
+		JWKSURL:  doc.JWKSURI,
+		Issuer:   p.opts.Issuer,
+		Audience: p.opts.ClientID,
+	}, verifier.NewHTTPKeySetFetcher(p.client))
+
+	mc, err := v.VerifyToken(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{
+		Issuer:     p.opts.Issuer,
+		Subject:    stringClaim(mc, "sub"),
+		Email:      strings.ToLower(stringClaim(mc, "email")),
+		GivenName:  stringClaim(mc, "given_name"),
+		FamilyName: stringClaim(mc, "family_name"),
+		Nonce:      stringClaim(mc, "nonce"),
+		Groups:     p.groups(mc),
+	}
+
+	if len(claims.Subject) == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	if len(claims.Email) == 0 {
+		return nil, ErrMissingEmail
+	}
+
+	// the email of a user is only trusted when the provider says it verified it
+	if verified, _ := mc["email_verified"].(bool); !verified {
+		return nil, ErrEmailNotVerified
+	}
+
+	return claims, nil
+}
+
+// Role returns the role of the user's groups.
+func (p *Provider) Role(groups []string) (auth.Role, error) {
+	role, ok := p.opts.GroupRoles.Role(groups)
+	if !ok {
+		return auth.Role{}, ErrNoRole
+	}
+
+	return role, nil
+}
+
+func (p *Provider) groups(mc map[string]interface{}) []string {
+	claim := p.opts.GroupsClaim
+	if len(claim) == 0 {
+		claim = DefaultGroupsClaim
+	}
+
+	switch v := mc[claim].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+		return groups
+	default:
+		return nil
+	}
+}
+
+func stringClaim(mc map[string]interface{}, name string) string {
+	s, _ := mc[name].(string)
+	return s
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	fetchedAt time.Time
+}
+
+// discoveryCache caches the providers' metadata across requests, see
+// https://openid.net/specs/openid-connect-discovery-1_0.html
+type discoveryCache struct {
+	mu     sync.Mutex
+	docs   map[string]*discoveryDocument
+	client *http.Client
+}
+
+func (c *discoveryCache) get(ctx context.Context, issuer string) (*discoveryDocument, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	doc, ok := c.docs[issuer]
+	if ok && time.Since(doc.fetchedAt) < discoveryCacheTTL {
+		return doc, nil
+	}
+
+	fetched, err := c.fetch(ctx, issuer)
+	if err != nil {
+		// keep using the last document while the provider is unreachable
+		if ok {
+			return doc, nil
+		}
+		return nil, err
+	}
+
+	c.docs[issuer] = fetched
+	return fetched, nil
+}
+
+func (c *discoveryCache) fetch(ctx context.Context, issuer string) (*discoveryDocument, error) {
+	u := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch oidc discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch oidc discovery document: unexpected status code %d", resp.StatusCode)
+	}
+
+	doc := &discoveryDocument{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode oidc discovery document: %w", err)
+	}
+
+	if doc.Issuer != issuer {
+		return nil, fmt.Errorf("oidc discovery document issuer %s does not match %s", doc.Issuer, issuer)
+	}
+
+	if len(doc.AuthorizationEndpoint) == 0 || len(doc.TokenEndpoint) == 0 || len(doc.JWKSURI) == 0 {
+		return nil, errors.New("oidc discovery document is missing endpoints")
+	}
+
+	doc.fetchedAt = time.Now()
+	return doc, nil
+}
@@ -0,0 +1,56 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+// OIDCRealm authenticates ID tokens issued to convoy by the identity
+// provider, the users must have been provisioned already.
+type OIDCRealm struct {
+	userRepo datastore.UserRepository
+	provider *Provider
+}
+
+func NewOIDCRealm(userRepo datastore.UserRepository, opts *config.OIDCRealmOptions) *OIDCRealm {
+	return &OIDCRealm{userRepo: userRepo, provider: NewProvider(opts)}
+}
+
+func (o *OIDCRealm) Authenticate(ctx context.Context, cred *auth.Credential) (*auth.AuthenticatedUser, error) {
+	if cred.Type != auth.CredentialTypeJWT {
+		return nil, fmt.Errorf("%s only authenticates credential type %s", o.GetName(), auth.CredentialTypeJWT.String())
+	}
+
+	claims, err := o.provider.VerifyIDToken(ctx, cred.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := o.provider.Role(claims.Groups)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := o.userRepo.FindUserByOIDCSubject(ctx, claims.Issuer, claims.Subject)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	authUser := &auth.AuthenticatedUser{
+		AuthenticatedByRealm: o.GetName(),
+		Credential:           *cred,
+		Role:                 role,
+		Metadata:             user,
+		User:                 user,
+	}
+
+	return authUser, nil
+}
+
+func (o *OIDCRealm) GetName() string {
+	return auth.OIDCRealmName
+}
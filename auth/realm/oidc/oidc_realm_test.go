@@ -0,0 +1,83 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOIDCRealm_Authenticate(t *testing.T) {
+	idp := newTestProvider(t)
+	user := &datastore.User{UID: "123456", Email: "jane@example.com"}
+
+	tests := []struct {
+		name       string
+		cred       *auth.Credential
+		dbFn       func(userRepo *mocks.MockUserRepository)
+		want       *auth.AuthenticatedUser
+		wantErrMsg string
+	}{
+		{
+			name: "should_authenticate_successfully",
+			cred: &auth.Credential{Type: auth.CredentialTypeJWT, Token: idp.sign(t, idp.claims(nil))},
+			dbFn: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().FindUserByOIDCSubject(gomock.Any(), idp.URL, "user-1").Times(1).Return(user, nil)
+			},
+			want: &auth.AuthenticatedUser{
+				AuthenticatedByRealm: auth.OIDCRealmName,
+				Role:                 auth.Role{Type: auth.RoleAdmin, Project: "project-1"},
+				Metadata:             user,
+				User:                 user,
+			},
+		},
+		{
+			name:       "should_error_for_wrong_credential_type",
+			cred:       &auth.Credential{Type: auth.CredentialTypeAPIKey, APIKey: "key"},
+			wantErrMsg: "oidc_realm only authenticates credential type JWT",
+		},
+		{
+			name:       "should_error_for_user_without_role",
+			cred:       &auth.Credential{Type: auth.CredentialTypeJWT, Token: idp.sign(t, idp.claims(jwt.MapClaims{"groups": []string{"sales"}}))},
+			wantErrMsg: ErrNoRole.Error(),
+		},
+		{
+			name: "should_error_for_unknown_user",
+			cred: &auth.Credential{Type: auth.CredentialTypeJWT, Token: idp.sign(t, idp.claims(nil))},
+			dbFn: func(userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().FindUserByOIDCSubject(gomock.Any(), idp.URL, "user-1").Times(1).Return(nil, errors.New("user not found"))
+			},
+			wantErrMsg: ErrInvalidToken.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			if tt.dbFn != nil {
+				tt.dbFn(userRepo)
+			}
+
+			o := NewOIDCRealm(userRepo, idp.options())
+
+			got, err := o.Authenticate(context.Background(), tt.cred)
+			if tt.wantErrMsg != "" {
+				require.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.want.Credential = *tt.cred
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/config"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+type testProvider struct {
+	*httptest.Server
+	key     *rsa.PrivateKey
+	idToken string
+}
+
+// newTestProvider starts an identity provider that issues idToken for the
+// code "valid-code".
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &testProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+			"jwks_uri":               p.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test-key",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != "client-id" || secret != "client-secret" || r.FormValue("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access-token", "id_token": p.idToken})
+	})
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+func (p *testProvider) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test-key"
+
+	s, err := token.SignedString(p.key)
+	require.NoError(t, err)
+
+	return s
+}
+
+func (p *testProvider) claims(overrides jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":            p.URL,
+		"aud":            "client-id",
+		"sub":            "user-1",
+		"email":          "Jane@Example.com",
+		"email_verified": true,
+		"given_name":     "Jane",
+		"family_name":    "Doe",
+		"nonce":          "nonce",
+		"groups":         []string{"engineering", "convoy-admins"},
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+	}
+
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+
+	return claims
+}
+
+func (p *testProvider) options() *config.OIDCRealmOptions {
+	return &config.OIDCRealmOptions{
+		Enabled:      true,
+		Issuer:       p.URL,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://convoy.example.com/login/oidc",
+		GroupRoles: config.OIDCGroupRoles{
+			{Group: "convoy-admins", Role: auth.Role{Type: auth.RoleAdmin, Project: "project-1"}},
+			{Group: "engineering", Role: auth.Role{Type: auth.RoleMember, Project: "project-1"}},
+		},
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := newTestProvider(t)
+	p := NewProvider(idp.options())
+
+	u, err := p.AuthCodeURL(context.Background(), "state", "nonce")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(u)
+	require.NoError(t, err)
+	require.Equal(t, idp.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	q := parsed.Query()
+	require.Equal(t, "code", q.Get("response_type"))
+	require.Equal(t, "client-id", q.Get("client_id"))
+	require.Equal(t, "https://convoy.example.com/login/oidc", q.Get("redirect_uri"))
+	require.Equal(t, "openid email profile", q.Get("scope"))
+	require.Equal(t, "state", q.Get("state"))
+	require.Equal(t, "nonce", q.Get("nonce"))
+}
+
+func TestProvider_ExchangeAndVerify(t *testing.T) {
+	idp := newTestProvider(t)
+	p := NewProvider(idp.options())
+	ctx := context.Background()
+
+	idp.idToken = idp.sign(t, idp.claims(nil))
+
+	token, err := p.Exchange(ctx, "valid-code")
+	require.NoError(t, err)
+
+	claims, err := p.VerifyIDToken(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, &Claims{
+		Issuer:     idp.URL,
+		Subject:    "user-1",
+		Email:      "jane@example.com",
+		GivenName:  "Jane",
+		FamilyName: "Doe",
+		Groups:     []string{"engineering", "convoy-admins"},
+		Nonce:      "nonce",
+	}, claims)
+
+	_, err = p.Exchange(ctx, "invalid-code")
+	require.Error(t, err)
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	idp := newTestProvider(t)
+
+	tests := []struct {
+		name      string
+		overrides jwt.MapClaims
+		opts      func(o *config.OIDCRealmOptions)
+		wantErr   error
+		groups    []string
+	}{
+		{
+			name:   "should_verify_token",
+			groups: []string{"engineering", "convoy-admins"},
+		},
+		{
+			name:      "should_read_groups_from_custom_claim",
+			overrides: jwt.MapClaims{"roles": "convoy-admins"},
+			opts:      func(o *config.OIDCRealmOptions) { o.GroupsClaim = "roles" },
+			groups:    []string{"convoy-admins"},
+		},
+		{
+			name:      "should_reject_token_issued_to_another_client",
+			overrides: jwt.MapClaims{"aud": "another-client"},
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name:      "should_reject_token_from_another_issuer",
+			overrides: jwt.MapClaims{"iss": "https://another-issuer.example.com"},
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name:      "should_reject_expired_token",
+			overrides: jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()},
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name:      "should_reject_token_without_email",
+			overrides: jwt.MapClaims{"email": nil},
+			wantErr:   ErrMissingEmail,
+		},
+		{
+			name:      "should_reject_unverified_email",
+			overrides: jwt.MapClaims{"email_verified": false},
+			wantErr:   ErrEmailNotVerified,
+		},
+		{
+			name:      "should_reject_token_without_email_verified",
+			overrides: jwt.MapClaims{"email_verified": nil},
+			wantErr:   ErrEmailNotVerified,
+		},
+		{
+			name:      "should_reject_token_without_subject",
+			overrides: jwt.MapClaims{"sub": nil},
+			wantErr:   ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := idp.options()
+			if tt.opts != nil {
+				tt.opts(opts)
+			}
+
+			claims, err := NewProvider(opts).VerifyIDToken(context.Background(), idp.sign(t, idp.claims(tt.overrides)))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.groups, claims.Groups)
+		})
+	}
+}
+
+func TestProvider_Role(t *testing.T) {
+	idp := newTestProvider(t)
+	p := NewProvider(idp.options())
+
+	// the first mapped group the user is in wins
+	role, err := p.Role([]string{"engineering", "convoy-admins"})
+	require.NoError(t, err)
+	require.Equal(t, auth.Role{Type: auth.RoleAdmin, Project: "project-1"}, role)
+
+	role, err = p.Role([]string{"engineering"})
+	require.NoError(t, err)
+	require.Equal(t, auth.Role{Type: auth.RoleMember, Project: "project-1"}, role)
+
+	_, err = p.Role([]string{"sales"})
+	require.ErrorIs(t, err, ErrNoRole)
+
+	// everyone is a member when no groups are mapped
+	opts := idp.options()
+	opts.GroupRoles = nil
+
+	role, err = NewProvider(opts).Role(nil)
+	require.NoError(t, err)
+	require.Equal(t, auth.Role{Type: auth.RoleMember}, role)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/frain-dev/convoy/auth/realm/file"
 	"github.com/frain-dev/convoy/auth/realm/jwt"
 	"github.com/frain-dev/convoy/auth/realm/native"
+	"github.com/frain-dev/convoy/auth/realm/oidc"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
@@ -73,6 +74,14 @@ func Init(authConfig *config.AuthConfiguration,
 		}
 	}
 
+	if authConfig.OIDC.Enabled {
+		or := oidc.NewOIDCRealm(userRepo, &authConfig.OIDC)
+		err = rc.RegisterRealm(or)
+		if err != nil {
+			return errors.New("failed to register oidc realm in realm chain")
+		}
+	}
+
 	realmChainSingleton.Store(rc)
 	return nil
 }
@@ -6,10 +6,12 @@ import (
 
 // Role represents the permission a user is given, if the Type is RoleSuperUser,
 // Then the user will have access to everything regardless of the value of Project.
+// Permissions narrow what the role grants, a RoleCustom grants only its Permissions.
 type Role struct {
-	Type     RoleType `json:"type" db:"type"`
-	Project  string   `json:"project" db:"project"`
-	Endpoint string   `json:"endpoint,omitempty" db:"endpoint"`
+	Type        RoleType    `json:"type" db:"type"`
+	Project     string      `json:"project" db:"project"`
+	Endpoint    string      `json:"endpoint,omitempty" db:"endpoint"`
+	Permissions Permissions `json:"permissions,omitempty" db:"permissions"`
 }
 
 type RoleType string
@@ -19,11 +21,12 @@ const (
 	RoleAdmin     = RoleType("admin")
 	RoleMember    = RoleType("member")
 	RoleAPI       = RoleType("api")
+	RoleCustom    = RoleType("custom")
 )
 
 func (r RoleType) IsValid() bool {
 	switch r {
-	case RoleSuperUser, RoleAdmin, RoleMember, RoleAPI:
+	case RoleSuperUser, RoleAdmin, RoleMember, RoleAPI, RoleCustom:
 		return true
 	default:
 		return false
@@ -38,6 +41,19 @@ func (r *Role) HasEndpoint(endpointID string) bool {
 	return r.Endpoint == endpointID
 }
 
+// Equal reports whether both roles grant the same access.
+func (r *Role) Equal(o *Role) bool {
+	if r.Type != o.Type || r.Project != o.Project || r.Endpoint != o.Endpoint {
+		return false
+	}
+
+	if len(r.Permissions) != len(o.Permissions) || (r.Permissions == nil) != (o.Permissions == nil) {
+		return false
+	}
+
+	return len(r.Permissions.Intersect(o.Permissions)) == len(r.Permissions)
+}
+
 func (r RoleType) String() string {
 	return string(r)
 }
@@ -51,5 +67,30 @@ func (r *Role) Validate(credType string) error {
 		return fmt.Errorf("invalid role type: %s", r.Type.String())
 	}
 
+	if err := r.Permissions.Validate(); err != nil {
+		return err
+	}
+
+	if r.Type == RoleCustom && len(r.Permissions) == 0 {
+		return fmt.Errorf("%s with a custom role must have at least one permission", credType)
+	}
+
 	return nil
 }
+
+// Grants returns the permissions the role grants on the projects it can
+// access, members are granted nothing.
+func (r *Role) Grants() Permissions {
+	switch r.Type {
+	case RoleSuperUser, RoleAdmin, RoleAPI:
+		if r.Permissions != nil {
+			return AllPermissions.Intersect(r.Permissions)
+		}
+
+		return AllPermissions
+	case RoleCustom:
+		return AllPermissions.Intersect(r.Permissions)
+	default:
+		return Permissions{}
+	}
+}
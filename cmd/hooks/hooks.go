@@ -36,6 +36,7 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/rdb"
 	"github.com/frain-dev/convoy/internal/telemetry"
 	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/transform"
 	redisQueue "github.com/frain-dev/convoy/queue/redis"
 	"github.com/spf13/cobra"
 )
@@ -83,6 +84,14 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 			return err
 		}
 
+		transform.Configure(transform.Options{
+			PoolSize:         cfg.Transform.PoolSize,
+			Timeout:          time.Duration(cfg.Transform.Timeout) * time.Millisecond,
+			MaxCallStackSize: cfg.Transform.MaxCallStackSize,
+			CacheSize:        cfg.Transform.CacheSize,
+			MaxPayloadSize:   cfg.Transform.MaxPayloadSize,
+		})
+
 		var ca cache.Cache
 		var q queue.Queuer
 
@@ -143,11 +152,13 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 		attemptsRepo := postgres.NewDeliveryAttemptRepo(postgresDB)
 		endpointListener := listener.NewEndpointListener(q, projectRepo, metaEventRepo)
 		eventDeliveryListener := listener.NewEventDeliveryListener(q, projectRepo, metaEventRepo, attemptsRepo)
+		auditLogListener := listener.NewAuditLogListener(q, projectRepo, metaEventRepo)
 
 		hooks.RegisterHook(datastore.EndpointCreated, endpointListener.AfterCreate)
 		hooks.RegisterHook(datastore.EndpointUpdated, endpointListener.AfterUpdate)
 		hooks.RegisterHook(datastore.EndpointDeleted, endpointListener.AfterDelete)
 		hooks.RegisterHook(datastore.EventDeliveryUpdated, eventDeliveryListener.AfterUpdate)
+		hooks.RegisterHook(datastore.AuditLogCreated, auditLogListener.AfterCreate)
 
 		if ok := shouldCheckMigration(cmd); ok {
 			err = checkPendingMigrations(lo, db)
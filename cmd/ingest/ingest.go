@@ -7,6 +7,7 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
+	"github.com/frain-dev/convoy/internal/pkg/dedup"
 	"github.com/frain-dev/convoy/internal/pkg/keys"
 	"github.com/frain-dev/convoy/internal/pkg/limiter"
 	"github.com/frain-dev/convoy/internal/pkg/memorystore"
@@ -48,9 +49,13 @@ func AddIngestCommand(a *cli.App) *cobra.Command {
 				return err
 			}
 
-			km := keys.NewHCPVaultKeyManagerFromConfig(cfg.HCPVault, a.Licenser, a.Cache)
+			km, err := keys.NewKeyManagerFromConfig(cfg, a.Licenser, a.Cache, a.DB)
+			if err != nil {
+				return err
+			}
 			if km.IsSet() {
-				if _, err = km.GetCurrentKeyFromCache(); err != nil {
+				// there is no data key until encryption is initialised
+				if _, err = km.GetCurrentKeyFromCache(); err != nil && !errors.Is(err, keys.ErrNoDataKey) {
 					if !errors.Is(err, keys.ErrCredentialEncryptionFeatureUnavailable) {
 						return err
 					}
@@ -60,6 +65,7 @@ func AddIngestCommand(a *cli.App) *cobra.Command {
 			if err = keys.Set(km); err != nil {
 				return err
 			}
+			keys.SetCache(a.Cache)
 
 			err = StartIngest(cmd.Context(), a, cfg, interval)
 			if err != nil {
@@ -126,7 +132,9 @@ func StartIngest(ctx context.Context, a *cli.App, cfg config.Configuration, inte
 		return err
 	}
 
-	ingest, err := pubsub.NewIngest(ctx, sourceTable, a.Queue, lo, rateLimiter, a.Licenser, host)
+	dedupWindow := dedup.NewWindow(dedup.NewStore(cfg.Dedup, a.Redis), metrics.GetDPInstance(a.Licenser))
+
+	ingest, err := pubsub.NewIngest(ctx, sourceTable, a.Queue, lo, rateLimiter, a.Licenser, host, postgres.NewEventTypesRepo(a.DB), dedupWindow)
 	if err != nil {
 		return err
 	}
@@ -102,9 +102,13 @@ func startConvoyServer(a *cli.App) error {
 	start := time.Now()
 	a.Logger.Info("Starting Convoy control plane...")
 
-	km := keys.NewHCPVaultKeyManagerFromConfig(cfg.HCPVault, a.Licenser, a.Cache)
+	km, err := keys.NewKeyManagerFromConfig(cfg, a.Licenser, a.Cache, a.DB)
+	if err != nil {
+		return err
+	}
 	if km.IsSet() {
-		if _, err = km.GetCurrentKeyFromCache(); err != nil {
+		// there is no data key until encryption is initialised
+		if _, err = km.GetCurrentKeyFromCache(); err != nil && !errors.Is(err, keys.ErrNoDataKey) {
 			if !errors.Is(err, keys.ErrCredentialEncryptionFeatureUnavailable) {
 				return err
 			}
@@ -114,6 +118,7 @@ func startConvoyServer(a *cli.App) error {
 	if err = keys.Set(km); err != nil {
 		return err
 	}
+	keys.SetCache(a.Cache)
 
 	apiKeyRepo := postgres.NewAPIKeyRepo(a.DB)
 	userRepo := postgres.NewUserRepo(a.DB)
@@ -170,6 +175,8 @@ func startConvoyServer(a *cli.App) error {
 	s.RegisterTask("58 23 * * *", convoy.ScheduleQueue, convoy.DeleteArchivedTasksProcessor)
 	s.RegisterTask("30 * * * *", convoy.ScheduleQueue, convoy.MonitorTwitterSources)
 	s.RegisterTask("0 * * * *", convoy.ScheduleQueue, convoy.TokenizeSearch)
+	s.RegisterTask("*/5 * * * *", convoy.ScheduleQueue, convoy.ProbeEndpoints)
+	s.RegisterTask("* * * * *", convoy.ScheduleQueue, convoy.PollRestApiSources)
 
 	// ensures that project data is backed up about 2 hours before they are deleted
 	if a.Licenser.RetentionPolicy() {
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/internal/pkg/cli"
+	"github.com/frain-dev/convoy/internal/pkg/eventcatalog"
+	"github.com/spf13/cobra"
+)
+
+func AddExportEventTypesCommand(a *cli.App) *cobra.Command {
+	var spec string
+	var format string
+	var version string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export-event-types <project-id>",
+		Short: "export a project's event types",
+		Long:  "export a project's event types and their schemas as an AsyncAPI document or the webhooks of an OpenAPI document, valid specs are asyncapi-3.0, asyncapi-2.6 and openapi-3.1",
+		Args:  cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			"CheckMigration":  "true",
+			"ShouldBootstrap": "false",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := eventcatalog.ParseSpec(spec)
+			if err != nil {
+				return err
+			}
+
+			f, err := eventcatalog.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			project, err := postgres.NewProjectRepo(a.DB).FetchProjectByID(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to fetch project: %v", err)
+			}
+
+			eventTypes, err := postgres.NewEventTypesRepo(a.DB).FetchAllEventTypes(cmd.Context(), project.UID)
+			if err != nil {
+				return fmt.Errorf("failed to fetch event types: %v", err)
+			}
+
+			doc, err := eventcatalog.Generate(s, project, eventTypes, eventcatalog.Options{Version: version})
+			if err != nil {
+				return err
+			}
+
+			b, _, err := eventcatalog.Encode(doc, f)
+			if err != nil {
+				return err
+			}
+
+			if output == "" {
+				_, err = cmd.OutOrStdout().Write(append(b, '\n'))
+				return err
+			}
+
+			err = os.WriteFile(output, b, 0o644)
+			if err != nil {
+				return err
+			}
+
+			a.Logger.Infof("Exported %d event types to %s", len(eventTypes), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&spec, "spec", string(eventcatalog.AsyncAPI3), "Document spec, one of asyncapi-3.0, asyncapi-2.6 or openapi-3.1")
+	cmd.Flags().StringVar(&format, "format", string(eventcatalog.JSONFormat), "Document format, json or yaml")
+	cmd.Flags().StringVar(&version, "doc-version", "", "Document version, it defaults to 1.0.0")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write the document to, it defaults to stdout")
+
+	return cmd
+}
@@ -14,7 +14,7 @@ import (
 var (
 	ErrCredentialEncryptionFeatureUnavailable = errors.New("credential encryption feature unavailable, please upgrade")
 	ErrEncryptionKeyCannotBeEmpty             = errors.New("encryption key cannot be empty")
-	ErrMissingHCPVaultConfig                  = errors.New("missing required HCP vault configuration")
+	ErrMissingKeyManagerConfig                = errors.New("missing required key manager configuration")
 	ErrEncryptionKeyMismatch                  = errors.New("provided encryption key does not match the current encryption key")
 	ErrOldEncryptionKeyMismatch               = errors.New("provided old key does not match the current encryption key")
 )
@@ -22,7 +22,7 @@ var (
 func AddInitEncryptionCommand(a *cli.App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init-encryption",
-		Short: "Initializes encryption for the specified table columns with the encryption key fetched from HCP Vault",
+		Short: "Initializes encryption for the specified table columns with the encryption key fetched from the key manager",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			timeout, err := cmd.Flags().GetInt("timeout")
@@ -46,12 +46,22 @@ func AddInitEncryptionCommand(a *cli.App) *cobra.Command {
 				return ErrCredentialEncryptionFeatureUnavailable
 			}
 
-			km := keys.NewHCPVaultKeyManagerFromConfig(cfg.HCPVault, a.Licenser, a.Cache)
+			db, err := postgres.NewDB(cfg)
+			if err != nil {
+				log.WithError(err).Error("Error connecting to database.")
+				return err
+			}
+			defer db.Close()
+
+			km, err := keys.NewKeyManagerFromConfig(cfg, a.Licenser, a.Cache, db)
+			if err != nil {
+				return err
+			}
 			if !km.IsSet() {
-				return ErrMissingHCPVaultConfig
+				return ErrMissingKeyManagerConfig
 			}
 
-			currentKey, err := km.GetCurrentKey()
+			currentKey, err := keys.GetKeyForInit(km)
 			if err != nil {
 				return err
 			}
@@ -62,13 +72,6 @@ func AddInitEncryptionCommand(a *cli.App) *cobra.Command {
 
 			log.Infof("Initializing encryption with the current encryption key...")
 
-			db, err := postgres.NewDB(cfg)
-			if err != nil {
-				log.WithError(err).Error("Error connecting to database.")
-				return err
-			}
-			defer db.Close()
-
 			err = keys.InitEncryption(a.Logger, db, km, currentKey, timeout)
 			if err != nil {
 				log.WithError(err).Error("Error initializing encryption key.")
@@ -13,7 +13,7 @@ import (
 func AddRevertEncryptionCommand(a *cli.App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "revert-encryption",
-		Short: "Reverts the encryption initialization for the specified table columns with the encryption key fetched from HCP Vaults",
+		Short: "Reverts the encryption initialization for the specified table columns with the encryption key fetched from the key manager",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 
@@ -34,12 +34,22 @@ func AddRevertEncryptionCommand(a *cli.App) *cobra.Command {
 				return fflag2.ErrCredentialEncryptionNotEnabled
 			}
 
-			km := keys.NewHCPVaultKeyManagerFromConfig(cfg.HCPVault, a.Licenser, a.Cache)
+			db, err := postgres.NewDB(cfg)
+			if err != nil {
+				log.WithError(err).Error("Error connecting to database.")
+				return err
+			}
+			defer db.Close()
+
+			km, err := keys.NewKeyManagerFromConfig(cfg, a.Licenser, a.Cache, db)
+			if err != nil {
+				return err
+			}
 			if !km.IsSet() {
-				return ErrMissingHCPVaultConfig
+				return ErrMissingKeyManagerConfig
 			}
 
-			currentKey, err := km.GetHCPSecretKey()
+			currentKey, err := keys.GetKeyForRevert(km)
 			if err != nil {
 				return err
 			}
@@ -50,13 +60,6 @@ func AddRevertEncryptionCommand(a *cli.App) *cobra.Command {
 
 			log.Infof("Reverting encryption with the current encryption key...")
 
-			db, err := postgres.NewDB(cfg)
-			if err != nil {
-				log.WithError(err).Error("Error connecting to database.")
-				return err
-			}
-			defer db.Close()
-
 			err = keys.RevertEncryption(a.Logger, db, currentKey, timeout)
 			if err != nil {
 				log.WithError(err).Error("Error reverting the encryption key.")
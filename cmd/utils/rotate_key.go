@@ -1,13 +1,19 @@
 package utils
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
+
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
 	fflag2 "github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/internal/pkg/keys"
 	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/worker/task"
 	"github.com/spf13/cobra"
 )
 
@@ -20,15 +26,17 @@ func AddRotateKeyCommand(a *cli.App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rotate-key <old-key> <new-key>",
 		Short: "Rotates the encryption key by re-encrypting data with a new key",
-		Args:  cobra.ExactArgs(2),
+		Long: `Rotates the encryption key by re-encrypting data with a new key in a background job run by the workers.
+Credentials stay readable while the job runs, a rotation that failed is resumed by running the command with the same keys again.`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			oldKey, newKey, err := validateAndGetKeys(args)
 			if err != nil {
 				return err
 			}
-			timeout, err := cmd.Flags().GetInt("timeout")
+			wait, err := cmd.Flags().GetBool("wait")
 			if err != nil {
-				log.WithError(err).Errorln("failed to get timeout")
+				log.WithError(err).Errorln("failed to get wait")
 				return err
 			}
 
@@ -46,40 +54,89 @@ func AddRotateKeyCommand(a *cli.App) *cobra.Command {
 				return ErrCredentialEncryptionFeatureUnavailable
 			}
 
-			km := keys.NewHCPVaultKeyManagerFromConfig(cfg.HCPVault, a.Licenser, a.Cache)
+			db, err := postgres.NewDB(cfg)
+			if err != nil {
+				log.WithError(err).Error("Error connecting to database.")
+				return err
+			}
+			defer db.Close()
+
+			km, err := keys.NewKeyManagerFromConfig(cfg, a.Licenser, a.Cache, db)
+			if err != nil {
+				return err
+			}
 			if !km.IsSet() {
-				return ErrMissingHCPVaultConfig
+				return ErrMissingKeyManagerConfig
 			}
+			keys.SetCache(a.Cache)
 
 			log.Infof("Starting key rotation...")
 
-			// Ensure the old key matches the current key
-			currentKey, err := km.GetCurrentKey()
+			jobRepo := postgres.NewJobRepo(db)
+			job, err := keys.StartKeyRotation(cmd.Context(), db, jobRepo, km, oldKey, newKey)
 			if err != nil {
+				if errors.Is(err, keys.ErrEncryptionKeyMismatch) {
+					return ErrOldEncryptionKeyMismatch
+				}
+				log.WithError(err).Error("Error rotating key.")
 				return err
 			}
-			if oldKey != currentKey {
-				return ErrOldEncryptionKeyMismatch
-			}
 
-			db, err := postgres.NewDB(cfg)
+			err = task.QueueKeyRotation(a.Queue, job.UID)
 			if err != nil {
-				log.WithError(err).Error("Error connecting to database.")
+				log.WithError(err).Error("Error queueing key rotation.")
 				return err
 			}
-			defer db.Close()
 
-			err = keys.RotateEncryptionKey(a.Logger, db, km, oldKey, newKey, timeout)
-			if err != nil {
-				log.WithError(err).Error("Error rotating key.")
+			log.Infof("Key rotation job %s queued, credentials are re-encrypted by the workers.", job.UID)
+			if !wait {
+				return nil
 			}
-			return err
+
+			return waitForKeyRotation(cmd.Context(), jobRepo, job.UID)
 		},
 	}
+	cmd.Flags().Bool("wait", false, "Wait for the key rotation to complete")
 	cmd.Flags().Int("timeout", 120, "Optional statement timeout in seconds (default: 120)")
+	_ = cmd.Flags().MarkDeprecated("timeout", "key rotation no longer locks tables")
 	return cmd
 }
 
+// waitForKeyRotation logs the progress of the key rotation job until it
+// completes or fails.
+func waitForKeyRotation(ctx context.Context, jobRepo datastore.JobRepository, jobID string) error {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		job, err := jobRepo.FetchJobById(ctx, jobID, "")
+		if err != nil {
+			return err
+		}
+
+		if job.Progress != nil {
+			log.Infof("Re-encrypted %d of %d rows", job.Progress.Processed, job.Progress.Total)
+		}
+
+		switch job.Status {
+		case datastore.JobStatusCompleted:
+			log.Infof("Key rotation completed successfully.")
+			return nil
+		case datastore.JobStatusFailed:
+			if job.Progress != nil && job.Progress.Error != "" {
+				return fmt.Errorf("key rotation failed: %s", job.Progress.Error)
+			}
+			return errors.New("key rotation failed")
+		}
+	}
+}
+
 func validateAndGetKeys(args []string) (string, string, error) {
 	oldKey := args[0]
 	newKey := args[1]
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/deadletter"
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/internal/pkg/keys"
 	"github.com/frain-dev/convoy/internal/pkg/retention"
@@ -137,9 +138,13 @@ func StartWorker(ctx context.Context, a *cli.App, cfg config.Configuration, inte
 	}
 	lo.SetLevel(lvl)
 
-	km := keys.NewHCPVaultKeyManagerFromConfig(cfg.HCPVault, a.Licenser, a.Cache)
+	km, err := keys.NewKeyManagerFromConfig(cfg, a.Licenser, a.Cache, a.DB)
+	if err != nil {
+		return err
+	}
 	if km.IsSet() {
-		if _, err = km.GetCurrentKeyFromCache(); err != nil {
+		// there is no data key until encryption is initialised
+		if _, err = km.GetCurrentKeyFromCache(); err != nil && !errors.Is(err, keys.ErrNoDataKey) {
 			if !errors.Is(err, keys.ErrCredentialEncryptionFeatureUnavailable) {
 				return err
 			}
@@ -149,6 +154,7 @@ func StartWorker(ctx context.Context, a *cli.App, cfg config.Configuration, inte
 	if err = keys.Set(km); err != nil {
 		return err
 	}
+	keys.SetCache(a.Cache)
 
 	sc, err := smtp.NewClient(&cfg.SMTP)
 	if err != nil {
@@ -228,6 +234,10 @@ func StartWorker(ctx context.Context, a *cli.App, cfg config.Configuration, inte
 	deviceRepo := postgres.NewDeviceRepo(a.DB)
 	configRepo := postgres.NewConfigRepo(a.DB)
 	attemptRepo := postgres.NewDeliveryAttemptRepo(a.DB)
+	scheduledEventRepo := postgres.NewScheduledEventRepo(a.DB)
+	deadLetterRepo := postgres.NewDeadLetterRepo(a.DB)
+	endpointProbeRepo := postgres.NewEndpointProbeRepo(a.DB)
+	sourceRepo := postgres.NewSourceRepo(a.DB)
 
 	rd, err := rdb.NewClient(cfg.Redis.BuildDsn())
 	if err != nil {
@@ -280,6 +290,7 @@ func StartWorker(ctx context.Context, a *cli.App, cfg config.Configuration, inte
 		net.AllowListOption(cfg.Dispatcher.AllowList),
 		net.BlockListOption(cfg.Dispatcher.BlockList),
 		net.InsecureSkipVerifyOption(cfg.Dispatcher.InsecureSkipVerify),
+		net.TokenCacheOption(a.Cache),
 	)
 	if err != nil {
 		lo.WithError(err).Fatal("Failed to create new net dispatcher")
@@ -427,9 +438,25 @@ func StartWorker(ctx context.Context, a *cli.App, cfg config.Configuration, inte
 		subRepo,
 		deviceRepo, a.Licenser), newTelemetry)
 
+	consumer.RegisterHandlers(convoy.ScheduledEventProcessor, task.ProcessScheduledEvent(scheduledEventRepo, a.Queue), nil)
+
+	deadLetterSink, err := deadletter.NewSink(cfg.DeadLetterSink)
+	if err != nil {
+		return err
+	}
+	consumer.RegisterHandlers(convoy.DeadLetterProcessor, task.ProcessDeadLetters(deadLetterRepo, eventDeliveryRepo, deadLetterSink), nil)
+
+	consumer.RegisterHandlers(convoy.EndpointVerificationProcessor, task.ProcessEndpointVerification(endpointRepo, projectRepo, a.Queue, dispatcher, a.Licenser), nil)
+	consumer.RegisterHandlers(convoy.ProbeEndpoints, task.ProbeEndpoints(endpointRepo, endpointProbeRepo, a.Queue), nil)
+	consumer.RegisterHandlers(convoy.EndpointProbeProcessor, task.ProcessEndpointProbe(endpointRepo, projectRepo, endpointProbeRepo, dispatcher, a.Licenser), nil)
+
+	consumer.RegisterHandlers(convoy.PollRestApiSources, task.PollRestApiSources(sourceRepo, a.Queue), nil)
+	consumer.RegisterHandlers(convoy.RestApiSourcePollProcessor, task.ProcessRestApiSourcePoll(sourceRepo, a.Queue, dispatcher), nil)
+
 	consumer.RegisterHandlers(convoy.MonitorTwitterSources, task.MonitorTwitterSources(a.DB, a.Queue, rd), nil)
 
 	consumer.RegisterHandlers(convoy.ExpireSecretsProcessor, task.ExpireSecret(endpointRepo), nil)
+	consumer.RegisterHandlers(convoy.KeyRotationProcessor, task.ProcessKeyRotation(lo, a.DB, jobRepo), nil)
 
 	consumer.RegisterHandlers(convoy.DailyAnalytics, task.PushDailyTelemetry(lo, a.DB, rd), nil)
 	consumer.RegisterHandlers(convoy.EmailProcessor, task.ProcessEmails(sc), nil)
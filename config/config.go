@@ -226,7 +226,29 @@ type AuthConfiguration struct {
 	File            FileRealmOption    `json:"file"`
 	Native          NativeRealmOptions `json:"native"`
 	Jwt             JwtRealmOptions    `json:"jwt"`
+	OIDC            OIDCRealmOptions   `json:"oidc"`
+	SCIM            SCIMOptions        `json:"scim"`
 	IsSignupEnabled bool               `json:"is_signup_enabled" envconfig:"CONVOY_SIGNUP_ENABLED"`
+
+	// InstanceAdmins are the emails of the users allowed to see
+	// instance wide operations such as key rotations.
+	InstanceAdmins []string `json:"instance_admins" envconfig:"CONVOY_INSTANCE_ADMINS"`
+}
+
+// IsInstanceAdmin reports whether email belongs to an instance admin.
+func (a AuthConfiguration) IsInstanceAdmin(email string) bool {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return false
+	}
+
+	for _, admin := range a.InstanceAdmins {
+		if strings.EqualFold(strings.TrimSpace(admin), email) {
+			return true
+		}
+	}
+
+	return false
 }
 
 type NativeRealmOptions struct {
@@ -241,6 +263,31 @@ type JwtRealmOptions struct {
 	RefreshExpiry int    `json:"refresh_expiry" envconfig:"CONVOY_JWT_REFRESH_EXPIRY"`
 }
 
+type OIDCRealmOptions struct {
+	Enabled      bool     `json:"enabled" envconfig:"CONVOY_OIDC_REALM_ENABLED"`
+	Issuer       string   `json:"issuer" envconfig:"CONVOY_OIDC_ISSUER"`
+	ClientID     string   `json:"client_id" envconfig:"CONVOY_OIDC_CLIENT_ID"`
+	ClientSecret string   `json:"client_secret" envconfig:"CONVOY_OIDC_CLIENT_SECRET"`
+	RedirectURL  string   `json:"redirect_url" envconfig:"CONVOY_OIDC_REDIRECT_URL"`
+	Scopes       []string `json:"scopes" envconfig:"CONVOY_OIDC_SCOPES"`
+
+	// GroupsClaim is the ID token claim that holds the user's groups, it
+	// defaults to groups.
+	GroupsClaim string `json:"groups_claim" envconfig:"CONVOY_OIDC_GROUPS_CLAIM"`
+
+	// OrganisationID is the organisation users who sign in are made members of.
+	OrganisationID string         `json:"organisation_id" envconfig:"CONVOY_OIDC_ORGANISATION_ID"`
+	GroupRoles     OIDCGroupRoles `json:"group_roles" envconfig:"CONVOY_OIDC_GROUP_ROLES"`
+
+	// AutoProvision creates the users that sign in for the first time,
+	// otherwise they have to be invited or provisioned with SCIM.
+	AutoProvision bool `json:"auto_provision" envconfig:"CONVOY_OIDC_AUTO_PROVISION"`
+}
+
+type SCIMOptions struct {
+	Enabled bool `json:"enabled" envconfig:"CONVOY_SCIM_ENABLED"`
+}
+
 type SMTPConfiguration struct {
 	SSL      bool   `json:"ssl" envconfig:"CONVOY_SMTP_SSL"`
 	Provider string `json:"provider" envconfig:"CONVOY_SMTP_PROVIDER"`
@@ -322,6 +369,111 @@ type OnPremStorage struct {
 	Path string `json:"path" envconfig:"CONVOY_STORAGE_PREM_PATH"`
 }
 
+type DeadLetterSinkType string
+
+const (
+	KafkaDeadLetterSink DeadLetterSinkType = "kafka"
+	SQSDeadLetterSink   DeadLetterSinkType = "sqs"
+	S3DeadLetterSink    DeadLetterSinkType = "s3"
+)
+
+// DeadLetterSinkConfiguration is where the payloads of dead-lettered event
+// deliveries are forwarded to, nothing is forwarded when Type is empty.
+type DeadLetterSinkConfiguration struct {
+	Type  DeadLetterSinkType               `json:"type" envconfig:"CONVOY_DEAD_LETTER_SINK_TYPE"`
+	Kafka KafkaDeadLetterSinkConfiguration `json:"kafka"`
+	SQS   SQSDeadLetterSinkConfiguration   `json:"sqs"`
+	S3    S3DeadLetterSinkConfiguration    `json:"s3"`
+}
+
+type KafkaDeadLetterSinkConfiguration struct {
+	Brokers  []string `json:"brokers" envconfig:"CONVOY_DEAD_LETTER_SINK_KAFKA_BROKERS"`
+	Topic    string   `json:"topic" envconfig:"CONVOY_DEAD_LETTER_SINK_KAFKA_TOPIC"`
+	AuthType string   `json:"auth_type" envconfig:"CONVOY_DEAD_LETTER_SINK_KAFKA_AUTH_TYPE"`
+	Hash     string   `json:"hash" envconfig:"CONVOY_DEAD_LETTER_SINK_KAFKA_HASH"`
+	TLS      bool     `json:"tls" envconfig:"CONVOY_DEAD_LETTER_SINK_KAFKA_TLS"`
+	Username string   `json:"username" envconfig:"CONVOY_DEAD_LETTER_SINK_KAFKA_USERNAME"`
+	Password string   `json:"password" envconfig:"CONVOY_DEAD_LETTER_SINK_KAFKA_PASSWORD"`
+}
+
+type SQSDeadLetterSinkConfiguration struct {
+	AccessKeyID   string `json:"access_key_id" envconfig:"CONVOY_DEAD_LETTER_SINK_SQS_ACCESS_KEY_ID"`
+	SecretKey     string `json:"secret_key" envconfig:"CONVOY_DEAD_LETTER_SINK_SQS_SECRET_KEY"`
+	DefaultRegion string `json:"default_region" envconfig:"CONVOY_DEAD_LETTER_SINK_SQS_DEFAULT_REGION"`
+	QueueName     string `json:"queue_name" envconfig:"CONVOY_DEAD_LETTER_SINK_SQS_QUEUE_NAME"`
+}
+
+type S3DeadLetterSinkConfiguration struct {
+	Prefix       string `json:"prefix" envconfig:"CONVOY_DEAD_LETTER_SINK_S3_PREFIX"`
+	Bucket       string `json:"bucket" envconfig:"CONVOY_DEAD_LETTER_SINK_S3_BUCKET"`
+	AccessKey    string `json:"access_key" envconfig:"CONVOY_DEAD_LETTER_SINK_S3_ACCESS_KEY"`
+	SecretKey    string `json:"secret_key" envconfig:"CONVOY_DEAD_LETTER_SINK_S3_SECRET_KEY"`
+	Region       string `json:"region" envconfig:"CONVOY_DEAD_LETTER_SINK_S3_REGION"`
+	SessionToken string `json:"session_token" envconfig:"CONVOY_DEAD_LETTER_SINK_S3_SESSION_TOKEN"`
+	Endpoint     string `json:"endpoint" envconfig:"CONVOY_DEAD_LETTER_SINK_S3_ENDPOINT"`
+}
+
+type DedupStore string
+
+const (
+	RedisDedupStore  DedupStore = "redis"
+	MemoryDedupStore DedupStore = "memory"
+)
+
+// DedupConfiguration configures where idempotency keys are remembered for
+// sources and events with a dedup window. The memory store isn't shared,
+// so it only dedupes requests received by the same instance.
+type DedupConfiguration struct {
+	Store DedupStore `json:"store" envconfig:"CONVOY_DEDUP_STORE"`
+}
+
+// TransformConfiguration configures the runtimes that run subscription and
+// source functions, zero values use the defaults of pkg/transform.
+type TransformConfiguration struct {
+	PoolSize         int    `json:"pool_size" envconfig:"CONVOY_TRANSFORM_POOL_SIZE"`
+	Timeout          uint64 `json:"timeout_ms" envconfig:"CONVOY_TRANSFORM_TIMEOUT_MS"`
+	MaxCallStackSize int    `json:"max_call_stack_size" envconfig:"CONVOY_TRANSFORM_MAX_CALL_STACK_SIZE"`
+	CacheSize        int    `json:"cache_size" envconfig:"CONVOY_TRANSFORM_CACHE_SIZE"`
+	MaxPayloadSize   int    `json:"max_payload_size" envconfig:"CONVOY_TRANSFORM_MAX_PAYLOAD_SIZE"`
+}
+
+type KeyManagerProvider string
+
+const (
+	HCPVaultKeyManagerProvider     KeyManagerProvider = "hcp_vault"
+	VaultKVKeyManagerProvider      KeyManagerProvider = "vault_kv"
+	VaultTransitKeyManagerProvider KeyManagerProvider = "vault_transit"
+	FileKeyManagerProvider         KeyManagerProvider = "file"
+	LocalKeyManagerProvider        KeyManagerProvider = "local"
+)
+
+// KeyManagerConfiguration selects where the key used to encrypt credentials
+// is kept, HCP Vault is used when no provider is set.
+type KeyManagerConfiguration struct {
+	Provider KeyManagerProvider          `json:"provider" envconfig:"CONVOY_KEY_MANAGER_PROVIDER"`
+	Vault    VaultConfiguration          `json:"vault"`
+	File     FileKeyManagerConfiguration `json:"file"`
+}
+
+// VaultConfiguration configures a self-hosted HashiCorp Vault. The kv
+// provider keeps the key at Path in the KV v2 engine mounted at Mount, the
+// transit provider wraps data keys with TransitKey of the transit engine
+// mounted at Mount.
+type VaultConfiguration struct {
+	Address    string `json:"address" envconfig:"CONVOY_VAULT_ADDRESS"`
+	Token      string `json:"token" envconfig:"CONVOY_VAULT_TOKEN"`
+	Namespace  string `json:"namespace" envconfig:"CONVOY_VAULT_NAMESPACE"`
+	Mount      string `json:"mount" envconfig:"CONVOY_VAULT_MOUNT"`
+	Path       string `json:"path" envconfig:"CONVOY_VAULT_PATH"`
+	TransitKey string `json:"transit_key" envconfig:"CONVOY_VAULT_TRANSIT_KEY"`
+}
+
+// FileKeyManagerConfiguration points to a file holding a 32 byte master key,
+// raw or base64 encoded, used to wrap data keys.
+type FileKeyManagerConfiguration struct {
+	MasterKeyPath string `json:"master_key_path" envconfig:"CONVOY_KEY_MANAGER_MASTER_KEY_PATH"`
+}
+
 type MetricsConfiguration struct {
 	IsEnabled  bool                           `json:"enabled" envconfig:"CONVOY_METRICS_ENABLED"`
 	Backend    MetricsBackend                 `json:"metrics_backend" envconfig:"CONVOY_METRICS_BACKEND"`
@@ -408,6 +560,10 @@ type Configuration struct {
 	LicenseKey          string                       `json:"license_key" envconfig:"CONVOY_LICENSE_KEY"`
 	Dispatcher          DispatcherConfiguration      `json:"dispatcher"`
 	HCPVault            HCPVaultConfig               `json:"hcp_vault"`
+	DeadLetterSink      DeadLetterSinkConfiguration  `json:"dead_letter_sink"`
+	Dedup               DedupConfiguration           `json:"dedup"`
+	Transform           TransformConfiguration       `json:"transform"`
+	KeyManager          KeyManagerConfiguration      `json:"key_manager"`
 }
 
 type DispatcherConfiguration struct {
@@ -539,6 +695,20 @@ func ensureQueueConfig(queueCfg RedisConfiguration) error {
 	return nil
 }
 
+func ensureOIDCConfig(oidc OIDCRealmOptions) error {
+	if len(oidc.Issuer) == 0 || len(oidc.ClientID) == 0 || len(oidc.RedirectURL) == 0 {
+		return errors.New("oidc realm requires an issuer, client id and redirect url")
+	}
+
+	for _, g := range oidc.GroupRoles {
+		if !g.Role.Type.IsValid() {
+			return fmt.Errorf("invalid role type %s for oidc group %s", g.Role.Type, g.Group)
+		}
+	}
+
+	return nil
+}
+
 func ensureMaxResponseSize(c *Configuration) {
 	bytes := c.MaxResponseSize * 1024
 
@@ -560,6 +730,31 @@ func validate(c *Configuration) error {
 		return err
 	}
 
+	switch c.DeadLetterSink.Type {
+	case "", KafkaDeadLetterSink, SQSDeadLetterSink, S3DeadLetterSink:
+	default:
+		return fmt.Errorf("unsupported dead letter sink type: %s", c.DeadLetterSink.Type)
+	}
+
+	switch c.Dedup.Store {
+	case "", RedisDedupStore, MemoryDedupStore:
+	default:
+		return fmt.Errorf("unsupported dedup store: %s", c.Dedup.Store)
+	}
+
+	if c.Auth.OIDC.Enabled {
+		if err := ensureOIDCConfig(c.Auth.OIDC); err != nil {
+			return err
+		}
+	}
+
+	switch c.KeyManager.Provider {
+	case "", HCPVaultKeyManagerProvider, VaultKVKeyManagerProvider, VaultTransitKeyManagerProvider,
+		FileKeyManagerProvider, LocalKeyManagerProvider:
+	default:
+		return fmt.Errorf("unsupported key manager provider: %s", c.KeyManager.Provider)
+	}
+
 	if c.Metrics.IsEnabled {
 		backend := c.Metrics.Backend
 		switch backend {
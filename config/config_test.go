@@ -589,3 +589,25 @@ func Test_DatabaseConfigurationBuildDsn(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthConfiguration_IsInstanceAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		admins   []string
+		email    string
+		expected bool
+	}{
+		{name: "no instance admins", admins: nil, email: "admin@example.com", expected: false},
+		{name: "empty email", admins: []string{"admin@example.com"}, email: "", expected: false},
+		{name: "listed email", admins: []string{"admin@example.com"}, email: "admin@example.com", expected: true},
+		{name: "matches case insensitively", admins: []string{" Admin@Example.com"}, email: "admin@example.com", expected: true},
+		{name: "unlisted email", admins: []string{"admin@example.com"}, email: "member@example.com", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := AuthConfiguration{InstanceAdmins: tc.admins}
+			require.Equal(t, tc.expected, a.IsInstanceAdmin(tc.email))
+		})
+	}
+}
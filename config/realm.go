@@ -38,3 +38,40 @@ func (a *APIKeyAuthConfig) Decode(value string) error {
 	*a = config
 	return err
 }
+
+type OIDCGroupRoles []OIDCGroupRole
+
+// OIDCGroupRole is the role members of an identity provider group get.
+type OIDCGroupRole struct {
+	Group string    `json:"group"`
+	Role  auth.Role `json:"role"`
+}
+
+// Decode loads in config from an env var named `CONVOY_OIDC_GROUP_ROLES`
+func (g *OIDCGroupRoles) Decode(value string) error {
+	config := OIDCGroupRoles{}
+	err := json.Unmarshal([]byte(value), &config)
+
+	*g = config
+	return err
+}
+
+// Role returns the role of the first group in the list the user is a member
+// of, so the most privileged groups should be listed first. Users get the
+// member role when no groups are mapped, and none when they aren't in any
+// of the mapped groups.
+func (g OIDCGroupRoles) Role(groups []string) (auth.Role, bool) {
+	if len(g) == 0 {
+		return auth.Role{Type: auth.RoleMember}, true
+	}
+
+	for _, gr := range g {
+		for _, group := range groups {
+			if gr.Group == group {
+				return gr.Role, true
+			}
+		}
+	}
+
+	return auth.Role{}, false
+}
@@ -0,0 +1,34 @@
+package listener
+
+import (
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
+)
+
+type AuditLogListener struct {
+	mEvent *services.MetaEvent
+}
+
+func NewAuditLogListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository) *AuditLogListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo)
+	return &AuditLogListener{mEvent: mEvent}
+}
+
+func (a *AuditLogListener) AfterCreate(data interface{}, _ interface{}) {
+	auditLog, ok := data.(*datastore.AuditLog)
+	if !ok {
+		log.Errorf("invalid type for event - %s", datastore.AuditLogCreated)
+		return
+	}
+
+	// organisation actions aren't streamed, meta events are configured per project
+	if len(auditLog.ProjectID) == 0 {
+		return
+	}
+
+	if err := a.mEvent.Run(string(datastore.AuditLogCreated), auditLog.ProjectID, auditLog); err != nil {
+		log.WithError(err).Error("audit log meta event failed")
+	}
+}
@@ -15,8 +15,8 @@ import (
 
 const (
 	createAPIKey = `
-    INSERT INTO convoy.api_keys (id,name,key_type,mask_id,role_type,role_project,role_endpoint,hash,salt,user_id,expires_at)
-    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11);
+    INSERT INTO convoy.api_keys (id,name,key_type,mask_id,role_type,role_project,role_endpoint,role_permissions,hash,salt,user_id,expires_at)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12);
     `
 
 	updateAPIKeyById = `
@@ -25,6 +25,7 @@ const (
 		role_type= $3,
 		role_project=$4,
 		role_endpoint=$5,
+		role_permissions=$6,
 		updated_at = NOW()
 	WHERE id = $1 AND deleted_at IS NULL ;
 	`
@@ -38,6 +39,7 @@ const (
 	    COALESCE(role_type,'') AS "role.type",
 	    COALESCE(role_project,'') AS "role.project",
 	    COALESCE(role_endpoint,'') AS "role.endpoint",
+	    role_permissions AS "role.permissions",
 	    hash,
 	    salt,
 	    COALESCE(user_id, '') AS user_id,
@@ -63,6 +65,7 @@ const (
 	    COALESCE(role_type,'') AS "role.type",
 	    COALESCE(role_project,'') AS "role.project",
 	    COALESCE(role_endpoint,'') AS "role.endpoint",
+	    role_permissions AS "role.permissions",
 	    hash,
 	    salt,
 	    COALESCE(user_id, '') AS user_id,
@@ -151,7 +154,7 @@ func (a *apiKeyRepo) CreateAPIKey(ctx context.Context, key *datastore.APIKey) er
 
 	result, err := a.db.GetDB().ExecContext(
 		ctx, createAPIKey, key.UID, key.Name, key.Type, key.MaskID,
-		roleType, projectID, endpointID, key.Hash,
+		roleType, projectID, endpointID, key.Role.Permissions, key.Hash,
 		key.Salt, userID, key.ExpiresAt,
 	)
 	if err != nil {
@@ -188,7 +191,7 @@ func (a *apiKeyRepo) UpdateAPIKey(ctx context.Context, key *datastore.APIKey) er
 	}
 
 	result, err := a.db.GetDB().ExecContext(
-		ctx, updateAPIKeyById, key.UID, key.Name, roleType, projectID, endpointID,
+		ctx, updateAPIKeyById, key.UID, key.Name, roleType, projectID, endpointID, key.Role.Permissions,
 	)
 	if err != nil {
 		return err
@@ -135,8 +135,9 @@ func Test_UpdateAPIKey(t *testing.T) {
 
 	apiKey.Name = "Updated-Test-Api-Key"
 	apiKey.Role = auth.Role{
-		Type:    auth.RoleSuperUser,
-		Project: project.UID,
+		Type:        auth.RoleCustom,
+		Project:     project.UID,
+		Permissions: auth.Permissions{auth.PermissionEventsRead, auth.PermissionDeliveriesRetry},
 	}
 
 	require.NoError(t, apiKeyRepo.UpdateAPIKey(context.Background(), apiKey))
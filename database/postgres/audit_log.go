@@ -0,0 +1,263 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+const auditLogExportBatchSize = 3000
+
+const (
+	createAuditLog = `
+	INSERT INTO convoy.audit_logs (id, organisation_id, project_id, actor_type, actor_id, actor_name,
+	action, resource_type, resource_id, changes, ip_address, user_agent)
+	VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING created_at;
+	`
+
+	baseAuditLogs = `
+	SELECT al.id, al.organisation_id, COALESCE(al.project_id, '') AS project_id,
+	al.actor_type AS "actor.type", al.actor_id AS "actor.id", al.actor_name AS "actor.name",
+	al.action, al.resource_type, al.resource_id, al.changes, al.ip_address,
+	al.user_agent, al.created_at FROM convoy.audit_logs al
+	WHERE al.organisation_id = :organisation_id
+	`
+	baseAuditLogsPagedForward = `%s %s AND al.id <= :cursor
+	ORDER BY al.id DESC
+	LIMIT :limit
+	`
+	baseAuditLogsPagedBackward = `
+	WITH audit_logs AS (
+		%s %s AND al.id >= :cursor
+		ORDER BY al.id ASC
+		LIMIT :limit
+	)
+
+	SELECT * from audit_logs ORDER BY id DESC
+	`
+
+	auditLogProjectFilter      = ` AND al.project_id = :project_id`
+	auditLogActorFilter        = ` AND al.actor_id = :actor_id`
+	auditLogResourceTypeFilter = ` AND al.resource_type = :resource_type`
+	auditLogResourceIDFilter   = ` AND al.resource_id = :resource_id`
+	auditLogActionFilter       = ` AND al.action = :action`
+	auditLogCreatedAtFilter    = ` AND al.created_at >= :start_date AND al.created_at <= :end_date`
+
+	baseCountPrevAuditLogs = `
+	SELECT COUNT(DISTINCT(al.id)) AS count
+	FROM convoy.audit_logs al WHERE al.organisation_id = :organisation_id
+	`
+	countPrevAuditLogs = ` AND al.id > :cursor GROUP BY al.id ORDER BY al.id DESC LIMIT 1`
+
+	exportAuditLogs = `%s %s AND al.id > :last_id ORDER BY al.id ASC LIMIT :limit`
+)
+
+type auditLogRepo struct {
+	db   database.Database
+	hook *hooks.Hook
+}
+
+func NewAuditLogRepo(db database.Database) datastore.AuditLogRepository {
+	return &auditLogRepo{db: db, hook: db.GetHook()}
+}
+
+func (a *auditLogRepo) CreateAuditLog(ctx context.Context, auditLog *datastore.AuditLog) error {
+	err := a.db.GetDB().QueryRowxContext(ctx, createAuditLog, auditLog.UID, auditLog.OrganisationID, auditLog.ProjectID,
+		auditLog.Actor.Type, auditLog.Actor.ID, auditLog.Actor.Name, auditLog.Action, auditLog.ResourceType,
+		auditLog.ResourceID, auditLog.Changes, auditLog.IPAddress, auditLog.UserAgent,
+	).Scan(&auditLog.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	go a.hook.Fire(datastore.AuditLogCreated, auditLog, nil)
+	return nil
+}
+
+func (a *auditLogRepo) LoadAuditLogsPaged(ctx context.Context, orgID string, filter *datastore.AuditLogFilter, pageable datastore.Pageable) ([]datastore.AuditLog, datastore.PaginationData, error) {
+	arg, filterQuery := auditLogFilterArgs(orgID, filter)
+	arg["limit"] = pageable.Limit()
+	arg["cursor"] = pageable.Cursor()
+
+	var baseQueryPagination string
+	if pageable.Direction == datastore.Next {
+		baseQueryPagination = baseAuditLogsPagedForward
+	} else {
+		baseQueryPagination = baseAuditLogsPagedBackward
+	}
+
+	query := fmt.Sprintf(baseQueryPagination, baseAuditLogs, filterQuery)
+	auditLogs, err := a.selectAuditLogs(ctx, query, arg)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	var prevRowCount datastore.PrevRowCount
+	if len(auditLogs) > 0 {
+		qarg := arg
+		qarg["cursor"] = auditLogs[0].UID
+
+		countQuery, qargs, err := sqlx.Named(baseCountPrevAuditLogs+filterQuery+countPrevAuditLogs, qarg)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		countQuery = a.db.GetReadDB().Rebind(countQuery)
+		rows, err := a.db.GetReadDB().QueryxContext(ctx, countQuery, qargs...)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+		defer closeWithError(rows)
+
+		if rows.Next() {
+			err = rows.StructScan(&prevRowCount)
+			if err != nil {
+				return nil, datastore.PaginationData{}, err
+			}
+		}
+	}
+
+	ids := make([]string, len(auditLogs))
+	for i := range auditLogs {
+		ids[i] = auditLogs[i].UID
+	}
+
+	if len(auditLogs) > pageable.PerPage {
+		auditLogs = auditLogs[:len(auditLogs)-1]
+	}
+
+	pagination := &datastore.PaginationData{PrevRowCount: prevRowCount}
+	pagination = pagination.Build(pageable, ids)
+
+	return auditLogs, *pagination, nil
+}
+
+// ExportAuditLogs writes the audit logs matching the filter to w as a json
+// array, oldest first. It's the caller's responsibility to close the writer.
+func (a *auditLogRepo) ExportAuditLogs(ctx context.Context, orgID string, filter *datastore.AuditLogFilter, w io.Writer) (int64, error) {
+	arg, filterQuery := auditLogFilterArgs(orgID, filter)
+	arg["limit"] = auditLogExportBatchSize
+	arg["last_id"] = ""
+
+	query := fmt.Sprintf(exportAuditLogs, baseAuditLogs, filterQuery)
+
+	_, err := w.Write([]byte(`[`))
+	if err != nil {
+		return 0, err
+	}
+
+	var numDocs int64
+	for {
+		auditLogs, err := a.selectAuditLogs(ctx, query, arg)
+		if err != nil {
+			return 0, err
+		}
+
+		for i := range auditLogs {
+			b, err := json.Marshal(auditLogs[i])
+			if err != nil {
+				return 0, err
+			}
+
+			if numDocs > 0 {
+				b = append(commaJSON, b...)
+			}
+
+			_, err = w.Write(b)
+			if err != nil {
+				return 0, err
+			}
+
+			numDocs++
+		}
+
+		if len(auditLogs) < auditLogExportBatchSize {
+			break
+		}
+
+		arg["last_id"] = auditLogs[len(auditLogs)-1].UID
+	}
+
+	_, err = w.Write([]byte(`]`))
+	if err != nil {
+		return 0, err
+	}
+
+	return numDocs, nil
+}
+
+func (a *auditLogRepo) selectAuditLogs(ctx context.Context, query string, arg map[string]interface{}) ([]datastore.AuditLog, error) {
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	query = a.db.GetReadDB().Rebind(query)
+	rows, err := a.db.GetReadDB().QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeWithError(rows)
+
+	auditLogs := make([]datastore.AuditLog, 0)
+	for rows.Next() {
+		var data datastore.AuditLog
+
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, err
+		}
+
+		auditLogs = append(auditLogs, data)
+	}
+
+	return auditLogs, nil
+}
+
+func auditLogFilterArgs(orgID string, filter *datastore.AuditLogFilter) (map[string]interface{}, string) {
+	arg := map[string]interface{}{
+		"organisation_id": orgID,
+	}
+
+	var filterQuery string
+	if len(filter.ProjectID) > 0 {
+		arg["project_id"] = filter.ProjectID
+		filterQuery += auditLogProjectFilter
+	}
+
+	if len(filter.ActorID) > 0 {
+		arg["actor_id"] = filter.ActorID
+		filterQuery += auditLogActorFilter
+	}
+
+	if len(filter.ResourceType) > 0 {
+		arg["resource_type"] = filter.ResourceType
+		filterQuery += auditLogResourceTypeFilter
+	}
+
+	if len(filter.ResourceID) > 0 {
+		arg["resource_id"] = filter.ResourceID
+		filterQuery += auditLogResourceIDFilter
+	}
+
+	if len(filter.Action) > 0 {
+		arg["action"] = filter.Action
+		filterQuery += auditLogActionFilter
+	}
+
+	if filter.SearchParams.CreatedAtEnd > 0 {
+		startDate, endDate := getCreatedDateFilter(filter.SearchParams.CreatedAtStart, filter.SearchParams.CreatedAtEnd)
+		arg["start_date"] = startDate
+		arg["end_date"] = endDate
+		filterQuery += auditLogCreatedAtFilter
+	}
+
+	return arg, filterQuery
+}
@@ -0,0 +1,126 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateAuditLog(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	project := seedProject(t, db)
+	auditLogRepo := NewAuditLogRepo(db)
+	ctx := context.Background()
+
+	auditLog := generateAuditLog(project)
+	require.NoError(t, auditLogRepo.CreateAuditLog(ctx, auditLog))
+	require.WithinDuration(t, time.Now(), auditLog.CreatedAt, 5*time.Second)
+
+	pageable := datastore.Pageable{PerPage: 10, Direction: datastore.Next, NextCursor: datastore.DefaultCursor}
+	auditLogs, _, err := auditLogRepo.LoadAuditLogsPaged(ctx, project.OrganisationID, &datastore.AuditLogFilter{}, pageable)
+	require.NoError(t, err)
+	require.Len(t, auditLogs, 1)
+
+	require.Equal(t, auditLog.Actor, auditLogs[0].Actor)
+	require.Equal(t, auditLog.Changes, auditLogs[0].Changes)
+	require.Equal(t, project.UID, auditLogs[0].ProjectID)
+
+	// audit logs can't be changed once written
+	_, err = db.GetDB().ExecContext(ctx, `UPDATE convoy.audit_logs SET action = 'deleted' WHERE id = $1`, auditLog.UID)
+	require.ErrorContains(t, err, "audit logs are append-only")
+
+	_, err = db.GetDB().ExecContext(ctx, `DELETE FROM convoy.audit_logs WHERE id = $1`, auditLog.UID)
+	require.ErrorContains(t, err, "audit logs are append-only")
+}
+
+func Test_LoadAuditLogsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	project := seedProject(t, db)
+	auditLogRepo := NewAuditLogRepo(db)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		auditLog := generateAuditLog(project)
+		if i < 2 {
+			auditLog.ProjectID = ""
+			auditLog.ResourceType = datastore.OrganisationMemberAuditResource
+		}
+
+		require.NoError(t, auditLogRepo.CreateAuditLog(ctx, auditLog))
+	}
+
+	pageable := datastore.Pageable{PerPage: 10, Direction: datastore.Next, NextCursor: datastore.DefaultCursor}
+
+	auditLogs, _, err := auditLogRepo.LoadAuditLogsPaged(ctx, project.OrganisationID, &datastore.AuditLogFilter{}, pageable)
+	require.NoError(t, err)
+	require.Len(t, auditLogs, 5)
+
+	auditLogs, _, err = auditLogRepo.LoadAuditLogsPaged(ctx, project.OrganisationID, &datastore.AuditLogFilter{ProjectID: project.UID}, pageable)
+	require.NoError(t, err)
+	require.Len(t, auditLogs, 3)
+
+	auditLogs, _, err = auditLogRepo.LoadAuditLogsPaged(ctx, project.OrganisationID, &datastore.AuditLogFilter{ResourceType: datastore.OrganisationMemberAuditResource}, pageable)
+	require.NoError(t, err)
+	require.Len(t, auditLogs, 2)
+
+	auditLogs, _, err = auditLogRepo.LoadAuditLogsPaged(ctx, ulid.Make().String(), &datastore.AuditLogFilter{}, pageable)
+	require.NoError(t, err)
+	require.Empty(t, auditLogs)
+}
+
+func Test_ExportAuditLogs(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	project := seedProject(t, db)
+	auditLogRepo := NewAuditLogRepo(db)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, auditLogRepo.CreateAuditLog(ctx, generateAuditLog(project)))
+	}
+
+	var buf bytes.Buffer
+	n, err := auditLogRepo.ExportAuditLogs(ctx, project.OrganisationID, &datastore.AuditLogFilter{ProjectID: project.UID}, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	var auditLogs []datastore.AuditLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &auditLogs))
+	require.Len(t, auditLogs, 3)
+	require.True(t, auditLogs[0].UID < auditLogs[2].UID)
+}
+
+func generateAuditLog(project *datastore.Project) *datastore.AuditLog {
+	return &datastore.AuditLog{
+		UID:            ulid.Make().String(),
+		OrganisationID: project.OrganisationID,
+		ProjectID:      project.UID,
+		Actor: datastore.AuditActor{
+			Type: datastore.UserAuditActor,
+			ID:   ulid.Make().String(),
+			Name: "jane@example.com",
+		},
+		Action:       datastore.AuditActionUpdated,
+		ResourceType: datastore.EndpointAuditResource,
+		ResourceID:   ulid.Make().String(),
+		Changes: datastore.AuditChanges{
+			"name": {Before: "old-name", After: "new-name"},
+		},
+		IPAddress: "10.0.0.1",
+		UserAgent: "curl/8.0",
+	}
+}
@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrDeadLetterNotCreated = errors.New("dead letter could not be created")
+
+const (
+	// an event delivery is dead-lettered again when it exhausts its retries
+	// after being redriven, this resets the row rather than adding another.
+	createDeadLetter = `
+	INSERT INTO convoy.dead_letters (id, project_id, event_delivery_id, event_id, endpoint_id, event_type,
+	reason, last_response_status, last_response_body, last_response_error, dead_lettered_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+	ON CONFLICT (event_delivery_id) DO UPDATE SET
+	  endpoint_id = EXCLUDED.endpoint_id,
+	  reason = EXCLUDED.reason,
+	  last_response_status = EXCLUDED.last_response_status,
+	  last_response_body = EXCLUDED.last_response_body,
+	  last_response_error = EXCLUDED.last_response_error,
+	  dead_lettered_at = NOW(),
+	  redriven_at = NULL,
+	  updated_at = NOW(),
+	  deleted_at = NULL
+	RETURNING id, dead_lettered_at, redrive_count;
+	`
+
+	fetchDeadLetterById = `
+	SELECT id, project_id, event_delivery_id, event_id, endpoint_id, event_type, reason,
+	last_response_status, last_response_body, last_response_error, redrive_count,
+	dead_lettered_at, redriven_at, created_at, updated_at
+	FROM convoy.dead_letters WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+
+	baseDeadLetters = `
+	SELECT dl.id, dl.project_id, dl.event_delivery_id, dl.event_id, dl.endpoint_id,
+	dl.event_type, dl.reason, dl.last_response_status, dl.last_response_body,
+	dl.last_response_error, dl.redrive_count, dl.dead_lettered_at, dl.redriven_at,
+	dl.created_at, dl.updated_at FROM convoy.dead_letters dl
+	WHERE dl.deleted_at IS NULL
+	`
+	baseDeadLettersPagedForward = `%s %s AND dl.id <= :cursor
+	GROUP BY dl.id
+	ORDER BY dl.id DESC
+	LIMIT :limit
+	`
+	baseDeadLettersPagedBackward = `
+	WITH dead_letters AS (
+		%s %s AND dl.id >= :cursor
+		GROUP BY dl.id
+		ORDER BY dl.id ASC
+		LIMIT :limit
+	)
+
+	SELECT * from dead_letters ORDER BY id DESC
+	`
+	baseDeadLetterFilter = ` AND dl.project_id = :project_id`
+
+	deadLetterIDsFilter            = ` AND dl.id IN (:ids)`
+	deadLetterEndpointFilter       = ` AND dl.endpoint_id IN (:endpoint_ids)`
+	deadLetterEventTypeFilter      = ` AND dl.event_type = :event_type`
+	deadLetterPendingFilter        = ` AND dl.redriven_at IS NULL`
+	deadLetterRedrivenFilter       = ` AND dl.redriven_at IS NOT NULL`
+	deadLetterDeadLetteredAtFilter = ` AND dl.dead_lettered_at >= :start_date AND dl.dead_lettered_at <= :end_date`
+
+	baseCountPrevDeadLetters = `
+	SELECT COUNT(DISTINCT(dl.id)) AS count
+	FROM convoy.dead_letters dl WHERE dl.deleted_at IS NULL
+	`
+	countPrevDeadLetters = ` AND dl.id > :cursor GROUP BY dl.id ORDER BY dl.id DESC LIMIT 1`
+
+	fetchDeadLettersForRedrive = `%s %s ORDER BY dl.dead_lettered_at ASC, dl.id ASC LIMIT :limit`
+
+	markDeadLettersRedriven = `
+	UPDATE convoy.dead_letters SET
+	  redriven_at = NOW(),
+	  redrive_count = redrive_count + 1,
+	  updated_at = NOW()
+	WHERE id IN (?) AND project_id = ? AND deleted_at IS NULL;
+	`
+)
+
+type deadLetterRepo struct {
+	db database.Database
+}
+
+func NewDeadLetterRepo(db database.Database) datastore.DeadLetterRepository {
+	return &deadLetterRepo{db: db}
+}
+
+func (d *deadLetterRepo) CreateDeadLetter(ctx context.Context, deadLetter *datastore.DeadLetter) error {
+	err := d.db.GetDB().QueryRowxContext(ctx, createDeadLetter, deadLetter.UID, deadLetter.ProjectID,
+		deadLetter.EventDeliveryID, deadLetter.EventID, deadLetter.EndpointID, deadLetter.EventType,
+		deadLetter.Reason, deadLetter.LastResponseStatus, deadLetter.LastResponseBody, deadLetter.LastResponseError,
+	).Scan(&deadLetter.UID, &deadLetter.DeadLetteredAt, &deadLetter.RedriveCount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrDeadLetterNotCreated
+		}
+
+		return err
+	}
+
+	deadLetter.RedrivenAt.Valid = false
+	return nil
+}
+
+func (d *deadLetterRepo) FindDeadLetterByID(ctx context.Context, projectID string, id string) (*datastore.DeadLetter, error) {
+	deadLetter := &datastore.DeadLetter{}
+	err := d.db.GetReadDB().QueryRowxContext(ctx, fetchDeadLetterById, id, projectID).StructScan(deadLetter)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrDeadLetterNotFound
+		}
+
+		return nil, err
+	}
+
+	return deadLetter, nil
+}
+
+func (d *deadLetterRepo) LoadDeadLettersPaged(ctx context.Context, projectID string, filter *datastore.DeadLetterFilter, pageable datastore.Pageable) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	arg, filterQuery := deadLetterFilterArgs(projectID, filter)
+	arg["limit"] = pageable.Limit()
+	arg["cursor"] = pageable.Cursor()
+
+	var baseQueryPagination string
+	if pageable.Direction == datastore.Next {
+		baseQueryPagination = baseDeadLettersPagedForward
+	} else {
+		baseQueryPagination = baseDeadLettersPagedBackward
+	}
+
+	query := fmt.Sprintf(baseQueryPagination, baseDeadLetters, filterQuery)
+	deadLetters, err := d.selectDeadLetters(ctx, query, arg)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	var prevRowCount datastore.PrevRowCount
+	if len(deadLetters) > 0 {
+		qarg := arg
+		qarg["cursor"] = deadLetters[0].UID
+
+		countQuery, qargs, err := sqlx.Named(baseCountPrevDeadLetters+filterQuery+countPrevDeadLetters, qarg)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		countQuery, qargs, err = sqlx.In(countQuery, qargs...)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		countQuery = d.db.GetReadDB().Rebind(countQuery)
+		rows, err := d.db.GetReadDB().QueryxContext(ctx, countQuery, qargs...)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+		defer closeWithError(rows)
+
+		if rows.Next() {
+			err = rows.StructScan(&prevRowCount)
+			if err != nil {
+				return nil, datastore.PaginationData{}, err
+			}
+		}
+	}
+
+	ids := make([]string, len(deadLetters))
+	for i := range deadLetters {
+		ids[i] = deadLetters[i].UID
+	}
+
+	if len(deadLetters) > pageable.PerPage {
+		deadLetters = deadLetters[:len(deadLetters)-1]
+	}
+
+	pagination := &datastore.PaginationData{PrevRowCount: prevRowCount}
+	pagination = pagination.Build(pageable, ids)
+
+	return deadLetters, *pagination, nil
+}
+
+// FindDeadLettersForRedrive returns the oldest dead letters matching the filter
+// that have not been redriven since they were last dead-lettered.
+func (d *deadLetterRepo) FindDeadLettersForRedrive(ctx context.Context, projectID string, filter *datastore.DeadLetterFilter, limit int) ([]datastore.DeadLetter, error) {
+	f := *filter
+	f.Status = datastore.PendingDeadLetterStatus
+
+	arg, filterQuery := deadLetterFilterArgs(projectID, &f)
+	arg["limit"] = limit
+
+	return d.selectDeadLetters(ctx, fmt.Sprintf(fetchDeadLettersForRedrive, baseDeadLetters, filterQuery), arg)
+}
+
+func (d *deadLetterRepo) MarkDeadLettersRedriven(ctx context.Context, projectID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(markDeadLettersRedriven, ids, projectID)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.GetDB().ExecContext(ctx, d.db.GetDB().Rebind(query), args...)
+	return err
+}
+
+func (d *deadLetterRepo) selectDeadLetters(ctx context.Context, query string, arg map[string]interface{}) ([]datastore.DeadLetter, error) {
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	query = d.db.GetReadDB().Rebind(query)
+	rows, err := d.db.GetReadDB().QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeWithError(rows)
+
+	deadLetters := make([]datastore.DeadLetter, 0)
+	for rows.Next() {
+		var data datastore.DeadLetter
+
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, err
+		}
+
+		deadLetters = append(deadLetters, data)
+	}
+
+	return deadLetters, nil
+}
+
+func deadLetterFilterArgs(projectID string, filter *datastore.DeadLetterFilter) (map[string]interface{}, string) {
+	arg := map[string]interface{}{
+		"project_id": projectID,
+	}
+
+	filterQuery := baseDeadLetterFilter
+	if len(filter.IDs) > 0 {
+		arg["ids"] = filter.IDs
+		filterQuery += deadLetterIDsFilter
+	}
+
+	if len(filter.EndpointIDs) > 0 {
+		arg["endpoint_ids"] = filter.EndpointIDs
+		filterQuery += deadLetterEndpointFilter
+	}
+
+	if len(filter.EventType) > 0 {
+		arg["event_type"] = filter.EventType
+		filterQuery += deadLetterEventTypeFilter
+	}
+
+	switch filter.Status {
+	case datastore.PendingDeadLetterStatus:
+		filterQuery += deadLetterPendingFilter
+	case datastore.RedrivenDeadLetterStatus:
+		filterQuery += deadLetterRedrivenFilter
+	}
+
+	if filter.SearchParams.CreatedAtEnd > 0 {
+		startDate, endDate := getCreatedDateFilter(filter.SearchParams.CreatedAtStart, filter.SearchParams.CreatedAtEnd)
+		arg["start_date"] = startDate
+		arg["end_date"] = endDate
+		filterQuery += deadLetterDeadLetteredAtFilter
+	}
+
+	return arg, filterQuery
+}
@@ -0,0 +1,126 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func Test_CreateDeadLetter(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	project := seedProject(t, db)
+	deadLetterRepo := NewDeadLetterRepo(db)
+	deadLetter := generateDeadLetter(project.UID, ulid.Make().String())
+	ctx := context.Background()
+
+	_, err := deadLetterRepo.FindDeadLetterByID(ctx, project.UID, deadLetter.UID)
+	require.True(t, errors.Is(err, datastore.ErrDeadLetterNotFound))
+
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, deadLetter))
+
+	newDeadLetter, err := deadLetterRepo.FindDeadLetterByID(ctx, project.UID, deadLetter.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, deadLetter.EventDeliveryID, newDeadLetter.EventDeliveryID)
+	require.Equal(t, deadLetter.Reason, newDeadLetter.Reason)
+	require.Equal(t, "500", newDeadLetter.LastResponseStatus.String)
+	require.False(t, newDeadLetter.RedrivenAt.Valid)
+	require.WithinDuration(t, time.Now(), newDeadLetter.DeadLetteredAt, 5*time.Second)
+}
+
+func Test_CreateDeadLetter_AfterRedrive(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	project := seedProject(t, db)
+	deadLetterRepo := NewDeadLetterRepo(db)
+	deadLetter := generateDeadLetter(project.UID, ulid.Make().String())
+	ctx := context.Background()
+
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, deadLetter))
+	require.NoError(t, deadLetterRepo.MarkDeadLettersRedriven(ctx, project.UID, []string{deadLetter.UID}))
+
+	// the event delivery failed again after it was redriven
+	again := generateDeadLetter(project.UID, deadLetter.EventDeliveryID)
+	again.LastResponseStatus = null.StringFrom("502")
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, again))
+	require.Equal(t, deadLetter.UID, again.UID)
+
+	newDeadLetter, err := deadLetterRepo.FindDeadLetterByID(ctx, project.UID, deadLetter.UID)
+	require.NoError(t, err)
+	require.Equal(t, "502", newDeadLetter.LastResponseStatus.String)
+	require.Equal(t, 1, newDeadLetter.RedriveCount)
+	require.False(t, newDeadLetter.RedrivenAt.Valid)
+}
+
+func Test_LoadDeadLettersPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	project := seedProject(t, db)
+	deadLetterRepo := NewDeadLetterRepo(db)
+	ctx := context.Background()
+
+	endpointID := ulid.Make().String()
+	var redriven []string
+	for i := 0; i < 5; i++ {
+		deadLetter := generateDeadLetter(project.UID, ulid.Make().String())
+		if i < 2 {
+			deadLetter.EndpointID = endpointID
+		}
+
+		require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, deadLetter))
+
+		if i%2 == 0 {
+			redriven = append(redriven, deadLetter.UID)
+		}
+	}
+	require.NoError(t, deadLetterRepo.MarkDeadLettersRedriven(ctx, project.UID, redriven))
+
+	pageable := datastore.Pageable{
+		PerPage:    10,
+		Direction:  datastore.Next,
+		NextCursor: datastore.DefaultCursor,
+	}
+
+	deadLetters, _, err := deadLetterRepo.LoadDeadLettersPaged(ctx, project.UID, &datastore.DeadLetterFilter{}, pageable)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 5)
+
+	deadLetters, _, err = deadLetterRepo.LoadDeadLettersPaged(ctx, project.UID, &datastore.DeadLetterFilter{EndpointIDs: []string{endpointID}}, pageable)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 2)
+
+	deadLetters, _, err = deadLetterRepo.LoadDeadLettersPaged(ctx, project.UID, &datastore.DeadLetterFilter{Status: datastore.RedrivenDeadLetterStatus}, pageable)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 3)
+
+	deadLetters, err = deadLetterRepo.FindDeadLettersForRedrive(ctx, project.UID, &datastore.DeadLetterFilter{}, 10)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 2)
+}
+
+func generateDeadLetter(projectID, eventDeliveryID string) *datastore.DeadLetter {
+	return &datastore.DeadLetter{
+		UID:                ulid.Make().String(),
+		ProjectID:          projectID,
+		EventDeliveryID:    eventDeliveryID,
+		EventID:            ulid.Make().String(),
+		EndpointID:         ulid.Make().String(),
+		EventType:          "invoice.paid",
+		Reason:             "Retry limit exceeded",
+		LastResponseStatus: null.StringFrom("500"),
+		LastResponseBody:   null.StringFrom("internal server error"),
+	}
+}
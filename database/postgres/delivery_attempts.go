@@ -134,14 +134,20 @@ func (d *deliveryAttemptRepo) DeleteProjectDeliveriesAttempts(ctx context.Contex
 func (d *deliveryAttemptRepo) GetFailureAndSuccessCounts(ctx context.Context, lookBackDuration uint64, resetTimes map[string]time.Time) (map[string]circuit_breaker.PollResult, error) {
 	resultsMap := map[string]circuit_breaker.PollResult{}
 
+	// endpoint health probes are counted along with delivery attempts
 	query := `
 		SELECT
             endpoint_id AS key,
             project_id AS tenant_id,
             COUNT(CASE WHEN status = false THEN 1 END) AS failures,
             COUNT(CASE WHEN status = true THEN 1 END) AS successes
-        FROM convoy.delivery_attempts
-        WHERE created_at >= NOW() - MAKE_INTERVAL(mins := $1)
+        FROM (
+            SELECT endpoint_id, project_id, status FROM convoy.delivery_attempts
+            WHERE created_at >= NOW() - MAKE_INTERVAL(mins := $1)
+            UNION ALL
+            SELECT endpoint_id, project_id, status FROM convoy.endpoint_probes
+            WHERE created_at >= NOW() - MAKE_INTERVAL(mins := $1)
+        ) AS results
         group by endpoint_id, project_id;
 	`
 
@@ -166,7 +172,11 @@ func (d *deliveryAttemptRepo) GetFailureAndSuccessCounts(ctx context.Context, lo
             project_id AS tenant_id,
 	        COUNT(CASE WHEN status = false THEN 1 END) AS failures,
 	        COUNT(CASE WHEN status = true THEN 1 END) AS successes
-	    FROM convoy.delivery_attempts
+	    FROM (
+	        SELECT endpoint_id, project_id, status, created_at FROM convoy.delivery_attempts
+	        UNION ALL
+	        SELECT endpoint_id, project_id, status, created_at FROM convoy.endpoint_probes
+	    ) AS results
 	    WHERE endpoint_id = '%s' AND created_at >= TIMESTAMP '%s' AT TIME ZONE 'UTC'
 	    group by endpoint_id, project_id;
 	`
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/internal/pkg/keys"
+)
+
+// currentKeyRing returns the keyring credentials are read and written with,
+// it holds the previous key as well while a key is rotated. It is resolved
+// on every call since some repos are created before the key manager is set,
+// there's no key until it is.
+func currentKeyRing(ctx context.Context, db database.Database) (string, error) {
+	return keys.CurrentKeyRing(ctx, db)
+}
+
+// quoteKeyRing escapes keyring to be used in a string literal, for the
+// queries that can't take it as an argument.
+func quoteKeyRing(keyring string) string {
+	return strings.ReplaceAll(keyring, "'", "''")
+}
+
+// isTableEncrypted reports whether the credentials in table are encrypted.
+func isTableEncrypted(ctx context.Context, db database.Database, table string) (bool, error) {
+	query := fmt.Sprintf("SELECT is_encrypted FROM convoy.%s WHERE is_encrypted=TRUE LIMIT 1;", table)
+
+	var isEncrypted bool
+	err := db.GetReadDB().GetContext(ctx, &isEncrypted, query)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("failed to check encryption status of %s: %w", table, err)
+	}
+
+	return isEncrypted, nil
+}
+
+// encryptionError reports a missing key when table is encrypted, pgcrypto
+// fails with an illegal argument error when it is given an empty key.
+func encryptionError(ctx context.Context, db database.Database, table string, err error) error {
+	if strings.Contains(err.Error(), "Illegal argument") {
+		isEncrypted, err2 := isTableEncrypted(ctx, db, table)
+		if err2 == nil && isEncrypted {
+			return keys.ErrCredentialEncryptionFeatureUnavailableUpgradeOrRevert
+		}
+	}
+
+	return err
+}
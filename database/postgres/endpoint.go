@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"github.com/frain-dev/convoy/internal/pkg/keys"
-	"github.com/frain-dev/convoy/pkg/log"
 	"strings"
 	"time"
 
@@ -31,7 +30,12 @@ const (
                 rate_limit, rate_limit_duration, advanced_signatures, slack_webhook_url,
                 support_email, app_id, project_id, authentication_type, authentication_type_api_key_header_name,
                 authentication_type_api_key_header_value,
-                is_encrypted, secrets_cipher, authentication_type_api_key_header_value_cipher
+                is_encrypted, secrets_cipher, authentication_type_api_key_header_value_cipher,
+                status_code_policies, http_method, content_type, body_encoding,
+                ordered_delivery, ordering_key, batch_delivery, batch_size, batch_window,
+                mtls_client_cert, mtls_client_cert_cipher,
+                authentication_oauth2, authentication_oauth2_cipher,
+                verification_required, health_check, key_version
             )
             VALUES
               (
@@ -39,8 +43,15 @@ const (
                 $5, $6, $7, $8, $9, $10, $11, $12, $13,
                 $14, $15, $16, $17, CASE WHEN $19 THEN '' ELSE $18 END,
                $19,
-               CASE WHEN $19 THEN pgp_sym_encrypt($4::TEXT, $20)  END, -- Ciphered values if encrypted
-               CASE WHEN $19 THEN pgp_sym_encrypt($18, $20) END
+               CASE WHEN $19 THEN convoy.keyring_encrypt($4::TEXT, convoy.keyring_version($20), $20)  END, -- Ciphered values if encrypted
+               CASE WHEN $19 THEN convoy.keyring_encrypt($18, convoy.keyring_version($20), $20) END,
+               $21, $22, $23, $24, $25, $26, $27, $28, $29,
+               CASE WHEN $19 THEN NULL ELSE $30::jsonb END,
+               CASE WHEN $19 THEN convoy.keyring_encrypt($30::TEXT, convoy.keyring_version($20), $20) END,
+               CASE WHEN $19 THEN NULL ELSE $31::jsonb END,
+               CASE WHEN $19 THEN convoy.keyring_encrypt($31::TEXT, convoy.keyring_version($20), $20) END,
+               $32, $33,
+               CASE WHEN $19 THEN convoy.keyring_version($20) END
               );
             `
 
@@ -50,17 +61,28 @@ const (
 	e.url, e.description, e.http_timeout,
 	e.rate_limit, e.rate_limit_duration, e.advanced_signatures,
 	e.slack_webhook_url, e.support_email, e.app_id,
-	e.project_id,
+	e.project_id, e.status_code_policies,
+	e.http_method, e.content_type, e.body_encoding,
+	e.ordered_delivery, e.ordering_key, e.batch_delivery, e.batch_size, e.batch_window,
+	e.verification_required, e.verified_at, e.health_check,
 	CASE
-        WHEN e.is_encrypted THEN pgp_sym_decrypt(e.secrets_cipher::bytea, $1)::jsonb
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.mtls_client_cert_cipher::bytea, e.key_version, $1)::jsonb
+        ELSE e.mtls_client_cert
+    END AS mtls_client_cert,
+	CASE
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.secrets_cipher::bytea, e.key_version, $1)::jsonb
         ELSE e.secrets
     END AS secrets, e.created_at, e.updated_at,
 	e.authentication_type AS "authentication.type",
 	e.authentication_type_api_key_header_name AS "authentication.api_key.header_name",
 	CASE
-        WHEN e.is_encrypted THEN pgp_sym_decrypt(e.authentication_type_api_key_header_value_cipher::bytea, $1)::TEXT
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.authentication_type_api_key_header_value_cipher::bytea, e.key_version, $1)::TEXT
         ELSE e.authentication_type_api_key_header_value
-    END AS "authentication.api_key.header_value"
+    END AS "authentication.api_key.header_value",
+	CASE
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.authentication_oauth2_cipher::bytea, e.key_version, $1)::jsonb
+        ELSE e.authentication_oauth2
+    END AS "authentication.oauth2"
 	FROM convoy.endpoints AS e
 	WHERE e.deleted_at IS NULL
 	`
@@ -73,21 +95,34 @@ const (
 
 	fetchEndpointsByOwnerId = baseEndpointFetch + ` AND e.project_id = $2 AND e.owner_id = $3 GROUP BY e.id ORDER BY e.id;`
 
+	fetchHealthCheckEndpoints = baseEndpointFetch + ` AND e.health_check AND e.status IN ('active', 'inactive') ORDER BY e.id;`
+
 	fetchEndpointByTargetURL = `
     SELECT e.id, e.name, e.status, e.owner_id, e.url,
     e.description, e.http_timeout, e.rate_limit, e.rate_limit_duration,
     e.advanced_signatures, e.slack_webhook_url, e.support_email,
-    e.app_id, e.project_id,
+    e.app_id, e.project_id, e.status_code_policies,
+	e.http_method, e.content_type, e.body_encoding,
+	e.ordered_delivery, e.ordering_key, e.batch_delivery, e.batch_size, e.batch_window,
+	e.verification_required, e.verified_at, e.health_check,
+    CASE
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.mtls_client_cert_cipher::bytea, e.key_version, $3)::jsonb
+        ELSE e.mtls_client_cert
+    END AS mtls_client_cert,
     CASE
-        WHEN e.is_encrypted THEN pgp_sym_decrypt(e.secrets_cipher::bytea, $3)::jsonb
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.secrets_cipher::bytea, e.key_version, $3)::jsonb
         ELSE e.secrets
     END AS secrets, e.created_at, e.updated_at,
     e.authentication_type AS "authentication.type",
     e.authentication_type_api_key_header_name AS "authentication.api_key.header_name",
 	CASE
-        WHEN e.is_encrypted THEN pgp_sym_decrypt(e.authentication_type_api_key_header_value_cipher::bytea, $3)::TEXT
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.authentication_type_api_key_header_value_cipher::bytea, e.key_version, $3)::TEXT
         ELSE e.authentication_type_api_key_header_value
-    END AS "authentication.api_key.header_value"
+    END AS "authentication.api_key.header_value",
+	CASE
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.authentication_oauth2_cipher::bytea, e.key_version, $3)::jsonb
+        ELSE e.authentication_oauth2
+    END AS "authentication.oauth2"
     FROM convoy.endpoints AS e WHERE e.deleted_at IS NULL AND e.url = $1 AND e.project_id = $2;
     `
 
@@ -98,15 +133,34 @@ const (
 	rate_limit = $9, rate_limit_duration = $10, advanced_signatures = $11,
 	slack_webhook_url = $12, support_email = $13,
 	authentication_type = $14, authentication_type_api_key_header_name = $15,
+	status_code_policies = $19, http_method = $20,
+	content_type = $21, body_encoding = $22,
+	ordered_delivery = $23, ordering_key = $24,
+	batch_delivery = $25, batch_size = $26, batch_window = $27,
+	verification_required = $30, verified_at = $31, health_check = $32,
+	mtls_client_cert_cipher = CASE
+        WHEN is_encrypted THEN convoy.keyring_encrypt($28::TEXT, key_version, $18)
+    END,
+    mtls_client_cert = CASE
+        WHEN is_encrypted THEN NULL
+        ELSE $28::jsonb
+    END,
+    authentication_oauth2_cipher = CASE
+        WHEN is_encrypted THEN convoy.keyring_encrypt($29::TEXT, key_version, $18)
+    END,
+    authentication_oauth2 = CASE
+        WHEN is_encrypted THEN NULL
+        ELSE $29::jsonb
+    END,
 	authentication_type_api_key_header_value_cipher = CASE
-        WHEN is_encrypted THEN pgp_sym_encrypt($16, $18)
+        WHEN is_encrypted THEN convoy.keyring_encrypt($16, key_version, $18)
     END,
     authentication_type_api_key_header_value = CASE
         WHEN is_encrypted THEN ''
         ELSE $16
     END,
     secrets_cipher = CASE
-        WHEN is_encrypted THEN pgp_sym_encrypt($17::jsonb::TEXT, $18)
+        WHEN is_encrypted THEN convoy.keyring_encrypt($17::jsonb::TEXT, key_version, $18)
     END,
     secrets = CASE
         WHEN is_encrypted THEN '[]'
@@ -117,28 +171,50 @@ const (
 	`
 
 	updateEndpointStatus = `
-	UPDATE convoy.endpoints SET status = $3
+	UPDATE convoy.endpoints SET status = CASE
+	    -- endpoints awaiting verification are only activated by their verification
+	    WHEN $3 = 'active' AND verification_required AND verified_at IS NULL THEN 'pending'
+	    ELSE $3
+	END
 	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL RETURNING
 	id, name, status, owner_id, url,
     description, http_timeout, rate_limit, rate_limit_duration,
     advanced_signatures, slack_webhook_url, support_email,
-    app_id, project_id,
+    app_id, project_id, status_code_policies,
+    http_method, content_type, body_encoding,
+    ordered_delivery, ordering_key, batch_delivery, batch_size, batch_window,
+    verification_required, verified_at, health_check,
+    CASE
+        WHEN is_encrypted THEN convoy.keyring_decrypt(mtls_client_cert_cipher::bytea, key_version, $4)::jsonb
+        ELSE mtls_client_cert
+    END AS mtls_client_cert,
     CASE
-        WHEN is_encrypted THEN pgp_sym_decrypt(secrets_cipher::bytea, $4)::jsonb
+        WHEN is_encrypted THEN convoy.keyring_decrypt(secrets_cipher::bytea, key_version, $4)::jsonb
         ELSE secrets
     END AS secrets, created_at, updated_at,
     authentication_type AS "authentication.type",
     authentication_type_api_key_header_name AS "authentication.api_key.header_name",
     CASE
-        WHEN is_encrypted THEN pgp_sym_decrypt(authentication_type_api_key_header_value_cipher::bytea, $4)::TEXT
+        WHEN is_encrypted THEN convoy.keyring_decrypt(authentication_type_api_key_header_value_cipher::bytea, key_version, $4)::TEXT
         ELSE authentication_type_api_key_header_value
-    END AS "authentication.api_key.header_value";
+    END AS "authentication.api_key.header_value",
+    CASE
+        WHEN is_encrypted THEN convoy.keyring_decrypt(authentication_oauth2_cipher::bytea, key_version, $4)::jsonb
+        ELSE authentication_oauth2
+    END AS "authentication.oauth2";
+	`
+
+	// the status check keeps a verification that completes after the
+	// endpoint was paused or re-verified from activating it.
+	markEndpointVerified = `
+	UPDATE convoy.endpoints SET status = 'active', verified_at = NOW(), updated_at = NOW()
+	WHERE id = $1 AND project_id = $2 AND status = 'pending' AND deleted_at IS NULL;
 	`
 
 	updateEndpointSecrets = `
 	UPDATE convoy.endpoints SET
 	    secrets_cipher = CASE
-        WHEN is_encrypted THEN pgp_sym_encrypt($3::jsonb::TEXT, $4)
+        WHEN is_encrypted THEN convoy.keyring_encrypt($3::jsonb::TEXT, key_version, $4)
         END,
         secrets = CASE
             WHEN is_encrypted THEN '[]'
@@ -149,18 +225,29 @@ const (
 	id, name, status, owner_id, url,
     description, http_timeout, rate_limit, rate_limit_duration,
     advanced_signatures, slack_webhook_url, support_email,
-    app_id, project_id,
+    app_id, project_id, status_code_policies,
+    http_method, content_type, body_encoding,
+    ordered_delivery, ordering_key, batch_delivery, batch_size, batch_window,
+    verification_required, verified_at, health_check,
 	CASE
-        WHEN is_encrypted THEN pgp_sym_decrypt(secrets_cipher::bytea, $4)::jsonb
+        WHEN is_encrypted THEN convoy.keyring_decrypt(mtls_client_cert_cipher::bytea, key_version, $4)::jsonb
+        ELSE mtls_client_cert
+    END AS mtls_client_cert,
+	CASE
+        WHEN is_encrypted THEN convoy.keyring_decrypt(secrets_cipher::bytea, key_version, $4)::jsonb
         ELSE secrets
     END AS secrets,
 	created_at, updated_at,
     authentication_type AS "authentication.type",
     authentication_type_api_key_header_name AS "authentication.api_key.header_name",
     CASE
-        WHEN is_encrypted THEN pgp_sym_decrypt(authentication_type_api_key_header_value_cipher::bytea, $4)::TEXT
+        WHEN is_encrypted THEN convoy.keyring_decrypt(authentication_type_api_key_header_value_cipher::bytea, key_version, $4)::TEXT
         ELSE authentication_type_api_key_header_value
-    END AS "authentication.api_key.header_value";
+    END AS "authentication.api_key.header_value",
+    CASE
+        WHEN is_encrypted THEN convoy.keyring_decrypt(authentication_oauth2_cipher::bytea, key_version, $4)::jsonb
+        ELSE authentication_oauth2
+    END AS "authentication.oauth2";
 	`
 
 	deleteEndpoint = `
@@ -184,17 +271,28 @@ const (
 	e.url, e.description, e.http_timeout,
 	e.rate_limit, e.rate_limit_duration, e.advanced_signatures,
 	e.slack_webhook_url, e.support_email, e.app_id,
-	e.project_id,
+	e.project_id, e.status_code_policies,
+	e.http_method, e.content_type, e.body_encoding,
+	e.ordered_delivery, e.ordering_key, e.batch_delivery, e.batch_size, e.batch_window,
+	e.verification_required, e.verified_at, e.health_check,
     CASE
-        WHEN e.is_encrypted THEN pgp_sym_decrypt(e.secrets_cipher::bytea, :encryption_key)::jsonb
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.mtls_client_cert_cipher::bytea, e.key_version, :encryption_key)::jsonb
+        ELSE e.mtls_client_cert
+    END AS mtls_client_cert,
+    CASE
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.secrets_cipher::bytea, e.key_version, :encryption_key)::jsonb
         ELSE e.secrets
     END AS secrets, e.created_at, e.updated_at,
 	e.authentication_type AS "authentication.type",
 	e.authentication_type_api_key_header_name AS "authentication.api_key.header_name",
 	CASE
-        WHEN e.is_encrypted THEN pgp_sym_decrypt(e.authentication_type_api_key_header_value_cipher::bytea, :encryption_key)::TEXT
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.authentication_type_api_key_header_value_cipher::bytea, e.key_version, :encryption_key)::TEXT
         ELSE e.authentication_type_api_key_header_value
-    END AS "authentication.api_key.header_value"
+    END AS "authentication.api_key.header_value",
+	CASE
+        WHEN e.is_encrypted THEN convoy.keyring_decrypt(e.authentication_oauth2_cipher::bytea, e.key_version, :encryption_key)::jsonb
+        ELSE e.authentication_oauth2
+    END AS "authentication.oauth2"
 	FROM convoy.endpoints AS e
 	WHERE e.deleted_at IS NULL
 	AND e.project_id = :project_id
@@ -238,32 +336,20 @@ const (
 type endpointRepo struct {
 	db   database.Database
 	hook *hooks.Hook
-	km   keys.KeyManager
 }
 
 func NewEndpointRepo(db database.Database) datastore.EndpointRepository {
-	km, err := keys.Get()
-	if err != nil {
-		log.Fatal(err)
-	}
-	return &endpointRepo{db: db, hook: db.GetHook(), km: km}
+	return &endpointRepo{db: db, hook: db.GetHook()}
 }
 
 // checkEncryptionStatus checks if any row is already encrypted.
 func checkEncryptionStatus(db database.Database) (bool, error) {
-	checkQuery := "SELECT is_encrypted FROM convoy.endpoints WHERE is_encrypted=TRUE LIMIT 1;"
-	var isEncrypted bool
-	err := db.GetReadDB().Get(&isEncrypted, checkQuery)
-	if err != nil && err.Error() != "sql: no rows in result set" {
-		return false, fmt.Errorf("failed to check encryption status of endpoints: %w", err)
-	}
-
-	return isEncrypted, nil
+	return isTableEncrypted(context.Background(), db, "endpoints")
 }
 
 func (e *endpointRepo) CreateEndpoint(ctx context.Context, endpoint *datastore.Endpoint, projectID string) error {
 	ac := endpoint.GetAuthConfig()
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return err
 	}
@@ -282,6 +368,10 @@ func (e *endpointRepo) CreateEndpoint(ctx context.Context, endpoint *datastore.E
 		endpoint.Description, endpoint.HttpTimeout, endpoint.RateLimit, endpoint.RateLimitDuration,
 		endpoint.AdvancedSignatures, endpoint.SlackWebhookURL, endpoint.SupportEmail, endpoint.AppID,
 		projectID, ac.Type, ac.ApiKey.HeaderName, ac.ApiKey.HeaderValue, isEncrypted, key,
+		endpoint.StatusCodePolicies, endpoint.HttpMethod, endpoint.ContentType, endpoint.BodyEncoding,
+		endpoint.OrderedDelivery, endpoint.OrderingKey,
+		endpoint.BatchDelivery, endpoint.BatchSize, endpoint.BatchWindow, endpoint.MtlsClientCert,
+		ac.OAuth2, endpoint.VerificationRequired, endpoint.HealthCheck,
 	}
 
 	result, err := e.db.GetDB().ExecContext(ctx, createEndpoint, args...)
@@ -312,7 +402,7 @@ func (e *endpointRepo) CreateEndpoint(ctx context.Context, endpoint *datastore.E
 
 func (e *endpointRepo) FindEndpointByID(ctx context.Context, id, projectID string) (*datastore.Endpoint, error) {
 	endpoint := &datastore.Endpoint{}
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return nil, err
 	}
@@ -332,7 +422,7 @@ func (e *endpointRepo) FindEndpointByID(ctx context.Context, id, projectID strin
 }
 
 func (e *endpointRepo) FindEndpointsByID(ctx context.Context, ids []string, projectID string) ([]datastore.Endpoint, error) {
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return nil, err
 	}
@@ -355,7 +445,7 @@ func (e *endpointRepo) FindEndpointsByID(ctx context.Context, ids []string, proj
 }
 
 func (e *endpointRepo) FindEndpointsByAppID(ctx context.Context, appID, projectID string) ([]datastore.Endpoint, error) {
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +462,7 @@ func (e *endpointRepo) FindEndpointsByAppID(ctx context.Context, appID, projectI
 }
 
 func (e *endpointRepo) FindEndpointsByOwnerID(ctx context.Context, projectID string, ownerID string) ([]datastore.Endpoint, error) {
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return nil, err
 	}
@@ -391,7 +481,7 @@ func (e *endpointRepo) FindEndpointsByOwnerID(ctx context.Context, projectID str
 func (e *endpointRepo) UpdateEndpoint(ctx context.Context, endpoint *datastore.Endpoint, projectID string) error {
 	ac := endpoint.GetAuthConfig()
 
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return err
 	}
@@ -400,6 +490,10 @@ func (e *endpointRepo) UpdateEndpoint(ctx context.Context, endpoint *datastore.E
 		endpoint.Description, endpoint.HttpTimeout, endpoint.RateLimit, endpoint.RateLimitDuration,
 		endpoint.AdvancedSignatures, endpoint.SlackWebhookURL, endpoint.SupportEmail,
 		ac.Type, ac.ApiKey.HeaderName, ac.ApiKey.HeaderValue, endpoint.Secrets, key,
+		endpoint.StatusCodePolicies, endpoint.HttpMethod, endpoint.ContentType, endpoint.BodyEncoding,
+		endpoint.OrderedDelivery, endpoint.OrderingKey,
+		endpoint.BatchDelivery, endpoint.BatchSize, endpoint.BatchWindow, endpoint.MtlsClientCert,
+		ac.OAuth2, endpoint.VerificationRequired, endpoint.VerifiedAt, endpoint.HealthCheck,
 	)
 	if err != nil {
 		isEncErr, err2 := e.isEncryptionError(err)
@@ -424,7 +518,7 @@ func (e *endpointRepo) UpdateEndpoint(ctx context.Context, endpoint *datastore.E
 
 func (e *endpointRepo) UpdateEndpointStatus(ctx context.Context, projectID string, endpointID string, status datastore.EndpointStatus) error {
 	endpoint := datastore.Endpoint{}
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return err
 	}
@@ -440,6 +534,43 @@ func (e *endpointRepo) UpdateEndpointStatus(ctx context.Context, projectID strin
 	return nil
 }
 
+func (e *endpointRepo) MarkEndpointVerified(ctx context.Context, projectID string, endpointID string) error {
+	r, err := e.db.GetDB().ExecContext(ctx, markEndpointVerified, endpointID, projectID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrEndpointNotUpdated
+	}
+
+	return nil
+}
+
+// FindHealthCheckEndpoints returns the active and inactive endpoints across
+// all projects that have health checks turned on.
+func (e *endpointRepo) FindHealthCheckEndpoints(ctx context.Context) ([]datastore.Endpoint, error) {
+	key, err := currentKeyRing(ctx, e.db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := e.db.GetReadDB().QueryxContext(ctx, fetchHealthCheckEndpoints, key)
+	if err != nil {
+		isEncErr, err2 := e.isEncryptionError(err)
+		if isEncErr && err2 != nil {
+			return nil, err2
+		}
+		return nil, err
+	}
+
+	return e.scanEndpoints(rows)
+}
+
 func (e *endpointRepo) DeleteEndpoint(ctx context.Context, endpoint *datastore.Endpoint, projectID string) error {
 	tx, err := e.db.GetDB().BeginTxx(ctx, &sql.TxOptions{})
 	if err != nil {
@@ -484,7 +615,7 @@ func (e *endpointRepo) CountProjectEndpoints(ctx context.Context, projectID stri
 
 func (e *endpointRepo) FindEndpointByTargetURL(ctx context.Context, projectID string, targetURL string) (*datastore.Endpoint, error) {
 	endpoint := &datastore.Endpoint{}
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return nil, err
 	}
@@ -509,7 +640,7 @@ func (e *endpointRepo) LoadEndpointsPaged(ctx context.Context, projectId string,
 		q = fmt.Sprintf("%%%s%%", q)
 	}
 
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return nil, datastore.PaginationData{}, err
 	}
@@ -621,7 +752,7 @@ func (e *endpointRepo) isEncryptionError(err error) (bool, error) {
 
 func (e *endpointRepo) UpdateSecrets(ctx context.Context, endpointID string, projectID string, secrets datastore.Secrets) error {
 	endpoint := datastore.Endpoint{}
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return err
 	}
@@ -642,7 +773,7 @@ func (e *endpointRepo) DeleteSecret(ctx context.Context, endpoint *datastore.End
 	sc.DeletedAt = null.NewTime(time.Now(), true)
 
 	updatedEndpoint := datastore.Endpoint{}
-	key, err := e.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, e.db)
 	if err != nil {
 		return err
 	}
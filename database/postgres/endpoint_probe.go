@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+var ErrEndpointProbeNotCreated = errors.New("endpoint probe could not be created")
+
+const (
+	createEndpointProbe = `
+	INSERT INTO convoy.endpoint_probes (id, project_id, endpoint_id, status, status_code, error)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at;
+	`
+
+	fetchEndpointProbes = `
+	SELECT id, project_id, endpoint_id, status, status_code, error, created_at
+	FROM convoy.endpoint_probes WHERE project_id = $1 AND endpoint_id = $2
+	ORDER BY created_at DESC LIMIT $3;
+	`
+
+	deleteEndpointProbes = `DELETE FROM convoy.endpoint_probes WHERE created_at < $1;`
+)
+
+type endpointProbeRepo struct {
+	db database.Database
+}
+
+func NewEndpointProbeRepo(db database.Database) datastore.EndpointProbeRepository {
+	return &endpointProbeRepo{db: db}
+}
+
+func (e *endpointProbeRepo) CreateEndpointProbe(ctx context.Context, probe *datastore.EndpointProbe) error {
+	err := e.db.GetDB().QueryRowxContext(ctx, createEndpointProbe, probe.UID, probe.ProjectID,
+		probe.EndpointID, probe.Status, probe.StatusCode, probe.Error,
+	).Scan(&probe.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEndpointProbeNotCreated
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// LoadEndpointProbes returns the endpoint's most recent probes, newest first.
+func (e *endpointProbeRepo) LoadEndpointProbes(ctx context.Context, projectID, endpointID string, limit int) ([]datastore.EndpointProbe, error) {
+	rows, err := e.db.GetReadDB().QueryxContext(ctx, fetchEndpointProbes, projectID, endpointID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer closeWithError(rows)
+
+	probes := make([]datastore.EndpointProbe, 0, limit)
+	for rows.Next() {
+		var probe datastore.EndpointProbe
+		if err = rows.StructScan(&probe); err != nil {
+			return nil, err
+		}
+
+		probes = append(probes, probe)
+	}
+
+	return probes, nil
+}
+
+func (e *endpointProbeRepo) DeleteEndpointProbes(ctx context.Context, before time.Time) error {
+	_, err := e.db.GetDB().ExecContext(ctx, deleteEndpointProbes, before)
+	return err
+}
@@ -151,6 +151,32 @@ func runUpdateEndpointStatusTest(t *testing.T, db database.Database) {
 	require.Equal(t, status, dbEndpoint.Status)
 }
 
+func Test_UpdateEndpointStatus_AwaitingVerification(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	endpointRepo := NewEndpointRepo(db)
+	project := seedProject(t, db)
+
+	endpoint := generateEndpoint(project)
+	endpoint.Status = datastore.PendingEndpointStatus
+	endpoint.VerificationRequired = true
+	require.NoError(t, endpointRepo.CreateEndpoint(context.Background(), endpoint, project.UID))
+
+	// only the endpoint's verification can activate it
+	require.NoError(t, endpointRepo.UpdateEndpointStatus(context.Background(), project.UID, endpoint.UID, datastore.ActiveEndpointStatus))
+
+	dbEndpoint, err := endpointRepo.FindEndpointByID(context.Background(), endpoint.UID, project.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.PendingEndpointStatus, dbEndpoint.Status)
+
+	require.NoError(t, endpointRepo.MarkEndpointVerified(context.Background(), project.UID, endpoint.UID))
+
+	dbEndpoint, err = endpointRepo.FindEndpointByID(context.Background(), endpoint.UID, project.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ActiveEndpointStatus, dbEndpoint.Status)
+}
+
 func Test_DeleteEndpoint(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
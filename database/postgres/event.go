@@ -23,8 +23,9 @@ const (
 	createEvent = `
 	INSERT INTO convoy.events (id,event_type,endpoints,project_id,
 	                           source_id,headers,raw,data,url_query_params,
-	                           idempotency_key,is_duplicate_event,acknowledged_at,metadata,status)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	                           idempotency_key,is_duplicate_event,acknowledged_at,metadata,status,schema_status,
+	                           content_type,original_raw,raw_encoding)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 
 	updateEventEndpoints = `
@@ -45,6 +46,10 @@ const (
 	COALESCE(source_id, '') AS source_id,
 	COALESCE(idempotency_key, '') AS idempotency_key,
 	COALESCE(url_query_params, '') AS url_query_params,
+	COALESCE(schema_status, '') AS schema_status,
+	COALESCE(content_type, '') AS content_type,
+	COALESCE(original_raw, '') AS original_raw,
+	COALESCE(raw_encoding, '') AS raw_encoding,
 	created_at,updated_at,acknowledged_at,metadata,status
 	FROM convoy.events WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
 	`
@@ -69,6 +74,10 @@ const (
 	COALESCE(ev.source_id, '') AS source_id,
 	COALESCE(ev.idempotency_key, '') AS idempotency_key,
 	COALESCE(ev.url_query_params, '') AS url_query_params,
+	COALESCE(ev.schema_status, '') AS schema_status,
+	COALESCE(ev.content_type, '') AS content_type,
+	COALESCE(ev.original_raw, '') AS original_raw,
+	COALESCE(ev.raw_encoding, '') AS raw_encoding,
 	ev.headers, ev.raw, ev.data, ev.created_at,
 	ev.updated_at, ev.deleted_at,ev.acknowledged_at,
 	COALESCE(s.id, '') AS "source_metadata.id",
@@ -100,6 +109,10 @@ const (
 	ev.headers, ev.raw, ev.data, ev.created_at,
 	COALESCE(idempotency_key, '') AS idempotency_key,
 	COALESCE(url_query_params, '') AS url_query_params,
+	COALESCE(ev.schema_status, '') AS schema_status,
+	COALESCE(ev.content_type, '') AS content_type,
+	COALESCE(ev.original_raw, '') AS original_raw,
+	COALESCE(ev.raw_encoding, '') AS raw_encoding,
 	ev.updated_at, ev.deleted_at,ev.acknowledged_at,
 	COALESCE(s.id, '') AS "source_metadata.id",
 	COALESCE(s.name, '') AS "source_metadata.name"
@@ -238,6 +251,10 @@ func (e *eventRepo) CreateEvent(ctx context.Context, event *datastore.Event) err
 		event.AcknowledgedAt,
 		event.Metadata,
 		event.Status,
+		event.SchemaStatus,
+		event.ContentType,
+		event.OriginalRaw,
+		event.RawEncoding,
 	)
 	if err != nil {
 		return err
@@ -777,6 +794,10 @@ BEGIN
         acknowledged_at    TIMESTAMPTZ,
         status             TEXT,
         metadata           TEXT,
+        schema_status      TEXT,
+        content_type       TEXT,
+        original_raw       TEXT,
+        raw_encoding       TEXT,
         PRIMARY KEY (id, created_at, project_id)
     ) PARTITION BY RANGE (project_id, created_at);
 
@@ -803,11 +824,13 @@ BEGIN
     INSERT INTO convoy.events_new (
         id, event_type, endpoints, project_id, source_id, headers, raw, data,
         created_at, updated_at, deleted_at, url_query_params, idempotency_key,
-        is_duplicate_event, acknowledged_at, status, metadata
+        is_duplicate_event, acknowledged_at, status, metadata, schema_status,
+        content_type, original_raw, raw_encoding
     )
     SELECT id, event_type, endpoints, project_id, source_id, headers, raw, data,
            created_at, updated_at, deleted_at, url_query_params, idempotency_key,
-           is_duplicate_event, acknowledged_at, status, metadata
+           is_duplicate_event, acknowledged_at, status, metadata, schema_status,
+           content_type, original_raw, raw_encoding
     FROM convoy.events;
 
     -- Manage table renaming
@@ -870,7 +893,11 @@ begin
         is_duplicate_event BOOLEAN default false,
         acknowledged_at    TIMESTAMP WITH TIME ZONE,
         status             TEXT,
-        metadata           TEXT
+        metadata           TEXT,
+        schema_status      TEXT,
+        content_type       TEXT,
+        original_raw       TEXT,
+        raw_encoding       TEXT
     );
 
     RAISE NOTICE 'Migrating data...';
@@ -35,12 +35,12 @@ var (
 
 const (
 	createEventDelivery = `
-    INSERT INTO convoy.event_deliveries (id,project_id,event_id,endpoint_id,device_id,subscription_id,headers,status,metadata,cli_metadata,description,url_query_params,idempotency_key,event_type,acknowledged_at)
-    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15);
+    INSERT INTO convoy.event_deliveries (id,project_id,event_id,endpoint_id,device_id,subscription_id,headers,status,metadata,cli_metadata,description,url_query_params,idempotency_key,event_type,acknowledged_at,ordering_key)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16);
     `
 	createEventDeliveries = `
-    INSERT INTO convoy.event_deliveries (id,project_id,event_id,endpoint_id,device_id,subscription_id,headers,status,metadata,cli_metadata,description,url_query_params,idempotency_key,event_type,acknowledged_at)
-    VALUES (:id, :project_id, :event_id, :endpoint_id, :device_id, :subscription_id, :headers, :status, :metadata, :cli_metadata, :description, :url_query_params, :idempotency_key, :event_type, :acknowledged_at);
+    INSERT INTO convoy.event_deliveries (id,project_id,event_id,endpoint_id,device_id,subscription_id,headers,status,metadata,cli_metadata,description,url_query_params,idempotency_key,event_type,acknowledged_at,ordering_key)
+    VALUES (:id, :project_id, :event_id, :endpoint_id, :device_id, :subscription_id, :headers, :status, :metadata, :cli_metadata, :description, :url_query_params, :idempotency_key, :event_type, :acknowledged_at, :ordering_key);
     `
 
 	baseFetchEventDelivery = `
@@ -53,6 +53,7 @@ const (
         COALESCE(ed.event_type,'') AS "event_type",
         COALESCE(ed.device_id,'') AS "device_id",
         COALESCE(ed.endpoint_id,'') AS "endpoint_id",
+        ed.ordering_key,
         COALESCE(ep.id, '') AS "endpoint_metadata.id",
         COALESCE(ep.name, '') AS "endpoint_metadata.name",
         COALESCE(ep.project_id, '') AS "endpoint_metadata.project_id",
@@ -114,7 +115,7 @@ const (
         COALESCE(event_type,'') AS "event_type",
         COALESCE(device_id,'') AS "device_id",
         COALESCE(endpoint_id,'') AS "endpoint_id",
-        acknowledged_at
+        ordering_key, acknowledged_at
     FROM convoy.event_deliveries
 	WHERE deleted_at IS NULL
     AND project_id = $1 AND id = $2
@@ -196,6 +197,43 @@ const (
     LIMIT 1000;
     `
 
+	hasPendingPredecessor = `
+    SELECT EXISTS(
+        SELECT 1 FROM convoy.event_deliveries
+        WHERE project_id = $1 AND endpoint_id = $2 AND ordering_key = $3
+        AND (created_at, id) < ($4, $5)
+        AND status NOT IN ('Success', 'Discarded', 'Failure')
+        AND deleted_at IS NULL
+    );
+    `
+
+	countScheduledEndpointDeliveries = `
+    SELECT COUNT(id) FROM convoy.event_deliveries
+    WHERE project_id = $1 AND endpoint_id = $2 AND status = 'Scheduled' AND deleted_at IS NULL;
+    `
+
+	// the delivery being processed is always claimed first, the rest are
+	// claimed oldest first and deliveries locked by another batch are skipped.
+	claimScheduledEndpointDeliveries = `
+    UPDATE convoy.event_deliveries SET status = 'Processing', updated_at = NOW()
+    WHERE id IN (
+        SELECT id FROM convoy.event_deliveries
+        WHERE project_id = $1 AND endpoint_id = $2 AND status = 'Scheduled' AND deleted_at IS NULL
+        ORDER BY id = $3 DESC, created_at, id
+        LIMIT $4
+        FOR UPDATE SKIP LOCKED
+    ) AND project_id = $1
+    RETURNING
+        id,project_id,event_id,subscription_id,
+        headers,attempts,status,metadata,cli_metadata,
+        COALESCE(url_query_params, '') AS url_query_params,
+        COALESCE(idempotency_key, '') AS idempotency_key,created_at,updated_at,
+        COALESCE(event_type,'') AS "event_type",
+        COALESCE(device_id,'') AS "device_id",
+        COALESCE(endpoint_id,'') AS "endpoint_id",
+        ordering_key, acknowledged_at;
+    `
+
 	countEventDeliveriesByStatus = `
     SELECT COUNT(id) FROM convoy.event_deliveries WHERE status = $1 AND (project_id = $2 OR $2 = '') AND created_at >= $3 AND created_at <= $4 AND deleted_at IS NULL;
     `
@@ -251,7 +289,7 @@ func (e *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, delivery *d
 		delivery.EventID, endpointID, deviceID,
 		delivery.SubscriptionID, delivery.Headers, delivery.Status,
 		delivery.Metadata, delivery.CLIMetadata, delivery.Description, delivery.URLQueryParams, delivery.IdempotencyKey, delivery.EventType,
-		delivery.AcknowledgedAt,
+		delivery.AcknowledgedAt, delivery.OrderingKey,
 	)
 	if err != nil {
 		return err
@@ -305,6 +343,7 @@ func (e *eventDeliveryRepo) CreateEventDeliveries(ctx context.Context, deliverie
 			"idempotency_key":  delivery.IdempotencyKey,
 			"event_type":       delivery.EventType,
 			"acknowledged_at":  delivery.AcknowledgedAt,
+			"ordering_key":     delivery.OrderingKey,
 		})
 	}
 
@@ -377,6 +416,55 @@ func (e *eventDeliveryRepo) FindEventDeliveryByIDSlim(ctx context.Context, proje
 	return eventDelivery, nil
 }
 
+// HasPendingPredecessor reports whether an event delivery to the same endpoint with the same
+// ordering key, that was created before eventDelivery, is yet to succeed, fail or be discarded.
+func (e *eventDeliveryRepo) HasPendingPredecessor(ctx context.Context, projectID string, eventDelivery *datastore.EventDelivery) (bool, error) {
+	var exists bool
+	err := e.db.GetDB().QueryRowxContext(ctx, hasPendingPredecessor, projectID, eventDelivery.EndpointID,
+		eventDelivery.OrderingKey, eventDelivery.CreatedAt, eventDelivery.UID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CountScheduledEndpointDeliveries returns the number of event deliveries to the
+// endpoint that are waiting to be sent.
+func (e *eventDeliveryRepo) CountScheduledEndpointDeliveries(ctx context.Context, projectID, endpointID string) (int64, error) {
+	var count int64
+	err := e.db.GetDB().QueryRowxContext(ctx, countScheduledEndpointDeliveries, projectID, endpointID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ClaimScheduledEndpointDeliveries marks up to limit scheduled event deliveries to the
+// endpoint as processing and returns them, so they can be sent in one batch.
+func (e *eventDeliveryRepo) ClaimScheduledEndpointDeliveries(ctx context.Context, projectID, endpointID, eventDeliveryID string, limit int) ([]datastore.EventDelivery, error) {
+	eventDeliveries := make([]datastore.EventDelivery, 0, limit)
+
+	rows, err := e.db.GetDB().QueryxContext(ctx, claimScheduledEndpointDeliveries, projectID, endpointID, eventDeliveryID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer closeWithError(rows)
+
+	for rows.Next() {
+		var ed datastore.EventDelivery
+		err = rows.StructScan(&ed)
+		if err != nil {
+			return nil, err
+		}
+
+		eventDeliveries = append(eventDeliveries, ed)
+	}
+
+	return eventDeliveries, nil
+}
+
 func (e *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context, projectID string, ids []string) ([]datastore.EventDelivery, error) {
 	eventDeliveries := make([]datastore.EventDelivery, 0)
 	query := fetchEventDeliveries + " WHERE id IN (?) AND project_id = ? AND deleted_at IS NULL"
@@ -190,6 +190,109 @@ func Test_eventDeliveryRepo_FindEventDeliveriesByEventID(t *testing.T) {
 	}
 }
 
+func Test_eventDeliveryRepo_HasPendingPredecessor(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	edRepo := NewEventDeliveryRepo(db)
+	ctx := context.Background()
+
+	create := func(status datastore.EventDeliveryStatus, orderingKey string) *datastore.EventDelivery {
+		ed := generateEventDelivery(project, endpoint, event, device, sub)
+		ed.Status = status
+		ed.OrderingKey = orderingKey
+
+		require.NoError(t, edRepo.CreateEventDelivery(ctx, ed))
+
+		dbEventDelivery, err := edRepo.FindEventDeliveryByIDSlim(ctx, project.UID, ed.UID)
+		require.NoError(t, err)
+
+		return dbEventDelivery
+	}
+
+	first := create(datastore.RetryEventStatus, "account-1")
+	second := create(datastore.ScheduledEventStatus, "account-1")
+	other := create(datastore.ScheduledEventStatus, "account-2")
+
+	blocked, err := edRepo.HasPendingPredecessor(ctx, project.UID, first)
+	require.NoError(t, err)
+	require.False(t, blocked)
+
+	blocked, err = edRepo.HasPendingPredecessor(ctx, project.UID, second)
+	require.NoError(t, err)
+	require.True(t, blocked)
+
+	blocked, err = edRepo.HasPendingPredecessor(ctx, project.UID, other)
+	require.NoError(t, err)
+	require.False(t, blocked)
+
+	require.NoError(t, edRepo.UpdateStatusOfEventDelivery(ctx, project.UID, *first, datastore.SuccessEventStatus))
+
+	blocked, err = edRepo.HasPendingPredecessor(ctx, project.UID, second)
+	require.NoError(t, err)
+	require.False(t, blocked)
+
+	// failed deliveries won't be retried, so they don't block the ones behind them
+	third := create(datastore.ScheduledEventStatus, "account-2")
+	require.NoError(t, edRepo.UpdateStatusOfEventDelivery(ctx, project.UID, *other, datastore.FailureEventStatus))
+
+	blocked, err = edRepo.HasPendingPredecessor(ctx, project.UID, third)
+	require.NoError(t, err)
+	require.False(t, blocked)
+}
+
+func Test_eventDeliveryRepo_ClaimScheduledEndpointDeliveries(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	edRepo := NewEventDeliveryRepo(db)
+	ctx := context.Background()
+
+	ids := make([]string, 0, 4)
+	for i := 0; i < 4; i++ {
+		ed := generateEventDelivery(project, endpoint, event, device, sub)
+		ed.Status = datastore.ScheduledEventStatus
+		ed.CreatedAt = time.Now().Add(time.Duration(i) * time.Second)
+
+		require.NoError(t, edRepo.CreateEventDelivery(ctx, ed))
+		ids = append(ids, ed.UID)
+	}
+
+	count, err := edRepo.CountScheduledEndpointDeliveries(ctx, project.UID, endpoint.UID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), count)
+
+	// the current delivery is claimed even though it is not the oldest
+	claimed, err := edRepo.ClaimScheduledEndpointDeliveries(ctx, project.UID, endpoint.UID, ids[3], 2)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+
+	claimedIDs := []string{claimed[0].UID, claimed[1].UID}
+	require.ElementsMatch(t, []string{ids[0], ids[3]}, claimedIDs)
+
+	for _, ed := range claimed {
+		require.Equal(t, datastore.ProcessingEventStatus, ed.Status)
+	}
+
+	count, err = edRepo.CountScheduledEndpointDeliveries(ctx, project.UID, endpoint.UID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
 func Test_eventDeliveryRepo_CountDeliveriesByStatus(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
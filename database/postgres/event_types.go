@@ -6,44 +6,77 @@ import (
 	"errors"
 	"github.com/frain-dev/convoy/database"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
 	"github.com/oklog/ulid/v2"
 	"time"
 )
 
 var (
-	ErrEventTypeNotFound   = errors.New("event type not found")
+	ErrEventTypeNotFound   = datastore.ErrEventTypeNotFound
 	ErrEventTypeNotCreated = errors.New("event type could not be created")
 	ErrEventTypeNotUpdated = errors.New("event type could not be updated")
 )
 
 const (
 	createEventType = `
-	INSERT INTO convoy.event_types (id, name, description, category, project_id, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5, now(), now());
+	INSERT INTO convoy.event_types (id, name, description, category, project_id, json_schema, schema_version, schema_validation, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now(), now());
 	`
 
 	updateEventType = `
 	UPDATE convoy.event_types SET
 	description = $3,
 	category = $4,
+	json_schema = $5,
+	schema_version = $6,
+	schema_validation = $7,
 	updated_at = NOW()
 	WHERE id = $1 and project_id = $2;
 	`
 
+	createEventTypeSchema = `
+	INSERT INTO convoy.event_type_schemas (id, event_type_id, project_id, version, json_schema)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (event_type_id, version) DO NOTHING;
+	`
+
+	eventTypeColumns = `
+	id, name, project_id, created_at, updated_at, deprecated_at,
+	COALESCE(description, '') AS description,
+	COALESCE(category, '') AS category,
+	COALESCE(json_schema, 'null'::jsonb) AS json_schema,
+	schema_version, schema_validation
+	`
+
 	deprecateEventType = `
 	UPDATE convoy.event_types SET
 	deprecated_at = NOW()
 	WHERE id = $1 and project_id = $2
-	returning *;
+	returning ` + eventTypeColumns + `;
 	`
 
 	fetchEventTypeById = `
-	SELECT * FROM convoy.event_types
+	SELECT ` + eventTypeColumns + ` FROM convoy.event_types
 	WHERE id = $1 and project_id = $2;
 	`
 
+	// event type names aren't unique, the type that isn't deprecated wins
+	fetchEventTypeByName = `
+	SELECT ` + eventTypeColumns + ` FROM convoy.event_types
+	WHERE name = $1 and project_id = $2
+	ORDER BY deprecated_at DESC NULLS FIRST, created_at DESC
+	LIMIT 1;
+	`
+
 	fetchAllEventTypes = `
-	SELECT * FROM convoy.event_types where project_id = $1;
+	SELECT ` + eventTypeColumns + ` FROM convoy.event_types where project_id = $1;
+	`
+
+	fetchEventTypeSchemas = `
+	SELECT id, event_type_id, project_id, version, json_schema, created_at
+	FROM convoy.event_type_schemas
+	WHERE event_type_id = $1 and project_id = $2
+	ORDER BY version DESC;
 	`
 )
 
@@ -56,12 +89,25 @@ func NewEventTypesRepo(db database.Database) datastore.EventTypesRepository {
 }
 
 func (e *eventTypesRepo) CreateEventType(ctx context.Context, eventType *datastore.ProjectEventType) error {
-	r, err := e.db.GetDB().ExecContext(ctx, createEventType,
+	if eventType.SchemaValidation == "" {
+		eventType.SchemaValidation = datastore.RejectSchemaValidation
+	}
+
+	tx, err := e.db.GetDB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	r, err := tx.ExecContext(ctx, createEventType,
 		eventType.UID,
 		eventType.Name,
 		eventType.Description,
 		eventType.Category,
 		eventType.ProjectId,
+		eventType.JSONSchema,
+		eventType.SchemaVersion,
+		eventType.SchemaValidation,
 	)
 	if err != nil {
 		return err
@@ -76,7 +122,30 @@ func (e *eventTypesRepo) CreateEventType(ctx context.Context, eventType *datasto
 		return ErrEventTypeNotCreated
 	}
 
-	return nil
+	err = createSchemaVersion(ctx, tx, eventType)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// createSchemaVersion records the event type's current schema, versions
+// that have been recorded are left as they are.
+func createSchemaVersion(ctx context.Context, tx *sqlx.Tx, eventType *datastore.ProjectEventType) error {
+	if !eventType.HasSchema() {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, createEventTypeSchema,
+		ulid.Make().String(),
+		eventType.UID,
+		eventType.ProjectId,
+		eventType.SchemaVersion,
+		eventType.JSONSchema,
+	)
+
+	return err
 }
 
 func (e *eventTypesRepo) CreateDefaultEventType(ctx context.Context, projectId string) error {
@@ -94,6 +163,9 @@ func (e *eventTypesRepo) CreateDefaultEventType(ctx context.Context, projectId s
 		eventType.Description,
 		eventType.Category,
 		eventType.ProjectId,
+		eventType.JSONSchema,
+		eventType.SchemaVersion,
+		datastore.RejectSchemaValidation,
 	)
 	if err != nil {
 		return err
@@ -112,11 +184,20 @@ func (e *eventTypesRepo) CreateDefaultEventType(ctx context.Context, projectId s
 }
 
 func (e *eventTypesRepo) UpdateEventType(ctx context.Context, eventType *datastore.ProjectEventType) error {
-	r, err := e.db.GetDB().ExecContext(ctx, updateEventType,
+	tx, err := e.db.GetDB().BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	r, err := tx.ExecContext(ctx, updateEventType,
 		eventType.UID,
 		eventType.ProjectId,
 		eventType.Description,
 		eventType.Category,
+		eventType.JSONSchema,
+		eventType.SchemaVersion,
+		eventType.SchemaValidation,
 	)
 	if err != nil {
 		return err
@@ -131,7 +212,12 @@ func (e *eventTypesRepo) UpdateEventType(ctx context.Context, eventType *datasto
 		return ErrEventTypeNotUpdated
 	}
 
-	return nil
+	err = createSchemaVersion(ctx, tx, eventType)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (e *eventTypesRepo) DeprecateEventType(ctx context.Context, id, projectId string) (*datastore.ProjectEventType, error) {
@@ -182,3 +268,26 @@ func (e *eventTypesRepo) FetchAllEventTypes(ctx context.Context, projectId strin
 
 	return eventTypes, nil
 }
+
+func (e *eventTypesRepo) FetchEventTypeByName(ctx context.Context, name, projectId string) (*datastore.ProjectEventType, error) {
+	eventType := &datastore.ProjectEventType{}
+	err := e.db.GetReadDB().QueryRowxContext(ctx, fetchEventTypeByName, name, projectId).StructScan(eventType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEventTypeNotFound
+		}
+		return nil, err
+	}
+
+	return eventType, nil
+}
+
+func (e *eventTypesRepo) FetchEventTypeSchemas(ctx context.Context, id, projectId string) ([]datastore.EventTypeSchema, error) {
+	schemas := make([]datastore.EventTypeSchema, 0)
+	err := e.db.GetReadDB().SelectContext(ctx, &schemas, fetchEventTypeSchemas, id, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemas, nil
+}
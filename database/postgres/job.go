@@ -12,7 +12,7 @@ import (
 )
 
 var (
-	ErrJobNotFound   = errors.New("job not found")
+	ErrJobNotFound   = datastore.ErrJobNotFound
 	ErrJobNotCreated = errors.New("job could not be created")
 	ErrJobNotUpdated = errors.New("job could not be updated")
 	ErrJobNotDeleted = errors.New("job could not be deleted")
@@ -20,16 +20,17 @@ var (
 
 const (
 	createJob = `
-	INSERT INTO convoy.jobs (id, type, status, project_id)
-	VALUES ($1, $2, $3, $4)
+	INSERT INTO convoy.jobs (id, type, status, project_id, progress)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5)
 	`
 
 	updateJobStartedAt = `
 	UPDATE convoy.jobs SET
 	status = 'running',
 	started_at = NOW(),
+	failed_at = NULL,
 	updated_at = NOW()
-	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	WHERE id = $1 AND COALESCE(project_id, '') = $2 AND deleted_at IS NULL;
 	`
 
 	updateJobCompletedAt = `
@@ -37,7 +38,7 @@ const (
 	status = 'completed',
 	completed_at = NOW(),
 	updated_at = NOW()
-	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	WHERE id = $1 AND COALESCE(project_id, '') = $2 AND deleted_at IS NULL;
 	`
 
 	updateJobFailedAt = `
@@ -45,33 +46,47 @@ const (
 	status = 'failed',
 	failed_at = NOW(),
 	updated_at = NOW()
-	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	WHERE id = $1 AND COALESCE(project_id, '') = $2 AND deleted_at IS NULL;
+	`
+
+	updateJobProgress = `
+	UPDATE convoy.jobs SET
+	progress = $3,
+	updated_at = NOW()
+	WHERE id = $1 AND COALESCE(project_id, '') = $2 AND deleted_at IS NULL;
 	`
 
 	deleteJob = `
 	UPDATE convoy.jobs SET
 	deleted_at = NOW()
-	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	WHERE id = $1 AND COALESCE(project_id, '') = $2 AND deleted_at IS NULL;
 	`
 
-	fetchJobById = `
-	SELECT * FROM convoy.jobs
-	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	// instance wide jobs have no project, they are matched by an empty project id
+	baseFetchJob = `
+	SELECT id, type, status, COALESCE(project_id, '') AS project_id, progress,
+	failed_at, started_at, completed_at, created_at, updated_at, deleted_at
+	FROM convoy.jobs`
+
+	fetchJobById = baseFetchJob + `
+	WHERE id = $1 AND COALESCE(project_id, '') = $2 AND deleted_at IS NULL;
 	`
 
-	fetchRunningJobsByProjectId = `
-	SELECT * FROM convoy.jobs
+	fetchLatestJobByType = baseFetchJob + `
+	WHERE type = $1 AND COALESCE(project_id, '') = $2 AND deleted_at IS NULL
+	ORDER BY id DESC LIMIT 1;
+	`
+
+	fetchRunningJobsByProjectId = baseFetchJob + `
 	WHERE status = 'running'
 	AND project_id = $1
 	AND deleted_at IS NULL;
 	`
 
-	fetchJobsByProjectId = `
-	SELECT * FROM convoy.jobs WHERE project_id = $1 AND deleted_at IS NULL;
+	fetchJobsByProjectId = baseFetchJob + ` WHERE project_id = $1 AND deleted_at IS NULL;
 	`
 
-	fetchJobsPaginated = `
-	SELECT * FROM convoy.jobs WHERE deleted_at IS NULL`
+	fetchJobsPaginated = baseFetchJob + ` WHERE deleted_at IS NULL`
 
 	baseJobsFilter = `
 	AND project_id = :project_id`
@@ -120,6 +135,7 @@ func (j *jobRepo) CreateJob(ctx context.Context, job *datastore.Job) error {
 		job.Type,
 		job.Status,
 		job.ProjectID,
+		job.Progress,
 	)
 	if err != nil {
 		return err
@@ -191,6 +207,24 @@ func (j *jobRepo) MarkJobAsFailed(ctx context.Context, uid, projectID string) er
 	return nil
 }
 
+func (j *jobRepo) UpdateJobProgress(ctx context.Context, uid, projectID string, progress *datastore.JobProgress) error {
+	r, err := j.db.GetDB().ExecContext(ctx, updateJobProgress, uid, projectID, progress)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrJobNotUpdated
+	}
+
+	return nil
+}
+
 func (j *jobRepo) DeleteJob(ctx context.Context, uid string, projectID string) error {
 	r, err := j.db.GetDB().ExecContext(ctx, deleteJob, uid, projectID)
 	if err != nil {
@@ -222,6 +256,19 @@ func (j *jobRepo) FetchJobById(ctx context.Context, uid string, projectID string
 	return job, nil
 }
 
+func (j *jobRepo) FetchLatestJobByType(ctx context.Context, jobType string, projectID string) (*datastore.Job, error) {
+	job := &datastore.Job{}
+	err := j.db.GetDB().QueryRowxContext(ctx, fetchLatestJobByType, jobType, projectID).StructScan(job)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
 func (j *jobRepo) FetchRunningJobsByProjectId(ctx context.Context, projectID string) ([]datastore.Job, error) {
 	var jobs []datastore.Job
 	rows, err := j.db.GetReadDB().QueryxContext(ctx, fetchRunningJobsByProjectId, projectID)
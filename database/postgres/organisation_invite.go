@@ -21,8 +21,8 @@ var (
 
 const (
 	createOrganisationInvite = `
-	INSERT INTO convoy.organisation_invites (id, organisation_id, invitee_email, token, role_type, role_project, role_endpoint, status, expires_at)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+	INSERT INTO convoy.organisation_invites (id, organisation_id, invitee_email, token, role_type, role_project, role_endpoint, role_permissions, status, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
 	`
 
 	updateOrganisationInvite = `
@@ -34,7 +34,8 @@ const (
 		status = $5,
 		expires_at = $6,
 		updated_at = NOW(),
-		deleted_at = $7
+		deleted_at = $7,
+		role_permissions = $8
 	WHERE id = $1 AND deleted_at IS NULL;
 	`
 
@@ -48,6 +49,7 @@ const (
 		role_type AS "role.type",
 	    COALESCE(role_project,'') AS "role.project",
 	    COALESCE(role_endpoint,'') AS "role.endpoint",
+	    role_permissions AS "role.permissions",
 	    created_at, updated_at, expires_at
 	FROM convoy.organisation_invites
 	WHERE id = $1 AND deleted_at IS NULL;
@@ -63,6 +65,7 @@ const (
 		role_type AS "role.type",
 	    COALESCE(role_project,'') AS "role.project",
 	    COALESCE(role_endpoint,'') AS "role.endpoint",
+	    role_permissions AS "role.permissions",
 	    created_at, updated_at, expires_at
 	FROM convoy.organisation_invites
 	WHERE token = $1 AND deleted_at IS NULL;
@@ -77,6 +80,7 @@ const (
 		role_type AS "role.type",
 	    COALESCE(role_project,'') AS "role.project",
 	    COALESCE(role_endpoint,'') AS "role.endpoint",
+	    role_permissions AS "role.permissions",
 	    created_at, updated_at, expires_at
 	FROM convoy.organisation_invites
 	WHERE organisation_id = :org_id
@@ -149,6 +153,7 @@ func (i *orgInviteRepo) CreateOrganisationInvite(ctx context.Context, iv *datast
 		iv.Role.Type,
 		projectID,
 		endpointID,
+		iv.Role.Permissions,
 		iv.Status,
 		iv.ExpiresAt,
 	)
@@ -280,6 +285,7 @@ func (i *orgInviteRepo) UpdateOrganisationInvite(ctx context.Context, iv *datast
 		iv.Status,
 		iv.ExpiresAt,
 		iv.DeletedAt,
+		iv.Role.Permissions,
 	)
 	if err != nil {
 		return err
@@ -21,8 +21,8 @@ var (
 
 const (
 	createOrgMember = `
-	INSERT INTO convoy.organisation_members (id, organisation_id, user_id, role_type, role_project, role_endpoint)
-	VALUES ($1, $2, $3, $4, $5, $6);
+	INSERT INTO convoy.organisation_members (id, organisation_id, user_id, role_type, role_project, role_endpoint, role_permissions)
+	VALUES ($1, $2, $3, $4, $5, $6, $7);
 	`
 
 	updateOrgMember = `
@@ -31,6 +31,7 @@ const (
 		role_type = $2,
 		role_project = $3,
 		role_endpoint = $4,
+		role_permissions = $5,
 		updated_at = NOW()
 	WHERE id = $1 AND deleted_at IS NULL;
 	`
@@ -48,6 +49,7 @@ const (
 		o.role_type AS "role.type",
 	    COALESCE(o.role_project,'') AS "role.project",
 	    COALESCE(o.role_endpoint,'') AS "role.endpoint",
+	    o.role_permissions AS "role.permissions",
 		u.id AS "user_id",
 		u.id AS "user_metadata.user_id",
 		u.first_name AS "user_metadata.first_name",
@@ -66,6 +68,7 @@ const (
 		o.role_type AS "role.type",
 	    COALESCE(o.role_project,'') AS "role.project",
 	    COALESCE(o.role_endpoint,'') AS "role.endpoint",
+	    o.role_permissions AS "role.permissions",
 		u.id AS "user_id",
 		u.id AS "user_metadata.user_id",
 		u.first_name AS "user_metadata.first_name",
@@ -84,6 +87,7 @@ const (
 		o.role_type AS "role.type",
 	    COALESCE(o.role_project,'') AS "role.project",
 	    COALESCE(o.role_endpoint,'') AS "role.endpoint",
+	    o.role_permissions AS "role.permissions",
 		u.id AS "user_id",
 		u.id AS "user_metadata.user_id",
 		u.first_name AS "user_metadata.first_name",
@@ -202,6 +206,7 @@ func (o *orgMemberRepo) CreateOrganisationMember(ctx context.Context, member *da
 		member.Role.Type,
 		projectID,
 		endpointID,
+		member.Role.Permissions,
 	)
 	if err != nil {
 		return err
@@ -437,6 +442,7 @@ func (o *orgMemberRepo) UpdateOrganisationMember(ctx context.Context, member *da
 		member.Role.Type,
 		projectID,
 		endpointID,
+		member.Role.Permissions,
 	)
 	if err != nil {
 		return err
@@ -5,8 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"github.com/frain-dev/convoy/internal/pkg/keys"
-	"github.com/frain-dev/convoy/pkg/log"
 
 	"github.com/frain-dev/convoy/database"
 	"github.com/frain-dev/convoy/datastore"
@@ -189,15 +187,10 @@ const (
 
 type portalLinkRepo struct {
 	db database.Database
-	km keys.KeyManager
 }
 
 func NewPortalLinkRepo(db database.Database) datastore.PortalLinkRepository {
-	km, err := keys.Get()
-	if err != nil {
-		log.Fatal(err)
-	}
-	return &portalLinkRepo{db: db, km: km}
+	return &portalLinkRepo{db: db}
 }
 
 func (p *portalLinkRepo) CreatePortalLink(ctx context.Context, portal *datastore.PortalLink) error {
@@ -446,7 +439,7 @@ func (p *portalLinkRepo) upsertPortalLinkEndpoint(ctx context.Context, tx *sqlx.
 			ids = append(ids, &PortalLinkEndpoint{PortalLinkID: portal.UID, EndpointID: endpointID})
 		}
 	} else if !util.IsStringEmpty(portal.OwnerID) {
-		key, err := p.km.GetCurrentKeyFromCache()
+		key, err := currentKeyRing(ctx, p.db)
 		if err != nil {
 			return err
 		}
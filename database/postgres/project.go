@@ -38,12 +38,19 @@ const (
 		strategy_retry_count, signature_header, signature_versions,
 		disable_endpoint, meta_events_enabled, meta_events_type,
 		meta_events_event_type, meta_events_url, meta_events_secret,
-		meta_events_pub_sub, ssl_enforce_secure_endpoints
+		meta_events_pub_sub, ssl_enforce_secure_endpoints,
+		strategy_status_code_policies, signature_scheme, signature_asymmetric,
+		is_encrypted, meta_events_secret_cipher, meta_events_pub_sub_cipher, key_version
 	  )
 	  VALUES
 		(
 		  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
-		  $14, $15, $16, $17, $18, $19
+		  $14, $15, $16, CASE WHEN $23 THEN NULL ELSE $17 END,
+		  CASE WHEN $23 THEN NULL ELSE $18::jsonb END, $19, $20, $21, $22,
+		  $23,
+		  CASE WHEN $23 THEN convoy.keyring_encrypt($17::TEXT, convoy.keyring_version($24), $24) END,
+		  CASE WHEN $23 THEN convoy.keyring_encrypt($18::jsonb::TEXT, convoy.keyring_version($24), $24) END,
+		  CASE WHEN $23 THEN convoy.keyring_version($24) END
 		);
 	`
 
@@ -63,10 +70,15 @@ const (
 		meta_events_type = $13,
 		meta_events_event_type = $14,
 		meta_events_url = $15,
-		meta_events_secret = $16,
-		meta_events_pub_sub = $17,
+		meta_events_secret = CASE WHEN is_encrypted THEN NULL ELSE $16 END,
+		meta_events_secret_cipher = CASE WHEN is_encrypted THEN convoy.keyring_encrypt($16::TEXT, key_version, $23) END,
+		meta_events_pub_sub = CASE WHEN is_encrypted THEN NULL ELSE $17::jsonb END,
+		meta_events_pub_sub_cipher = CASE WHEN is_encrypted THEN convoy.keyring_encrypt($17::jsonb::TEXT, key_version, $23) END,
 		search_policy = $18,
 		ssl_enforce_secure_endpoints = $19,
+		strategy_status_code_policies = $20,
+		signature_scheme = $21,
+		signature_asymmetric = $22,
 		updated_at = NOW()
 	WHERE id = $1 AND deleted_at IS NULL;
 	`
@@ -88,16 +100,25 @@ const (
 		c.strategy_type AS "config.strategy.type",
 		c.strategy_duration AS "config.strategy.duration",
 		c.strategy_retry_count AS "config.strategy.retry_count",
+		c.strategy_status_code_policies AS "config.strategy.status_code_policies",
 		c.signature_header AS "config.signature.header",
 		c.signature_versions AS "config.signature.versions",
+		c.signature_scheme AS "config.signature.scheme",
+		c.signature_asymmetric AS "config.signature.asymmetric",
 		c.disable_endpoint AS "config.disable_endpoint",
 		c.ssl_enforce_secure_endpoints as "config.ssl.enforce_secure_endpoints",
 		c.meta_events_enabled AS "config.meta_event.is_enabled",
 		COALESCE(c.meta_events_type, '') AS "config.meta_event.type",
 		c.meta_events_event_type AS "config.meta_event.event_type",
 		COALESCE(c.meta_events_url, '') AS "config.meta_event.url",
-		COALESCE(c.meta_events_secret, '') AS "config.meta_event.secret",
-		c.meta_events_pub_sub AS "config.meta_event.pub_sub",
+		CASE
+			WHEN c.is_encrypted THEN COALESCE(convoy.keyring_decrypt(c.meta_events_secret_cipher::bytea, c.key_version, $2), '')
+			ELSE COALESCE(c.meta_events_secret, '')
+		END AS "config.meta_event.secret",
+		CASE
+			WHEN c.is_encrypted THEN convoy.keyring_decrypt(c.meta_events_pub_sub_cipher::bytea, c.key_version, $2)::jsonb
+			ELSE c.meta_events_pub_sub
+		END AS "config.meta_event.pub_sub",
 		p.created_at,
 		p.updated_at,
 		p.deleted_at
@@ -125,14 +146,23 @@ const (
 	c.strategy_duration AS "config.strategy.duration",
 	c.ssl_enforce_secure_endpoints as "config.ssl.enforce_secure_endpoints",
 	c.strategy_retry_count AS "config.strategy.retry_count",
+	c.strategy_status_code_policies AS "config.strategy.status_code_policies",
 	c.signature_header AS "config.signature.header",
 	c.signature_versions AS "config.signature.versions",
+	c.signature_scheme AS "config.signature.scheme",
+	c.signature_asymmetric AS "config.signature.asymmetric",
 	c.meta_events_enabled AS "config.meta_event.is_enabled",
 	COALESCE(c.meta_events_type, '') AS "config.meta_event.type",
 	c.meta_events_event_type AS "config.meta_event.event_type",
 	COALESCE(c.meta_events_url, '') AS "config.meta_event.url",
-	COALESCE(c.meta_events_secret, '') AS "config.meta_event.secret",
-	c.meta_events_pub_sub AS "config.meta_event.pub_sub",
+	CASE
+		WHEN c.is_encrypted THEN COALESCE(convoy.keyring_decrypt(c.meta_events_secret_cipher::bytea, c.key_version, $2), '')
+		ELSE COALESCE(c.meta_events_secret, '')
+	END AS "config.meta_event.secret",
+	CASE
+		WHEN c.is_encrypted THEN convoy.keyring_decrypt(c.meta_events_pub_sub_cipher::bytea, c.key_version, $2)::jsonb
+		ELSE c.meta_events_pub_sub
+	END AS "config.meta_event.pub_sub",
 	p.created_at,
 	p.updated_at,
 	p.deleted_at
@@ -188,10 +218,13 @@ const (
 	id, name, status, owner_id, url,
     description, http_timeout, rate_limit, rate_limit_duration,
     advanced_signatures, slack_webhook_url, support_email,
-    app_id, project_id, secrets, created_at, updated_at,
+    app_id, project_id, status_code_policies,
+    http_method, content_type, body_encoding,
+    ordered_delivery, ordering_key, batch_delivery, batch_size, batch_window, mtls_client_cert, secrets, created_at, updated_at,
     authentication_type AS "authentication.type",
     authentication_type_api_key_header_name AS "authentication.api_key.header_name",
-    authentication_type_api_key_header_value AS "authentication.api_key.header_value";
+    authentication_type_api_key_header_value AS "authentication.api_key.header_value",
+    authentication_oauth2 AS "authentication.oauth2";
 	`
 
 	getProjectsWithEventsInTheInterval = `
@@ -215,6 +248,8 @@ type projectRepo struct {
 	hook *hooks.Hook
 }
 
+const projectConfigurationsTable = "project_configurations"
+
 func NewProjectRepo(db database.Database) datastore.ProjectRepository {
 	return &projectRepo{db: db, hook: db.GetHook()}
 }
@@ -241,6 +276,16 @@ func (p *projectRepo) CreateProject(ctx context.Context, project *datastore.Proj
 	sgc := project.Config.GetSignatureConfig()
 	me := project.Config.GetMetaEventConfig()
 
+	key, err := currentKeyRing(ctx, p.db)
+	if err != nil {
+		return err
+	}
+
+	isEncrypted, err := isTableEncrypted(ctx, p.db, projectConfigurationsTable)
+	if err != nil {
+		return err
+	}
+
 	configID := ulid.Make().String()
 	result, err := tx.ExecContext(ctx, createProjectConfiguration,
 		configID,
@@ -262,9 +307,14 @@ func (p *projectRepo) CreateProject(ctx context.Context, project *datastore.Proj
 		me.Secret,
 		me.PubSub,
 		project.Config.SSL.EnforceSecureEndpoints,
+		sc.StatusCodePolicies,
+		sgc.Scheme,
+		sgc.Asymmetric,
+		isEncrypted,
+		key,
 	)
 	if err != nil {
-		return err
+		return encryptionError(ctx, p.db, projectConfigurationsTable, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -303,7 +353,12 @@ func (p *projectRepo) CreateProject(ctx context.Context, project *datastore.Proj
 }
 
 func (p *projectRepo) LoadProjects(ctx context.Context, f *datastore.ProjectFilter) ([]*datastore.Project, error) {
-	rows, err := p.db.GetReadDB().QueryxContext(ctx, fetchProjects, f.OrgID)
+	key, err := currentKeyRing(ctx, p.db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := p.db.GetReadDB().QueryxContext(ctx, fetchProjects, f.OrgID, key)
 	if err != nil {
 		return nil, err
 	}
@@ -361,6 +416,11 @@ func (p *projectRepo) UpdateProject(ctx context.Context, project *datastore.Proj
 	ssl := project.Config.GetSSLConfig()
 	me := project.Config.GetMetaEventConfig()
 
+	key, err := currentKeyRing(ctx, p.db)
+	if err != nil {
+		return err
+	}
+
 	cRes, err := tx.ExecContext(ctx, updateProjectConfiguration,
 		project.ProjectConfigID,
 		project.Config.MaxIngestSize,
@@ -381,9 +441,13 @@ func (p *projectRepo) UpdateProject(ctx context.Context, project *datastore.Proj
 		me.PubSub,
 		project.Config.SearchPolicy,
 		ssl.EnforceSecureEndpoints,
+		sc.StatusCodePolicies,
+		sgc.Scheme,
+		sgc.Asymmetric,
+		key,
 	)
 	if err != nil {
-		return fmt.Errorf("update project config err: %v", err)
+		return fmt.Errorf("update project config err: %w", encryptionError(ctx, p.db, projectConfigurationsTable, err))
 	}
 
 	rowsAffected, err = cRes.RowsAffected()
@@ -428,8 +492,13 @@ func (p *projectRepo) UpdateProject(ctx context.Context, project *datastore.Proj
 }
 
 func (p *projectRepo) FetchProjectByID(ctx context.Context, id string) (*datastore.Project, error) {
+	key, err := currentKeyRing(ctx, p.db)
+	if err != nil {
+		return nil, err
+	}
+
 	var project datastore.Project
-	err := p.db.GetDB().GetContext(ctx, &project, fetchProjectById, id)
+	err = p.db.GetDB().GetContext(ctx, &project, fetchProjectById, id, key)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, datastore.ErrProjectNotFound
@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrScheduledEventNotCreated = errors.New("scheduled event could not be created")
+	ErrScheduledEventNotUpdated = errors.New("scheduled event could not be updated")
+)
+
+const (
+	createScheduledEvent = `
+	INSERT INTO convoy.scheduled_events (id, project_id, event_id, event_type, kind, payload, status, deliver_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	fetchScheduledEventById = `
+	SELECT id, project_id, event_id, event_type, kind, payload, status,
+	deliver_at, dispatched_at, created_at, updated_at
+	FROM convoy.scheduled_events WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+	baseScheduledEventsPaged = `
+	SELECT se.id, se.project_id, se.event_id, se.event_type, se.kind,
+	se.payload, se.status, se.deliver_at, se.dispatched_at,
+	se.created_at, se.updated_at FROM convoy.scheduled_events se
+	WHERE se.deleted_at IS NULL
+	`
+	baseScheduledEventsPagedForward = `%s %s AND se.id <= :cursor
+	GROUP BY se.id
+	ORDER BY se.id DESC
+	LIMIT :limit
+	`
+	baseScheduledEventsPagedBackward = `
+	WITH scheduled_events AS (
+		%s %s AND se.id >= :cursor
+		GROUP BY se.id
+		ORDER BY se.id ASC
+		LIMIT :limit
+	)
+
+	SELECT * from scheduled_events ORDER BY id DESC
+	`
+	baseScheduledEventFilter = ` AND se.project_id = :project_id
+	AND se.created_at >= :start_date
+	AND se.created_at <= :end_date`
+
+	scheduledEventStatusFilter = ` AND se.status = :status`
+
+	baseCountPrevScheduledEvents = `
+	SELECT COUNT(DISTINCT(se.id)) AS count
+	FROM convoy.scheduled_events se WHERE se.deleted_at IS NULL
+	`
+	countPrevScheduledEvents = ` AND se.id > :cursor GROUP BY se.id ORDER BY se.id DESC LIMIT 1`
+
+	// only events that are still scheduled can change state, this
+	// stops a cancelled event from being dispatched and vice versa.
+	updateScheduledEventStatus = `
+	UPDATE convoy.scheduled_events SET
+	  status = $3,
+	  dispatched_at = CASE WHEN $3 = 'Dispatched' THEN NOW() ELSE dispatched_at END,
+	  updated_at = NOW()
+	WHERE id = $1 AND project_id = $2 AND status = 'Scheduled' AND deleted_at IS NULL;
+	`
+	// used when a dispatched event could not be queued, so the
+	// dispatch job can pick it up again.
+	restoreScheduledEvent = `
+	UPDATE convoy.scheduled_events SET
+	  status = 'Scheduled',
+	  dispatched_at = NULL,
+	  updated_at = NOW()
+	WHERE id = $1 AND project_id = $2 AND status = 'Dispatched' AND deleted_at IS NULL;
+	`
+	rescheduleEvent = `
+	UPDATE convoy.scheduled_events SET
+	  deliver_at = $3,
+	  updated_at = NOW()
+	WHERE id = $1 AND project_id = $2 AND status = 'Scheduled' AND deleted_at IS NULL;
+	`
+)
+
+type scheduledEventRepo struct {
+	db database.Database
+}
+
+func NewScheduledEventRepo(db database.Database) datastore.ScheduledEventRepository {
+	return &scheduledEventRepo{db: db}
+}
+
+func (s *scheduledEventRepo) CreateScheduledEvent(ctx context.Context, scheduledEvent *datastore.ScheduledEvent) error {
+	r, err := s.db.GetDB().ExecContext(ctx, createScheduledEvent, scheduledEvent.UID, scheduledEvent.ProjectID,
+		scheduledEvent.EventID, scheduledEvent.EventType, scheduledEvent.Kind, scheduledEvent.Payload,
+		scheduledEvent.Status, scheduledEvent.DeliverAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrScheduledEventNotCreated
+	}
+
+	return nil
+}
+
+func (s *scheduledEventRepo) FindScheduledEventByID(ctx context.Context, projectID string, id string) (*datastore.ScheduledEvent, error) {
+	scheduledEvent := &datastore.ScheduledEvent{}
+	err := s.db.GetDB().QueryRowxContext(ctx, fetchScheduledEventById, id, projectID).StructScan(scheduledEvent)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrScheduledEventNotFound
+		}
+
+		return nil, err
+	}
+
+	return scheduledEvent, nil
+}
+
+func (s *scheduledEventRepo) LoadScheduledEventsPaged(ctx context.Context, projectID string, status datastore.ScheduleStatus, filter *datastore.Filter) ([]datastore.ScheduledEvent, datastore.PaginationData, error) {
+	var query, countQuery, filterQuery string
+	var err error
+	var args, qargs []interface{}
+
+	startDate, endDate := getCreatedDateFilter(filter.SearchParams.CreatedAtStart, filter.SearchParams.CreatedAtEnd)
+
+	arg := map[string]interface{}{
+		"project_id": projectID,
+		"status":     status,
+		"start_date": startDate,
+		"end_date":   endDate,
+		"limit":      filter.Pageable.Limit(),
+		"cursor":     filter.Pageable.Cursor(),
+	}
+
+	var baseQueryPagination string
+	if filter.Pageable.Direction == datastore.Next {
+		baseQueryPagination = baseScheduledEventsPagedForward
+	} else {
+		baseQueryPagination = baseScheduledEventsPagedBackward
+	}
+
+	filterQuery = baseScheduledEventFilter
+	if len(status) > 0 {
+		filterQuery += scheduledEventStatusFilter
+	}
+
+	query = fmt.Sprintf(baseQueryPagination, baseScheduledEventsPaged, filterQuery)
+
+	query, args, err = sqlx.Named(query, arg)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	query = s.db.GetReadDB().Rebind(query)
+	rows, err := s.db.GetReadDB().QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+	defer closeWithError(rows)
+
+	scheduledEvents := make([]datastore.ScheduledEvent, 0)
+	for rows.Next() {
+		var data datastore.ScheduledEvent
+
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		scheduledEvents = append(scheduledEvents, data)
+	}
+
+	var prevRowCount datastore.PrevRowCount
+	if len(scheduledEvents) > 0 {
+		first := scheduledEvents[0]
+		qarg := arg
+		qarg["cursor"] = first.UID
+
+		cq := baseCountPrevScheduledEvents + filterQuery + countPrevScheduledEvents
+		countQuery, qargs, err = sqlx.Named(cq, qarg)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		countQuery = s.db.GetReadDB().Rebind(countQuery)
+		rows, err = s.db.GetReadDB().QueryxContext(ctx, countQuery, qargs...)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+		defer closeWithError(rows)
+
+		if rows.Next() {
+			err = rows.StructScan(&prevRowCount)
+			if err != nil {
+				return nil, datastore.PaginationData{}, err
+			}
+		}
+	}
+
+	ids := make([]string, len(scheduledEvents))
+	for i := range scheduledEvents {
+		ids[i] = scheduledEvents[i].UID
+	}
+
+	if len(scheduledEvents) > filter.Pageable.PerPage {
+		scheduledEvents = scheduledEvents[:len(scheduledEvents)-1]
+	}
+
+	pagination := &datastore.PaginationData{PrevRowCount: prevRowCount}
+	pagination = pagination.Build(filter.Pageable, ids)
+
+	return scheduledEvents, *pagination, nil
+}
+
+func (s *scheduledEventRepo) UpdateScheduledEventStatus(ctx context.Context, projectID string, id string, status datastore.ScheduleStatus) error {
+	result, err := s.db.GetDB().ExecContext(ctx, updateScheduledEventStatus, id, projectID, status)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrScheduledEventNotUpdated
+	}
+
+	return nil
+}
+
+func (s *scheduledEventRepo) RestoreScheduledEvent(ctx context.Context, projectID string, id string) error {
+	result, err := s.db.GetDB().ExecContext(ctx, restoreScheduledEvent, id, projectID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrScheduledEventNotUpdated
+	}
+
+	return nil
+}
+
+func (s *scheduledEventRepo) RescheduleEvent(ctx context.Context, projectID string, id string, deliverAt time.Time) error {
+	result, err := s.db.GetDB().ExecContext(ctx, rescheduleEvent, id, projectID, deliverAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrScheduledEventNotUpdated
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateScheduledEvent(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	scheduledEventRepo := NewScheduledEventRepo(db)
+	scheduledEvent := generateScheduledEvent(t, db)
+	ctx := context.Background()
+
+	_, err := scheduledEventRepo.FindScheduledEventByID(ctx, scheduledEvent.ProjectID, scheduledEvent.UID)
+	require.True(t, errors.Is(err, datastore.ErrScheduledEventNotFound))
+
+	require.NoError(t, scheduledEventRepo.CreateScheduledEvent(ctx, scheduledEvent))
+
+	newScheduledEvent, err := scheduledEventRepo.FindScheduledEventByID(ctx, scheduledEvent.ProjectID, scheduledEvent.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, scheduledEvent.EventID, newScheduledEvent.EventID)
+	require.Equal(t, scheduledEvent.Kind, newScheduledEvent.Kind)
+	require.Equal(t, scheduledEvent.Payload, newScheduledEvent.Payload)
+	require.Equal(t, datastore.ScheduleActiveStatus, newScheduledEvent.Status)
+	require.WithinDuration(t, scheduledEvent.DeliverAt, newScheduledEvent.DeliverAt, time.Second)
+}
+
+func Test_UpdateScheduledEventStatus(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	scheduledEventRepo := NewScheduledEventRepo(db)
+	scheduledEvent := generateScheduledEvent(t, db)
+	ctx := context.Background()
+
+	require.NoError(t, scheduledEventRepo.CreateScheduledEvent(ctx, scheduledEvent))
+
+	err := scheduledEventRepo.UpdateScheduledEventStatus(ctx, scheduledEvent.ProjectID, scheduledEvent.UID, datastore.ScheduleDispatchedStatus)
+	require.NoError(t, err)
+
+	newScheduledEvent, err := scheduledEventRepo.FindScheduledEventByID(ctx, scheduledEvent.ProjectID, scheduledEvent.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ScheduleDispatchedStatus, newScheduledEvent.Status)
+	require.True(t, newScheduledEvent.DispatchedAt.Valid)
+
+	// a dispatched event can no longer be cancelled or rescheduled
+	err = scheduledEventRepo.UpdateScheduledEventStatus(ctx, scheduledEvent.ProjectID, scheduledEvent.UID, datastore.ScheduleCancelledStatus)
+	require.ErrorIs(t, err, ErrScheduledEventNotUpdated)
+
+	err = scheduledEventRepo.RescheduleEvent(ctx, scheduledEvent.ProjectID, scheduledEvent.UID, time.Now().Add(time.Hour))
+	require.ErrorIs(t, err, ErrScheduledEventNotUpdated)
+
+	// an event that could not be queued goes back to being scheduled
+	require.NoError(t, scheduledEventRepo.RestoreScheduledEvent(ctx, scheduledEvent.ProjectID, scheduledEvent.UID))
+
+	newScheduledEvent, err = scheduledEventRepo.FindScheduledEventByID(ctx, scheduledEvent.ProjectID, scheduledEvent.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ScheduleActiveStatus, newScheduledEvent.Status)
+	require.False(t, newScheduledEvent.DispatchedAt.Valid)
+
+	err = scheduledEventRepo.RestoreScheduledEvent(ctx, scheduledEvent.ProjectID, scheduledEvent.UID)
+	require.ErrorIs(t, err, ErrScheduledEventNotUpdated)
+}
+
+func Test_RescheduleEvent(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	scheduledEventRepo := NewScheduledEventRepo(db)
+	scheduledEvent := generateScheduledEvent(t, db)
+	ctx := context.Background()
+
+	require.NoError(t, scheduledEventRepo.CreateScheduledEvent(ctx, scheduledEvent))
+
+	deliverAt := time.Now().Add(2 * time.Hour)
+	err := scheduledEventRepo.RescheduleEvent(ctx, scheduledEvent.ProjectID, scheduledEvent.UID, deliverAt)
+	require.NoError(t, err)
+
+	newScheduledEvent, err := scheduledEventRepo.FindScheduledEventByID(ctx, scheduledEvent.ProjectID, scheduledEvent.UID)
+	require.NoError(t, err)
+	require.WithinDuration(t, deliverAt, newScheduledEvent.DeliverAt, time.Second)
+}
+
+func Test_LoadScheduledEventsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	project := seedProject(t, db)
+	scheduledEventRepo := NewScheduledEventRepo(db)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		scheduledEvent := &datastore.ScheduledEvent{
+			UID:       ulid.Make().String(),
+			ProjectID: project.UID,
+			EventID:   ulid.Make().String(),
+			EventType: "invoice.paid",
+			Kind:      datastore.SingleScheduledEvent,
+			Payload:   []byte(`payload`),
+			Status:    datastore.ScheduleActiveStatus,
+			DeliverAt: time.Now().Add(time.Hour),
+		}
+
+		require.NoError(t, scheduledEventRepo.CreateScheduledEvent(ctx, scheduledEvent))
+
+		if i%2 == 0 {
+			err := scheduledEventRepo.UpdateScheduledEventStatus(ctx, project.UID, scheduledEvent.UID, datastore.ScheduleCancelledStatus)
+			require.NoError(t, err)
+		}
+	}
+
+	filter := &datastore.Filter{
+		SearchParams: datastore.SearchParams{
+			CreatedAtStart: time.Now().Add(-time.Hour).Unix(),
+			CreatedAtEnd:   time.Now().Add(5 * time.Minute).Unix(),
+		},
+		Pageable: datastore.Pageable{
+			PerPage:    10,
+			Direction:  datastore.Next,
+			NextCursor: datastore.DefaultCursor,
+		},
+	}
+
+	scheduledEvents, _, err := scheduledEventRepo.LoadScheduledEventsPaged(ctx, project.UID, "", filter)
+	require.NoError(t, err)
+	require.Len(t, scheduledEvents, 5)
+
+	scheduledEvents, _, err = scheduledEventRepo.LoadScheduledEventsPaged(ctx, project.UID, datastore.ScheduleCancelledStatus, filter)
+	require.NoError(t, err)
+	require.Len(t, scheduledEvents, 3)
+}
+
+func generateScheduledEvent(t *testing.T, db database.Database) *datastore.ScheduledEvent {
+	project := seedProject(t, db)
+
+	return &datastore.ScheduledEvent{
+		UID:       ulid.Make().String(),
+		ProjectID: project.UID,
+		EventID:   ulid.Make().String(),
+		EventType: "invoice.paid",
+		Kind:      datastore.SingleScheduledEvent,
+		Payload:   []byte(`payload`),
+		Status:    datastore.ScheduleActiveStatus,
+		DeliverAt: time.Now().Add(time.Hour),
+	}
+}
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrSCIMUserNotCreated = errors.New("scim user could not be created")
+	ErrSCIMUserNotUpdated = errors.New("scim user could not be updated")
+	ErrSCIMUserNotDeleted = errors.New("scim user could not be deleted")
+)
+
+const (
+	createSCIMUser = `
+	INSERT INTO convoy.scim_users (id, organisation_id, user_id, external_id, active)
+	VALUES ($1, $2, $3, $4, $5);
+	`
+
+	updateSCIMUser = `
+	UPDATE convoy.scim_users
+	SET
+		external_id = $3,
+		active = $4,
+		updated_at = NOW()
+	WHERE id = $1 AND organisation_id = $2 AND deleted_at IS NULL;
+	`
+
+	deleteSCIMUser = `
+	UPDATE convoy.scim_users SET
+	deleted_at = NOW()
+	WHERE id = $1 AND organisation_id = $2 AND deleted_at IS NULL;
+	`
+
+	fetchSCIMUsers = `
+	SELECT
+		s.id AS id,
+		s.organisation_id AS "organisation_id",
+		s.user_id AS "user_id",
+		COALESCE(s.external_id, '') AS "external_id",
+		s.active AS "active",
+		u.id AS "user_metadata.user_id",
+		u.first_name AS "user_metadata.first_name",
+		u.last_name AS "user_metadata.last_name",
+		u.email AS "user_metadata.email",
+		s.created_at AS "created_at",
+		s.updated_at AS "updated_at"
+	FROM convoy.scim_users s
+	JOIN convoy.users u ON s.user_id = u.id
+	WHERE s.deleted_at IS NULL
+	`
+
+	fetchSCIMUsersFiltered = fetchSCIMUsers + `
+	AND s.organisation_id = :organisation_id
+	AND (u.email = :email OR :email = '')
+	AND (s.external_id = :external_id OR :external_id = '')
+	`
+
+	countSCIMUsers = `
+	SELECT COUNT(s.id) AS count
+	FROM convoy.scim_users s
+	JOIN convoy.users u ON s.user_id = u.id
+	WHERE s.deleted_at IS NULL
+	AND s.organisation_id = :organisation_id
+	AND (u.email = :email OR :email = '')
+	AND (s.external_id = :external_id OR :external_id = '')
+	`
+)
+
+type scimUserRepo struct {
+	db database.Database
+}
+
+func NewSCIMUserRepo(db database.Database) datastore.SCIMUserRepository {
+	return &scimUserRepo{db: db}
+}
+
+func (s *scimUserRepo) CreateSCIMUser(ctx context.Context, user *datastore.SCIMUser) error {
+	r, err := s.db.GetDB().ExecContext(ctx, createSCIMUser,
+		user.UID,
+		user.OrganisationID,
+		user.UserID,
+		user.ExternalID,
+		user.Active,
+	)
+	if err != nil {
+		return err
+	}
+
+	nRows, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if nRows < 1 {
+		return ErrSCIMUserNotCreated
+	}
+
+	return nil
+}
+
+func (s *scimUserRepo) UpdateSCIMUser(ctx context.Context, user *datastore.SCIMUser) error {
+	r, err := s.db.GetDB().ExecContext(ctx, updateSCIMUser,
+		user.UID,
+		user.OrganisationID,
+		user.ExternalID,
+		user.Active,
+	)
+	if err != nil {
+		return err
+	}
+
+	nRows, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if nRows < 1 {
+		return ErrSCIMUserNotUpdated
+	}
+
+	return nil
+}
+
+func (s *scimUserRepo) DeleteSCIMUser(ctx context.Context, id string, orgID string) error {
+	r, err := s.db.GetDB().ExecContext(ctx, deleteSCIMUser, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	nRows, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if nRows < 1 {
+		return ErrSCIMUserNotDeleted
+	}
+
+	return nil
+}
+
+func (s *scimUserRepo) FetchSCIMUserByID(ctx context.Context, id string, orgID string) (*datastore.SCIMUser, error) {
+	return s.fetchSCIMUser(ctx, fmt.Sprintf("%s AND s.id = $1 AND s.organisation_id = $2;", fetchSCIMUsers), id, orgID)
+}
+
+func (s *scimUserRepo) FetchSCIMUserByUserID(ctx context.Context, userID string, orgID string) (*datastore.SCIMUser, error) {
+	return s.fetchSCIMUser(ctx, fmt.Sprintf("%s AND s.user_id = $1 AND s.organisation_id = $2;", fetchSCIMUsers), userID, orgID)
+}
+
+func (s *scimUserRepo) fetchSCIMUser(ctx context.Context, query string, args ...interface{}) (*datastore.SCIMUser, error) {
+	user := &datastore.SCIMUser{}
+	err := s.db.GetReadDB().QueryRowxContext(ctx, query, args...).StructScan(user)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrSCIMUserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *scimUserRepo) LoadSCIMUsers(ctx context.Context, orgID string, filter *datastore.SCIMUserFilter) ([]datastore.SCIMUser, int64, error) {
+	arg := map[string]interface{}{
+		"organisation_id": orgID,
+		"email":           filter.Email,
+		"external_id":     filter.ExternalID,
+		"limit":           filter.Limit,
+		"offset":          filter.Offset,
+	}
+
+	query, args, err := sqlx.Named(fetchSCIMUsersFiltered+" ORDER BY s.id LIMIT :limit OFFSET :offset;", arg)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query = s.db.GetReadDB().Rebind(query)
+
+	rows, err := s.db.GetReadDB().QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer closeWithError(rows)
+
+	users := make([]datastore.SCIMUser, 0)
+	for rows.Next() {
+		var user datastore.SCIMUser
+
+		err = rows.StructScan(&user)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		users = append(users, user)
+	}
+
+	countQuery, qargs, err := sqlx.Named(countSCIMUsers, arg)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	countQuery = s.db.GetReadDB().Rebind(countQuery)
+
+	var count int64
+	err = s.db.GetReadDB().QueryRowxContext(ctx, countQuery, qargs...).Scan(&count)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, count, nil
+}
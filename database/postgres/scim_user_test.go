@@ -0,0 +1,121 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func seedSCIMUser(t *testing.T, repo datastore.SCIMUserRepository, org *datastore.Organisation, user *datastore.User, externalID string) *datastore.SCIMUser {
+	t.Helper()
+
+	s := &datastore.SCIMUser{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		UserID:         user.UID,
+		ExternalID:     externalID,
+		Active:         true,
+	}
+
+	require.NoError(t, repo.CreateSCIMUser(context.Background(), s))
+
+	return s
+}
+
+func TestCreateSCIMUser(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSCIMUserRepo(db)
+	org := seedOrg(t, db)
+	user := seedUser(t, db)
+
+	s := seedSCIMUser(t, repo, org, user, "okta-1")
+
+	dbUser, err := repo.FetchSCIMUserByID(context.Background(), s.UID, org.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, s.UID, dbUser.UID)
+	require.Equal(t, user.UID, dbUser.UserID)
+	require.Equal(t, "okta-1", dbUser.ExternalID)
+	require.True(t, dbUser.Active)
+	require.Equal(t, user.Email, dbUser.UserMetadata.Email)
+
+	// a user can only be provisioned once per organisation
+	err = repo.CreateSCIMUser(context.Background(), &datastore.SCIMUser{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		UserID:         user.UID,
+		Active:         true,
+	})
+	require.Error(t, err)
+}
+
+func TestUpdateSCIMUser(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSCIMUserRepo(db)
+	org := seedOrg(t, db)
+	user := seedUser(t, db)
+
+	s := seedSCIMUser(t, repo, org, user, "okta-1")
+
+	s.ExternalID = "okta-2"
+	s.Active = false
+	require.NoError(t, repo.UpdateSCIMUser(context.Background(), s))
+
+	dbUser, err := repo.FetchSCIMUserByUserID(context.Background(), user.UID, org.UID)
+	require.NoError(t, err)
+	require.Equal(t, "okta-2", dbUser.ExternalID)
+	require.False(t, dbUser.Active)
+}
+
+func TestDeleteSCIMUser(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSCIMUserRepo(db)
+	org := seedOrg(t, db)
+	user := seedUser(t, db)
+
+	s := seedSCIMUser(t, repo, org, user, "okta-1")
+
+	require.NoError(t, repo.DeleteSCIMUser(context.Background(), s.UID, org.UID))
+
+	_, err := repo.FetchSCIMUserByID(context.Background(), s.UID, org.UID)
+	require.ErrorIs(t, err, datastore.ErrSCIMUserNotFound)
+}
+
+func TestLoadSCIMUsers(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSCIMUserRepo(db)
+	userRepo := NewUserRepo(db)
+	org := seedOrg(t, db)
+
+	var users []*datastore.User
+	for i := 0; i < 3; i++ {
+		user := generateUser(t)
+		require.NoError(t, userRepo.CreateUser(context.Background(), user))
+		seedSCIMUser(t, repo, org, user, ulid.Make().String())
+		users = append(users, user)
+	}
+
+	all, count, err := repo.LoadSCIMUsers(context.Background(), org.UID, &datastore.SCIMUserFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+	require.Len(t, all, 2)
+
+	filtered, count, err := repo.LoadSCIMUsers(context.Background(), org.UID, &datastore.SCIMUserFilter{Email: users[1].Email, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	require.Equal(t, users[1].UID, filtered[0].UserID)
+}
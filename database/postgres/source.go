@@ -18,17 +18,33 @@ import (
 const (
 	createSource = `
     INSERT INTO convoy.sources (id,source_verifier_id,name,type,mask_id,provider,is_disabled,forward_headers,project_id,
-                                pub_sub,custom_response_body,custom_response_content_type,idempotency_keys, body_function, header_function)
-    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15);
+                                pub_sub,custom_response_body,custom_response_content_type,idempotency_keys, body_function, header_function,
+                                change_stream, synchronous, rest_api, converters, dedup_window,
+                                is_encrypted, pub_sub_cipher, change_stream_cipher, rest_api_cipher, key_version)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,CASE WHEN $21 THEN NULL ELSE $10::jsonb END,$11,$12,$13,$14,$15,
+            CASE WHEN $21 THEN NULL ELSE $16::jsonb END,$17,CASE WHEN $21 THEN NULL ELSE $18::jsonb END,$19,$20,
+            $21, CASE WHEN $21 THEN convoy.keyring_encrypt($10::jsonb::TEXT, convoy.keyring_version($22), $22) END,
+            CASE WHEN $21 THEN convoy.keyring_encrypt($16::jsonb::TEXT, convoy.keyring_version($22), $22) END,
+            CASE WHEN $21 THEN convoy.keyring_encrypt($18::jsonb::TEXT, convoy.keyring_version($22), $22) END,
+            CASE WHEN $21 THEN convoy.keyring_version($22) END);
     `
 
 	createSourceVerifier = `
     INSERT INTO convoy.source_verifiers (
         id,type,basic_username,basic_password,
         api_key_header_name,api_key_header_value,
-        hmac_hash,hmac_header,hmac_secret,hmac_encoding
+        hmac_hash,hmac_header,hmac_secret,hmac_encoding,
+        jwt_header,jwt_jwks_url,jwt_public_keys,jwt_issuer,jwt_audience,
+        hmac_timestamp_header,hmac_tolerance,
+        is_encrypted,basic_password_cipher,api_key_header_value_cipher,hmac_secret_cipher,key_version
     )
-    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10);
+    VALUES ($1,$2,$3,CASE WHEN $18 THEN NULL ELSE $4 END,$5,CASE WHEN $18 THEN NULL ELSE $6 END,
+            $7,$8,CASE WHEN $18 THEN NULL ELSE $9 END,$10,$11,$12,$13,$14,$15,$16,$17,
+            $18,
+            CASE WHEN $18 THEN convoy.keyring_encrypt($4::TEXT, convoy.keyring_version($19), $19) END,
+            CASE WHEN $18 THEN convoy.keyring_encrypt($6::TEXT, convoy.keyring_version($19), $19) END,
+            CASE WHEN $18 THEN convoy.keyring_encrypt($9::TEXT, convoy.keyring_version($19), $19) END,
+            CASE WHEN $18 THEN convoy.keyring_version($19) END);
     `
 
 	updateSourceById = `
@@ -40,12 +56,35 @@ const (
 	is_disabled=$6,
 	forward_headers=$7,
 	project_id =$8,
-	pub_sub= $9,
+	pub_sub = CASE
+        WHEN is_encrypted THEN NULL
+        ELSE $9::jsonb
+    END,
+	pub_sub_cipher = CASE
+        WHEN is_encrypted THEN convoy.keyring_encrypt($9::jsonb::TEXT, key_version, $20)
+    END,
 	custom_response_body = $10,
 	custom_response_content_type = $11,
 	idempotency_keys = $12,
 	body_function = $13,
 	header_function = $14,
+	change_stream = CASE
+        WHEN is_encrypted THEN NULL
+        ELSE $15::jsonb
+    END,
+	change_stream_cipher = CASE
+        WHEN is_encrypted THEN convoy.keyring_encrypt($15::jsonb::TEXT, key_version, $20)
+    END,
+	synchronous = $16,
+	rest_api = CASE
+        WHEN is_encrypted THEN NULL
+        ELSE $17::jsonb
+    END,
+	rest_api_cipher = CASE
+        WHEN is_encrypted THEN convoy.keyring_encrypt($17::jsonb::TEXT, key_version, $20)
+    END,
+	converters = $18,
+	dedup_window = $19,
 	updated_at = NOW()
 	WHERE id = $1 AND deleted_at IS NULL ;
 	`
@@ -54,13 +93,23 @@ const (
 	UPDATE convoy.source_verifiers SET
         type=$2,
         basic_username=$3,
-        basic_password=$4,
+        basic_password = CASE WHEN is_encrypted THEN NULL ELSE $4 END,
+        basic_password_cipher = CASE WHEN is_encrypted THEN convoy.keyring_encrypt($4::TEXT, key_version, $18) END,
         api_key_header_name=$5,
-        api_key_header_value=$6,
+        api_key_header_value = CASE WHEN is_encrypted THEN NULL ELSE $6 END,
+        api_key_header_value_cipher = CASE WHEN is_encrypted THEN convoy.keyring_encrypt($6::TEXT, key_version, $18) END,
         hmac_hash=$7,
         hmac_header=$8,
-        hmac_secret=$9,
+        hmac_secret = CASE WHEN is_encrypted THEN NULL ELSE $9 END,
+        hmac_secret_cipher = CASE WHEN is_encrypted THEN convoy.keyring_encrypt($9::TEXT, key_version, $18) END,
         hmac_encoding=$10,
+        jwt_header=$11,
+        jwt_jwks_url=$12,
+        jwt_public_keys=$13,
+        jwt_issuer=$14,
+        jwt_audience=$15,
+        hmac_timestamp_header=$16,
+        hmac_tolerance=$17,
 		updated_at = NOW()
 	WHERE id = $1 AND deleted_at IS NULL;
 	`
@@ -70,27 +119,58 @@ const (
 		s.id,
 		s.name,
 		s.type,
-		s.pub_sub,
+		CASE
+			WHEN s.is_encrypted THEN convoy.keyring_decrypt(s.pub_sub_cipher::bytea, s.key_version, :encryption_key)::jsonb
+			ELSE s.pub_sub
+		END AS pub_sub,
+		CASE
+			WHEN s.is_encrypted THEN convoy.keyring_decrypt(s.change_stream_cipher::bytea, s.key_version, :encryption_key)::jsonb
+			ELSE s.change_stream
+		END AS change_stream,
+		CASE
+			WHEN s.is_encrypted THEN convoy.keyring_decrypt(s.rest_api_cipher::bytea, s.key_version, :encryption_key)::jsonb
+			ELSE s.rest_api
+		END AS rest_api,
+		s.poll_state,
 		s.mask_id,
 		s.provider,
 		s.is_disabled,
 		s.forward_headers,
 		s.idempotency_keys,
+		s.converters,
+		s.dedup_window,
 		s.project_id,
 		s.body_function,
 		s.header_function,
+		s.synchronous,
 		COALESCE(s.source_verifier_id, '') AS source_verifier_id,
 		COALESCE(s.custom_response_body, '') AS "custom_response.body",
 		COALESCE(s.custom_response_content_type, '') AS "custom_response.content_type",
 		COALESCE(sv.type, '') AS "verifier.type",
 		COALESCE(sv.basic_username, '') AS "verifier.basic_auth.username",
-		COALESCE(sv.basic_password, '') AS "verifier.basic_auth.password",
+		CASE
+			WHEN sv.is_encrypted THEN COALESCE(convoy.keyring_decrypt(sv.basic_password_cipher::bytea, sv.key_version, :encryption_key), '')
+			ELSE COALESCE(sv.basic_password, '')
+		END AS "verifier.basic_auth.password",
         COALESCE(sv.api_key_header_name, '') AS "verifier.api_key.header_name",
-        COALESCE(sv.api_key_header_value, '') AS "verifier.api_key.header_value",
+		CASE
+			WHEN sv.is_encrypted THEN COALESCE(convoy.keyring_decrypt(sv.api_key_header_value_cipher::bytea, sv.key_version, :encryption_key), '')
+			ELSE COALESCE(sv.api_key_header_value, '')
+		END AS "verifier.api_key.header_value",
         COALESCE(sv.hmac_hash, '') AS "verifier.hmac.hash",
         COALESCE(sv.hmac_header, '') AS "verifier.hmac.header",
-        COALESCE(sv.hmac_secret, '') AS "verifier.hmac.secret",
+		CASE
+			WHEN sv.is_encrypted THEN COALESCE(convoy.keyring_decrypt(sv.hmac_secret_cipher::bytea, sv.key_version, :encryption_key), '')
+			ELSE COALESCE(sv.hmac_secret, '')
+		END AS "verifier.hmac.secret",
         COALESCE(sv.hmac_encoding, '') AS "verifier.hmac.encoding",
+        COALESCE(sv.hmac_timestamp_header, '') AS "verifier.hmac.timestamp_header",
+        COALESCE(sv.hmac_tolerance, 0) AS "verifier.hmac.tolerance",
+        COALESCE(sv.jwt_header, '') AS "verifier.jwt.header",
+        COALESCE(sv.jwt_jwks_url, '') AS "verifier.jwt.jwks_url",
+        COALESCE(sv.jwt_public_keys, '') AS "verifier.jwt.public_keys",
+        COALESCE(sv.jwt_issuer, '') AS "verifier.jwt.issuer",
+        COALESCE(sv.jwt_audience, '') AS "verifier.jwt.audience",
 		s.created_at,
 		s.updated_at
 	FROM convoy.sources AS s
@@ -103,7 +183,14 @@ const (
 	    id,
 		name,
 		type,
-		pub_sub,
+		CASE
+			WHEN is_encrypted THEN convoy.keyring_decrypt(pub_sub_cipher::bytea, key_version, :encryption_key)::jsonb
+			ELSE pub_sub
+		END AS pub_sub,
+		CASE
+			WHEN is_encrypted THEN convoy.keyring_decrypt(change_stream_cipher::bytea, key_version, :encryption_key)::jsonb
+			ELSE change_stream
+		END AS change_stream,
 		mask_id,
 		provider,
 		is_disabled,
@@ -115,7 +202,7 @@ const (
 		created_at,
 		updated_at
 	FROM convoy.sources
-	WHERE type = '%s' AND project_id IN (:project_ids) AND deleted_at IS NULL
+	WHERE type IN ('%s', '%s') AND project_id IN (:project_ids) AND deleted_at IS NULL
 	AND (id <= :cursor OR :cursor = '')
     ORDER BY id DESC
     LIMIT :limit
@@ -177,8 +264,15 @@ const (
 )
 
 var (
-	fetchSource       = baseFetchSource + ` AND %s = $1;`
-	fetchSourceByName = baseFetchSource + ` AND %s = $1 AND %s = $2;`
+	fetchSource = baseFetchSource + ` AND %s = :value;`
+
+	fetchRestApiSources = baseFetchSource + ` AND s.type = 'rest_api' AND s.is_disabled = false;`
+
+	updateSourcePollState = `
+	UPDATE convoy.sources SET poll_state = $3
+	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+	fetchSourceByName = baseFetchSource + ` AND s.project_id = :project_id AND s.name = :name;`
 )
 
 var (
@@ -192,6 +286,11 @@ type sourceRepo struct {
 	db database.Database
 }
 
+const (
+	sourcesTable         = "sources"
+	sourceVerifiersTable = "source_verifiers"
+)
+
 func NewSourceRepo(db database.Database) datastore.SourceRepository {
 	return &sourceRepo{db: db}
 }
@@ -204,10 +303,16 @@ func (s *sourceRepo) CreateSource(ctx context.Context, source *datastore.Source)
 	}
 	defer rollbackTx(tx)
 
+	key, err := currentKeyRing(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
 	var (
 		hmac   datastore.HMac
 		basic  datastore.BasicAuth
 		apiKey datastore.ApiKey
+		jwt    datastore.JWTAuth
 	)
 
 	switch source.Verifier.Type {
@@ -217,18 +322,27 @@ func (s *sourceRepo) CreateSource(ctx context.Context, source *datastore.Source)
 		basic = *source.Verifier.BasicAuth
 	case datastore.HMacVerifier:
 		hmac = *source.Verifier.HMac
+	case datastore.JWTVerifier:
+		jwt = *source.Verifier.JWT
 	}
 
 	if !util.IsStringEmpty(string(source.Verifier.Type)) {
 		id := ulid.Make().String()
 		sourceVerifierID = &id
 
+		isEncrypted, err := isTableEncrypted(ctx, s.db, sourceVerifiersTable)
+		if err != nil {
+			return err
+		}
+
 		result2, err := tx.ExecContext(
 			ctx, createSourceVerifier, sourceVerifierID, source.Verifier.Type, basic.UserName, basic.Password,
 			apiKey.HeaderName, apiKey.HeaderValue, hmac.Hash, hmac.Header, hmac.Secret, hmac.Encoding,
+			jwt.Header, jwt.JWKSURL, jwt.PublicKeys, jwt.Issuer, jwt.Audience,
+			hmac.TimestampHeader, hmac.Tolerance, isEncrypted, key,
 		)
 		if err != nil {
-			return err
+			return encryptionError(ctx, s.db, sourceVerifiersTable, err)
 		}
 
 		rowsAffected, err := result2.RowsAffected()
@@ -245,14 +359,21 @@ func (s *sourceRepo) CreateSource(ctx context.Context, source *datastore.Source)
 		source.VerifierID = *sourceVerifierID
 	}
 
+	isEncrypted, err := isTableEncrypted(ctx, s.db, sourcesTable)
+	if err != nil {
+		return err
+	}
+
 	result1, err := tx.ExecContext(
 		ctx, createSource, source.UID, sourceVerifierID, source.Name, source.Type, source.MaskID,
 		source.Provider, source.IsDisabled, pq.Array(source.ForwardHeaders), source.ProjectID,
 		source.PubSub, source.CustomResponse.Body, source.CustomResponse.ContentType,
-		source.IdempotencyKeys, source.BodyFunction, source.HeaderFunction,
+		source.IdempotencyKeys, source.BodyFunction, source.HeaderFunction, source.ChangeStream,
+		source.Synchronous, source.RestApi, source.Converters, source.DedupWindow,
+		isEncrypted, key,
 	)
 	if err != nil {
-		return err
+		return encryptionError(ctx, s.db, sourcesTable, err)
 	}
 
 	rowsAffected, err := result1.RowsAffected()
@@ -279,14 +400,20 @@ func (s *sourceRepo) UpdateSource(ctx context.Context, projectID string, source
 	}
 	defer rollbackTx(tx)
 
+	key, err := currentKeyRing(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
 	result, err := tx.ExecContext(
 		ctx, updateSourceById, source.UID, source.Name, source.Type, source.MaskID,
 		source.Provider, source.IsDisabled, source.ForwardHeaders, projectID,
 		source.PubSub, source.CustomResponse.Body, source.CustomResponse.ContentType,
-		source.IdempotencyKeys, source.BodyFunction, source.HeaderFunction,
+		source.IdempotencyKeys, source.BodyFunction, source.HeaderFunction, source.ChangeStream,
+		source.Synchronous, source.RestApi, source.Converters, source.DedupWindow, key,
 	)
 	if err != nil {
-		return err
+		return encryptionError(ctx, s.db, sourcesTable, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -301,6 +428,7 @@ func (s *sourceRepo) UpdateSource(ctx context.Context, projectID string, source
 		hmac   datastore.HMac
 		basic  datastore.BasicAuth
 		apiKey datastore.ApiKey
+		jwt    datastore.JWTAuth
 	)
 
 	switch source.Verifier.Type {
@@ -310,15 +438,19 @@ func (s *sourceRepo) UpdateSource(ctx context.Context, projectID string, source
 		basic = *source.Verifier.BasicAuth
 	case datastore.HMacVerifier:
 		hmac = *source.Verifier.HMac
+	case datastore.JWTVerifier:
+		jwt = *source.Verifier.JWT
 	}
 
 	if !util.IsStringEmpty(string(source.Verifier.Type)) {
 		result2, err := tx.ExecContext(
 			ctx, updateSourceVerifierById, source.VerifierID, source.Verifier.Type, basic.UserName, basic.Password,
 			apiKey.HeaderName, apiKey.HeaderValue, hmac.Hash, hmac.Header, hmac.Secret, hmac.Encoding,
+			jwt.Header, jwt.JWKSURL, jwt.PublicKeys, jwt.Issuer, jwt.Audience,
+			hmac.TimestampHeader, hmac.Tolerance, key,
 		)
 		if err != nil {
-			return err
+			return encryptionError(ctx, s.db, sourceVerifiersTable, err)
 		}
 
 		rowsAffected, err = result2.RowsAffected()
@@ -340,39 +472,36 @@ func (s *sourceRepo) UpdateSource(ctx context.Context, projectID string, source
 }
 
 func (s *sourceRepo) FindSourceByID(ctx context.Context, projectId string, id string) (*datastore.Source, error) {
-	source := &datastore.Source{}
-	err := s.db.GetDB().QueryRowxContext(ctx, fmt.Sprintf(fetchSource, "s.id"), id).StructScan(source)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, datastore.ErrSourceNotFound
-		}
-		return nil, err
-	}
-
-	return source, nil
+	return s.findSource(ctx, fmt.Sprintf(fetchSource, "s.id"), map[string]interface{}{"value": id})
 }
 
 func (s *sourceRepo) FindSourceByName(ctx context.Context, projectID string, name string) (*datastore.Source, error) {
-	source := &datastore.Source{}
-	err := s.db.GetDB().QueryRowxContext(ctx, fmt.Sprintf(fetchSourceByName, "s.project_id", "s.name"), projectID, name).StructScan(source)
+	return s.findSource(ctx, fetchSourceByName, map[string]interface{}{"project_id": projectID, "name": name})
+}
+
+func (s *sourceRepo) FindSourceByMaskID(ctx context.Context, maskID string) (*datastore.Source, error) {
+	return s.findSource(ctx, fmt.Sprintf(fetchSource, "s.mask_id"), map[string]interface{}{"value": maskID})
+}
+
+func (s *sourceRepo) findSource(ctx context.Context, query string, arg map[string]interface{}) (*datastore.Source, error) {
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, datastore.ErrSourceNotFound
-		}
 		return nil, err
 	}
+	arg["encryption_key"] = key
 
-	return source, nil
-}
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *sourceRepo) FindSourceByMaskID(ctx context.Context, maskID string) (*datastore.Source, error) {
 	source := &datastore.Source{}
-	err := s.db.GetDB().QueryRowxContext(ctx, fmt.Sprintf(fetchSource, "s.mask_id"), maskID).StructScan(source)
+	err = s.db.GetDB().QueryRowxContext(ctx, s.db.GetDB().Rebind(query), args...).StructScan(source)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, datastore.ErrSourceNotFound
 		}
-		return nil, err
+		return nil, encryptionError(ctx, s.db, sourcesTable, err)
 	}
 
 	return source, nil
@@ -409,13 +538,19 @@ func (s *sourceRepo) DeleteSourceByID(ctx context.Context, projectId string, id,
 }
 
 func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, projectID string, filter *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
+	key, err := currentKeyRing(ctx, s.db)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
 	arg := map[string]interface{}{
-		"type":       filter.Type,
-		"provider":   filter.Provider,
-		"project_id": projectID,
-		"limit":      pageable.Limit(),
-		"cursor":     pageable.Cursor(),
-		"query":      "%" + filter.Query + "%",
+		"type":           filter.Type,
+		"provider":       filter.Provider,
+		"project_id":     projectID,
+		"limit":          pageable.Limit(),
+		"cursor":         pageable.Cursor(),
+		"query":          "%" + filter.Query + "%",
+		"encryption_key": key,
 	}
 
 	var query string
@@ -503,13 +638,19 @@ func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, projectID string, fil
 }
 
 func (s *sourceRepo) LoadPubSubSourcesByProjectIDs(ctx context.Context, projectIDs []string, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
+	key, err := currentKeyRing(ctx, s.db)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
 	arg := map[string]interface{}{
-		"project_ids": projectIDs,
-		"limit":       pageable.Limit(),
-		"cursor":      pageable.Cursor(),
+		"project_ids":    projectIDs,
+		"limit":          pageable.Limit(),
+		"cursor":         pageable.Cursor(),
+		"encryption_key": key,
 	}
 
-	query := fmt.Sprintf(fetchPubSubSources, datastore.PubSubSource)
+	query := fmt.Sprintf(fetchPubSubSources, datastore.PubSubSource, datastore.DBChangeStream)
 
 	query, args, err := sqlx.Named(query, arg)
 	if err != nil {
@@ -557,3 +698,52 @@ func (s *sourceRepo) LoadPubSubSourcesByProjectIDs(ctx context.Context, projectI
 
 	return sources, *pagination, nil
 }
+
+func (s *sourceRepo) LoadRestApiSources(ctx context.Context) ([]datastore.Source, error) {
+	key, err := currentKeyRing(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sqlx.Named(fetchRestApiSources, map[string]interface{}{"encryption_key": key})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.GetReadDB().QueryxContext(ctx, s.db.GetReadDB().Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeWithError(rows)
+
+	sources := make([]datastore.Source, 0)
+	for rows.Next() {
+		var source datastore.Source
+		err = rows.StructScan(&source)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+func (s *sourceRepo) UpdateSourcePollState(ctx context.Context, projectID string, id string, state *datastore.PollState) error {
+	result, err := s.db.GetDB().ExecContext(ctx, updateSourcePollState, id, projectID, state)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSourceNotUpdated
+	}
+
+	return nil
+}
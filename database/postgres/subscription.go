@@ -7,10 +7,8 @@ import (
 	"fmt"
 	"github.com/frain-dev/convoy/database"
 	"github.com/frain-dev/convoy/datastore"
-	"github.com/frain-dev/convoy/internal/pkg/keys"
 	"github.com/frain-dev/convoy/pkg/compare"
 	"github.com/frain-dev/convoy/pkg/flatten"
-	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
 	"github.com/jmoiron/sqlx"
 	"github.com/oklog/ulid/v2"
@@ -88,7 +86,7 @@ const (
 
 	CASE
     WHEN em.is_encrypted THEN
-        COALESCE(pgp_sym_decrypt(em.secrets_cipher::bytea, '%s')::jsonb, '[]'::jsonb)
+        COALESCE(convoy.keyring_decrypt(em.secrets_cipher::bytea, em.key_version, '%[1]s')::jsonb, '[]'::jsonb)
     ELSE
         COALESCE(em.secrets, '[]'::jsonb)
     END AS "endpoint_metadata.secrets",
@@ -113,12 +111,21 @@ const (
 
 	COALESCE(sv.type, '') AS "source_metadata.verifier.type",
 	COALESCE(sv.basic_username, '') AS "source_metadata.verifier.basic_auth.username",
-	COALESCE(sv.basic_password, '') AS "source_metadata.verifier.basic_auth.password",
+	CASE
+    WHEN sv.is_encrypted THEN COALESCE(convoy.keyring_decrypt(sv.basic_password_cipher::bytea, sv.key_version, '%[1]s'), '')
+    ELSE COALESCE(sv.basic_password, '')
+    END AS "source_metadata.verifier.basic_auth.password",
 	COALESCE(sv.api_key_header_name, '') AS "source_metadata.verifier.api_key.header_name",
-	COALESCE(sv.api_key_header_value, '') AS "source_metadata.verifier.api_key.header_value",
+	CASE
+    WHEN sv.is_encrypted THEN COALESCE(convoy.keyring_decrypt(sv.api_key_header_value_cipher::bytea, sv.key_version, '%[1]s'), '')
+    ELSE COALESCE(sv.api_key_header_value, '')
+    END AS "source_metadata.verifier.api_key.header_value",
 	COALESCE(sv.hmac_hash, '') AS "source_metadata.verifier.hmac.hash",
 	COALESCE(sv.hmac_header, '') AS "source_metadata.verifier.hmac.header",
-	COALESCE(sv.hmac_secret, '') AS "source_metadata.verifier.hmac.secret",
+	CASE
+    WHEN sv.is_encrypted THEN COALESCE(convoy.keyring_decrypt(sv.hmac_secret_cipher::bytea, sv.key_version, '%[1]s'), '')
+    ELSE COALESCE(sv.hmac_secret, '')
+    END AS "source_metadata.verifier.hmac.secret",
 	COALESCE(sv.hmac_encoding, '') AS "source_metadata.verifier.hmac.encoding"
 
 	FROM convoy.subscriptions s
@@ -285,15 +292,10 @@ var (
 
 type subscriptionRepo struct {
 	db database.Database
-	km keys.KeyManager
 }
 
 func NewSubscriptionRepo(db database.Database) datastore.SubscriptionRepository {
-	km, err := keys.Get()
-	if err != nil {
-		log.Fatal(err)
-	}
-	return &subscriptionRepo{db: db, km: km}
+	return &subscriptionRepo{db: db}
 }
 
 func (s *subscriptionRepo) FetchUpdatedSubscriptions(ctx context.Context, projectIDs []string, t time.Time, pageSize int64) ([]datastore.Subscription, error) {
@@ -463,7 +465,7 @@ func (s *subscriptionRepo) CreateSubscription(ctx context.Context, projectID str
 	if projectID != subscription.ProjectID {
 		return datastore.ErrNotAuthorisedToAccessDocument
 	}
-	key, err := s.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
 		return err
 	}
@@ -532,7 +534,7 @@ func (s *subscriptionRepo) CreateSubscription(ctx context.Context, projectID str
 	}
 
 	_subscription := &datastore.Subscription{}
-	err = tx.QueryRowxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, key, "s.id", "s.project_id"), subscription.UID, projectID).StructScan(_subscription)
+	err = tx.QueryRowxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, quoteKeyRing(key), "s.id", "s.project_id"), subscription.UID, projectID).StructScan(_subscription)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return datastore.ErrSubscriptionNotFound
@@ -574,7 +576,7 @@ func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, projectID str
 	fc := subscription.GetFilterConfig()
 	rlc := subscription.GetRateLimitConfig()
 
-	key, err := s.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
 		return err
 	}
@@ -629,7 +631,7 @@ func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, projectID str
 	}
 
 	_subscription := &datastore.Subscription{}
-	err = tx.QueryRowxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, key, "s.id", "s.project_id"), subscription.UID, projectID).StructScan(_subscription)
+	err = tx.QueryRowxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, quoteKeyRing(key), "s.id", "s.project_id"), subscription.UID, projectID).StructScan(_subscription)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return datastore.ErrSubscriptionNotFound
@@ -693,12 +695,12 @@ func (s *subscriptionRepo) LoadSubscriptionsPaged(ctx context.Context, projectID
 		filterQuery += ` AND s.name LIKE :name`
 	}
 
-	key, err := s.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
 		return nil, datastore.PaginationData{}, err
 	}
 
-	query = fmt.Sprintf(query, fmt.Sprintf(baseFetchSubscription, key), filterQuery)
+	query = fmt.Sprintf(query, fmt.Sprintf(baseFetchSubscription, quoteKeyRing(key)), filterQuery)
 
 	query, args, err := sqlx.Named(query, arg)
 	if err != nil {
@@ -796,11 +798,11 @@ func (s *subscriptionRepo) DeleteSubscription(ctx context.Context, projectID str
 
 func (s *subscriptionRepo) FindSubscriptionByID(ctx context.Context, projectID string, subscriptionID string) (*datastore.Subscription, error) {
 	subscription := &datastore.Subscription{}
-	key, err := s.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
-	err = s.db.GetDB().QueryRowxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, key, "s.id", "s.project_id"), subscriptionID, projectID).StructScan(subscription)
+	err = s.db.GetDB().QueryRowxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, quoteKeyRing(key), "s.id", "s.project_id"), subscriptionID, projectID).StructScan(subscription)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, datastore.ErrSubscriptionNotFound
@@ -814,11 +816,11 @@ func (s *subscriptionRepo) FindSubscriptionByID(ctx context.Context, projectID s
 }
 
 func (s *subscriptionRepo) FindSubscriptionsBySourceID(ctx context.Context, projectID string, sourceID string) ([]datastore.Subscription, error) {
-	key, err := s.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := s.db.GetDB().QueryxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, key, "s.project_id", "s.source_id"), projectID, sourceID)
+	rows, err := s.db.GetDB().QueryxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, quoteKeyRing(key), "s.project_id", "s.source_id"), projectID, sourceID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, datastore.ErrSubscriptionNotFound
@@ -831,11 +833,11 @@ func (s *subscriptionRepo) FindSubscriptionsBySourceID(ctx context.Context, proj
 }
 
 func (s *subscriptionRepo) FindSubscriptionsByEndpointID(ctx context.Context, projectId string, endpointID string) ([]datastore.Subscription, error) {
-	key, err := s.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := s.db.GetDB().QueryxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, key, "s.project_id", "s.endpoint_id"), projectId, endpointID)
+	rows, err := s.db.GetDB().QueryxContext(ctx, fmt.Sprintf(fetchSubscriptionByID, quoteKeyRing(key), "s.project_id", "s.endpoint_id"), projectId, endpointID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, datastore.ErrSubscriptionNotFound
@@ -864,11 +866,11 @@ func (s *subscriptionRepo) FindSubscriptionByDeviceID(ctx context.Context, proje
 }
 
 func (s *subscriptionRepo) FindCLISubscriptions(ctx context.Context, projectID string) ([]datastore.Subscription, error) {
-	key, err := s.km.GetCurrentKeyFromCache()
+	key, err := currentKeyRing(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := s.db.GetReadDB().QueryxContext(ctx, fmt.Sprintf(fetchCLISubscriptions, key, "s.project_id", "s.type"), projectID, datastore.SubscriptionTypeCLI)
+	rows, err := s.db.GetReadDB().QueryxContext(ctx, fmt.Sprintf(fetchCLISubscriptions, quoteKeyRing(key), "s.project_id", "s.type"), projectID, datastore.SubscriptionTypeCLI)
 	if err != nil {
 		return nil, err
 	}
@@ -16,8 +16,9 @@ const (
     INSERT INTO convoy.users (
 		id,first_name,last_name,email,password,
         email_verified,reset_password_token, email_verification_token,
-        reset_password_expires_at,email_verification_expires_at, auth_type)
-    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+        reset_password_expires_at,email_verification_expires_at, auth_type,
+        provisioned_by, oidc_issuer, oidc_subject)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
     `
 
 	updateUser = `
@@ -30,7 +31,9 @@ const (
          reset_password_token=$7,
          email_verification_token=$8,
          reset_password_expires_at=$9,
-         email_verification_expires_at=$10
+         email_verification_expires_at=$10,
+         oidc_issuer=$11,
+         oidc_subject=$12
     WHERE id = $1 AND deleted_at IS NULL;
     `
 
@@ -72,6 +75,9 @@ func (u *userRepo) CreateUser(ctx context.Context, user *datastore.User) error {
 		user.ResetPasswordExpiresAt,
 		user.EmailVerificationExpiresAt,
 		user.AuthType,
+		user.ProvisionedBy,
+		user.OIDCIssuer,
+		user.OIDCSubject,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate") {
@@ -95,7 +101,7 @@ func (u *userRepo) CreateUser(ctx context.Context, user *datastore.User) error {
 func (u *userRepo) UpdateUser(ctx context.Context, user *datastore.User) error {
 	result, err := u.db.GetDB().Exec(
 		updateUser, user.UID, user.FirstName, user.LastName, user.Email, user.Password, user.EmailVerified, user.ResetPasswordToken,
-		user.EmailVerificationToken, user.ResetPasswordExpiresAt, user.EmailVerificationExpiresAt,
+		user.EmailVerificationToken, user.ResetPasswordExpiresAt, user.EmailVerificationExpiresAt, user.OIDCIssuer, user.OIDCSubject,
 	)
 	if err != nil {
 		return err
@@ -165,6 +171,19 @@ func (u *userRepo) FindUserByEmailVerificationToken(ctx context.Context, token s
 	return user, nil
 }
 
+func (u *userRepo) FindUserByOIDCSubject(ctx context.Context, issuer, subject string) (*datastore.User, error) {
+	user := &datastore.User{}
+	err := u.db.GetDB().QueryRowxContext(ctx, fmt.Sprintf("%s AND oidc_issuer = $1 AND oidc_subject = $2;", fetchUsers), issuer, subject).StructScan(user)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
 func (o *userRepo) CountUsers(ctx context.Context) (int64, error) {
 	var count int64
 	err := o.db.GetReadDB().GetContext(ctx, &count, countUsers)
@@ -36,6 +36,23 @@ type ApiKeyFilter struct {
 	KeyType     KeyType
 }
 
+type DeadLetterFilter struct {
+	IDs          []string
+	EndpointIDs  []string
+	EventType    string
+	Status       DeadLetterStatus
+	SearchParams SearchParams
+}
+
+type AuditLogFilter struct {
+	ProjectID    string
+	ActorID      string
+	ResourceType AuditResourceType
+	ResourceID   string
+	Action       AuditAction
+	SearchParams SearchParams
+}
+
 type FilterBy struct {
 	OwnerID          string
 	EndpointID       string
@@ -1,8 +1,10 @@
 package datastore
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +13,7 @@ import (
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/frain-dev/convoy/pkg/flatten"
 
@@ -182,16 +185,50 @@ const (
 	EventDeliveryUpdated HookEventType = "eventdelivery.updated"
 	EventDeliverySuccess HookEventType = "eventdelivery.success"
 	EventDeliveryFailed  HookEventType = "eventdelivery.failed"
+	AuditLogCreated      HookEventType = "audit_log.created"
 )
 
 const (
 	GithubSourceProvider  SourceProvider = "github"
 	TwitterSourceProvider SourceProvider = "twitter"
 	ShopifySourceProvider SourceProvider = "shopify"
+	StripeSourceProvider  SourceProvider = "stripe"
+	SlackSourceProvider   SourceProvider = "slack"
+	TwilioSourceProvider  SourceProvider = "twilio"
+
+	// StandardWebhooksSourceProvider verifies requests signed following the
+	// Standard Webhooks spec, e.g. webhooks sent through Svix.
+	StandardWebhooksSourceProvider SourceProvider = "standard_webhooks"
 )
 
 const (
 	APIKeyAuthentication EndpointAuthenticationType = "api_key"
+	OAuth2Authentication EndpointAuthenticationType = "oauth2"
+)
+
+// EndpointBodyEncoding is how the event payload is written into the
+// body of an event delivery request.
+type EndpointBodyEncoding string
+
+const (
+	// JSONBodyEncoding sends the payload as is.
+	JSONBodyEncoding EndpointBodyEncoding = "json"
+	// FormBodyEncoding sends the top level fields of the payload as form values.
+	FormBodyEncoding EndpointBodyEncoding = "form"
+	// CloudEventsBodyEncoding wraps the payload in a structured mode CloudEvent.
+	CloudEventsBodyEncoding EndpointBodyEncoding = "cloudevents"
+	// RawBodyEncoding sends string payloads without JSON quoting, this
+	// lets subscription functions produce non-JSON bodies.
+	RawBodyEncoding EndpointBodyEncoding = "raw"
+	// OriginalBodyEncoding sends the body events were ingested with unchanged,
+	// with its original content type. Events that weren't ingested from a
+	// request are sent as json.
+	OriginalBodyEncoding EndpointBodyEncoding = "original"
+)
+
+const (
+	DefaultBatchSize   = 100
+	DefaultBatchWindow = 1000 // milliseconds
 )
 
 const (
@@ -203,7 +240,8 @@ const (
 
 func (s SourceProvider) IsValid() bool {
 	switch s {
-	case GithubSourceProvider, TwitterSourceProvider, ShopifySourceProvider:
+	case GithubSourceProvider, TwitterSourceProvider, ShopifySourceProvider,
+		StripeSourceProvider, SlackSourceProvider, TwilioSourceProvider, StandardWebhooksSourceProvider:
 		return true
 	}
 	return false
@@ -222,6 +260,7 @@ const (
 	HMacVerifier      VerifierType = "hmac"
 	BasicAuthVerifier VerifierType = "basic_auth"
 	APIKeyVerifier    VerifierType = "api_key"
+	JWTVerifier       VerifierType = "jwt"
 )
 
 const (
@@ -339,6 +378,7 @@ var (
 func GetDefaultSignatureConfig() *SignatureConfiguration {
 	return &SignatureConfiguration{
 		Header: "X-Convoy-Signature",
+		Scheme: ConvoySignatureScheme,
 		Versions: []SignatureVersion{
 			{
 				UID:       ulid.Make().String(),
@@ -397,7 +437,13 @@ func (s Secrets) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
-	b, err := json.Marshal(s)
+	secrets := make(Secrets, len(s))
+	for i := range s {
+		secrets[i] = s[i]
+		secrets[i].StandardWebhooksValue = ""
+	}
+
+	b, err := json.Marshal(secrets)
 	if err != nil {
 		return nil, err
 	}
@@ -427,6 +473,42 @@ type Endpoint struct {
 	RateLimitDuration uint64  `json:"rate_limit_duration" db:"rate_limit_duration"`
 	FailureRate       float64 `json:"failure_rate" db:"-"`
 
+	// StatusCodePolicies take precedence over the project's retry strategy policies
+	StatusCodePolicies StatusCodePolicies `json:"status_code_policies" db:"status_code_policies"`
+
+	HttpMethod   string               `json:"http_method" db:"http_method"`
+	ContentType  string               `json:"content_type" db:"content_type"`
+	BodyEncoding EndpointBodyEncoding `json:"body_encoding" db:"body_encoding"`
+
+	// OrderedDelivery serializes event deliveries to the endpoint, a delivery is
+	// only sent after every delivery created before it succeeded, failed or was discarded.
+	OrderedDelivery bool `json:"ordered_delivery" db:"ordered_delivery"`
+
+	// OrderingKey scopes ordered delivery, deliveries with different keys don't
+	// block each other. It is either a path into the event payload, e.g.
+	// data.account_id, or a header name prefixed with header., e.g. header.X-Account-Id
+	OrderingKey string `json:"ordering_key" db:"ordering_key"`
+
+	// BatchDelivery coalesces event deliveries to the endpoint into one request with
+	// a JSON array body. A batch is sent once it has BatchSize deliveries or its
+	// oldest delivery has waited for BatchWindow milliseconds.
+	BatchDelivery bool   `json:"batch_delivery" db:"batch_delivery"`
+	BatchSize     uint64 `json:"batch_size" db:"batch_size"`
+	BatchWindow   uint64 `json:"batch_window" db:"batch_window"`
+
+	// MtlsClientCert is presented to the endpoint when it requires mutual TLS.
+	MtlsClientCert *MtlsClientCert `json:"mtls_client_cert,omitempty" db:"mtls_client_cert"`
+
+	// VerificationRequired keeps the endpoint pending until it answers the
+	// challenge sent to it with the challenge's HMAC, this is repeated whenever
+	// its url changes.
+	VerificationRequired bool      `json:"verification_required" db:"verification_required"`
+	VerifiedAt           null.Time `json:"verified_at,omitempty" db:"verified_at" swaggertype:"string"`
+
+	// HealthCheck periodically probes the endpoint and moves its status
+	// between active and inactive based on the results.
+	HealthCheck bool `json:"health_check" db:"health_check"`
+
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
@@ -442,6 +524,61 @@ func (e *Endpoint) FindSecret(secretID string) *Secret {
 	return nil
 }
 
+// DeliveryMethod returns the http method event deliveries are sent with.
+func (e *Endpoint) DeliveryMethod() string {
+	if isStringEmpty(e.HttpMethod) {
+		return string(convoy.HttpPost)
+	}
+
+	return e.HttpMethod
+}
+
+// DeliveryContentType returns the content type event deliveries are sent with,
+// it defaults to the content type of the endpoint's body encoding.
+func (e *Endpoint) DeliveryContentType() string {
+	if !isStringEmpty(e.ContentType) {
+		return e.ContentType
+	}
+
+	switch e.BodyEncoding {
+	case FormBodyEncoding:
+		return "application/x-www-form-urlencoded"
+	case CloudEventsBodyEncoding:
+		if e.BatchDelivery {
+			return "application/cloudevents-batch+json"
+		}
+		return "application/cloudevents+json"
+	case RawBodyEncoding:
+		return "application/octet-stream"
+	default:
+		return "application/json"
+	}
+}
+
+// BatchLimit returns the maximum number of event deliveries sent in one batch.
+func (e *Endpoint) BatchLimit() int {
+	if e.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+
+	return int(e.BatchSize)
+}
+
+// BatchWaitDuration returns how long a delivery waits for its batch to fill up.
+func (e *Endpoint) BatchWaitDuration() time.Duration {
+	if e.BatchWindow == 0 {
+		return DefaultBatchWindow * time.Millisecond
+	}
+
+	return time.Duration(e.BatchWindow) * time.Millisecond
+}
+
+// AwaitingVerification reports whether the endpoint is still to answer its
+// verification challenge.
+func (e *Endpoint) AwaitingVerification() bool {
+	return e.VerificationRequired && !e.VerifiedAt.Valid
+}
+
 type EndpointConfig struct {
 	AdvancedSignatures bool                    `json:"advanced_signatures" db:"advanced_signatures"`
 	Secrets            []Secret                `json:"secrets" db:"secrets"`
@@ -454,6 +591,10 @@ func (e *Endpoint) GetAuthConfig() EndpointAuthentication {
 		if e.Authentication.ApiKey != nil {
 			return *e.Authentication
 		}
+
+		if e.Authentication.OAuth2 != nil {
+			return EndpointAuthentication{Type: e.Authentication.Type, ApiKey: &ApiKey{}, OAuth2: e.Authentication.OAuth2}
+		}
 	}
 
 	return EndpointAuthentication{ApiKey: &ApiKey{}}
@@ -472,6 +613,13 @@ type Secret struct {
 	UID   string `json:"uid" db:"id"`
 	Value string `json:"value" db:"value"`
 
+	// PublicKey is used to verify asymmetric standard webhooks signatures.
+	PublicKey string `json:"public_key,omitempty" db:"public_key"`
+
+	// StandardWebhooksValue is the secret in the whsec_ format Standard Webhooks
+	// libraries expect, it is only set on api responses and is never stored.
+	StandardWebhooksValue string `json:"standard_webhooks_value,omitempty" db:"-"`
+
 	ExpiresAt null.Time `json:"expires_at,omitempty" db:"expires_at,omitempty" swaggertype:"string"`
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
@@ -479,8 +627,75 @@ type Secret struct {
 }
 
 type EndpointAuthentication struct {
-	Type   EndpointAuthenticationType `json:"type,omitempty" db:"type" valid:"optional,in(api_key)~unsupported authentication type"`
+	Type   EndpointAuthenticationType `json:"type,omitempty" db:"type" valid:"optional,in(api_key|oauth2)~unsupported authentication type"`
 	ApiKey *ApiKey                    `json:"api_key" db:"api_key"`
+	OAuth2 *OAuth2                    `json:"oauth2,omitempty" db:"oauth2"`
+}
+
+// OAuth2 is an OAuth2 client credentials grant, the access tokens it issues
+// are sent to the endpoint as a bearer token.
+type OAuth2 struct {
+	TokenURL     string   `json:"token_url" valid:"required~please provide the oauth2 token url,url~please provide a valid oauth2 token url"`
+	ClientID     string   `json:"client_id" valid:"required~please provide the oauth2 client id"`
+	ClientSecret string   `json:"client_secret" valid:"required~please provide the oauth2 client secret"`
+	Scopes       []string `json:"scopes,omitempty"`
+	Audience     string   `json:"audience,omitempty"`
+}
+
+func (o *OAuth2) Scan(v interface{}) error {
+	b, ok := v.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(b, o)
+}
+
+func (o OAuth2) Value() (driver.Value, error) {
+	return json.Marshal(o)
+}
+
+// MtlsClientCert is a PEM encoded client certificate and key, with an optional CA
+// bundle used to verify the endpoint's server certificate. The key is never
+// returned in API responses.
+type MtlsClientCert struct {
+	ClientCert string `json:"client_cert"`
+	ClientKey  string `json:"-"`
+	CACert     string `json:"ca_cert,omitempty"`
+}
+
+// mtlsClientCert is how MtlsClientCert is stored, it includes the client key.
+type mtlsClientCert struct {
+	ClientCert string `json:"client_cert"`
+	ClientKey  string `json:"client_key"`
+	CACert     string `json:"ca_cert,omitempty"`
+}
+
+func (m *MtlsClientCert) Scan(v interface{}) error {
+	b, ok := v.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var c mtlsClientCert
+	if err := json.Unmarshal(b, &c); err != nil {
+		return err
+	}
+
+	*m = MtlsClientCert(c)
+	return nil
+}
+
+func (m MtlsClientCert) Value() (driver.Value, error) {
+	return json.Marshal(mtlsClientCert(m))
 }
 
 var (
@@ -488,6 +703,7 @@ var (
 	ErrDeviceNotFound    = errors.New("device not found")
 	ErrOrgInviteNotFound = errors.New("organisation invite not found")
 	ErrOrgMemberNotFound = errors.New("organisation member not found")
+	ErrSCIMUserNotFound  = errors.New("scim user not found")
 )
 
 type Project struct {
@@ -596,17 +812,89 @@ type RateLimitConfiguration struct {
 }
 
 type StrategyConfiguration struct {
-	Type       StrategyProvider `json:"type" db:"type" valid:"optional~please provide a valid strategy type, in(linear|exponential)~unsupported strategy type"`
-	Duration   uint64           `json:"duration" db:"duration" valid:"optional~please provide a valid duration in seconds,int"`
-	RetryCount uint64           `json:"retry_count" db:"retry_count" valid:"optional~please provide a valid retry count,int"`
+	Type               StrategyProvider   `json:"type" db:"type" valid:"optional~please provide a valid strategy type, in(linear|exponential)~unsupported strategy type"`
+	Duration           uint64             `json:"duration" db:"duration" valid:"optional~please provide a valid duration in seconds,int"`
+	RetryCount         uint64             `json:"retry_count" db:"retry_count" valid:"optional~please provide a valid retry count,int"`
+	StatusCodePolicies StatusCodePolicies `json:"status_code_policies" db:"status_code_policies"`
+}
+
+type StatusCodeAction string
+
+const (
+	RetryStatusCodeAction   StatusCodeAction = "retry"
+	DiscardStatusCodeAction StatusCodeAction = "discard"
+	FailStatusCodeAction    StatusCodeAction = "fail"
+)
+
+// StatusCodePolicy tells the delivery worker what to do with an event delivery
+// when the endpoint responds with one of StatusCodes. Deliveries are retried
+// by default, discard and fail stop retrying immediately.
+type StatusCodePolicy struct {
+	StatusCodes []int            `json:"status_codes" db:"status_codes"`
+	Action      StatusCodeAction `json:"action" db:"action" valid:"required~please provide a status code action,in(retry|discard|fail)~unsupported status code action"`
+}
+
+type StatusCodePolicies []StatusCodePolicy
+
+func (s *StatusCodePolicies) Scan(v interface{}) error {
+	b, ok := v.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", v)
+	}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(b, s)
+}
+
+func (s StatusCodePolicies) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(s)
+}
+
+// Action returns the action of the first policy that matches statusCode.
+func (s StatusCodePolicies) Action(statusCode int) (StatusCodeAction, bool) {
+	for _, policy := range s {
+		for _, code := range policy.StatusCodes {
+			if code == statusCode {
+				return policy.Action, true
+			}
+		}
+	}
+
+	return "", false
 }
 
 type SignatureConfiguration struct {
 	Hash     string                         `json:"-" db:"hash"` // Deprecated
 	Header   config.SignatureHeaderProvider `json:"header,omitempty" valid:"required~please provide a valid signature header"`
 	Versions SignatureVersions              `json:"versions" db:"versions"`
+
+	// Scheme selects the signature headers sent with event deliveries,
+	// Header and Versions only apply to the convoy scheme.
+	Scheme SignatureScheme `json:"scheme" db:"scheme" valid:"optional,in(convoy|standard_webhooks)~unsupported signature scheme"`
+
+	// Asymmetric switches standard webhooks signatures to Ed25519, receivers
+	// verify them with the public key of the endpoint secret.
+	Asymmetric bool `json:"asymmetric" db:"asymmetric"`
+}
+
+func (s *SignatureConfiguration) IsStandardWebhooks() bool {
+	return s != nil && s.Scheme == StandardWebhooksSignatureScheme
 }
 
+type SignatureScheme string
+
+const (
+	ConvoySignatureScheme           SignatureScheme = "convoy"
+	StandardWebhooksSignatureScheme SignatureScheme = "standard_webhooks"
+)
+
 type SignatureVersion struct {
 	UID       string       `json:"uid" db:"id"`
 	Hash      string       `json:"hash,omitempty" db:"hash" valid:"required~please provide a valid hash,supported_hash~unsupported hash type"`
@@ -673,6 +961,7 @@ var (
 	ErrProjectNotFound               = errors.New("project not found")
 	ErrAPIKeyNotFound                = errors.New("api key not found")
 	ErrEndpointNotFound              = errors.New("endpoint not found")
+	ErrJobNotFound                   = errors.New("job not found")
 	ErrSubscriptionNotFound          = errors.New("subscription not found")
 	ErrEventDeliveryNotFound         = errors.New("event delivery not found")
 	ErrDeliveryAttemptNotFound       = errors.New("event delivery attempt not found")
@@ -685,6 +974,13 @@ var (
 	ErrNoActiveSecret                = errors.New("no active secret found")
 	ErrSecretNotFound                = errors.New("secret not found")
 	ErrMetaEventNotFound             = errors.New("meta event not found")
+	ErrScheduledEventNotFound        = errors.New("scheduled event not found")
+	ErrDeadLetterNotFound            = errors.New("dead letter not found")
+	ErrEventTypeNotFound             = errors.New("event type not found")
+
+	// ErrPubSubMessageRejected is wrapped by the errors of pub sub handlers for
+	// messages that fail however often they are retried.
+	ErrPubSubMessageRejected = errors.New("pub sub message rejected")
 )
 
 type AppMetadata struct {
@@ -747,15 +1043,36 @@ type Event struct {
 	Data json.RawMessage `json:"data,omitempty" db:"data"`
 	Raw  string          `json:"raw,omitempty" db:"raw"`
 
+	// ContentType is the content type of the request the event was ingested
+	// from and OriginalRaw is the body of the request as it was received, it
+	// is only delivered to endpoints with the original body encoding.
+	ContentType string      `json:"content_type,omitempty" db:"content_type"`
+	OriginalRaw string      `json:"original_raw,omitempty" db:"original_raw"`
+	RawEncoding RawEncoding `json:"raw_encoding,omitempty" db:"raw_encoding"`
+
 	Status   EventStatus `json:"status" db:"status"`
 	Metadata string      `json:"metadata,omitempty" db:"metadata"`
 
+	// SchemaStatus is whether Data matches the schema of the event type,
+	// it is empty when the event type has no schema.
+	SchemaStatus EventSchemaStatus `json:"schema_status,omitempty" db:"schema_status"`
+
 	AcknowledgedAt null.Time `json:"acknowledged_at,omitempty" db:"acknowledged_at,omitempty" swaggertype:"string"`
 	CreatedAt      time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt      time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt      null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
 }
 
+// OriginalBody returns the body the event was ingested with, it is nil for
+// events that weren't ingested from a request.
+func (e *Event) OriginalBody() *OriginalBody {
+	if isStringEmpty(e.ContentType) {
+		return nil
+	}
+
+	return &OriginalBody{ContentType: e.ContentType, Encoding: e.RawEncoding, Data: e.OriginalRaw}
+}
+
 func (e *Event) GetRawHeaders() map[string]interface{} {
 	h := make(map[string]interface{}, len(e.Headers))
 
@@ -849,6 +1166,45 @@ type Metadata struct {
 	RetryLimit uint64 `json:"retry_limit" bson:"retry_limit"`
 
 	MaxRetrySeconds uint64 `json:"max_retry_seconds" bson:"max_retry_seconds"`
+
+	// OriginalBody is the body of the event as it was ingested, it is only
+	// kept for endpoints with the original body encoding.
+	OriginalBody *OriginalBody `json:"original_body,omitempty"`
+}
+
+// RawEncoding is how a body is stored in a text column.
+type RawEncoding string
+
+const (
+	// Base64RawEncoding is used for bodies that aren't valid utf-8 text.
+	Base64RawEncoding RawEncoding = "base64"
+)
+
+// OriginalBody is a request body as it was received.
+type OriginalBody struct {
+	ContentType string      `json:"content_type"`
+	Encoding    RawEncoding `json:"encoding,omitempty"`
+	Data        string      `json:"data"`
+}
+
+func NewOriginalBody(contentType string, body []byte) *OriginalBody {
+	o := &OriginalBody{ContentType: contentType, Data: string(body)}
+
+	// text columns can't hold invalid utf-8 or null bytes
+	if !utf8.Valid(body) || bytes.IndexByte(body, 0) != -1 {
+		o.Encoding = Base64RawEncoding
+		o.Data = base64.StdEncoding.EncodeToString(body)
+	}
+
+	return o
+}
+
+func (o *OriginalBody) Bytes() ([]byte, error) {
+	if o.Encoding == Base64RawEncoding {
+		return base64.StdEncoding.DecodeString(o.Data)
+	}
+
+	return []byte(o.Data), nil
 }
 
 func (m *Metadata) Scan(value interface{}) error {
@@ -967,6 +1323,7 @@ type EventDelivery struct {
 	Latency        string    `json:"latency" db:"latency"`
 	LatencySeconds float64   `json:"latency_seconds" db:"latency_seconds"`
 	EventType      EventType `json:"event_type,omitempty" db:"event_type"`
+	OrderingKey    string    `json:"ordering_key,omitempty" db:"ordering_key"`
 
 	Endpoint *Endpoint `json:"endpoint_metadata,omitempty" db:"endpoint_metadata"`
 	Event    *Event    `json:"event_metadata,omitempty" db:"event_metadata"`
@@ -1112,29 +1469,73 @@ type CustomResponse struct {
 }
 
 type Source struct {
-	UID             string          `json:"uid" db:"id"`
-	ProjectID       string          `json:"project_id" db:"project_id"`
-	MaskID          string          `json:"mask_id" db:"mask_id"`
-	Name            string          `json:"name" db:"name"`
-	URL             string          `json:"url" db:"-"`
-	Type            SourceType      `json:"type" db:"type"`
-	Provider        SourceProvider  `json:"provider" db:"provider"`
-	IsDisabled      bool            `json:"is_disabled" db:"is_disabled"`
-	VerifierID      string          `json:"-" db:"source_verifier_id"`
-	Verifier        *VerifierConfig `json:"verifier" db:"verifier"`
-	CustomResponse  CustomResponse  `json:"custom_response" db:"custom_response"`
-	ProviderConfig  *ProviderConfig `json:"provider_config" db:"provider_config"`
-	ForwardHeaders  pq.StringArray  `json:"forward_headers" db:"forward_headers"`
-	PubSub          *PubSubConfig   `json:"pub_sub" db:"pub_sub"`
-	IdempotencyKeys pq.StringArray  `json:"idempotency_keys" db:"idempotency_keys"`
-	BodyFunction    *string         `json:"body_function" db:"body_function"`
-	HeaderFunction  *string         `json:"header_function" db:"header_function"`
+	UID             string              `json:"uid" db:"id"`
+	ProjectID       string              `json:"project_id" db:"project_id"`
+	MaskID          string              `json:"mask_id" db:"mask_id"`
+	Name            string              `json:"name" db:"name"`
+	URL             string              `json:"url" db:"-"`
+	Type            SourceType          `json:"type" db:"type"`
+	Provider        SourceProvider      `json:"provider" db:"provider"`
+	IsDisabled      bool                `json:"is_disabled" db:"is_disabled"`
+	VerifierID      string              `json:"-" db:"source_verifier_id"`
+	Verifier        *VerifierConfig     `json:"verifier" db:"verifier"`
+	CustomResponse  CustomResponse      `json:"custom_response" db:"custom_response"`
+	ProviderConfig  *ProviderConfig     `json:"provider_config" db:"provider_config"`
+	ForwardHeaders  pq.StringArray      `json:"forward_headers" db:"forward_headers"`
+	PubSub          *PubSubConfig       `json:"pub_sub" db:"pub_sub"`
+	ChangeStream    *ChangeStreamConfig `json:"change_stream" db:"change_stream"`
+	RestApi         *RestApiConfig      `json:"rest_api" db:"rest_api"`
+	PollState       *PollState          `json:"poll_state,omitempty" db:"poll_state"`
+	IdempotencyKeys pq.StringArray      `json:"idempotency_keys" db:"idempotency_keys"`
+	Converters      pq.StringArray      `json:"converters" db:"converters"`
+	DedupWindow     string              `json:"dedup_window" db:"dedup_window"`
+	BodyFunction    *string             `json:"body_function" db:"body_function"`
+	HeaderFunction  *string             `json:"header_function" db:"header_function"`
+
+	// Synchronous makes ingest requests wait for the first delivery attempt
+	// and reply with the endpoint's response, it is only allowed on sources
+	// with a single subscription.
+	Synchronous bool `json:"synchronous" db:"synchronous"`
 
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at" swaggertype:"string"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at" swaggertype:"string"`
 	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
 }
 
+// PayloadConverter converts request bodies of a content type to the JSON
+// data of events, so they can be filtered and transformed.
+type PayloadConverter string
+
+const (
+	FormPayloadConverter PayloadConverter = "form"
+	XMLPayloadConverter  PayloadConverter = "xml"
+)
+
+func (p PayloadConverter) IsValid() bool {
+	switch p {
+	case FormPayloadConverter, XMLPayloadConverter:
+		return true
+	default:
+		return false
+	}
+}
+
+// Converts reports whether the source uses converter. Sources that haven't
+// set their converters convert form bodies, like they always have.
+func (s *Source) Converts(converter PayloadConverter) bool {
+	if s.Converters == nil {
+		return converter == FormPayloadConverter
+	}
+
+	for _, c := range s.Converters {
+		if PayloadConverter(c) == converter {
+			return true
+		}
+	}
+
+	return false
+}
+
 type PubSubConfig struct {
 	Type    PubSubType          `json:"type" db:"type"`
 	Workers int                 `json:"workers" db:"workers"`
@@ -1169,6 +1570,187 @@ func (p PubSubConfig) Value() (driver.Value, error) {
 	return b, nil
 }
 
+// ChangeStreamConfig configures a db_change_stream source. Inserts, updates and
+// deletes on the tables are read from a Postgres logical replication slot
+// using the wal2json output plugin.
+type ChangeStreamConfig struct {
+	// DSN is the connection string of the database to stream changes from,
+	// the user needs the REPLICATION attribute.
+	DSN string `json:"dsn" db:"dsn"`
+
+	// SlotName is the logical replication slot the changes are read from,
+	// it is created when it doesn't exist.
+	SlotName string `json:"slot_name" db:"slot_name"`
+
+	// Tables are the tables to stream changes from e.g. public.orders,
+	// unqualified tables are in the public schema.
+	Tables []string `json:"tables" db:"tables"`
+
+	// PollInterval is how often the slot is polled for changes in seconds.
+	PollInterval int `json:"poll_interval" db:"poll_interval"`
+}
+
+func (c *ChangeStreamConfig) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	var cs ChangeStreamConfig
+	err := json.Unmarshal(b, &cs)
+	if err != nil {
+		return err
+	}
+
+	*c = cs
+	return nil
+}
+
+func (c ChangeStreamConfig) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// RestApiConfig configures a rest_api source. The url is polled on an interval
+// and every item in the response that hasn't been seen becomes an event.
+type RestApiConfig struct {
+	// URL is polled with a GET request.
+	URL string `json:"url" db:"url"`
+
+	// Headers are sent with every request.
+	Headers map[string]string `json:"headers,omitempty" db:"headers"`
+
+	// Authentication authenticates the requests with an api key header
+	// or an oauth2 access token.
+	Authentication *EndpointAuthentication `json:"authentication,omitempty" db:"authentication"`
+
+	// ItemsPath is the JSONPath of the items array in the response e.g.
+	// $.data, it defaults to the root of the response.
+	ItemsPath string `json:"items_path" db:"items_path"`
+
+	// IDPath is the JSONPath of the unique id in an item e.g. $.id, it is
+	// used to skip items that have been seen.
+	IDPath string `json:"id_path" db:"id_path"`
+
+	// EventType is the event type of the events created from the items.
+	EventType string `json:"event_type" db:"event_type"`
+
+	// CursorPath is the JSONPath of the next page cursor in the response, it
+	// is sent in the CursorParam query param of the next request.
+	CursorPath  string `json:"cursor_path,omitempty" db:"cursor_path"`
+	CursorParam string `json:"cursor_param,omitempty" db:"cursor_param"`
+
+	// PollInterval is how often the url is polled in seconds.
+	PollInterval int `json:"poll_interval" db:"poll_interval"`
+}
+
+func (r *RestApiConfig) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	var rc RestApiConfig
+	err := json.Unmarshal(b, &rc)
+	if err != nil {
+		return err
+	}
+
+	*r = rc
+	return nil
+}
+
+func (r RestApiConfig) Value() (driver.Value, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// PollState is where a rest_api source left off.
+type PollState struct {
+	// Cursor is sent with the next request.
+	Cursor string `json:"cursor,omitempty"`
+
+	// SeenIDs are the ids of the most recent items, oldest first.
+	SeenIDs []string `json:"seen_ids,omitempty"`
+
+	PolledAt time.Time `json:"polled_at"`
+
+	// Error is why the last poll failed.
+	Error string `json:"error,omitempty"`
+}
+
+// MaxSeenIDs is how many item ids a poll state remembers on top of the
+// ids returned by the last poll.
+const MaxSeenIDs = 1000
+
+func (p *PollState) HasSeen(id string) bool {
+	for _, seen := range p.SeenIDs {
+		if seen == id {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkSeen remembers id.
+func (p *PollState) MarkSeen(id string) {
+	p.SeenIDs = append(p.SeenIDs, id)
+}
+
+// Forget forgets the oldest ids past MaxSeenIDs. The ids in polled are the
+// ones the last poll returned, they are always remembered since an api
+// that returns its whole list on every poll returns them again.
+func (p *PollState) Forget(polled map[string]bool) {
+	excess := len(p.SeenIDs) - MaxSeenIDs
+	if excess <= 0 {
+		return
+	}
+
+	kept := make([]string, 0, MaxSeenIDs)
+	for _, id := range p.SeenIDs {
+		if excess > 0 && !polled[id] {
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+
+	p.SeenIDs = kept
+}
+
+func (p *PollState) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	var ps PollState
+	err := json.Unmarshal(b, &ps)
+	if err != nil {
+		return err
+	}
+
+	*p = ps
+	return nil
+}
+
+func (p PollState) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
 type SQSPubSubConfig struct {
 	AccessKeyID   string `json:"access_key_id" db:"access_key_id"`
 	SecretKey     string `json:"secret_key" db:"secret_key"`
@@ -1229,6 +1811,15 @@ type User struct {
 	ResetPasswordExpiresAt     time.Time `json:"reset_password_expires_at,omitempty" db:"reset_password_expires_at,omitempty" swaggertype:"string"`
 	EmailVerificationExpiresAt time.Time `json:"-" db:"email_verification_expires_at,omitempty" swaggertype:"string"`
 	AuthType                   string    `json:"auth_type" db:"auth_type" swaggertype:"string"`
+
+	// ProvisionedBy is the organisation whose identity provider provisioned
+	// the user, only it may change the user's profile.
+	ProvisionedBy string `json:"-" db:"provisioned_by"`
+
+	// OIDCIssuer and OIDCSubject identify the user's account at the OIDC
+	// identity provider they sign in with.
+	OIDCIssuer  string `json:"-" db:"oidc_issuer"`
+	OIDCSubject string `json:"-" db:"oidc_subject"`
 }
 
 type RetryConfiguration struct {
@@ -1317,6 +1908,7 @@ type VerifierConfig struct {
 	HMac      *HMac        `json:"hmac" db:"hmac"`
 	BasicAuth *BasicAuth   `json:"basic_auth" db:"basic_auth"`
 	ApiKey    *ApiKey      `json:"api_key" db:"api_key"`
+	JWT       *JWTAuth     `json:"jwt" db:"jwt"`
 }
 
 type HMac struct {
@@ -1324,6 +1916,14 @@ type HMac struct {
 	Hash     string       `json:"hash" db:"hash" valid:"supported_hash,required"`
 	Secret   string       `json:"secret" db:"secret" valid:"required"`
 	Encoding EncodingType `json:"encoding" db:"encoding" valid:"supported_encoding~please provide a valid encoding type,required"`
+
+	// TimestampHeader is the header the signed timestamp is sent in, when it
+	// is the signature header the header is read as t=<timestamp>,v1=<signature>.
+	// The signature is computed over <timestamp>,<payload> when it is set.
+	TimestampHeader string `json:"timestamp_header" db:"timestamp_header"`
+
+	// Tolerance is how old the timestamp can be in seconds.
+	Tolerance int `json:"tolerance" db:"tolerance"`
 }
 
 type BasicAuth struct {
@@ -1336,6 +1936,17 @@ type ApiKey struct {
 	HeaderName  string `json:"header_name" db:"header_name" valid:"required"`
 }
 
+// JWTAuth verifies a token sent with the request against a key set
+// or static public keys.
+type JWTAuth struct {
+	// Header defaults to the Authorization header with a Bearer token.
+	Header     string `json:"header" db:"header"`
+	JWKSURL    string `json:"jwks_url" db:"jwks_url"`
+	PublicKeys string `json:"public_keys" db:"public_keys"`
+	Issuer     string `json:"issuer" db:"issuer"`
+	Audience   string `json:"audience" db:"audience"`
+}
+
 type Organisation struct {
 	UID            string      `json:"uid" db:"id"`
 	OwnerID        string      `json:"" db:"owner_id"`
@@ -1428,6 +2039,27 @@ type OrganisationMember struct {
 	DeletedAt      null.Time    `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
 }
 
+// SCIMUser is a user provisioned into an organisation by its identity
+// provider, deactivated users keep their record but lose their membership.
+type SCIMUser struct {
+	UID            string       `json:"uid" db:"id"`
+	OrganisationID string       `json:"organisation_id" db:"organisation_id"`
+	UserID         string       `json:"user_id" db:"user_id"`
+	ExternalID     string       `json:"external_id" db:"external_id"`
+	Active         bool         `json:"active" db:"active"`
+	UserMetadata   UserMetadata `json:"user_metadata" db:"user_metadata"`
+	CreatedAt      time.Time    `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt      time.Time    `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt      null.Time    `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+type SCIMUserFilter struct {
+	Email      string
+	ExternalID string
+	Offset     int
+	Limit      int
+}
+
 type Device struct {
 	UID        string       `json:"uid" db:"id"`
 	ProjectID  string       `json:"project_id,omitempty" db:"project_id"`
@@ -1448,6 +2080,34 @@ const (
 	DeviceStatusDisabled DeviceStatus = "disabled"
 )
 
+type SchemaValidation string
+
+const (
+	// RejectSchemaValidation rejects events whose payload doesn't match
+	// the schema of their event type.
+	RejectSchemaValidation SchemaValidation = "reject"
+
+	// FlagSchemaValidation accepts events whose payload doesn't match the
+	// schema of their event type, they are flagged with InvalidSchemaStatus.
+	FlagSchemaValidation SchemaValidation = "flag"
+)
+
+func (s SchemaValidation) IsValid() bool {
+	switch s {
+	case RejectSchemaValidation, FlagSchemaValidation:
+		return true
+	default:
+		return false
+	}
+}
+
+type EventSchemaStatus string
+
+const (
+	ValidSchemaStatus   EventSchemaStatus = "valid"
+	InvalidSchemaStatus EventSchemaStatus = "invalid"
+)
+
 type ProjectEventType struct {
 	UID          string    `json:"uid" db:"id"`
 	Name         string    `json:"name" db:"name"`
@@ -1457,19 +2117,81 @@ type ProjectEventType struct {
 	UpdatedAt    time.Time `json:"-" db:"updated_at"`
 	Description  string    `json:"description" db:"description"`
 	DeprecatedAt null.Time `json:"deprecated_at" db:"deprecated_at"`
+
+	// JSONSchema is the current schema of the event type's payloads,
+	// SchemaVersion is bumped every time it changes.
+	JSONSchema       json.RawMessage  `json:"json_schema,omitempty" db:"json_schema" swaggertype:"object"`
+	SchemaVersion    int              `json:"schema_version" db:"schema_version"`
+	SchemaValidation SchemaValidation `json:"schema_validation" db:"schema_validation"`
+}
+
+func (p *ProjectEventType) HasSchema() bool {
+	return len(p.JSONSchema) > 0 && string(p.JSONSchema) != "null"
+}
+
+// EventTypeSchema is a version of an event type's schema.
+type EventTypeSchema struct {
+	UID         string          `json:"uid" db:"id"`
+	EventTypeID string          `json:"event_type_id" db:"event_type_id"`
+	ProjectID   string          `json:"-" db:"project_id"`
+	Version     int             `json:"version" db:"version"`
+	JSONSchema  json.RawMessage `json:"json_schema" db:"json_schema" swaggertype:"object"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
 }
 
 type Job struct {
-	UID         string    `json:"uid" db:"id"`
-	Type        string    `json:"type" db:"type"`
-	Status      JobStatus `json:"status,omitempty" db:"status"`
-	ProjectID   string    `json:"project_id,omitempty" db:"project_id"`
-	FailedAt    null.Time `json:"failed_at,omitempty" db:"failed_at,omitempty" swaggertype:"string"`
-	StartedAt   null.Time `json:"started_at,omitempty" db:"started_at,omitempty" swaggertype:"string"`
-	CompletedAt null.Time `json:"completed_at,omitempty" db:"completed_at,omitempty" swaggertype:"string"`
-	CreatedAt   time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
-	UpdatedAt   time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
-	DeletedAt   null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+	UID         string       `json:"uid" db:"id"`
+	Type        string       `json:"type" db:"type"`
+	Status      JobStatus    `json:"status,omitempty" db:"status"`
+	ProjectID   string       `json:"project_id,omitempty" db:"project_id"`
+	Progress    *JobProgress `json:"progress,omitempty" db:"progress"`
+	FailedAt    null.Time    `json:"failed_at,omitempty" db:"failed_at,omitempty" swaggertype:"string"`
+	StartedAt   null.Time    `json:"started_at,omitempty" db:"started_at,omitempty" swaggertype:"string"`
+	CompletedAt null.Time    `json:"completed_at,omitempty" db:"completed_at,omitempty" swaggertype:"string"`
+	CreatedAt   time.Time    `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt   time.Time    `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt   null.Time    `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+// JobProgress records how far a job that works in batches has got, so it
+// can resume from there after a crash.
+type JobProgress struct {
+	Total     int64 `json:"total"`
+	Processed int64 `json:"processed"`
+
+	// Table and Cursor are the table being processed and the id of the
+	// last row processed in it.
+	Table  string `json:"table,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+
+	FromVersion string `json:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty"`
+
+	// PreviousKey is the key of FromVersion encrypted with the key of
+	// ToVersion, credentials not yet re-encrypted are read with it.
+	PreviousKey string `json:"previous_key,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func (p *JobProgress) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(b, p)
+}
+
+func (p *JobProgress) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(p)
 }
 
 type JobStatus string
@@ -1481,6 +2203,9 @@ const (
 	JobStatusCompleted JobStatus = "completed"
 )
 
+// JobTypeKeyRotation re-encrypts credentials with a new encryption key.
+const JobTypeKeyRotation = "key_rotation"
+
 type UserMetadata struct {
 	UserID    string `json:"-" db:"user_id"`
 	FirstName string `json:"first_name" db:"first_name"`
@@ -1625,6 +2350,204 @@ func (m *MetaEventAttempt) Value() (driver.Value, error) {
 	return b, nil
 }
 
+type (
+	ScheduledEventKind string
+	ScheduleStatus     string
+)
+
+const (
+	SingleScheduledEvent    ScheduledEventKind = "single"
+	FanoutScheduledEvent    ScheduledEventKind = "fanout"
+	BroadcastScheduledEvent ScheduledEventKind = "broadcast"
+	DynamicScheduledEvent   ScheduledEventKind = "dynamic"
+)
+
+const (
+	ScheduleActiveStatus     ScheduleStatus = "Scheduled"
+	ScheduleDispatchedStatus ScheduleStatus = "Dispatched"
+	ScheduleCancelledStatus  ScheduleStatus = "Cancelled"
+)
+
+// TaskName returns the task that creates the event once it is dispatched.
+func (k ScheduledEventKind) TaskName() convoy.TaskName {
+	switch k {
+	case BroadcastScheduledEvent:
+		return convoy.CreateBroadcastEventProcessor
+	case DynamicScheduledEvent:
+		return convoy.CreateDynamicEventProcessor
+	default:
+		return convoy.CreateEventProcessor
+	}
+}
+
+// ScheduledEvent is an event that has been accepted but is held back
+// until DeliverAt, Payload is the encoded event creation job.
+type ScheduledEvent struct {
+	UID          string             `json:"uid" db:"id"`
+	ProjectID    string             `json:"project_id" db:"project_id"`
+	EventID      string             `json:"event_id" db:"event_id"`
+	EventType    string             `json:"event_type" db:"event_type"`
+	Kind         ScheduledEventKind `json:"kind" db:"kind"`
+	Payload      []byte             `json:"-" db:"payload"`
+	Status       ScheduleStatus     `json:"status" db:"status"`
+	DeliverAt    time.Time          `json:"deliver_at" db:"deliver_at" swaggertype:"string"`
+	DispatchedAt null.Time          `json:"dispatched_at,omitempty" db:"dispatched_at" swaggertype:"string"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+// JobID returns the id of the event creation job, it matches the id
+// the event would have been queued with if it wasn't scheduled.
+func (s *ScheduledEvent) JobID() string {
+	return fmt.Sprintf("%s:%s:%s", s.Kind, s.ProjectID, s.EventID)
+}
+
+type DeadLetterStatus string
+
+const (
+	// PendingDeadLetterStatus is a dead letter that has not been redriven since
+	// its event delivery was last dead-lettered.
+	PendingDeadLetterStatus  DeadLetterStatus = "pending"
+	RedrivenDeadLetterStatus DeadLetterStatus = "redriven"
+)
+
+// DeadLetter records an event delivery that exhausted its retries, along
+// with the last response the endpoint sent before it was given up on.
+type DeadLetter struct {
+	UID             string    `json:"uid" db:"id"`
+	ProjectID       string    `json:"project_id" db:"project_id"`
+	EventDeliveryID string    `json:"event_delivery_id" db:"event_delivery_id"`
+	EventID         string    `json:"event_id" db:"event_id"`
+	EndpointID      string    `json:"endpoint_id" db:"endpoint_id"`
+	EventType       EventType `json:"event_type" db:"event_type"`
+	Reason          string    `json:"reason" db:"reason"`
+
+	LastResponseStatus null.String `json:"last_response_status,omitempty" db:"last_response_status" swaggertype:"string"`
+	LastResponseBody   null.String `json:"last_response_body,omitempty" db:"last_response_body" swaggertype:"string"`
+	LastResponseError  null.String `json:"last_response_error,omitempty" db:"last_response_error" swaggertype:"string"`
+
+	RedriveCount   int       `json:"redrive_count" db:"redrive_count"`
+	DeadLetteredAt time.Time `json:"dead_lettered_at" db:"dead_lettered_at" swaggertype:"string"`
+	RedrivenAt     null.Time `json:"redriven_at,omitempty" db:"redriven_at" swaggertype:"string"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+// EndpointProbe is the result of a health check request sent to an endpoint.
+type EndpointProbe struct {
+	UID        string    `json:"uid" db:"id"`
+	ProjectID  string    `json:"project_id" db:"project_id"`
+	EndpointID string    `json:"endpoint_id" db:"endpoint_id"`
+	Status     bool      `json:"status" db:"status"`
+	StatusCode int       `json:"status_code" db:"status_code"`
+	Error      string    `json:"error" db:"error"`
+	CreatedAt  time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+}
+
+type (
+	AuditActorType    string
+	AuditAction       string
+	AuditResourceType string
+)
+
+const (
+	UserAuditActor       AuditActorType = "user"
+	APIKeyAuditActor     AuditActorType = "api_key"
+	PortalLinkAuditActor AuditActorType = "portal_link"
+)
+
+const (
+	AuditActionCreated      AuditAction = "created"
+	AuditActionUpdated      AuditAction = "updated"
+	AuditActionDeleted      AuditAction = "deleted"
+	AuditActionPaused       AuditAction = "paused"
+	AuditActionActivated    AuditAction = "activated"
+	AuditActionSecretRolled AuditAction = "secret_rolled"
+	AuditActionRegenerated  AuditAction = "regenerated"
+	AuditActionRevoked      AuditAction = "revoked"
+	AuditActionAccepted     AuditAction = "accepted"
+)
+
+const (
+	ProjectAuditResource            AuditResourceType = "project"
+	EndpointAuditResource           AuditResourceType = "endpoint"
+	SubscriptionAuditResource       AuditResourceType = "subscription"
+	SourceAuditResource             AuditResourceType = "source"
+	APIKeyAuditResource             AuditResourceType = "api_key"
+	PortalLinkAuditResource         AuditResourceType = "portal_link"
+	OrganisationMemberAuditResource AuditResourceType = "organisation_member"
+	OrganisationInviteAuditResource AuditResourceType = "organisation_invite"
+)
+
+// AuditLog records a management action taken on a resource of an
+// organisation. Audit logs are append-only, they are never updated
+// or deleted once written.
+type AuditLog struct {
+	UID            string            `json:"uid" db:"id"`
+	OrganisationID string            `json:"organisation_id" db:"organisation_id"`
+	ProjectID      string            `json:"project_id,omitempty" db:"project_id"`
+	Actor          AuditActor        `json:"actor" db:"actor"`
+	Action         AuditAction       `json:"action" db:"action"`
+	ResourceType   AuditResourceType `json:"resource_type" db:"resource_type"`
+	ResourceID     string            `json:"resource_id" db:"resource_id"`
+	Changes        AuditChanges      `json:"changes" db:"changes"`
+	IPAddress      string            `json:"ip_address" db:"ip_address"`
+	UserAgent      string            `json:"user_agent" db:"user_agent"`
+	CreatedAt      time.Time         `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+}
+
+// AuditActor is who took the action, the user behind a dashboard session or
+// personal access token, a project api key or a portal link.
+type AuditActor struct {
+	Type AuditActorType `json:"type" db:"type"`
+	ID   string         `json:"id" db:"id"`
+	Name string         `json:"name" db:"name"`
+}
+
+// AuditChange is the value of a field before and after an action,
+// Before is nil for created resources and After is nil for deleted ones.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges are the fields an action changed, keyed by their json name.
+type AuditChanges map[string]AuditChange
+
+func (a *AuditChanges) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(b, a)
+}
+
+func (a AuditChanges) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("{}"), nil
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
 type Password struct {
 	Plaintext string
 	Hash      []byte
@@ -1,6 +1,7 @@
 package datastore
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -71,3 +72,137 @@ func TestProject_IsOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusCodePolicies_Action(t *testing.T) {
+	policies := StatusCodePolicies{
+		{StatusCodes: []int{410}, Action: DiscardStatusCodeAction},
+		{StatusCodes: []int{400, 422}, Action: FailStatusCodeAction},
+		{StatusCodes: []int{422}, Action: RetryStatusCodeAction},
+	}
+
+	tt := []struct {
+		name       string
+		statusCode int
+		action     StatusCodeAction
+		found      bool
+	}{
+		{
+			name:       "discard gone",
+			statusCode: 410,
+			action:     DiscardStatusCodeAction,
+			found:      true,
+		},
+		{
+			name:       "first matching policy wins",
+			statusCode: 422,
+			action:     FailStatusCodeAction,
+			found:      true,
+		},
+		{
+			name:       "no matching policy",
+			statusCode: 500,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			action, found := policies.Action(tc.statusCode)
+			require.Equal(t, tc.found, found)
+			require.Equal(t, tc.action, action)
+		})
+	}
+}
+
+func TestEndpoint_DeliveryContentType(t *testing.T) {
+	tt := []struct {
+		name        string
+		endpoint    *Endpoint
+		contentType string
+	}{
+		{
+			name:        "default to json",
+			endpoint:    &Endpoint{},
+			contentType: "application/json",
+		},
+		{
+			name:        "derive from form encoding",
+			endpoint:    &Endpoint{BodyEncoding: FormBodyEncoding},
+			contentType: "application/x-www-form-urlencoded",
+		},
+		{
+			name:        "derive from cloudevents encoding",
+			endpoint:    &Endpoint{BodyEncoding: CloudEventsBodyEncoding},
+			contentType: "application/cloudevents+json",
+		},
+		{
+			name:        "prefer configured content type",
+			endpoint:    &Endpoint{BodyEncoding: RawBodyEncoding, ContentType: "application/xml"},
+			contentType: "application/xml",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.contentType, tc.endpoint.DeliveryContentType())
+		})
+	}
+}
+
+func TestSource_Converts(t *testing.T) {
+	source := &Source{}
+	require.True(t, source.Converts(FormPayloadConverter))
+	require.False(t, source.Converts(XMLPayloadConverter))
+
+	source.Converters = []string{}
+	require.False(t, source.Converts(FormPayloadConverter))
+
+	source.Converters = []string{"xml"}
+	require.False(t, source.Converts(FormPayloadConverter))
+	require.True(t, source.Converts(XMLPayloadConverter))
+}
+
+func TestOriginalBody(t *testing.T) {
+	text := NewOriginalBody("text/xml", []byte("<a>é</a>"))
+	require.Empty(t, text.Encoding)
+	require.Equal(t, "<a>é</a>", text.Data)
+
+	binary := NewOriginalBody("application/octet-stream", []byte{0xff, 0x00})
+	require.Equal(t, Base64RawEncoding, binary.Encoding)
+
+	b, err := binary.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff, 0x00}, b)
+
+	event := &Event{Raw: `{"id":"1"}`, OriginalRaw: "id=1"}
+	require.Nil(t, event.OriginalBody())
+
+	event.ContentType = "application/x-www-form-urlencoded"
+	require.Equal(t, &OriginalBody{ContentType: "application/x-www-form-urlencoded", Data: "id=1"}, event.OriginalBody())
+}
+
+func TestSecrets_Value(t *testing.T) {
+	secrets := Secrets{{UID: "secret-1", Value: "secret", StandardWebhooksValue: "whsec_c2VjcmV0"}}
+
+	v, err := secrets.Value()
+	require.NoError(t, err)
+	require.NotContains(t, string(v.([]byte)), "whsec_")
+	require.Equal(t, "whsec_c2VjcmV0", secrets[0].StandardWebhooksValue)
+}
+
+func TestPollState_Forget(t *testing.T) {
+	state := &PollState{}
+	for i := 0; i < MaxSeenIDs+10; i++ {
+		state.MarkSeen(fmt.Sprintf("%d", i))
+	}
+
+	// the oldest ids are returned by every poll, so they're kept
+	state.Forget(map[string]bool{"0": true, "1": true})
+
+	require.Len(t, state.SeenIDs, MaxSeenIDs)
+	require.True(t, state.HasSeen("0"))
+	require.True(t, state.HasSeen("1"))
+	require.False(t, state.HasSeen("2"))
+	require.False(t, state.HasSeen("11"))
+	require.True(t, state.HasSeen("12"))
+	require.True(t, state.HasSeen(fmt.Sprintf("%d", MaxSeenIDs+9)))
+}
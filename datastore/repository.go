@@ -33,6 +33,9 @@ type EventDeliveryRepository interface {
 	UpdateStatusOfEventDeliveries(ctx context.Context, projectID string, ids []string, status EventDeliveryStatus) error
 	FindDiscardedEventDeliveries(ctx context.Context, projectID, deviceId string, params SearchParams) ([]EventDelivery, error)
 	FindStuckEventDeliveriesByStatus(ctx context.Context, status EventDeliveryStatus) ([]EventDelivery, error)
+	HasPendingPredecessor(ctx context.Context, projectID string, eventDelivery *EventDelivery) (bool, error)
+	CountScheduledEndpointDeliveries(ctx context.Context, projectID, endpointID string) (int64, error)
+	ClaimScheduledEndpointDeliveries(ctx context.Context, projectID, endpointID, eventDeliveryID string, limit int) ([]EventDelivery, error)
 	UpdateEventDeliveryMetadata(ctx context.Context, projectID string, eventDelivery *EventDelivery) error
 	CountEventDeliveries(ctx context.Context, projectID string, endpointIDs []string, eventID string, status []EventDeliveryStatus, params SearchParams) (int64, error)
 	DeleteProjectEventDeliveries(ctx context.Context, projectID string, filter *EventDeliveryFilter, hardDelete bool) error
@@ -105,6 +108,15 @@ type OrganisationMemberRepository interface {
 	FetchOrganisationMemberByUserID(ctx context.Context, userID string, organisationID string) (*OrganisationMember, error)
 }
 
+type SCIMUserRepository interface {
+	CreateSCIMUser(ctx context.Context, user *SCIMUser) error
+	UpdateSCIMUser(ctx context.Context, user *SCIMUser) error
+	DeleteSCIMUser(ctx context.Context, id string, orgID string) error
+	FetchSCIMUserByID(ctx context.Context, id string, orgID string) (*SCIMUser, error)
+	FetchSCIMUserByUserID(ctx context.Context, userID string, orgID string) (*SCIMUser, error)
+	LoadSCIMUsers(ctx context.Context, orgID string, filter *SCIMUserFilter) ([]SCIMUser, int64, error)
+}
+
 type EndpointRepository interface {
 	CreateEndpoint(ctx context.Context, endpoint *Endpoint, projectID string) error
 	FindEndpointByID(ctx context.Context, id string, projectID string) (*Endpoint, error)
@@ -114,6 +126,8 @@ type EndpointRepository interface {
 	FindEndpointByTargetURL(ctx context.Context, projectID string, targetURL string) (*Endpoint, error)
 	UpdateEndpoint(ctx context.Context, endpoint *Endpoint, projectID string) error
 	UpdateEndpointStatus(ctx context.Context, projectID, endpointID string, status EndpointStatus) error
+	MarkEndpointVerified(ctx context.Context, projectID, endpointID string) error
+	FindHealthCheckEndpoints(ctx context.Context) ([]Endpoint, error)
 	DeleteEndpoint(ctx context.Context, endpoint *Endpoint, projectID string) error
 	CountProjectEndpoints(ctx context.Context, projectID string) (int64, error)
 	LoadEndpointsPaged(ctx context.Context, projectID string, filter *Filter, pageable Pageable) ([]Endpoint, PaginationData, error)
@@ -147,6 +161,8 @@ type SourceRepository interface {
 	DeleteSourceByID(ctx context.Context, projectId string, id string, sourceVerifierId string) error
 	LoadSourcesPaged(ctx context.Context, projectId string, filter *SourceFilter, pageable Pageable) ([]Source, PaginationData, error)
 	LoadPubSubSourcesByProjectIDs(ctx context.Context, projectIds []string, pageable Pageable) ([]Source, PaginationData, error)
+	LoadRestApiSources(ctx context.Context) ([]Source, error)
+	UpdateSourcePollState(ctx context.Context, projectId string, id string, state *PollState) error
 }
 
 type DeviceRepository interface {
@@ -164,8 +180,10 @@ type JobRepository interface {
 	MarkJobAsStarted(ctx context.Context, uid, projectID string) error
 	MarkJobAsCompleted(ctx context.Context, uid, projectID string) error
 	MarkJobAsFailed(ctx context.Context, uid, projectID string) error
+	UpdateJobProgress(ctx context.Context, uid, projectID string, progress *JobProgress) error
 	DeleteJob(ctx context.Context, uid string, projectID string) error
 	FetchJobById(ctx context.Context, uid string, projectID string) (*Job, error)
+	FetchLatestJobByType(ctx context.Context, jobType string, projectID string) (*Job, error)
 	FetchRunningJobsByProjectId(ctx context.Context, projectID string) ([]Job, error)
 	FetchJobsByProjectId(ctx context.Context, projectID string) ([]Job, error)
 	LoadJobsPaged(ctx context.Context, projectID string, pageable Pageable) ([]Job, PaginationData, error)
@@ -179,6 +197,7 @@ type UserRepository interface {
 	FindUserByID(context.Context, string) (*User, error)
 	FindUserByToken(context.Context, string) (*User, error)
 	FindUserByEmailVerificationToken(ctx context.Context, token string) (*User, error)
+	FindUserByOIDCSubject(ctx context.Context, issuer, subject string) (*User, error)
 }
 
 type ConfigurationRepository interface {
@@ -204,6 +223,35 @@ type MetaEventRepository interface {
 	UpdateMetaEvent(ctx context.Context, projectID string, metaEvent *MetaEvent) error
 }
 
+type ScheduledEventRepository interface {
+	CreateScheduledEvent(context.Context, *ScheduledEvent) error
+	FindScheduledEventByID(ctx context.Context, projectID string, id string) (*ScheduledEvent, error)
+	LoadScheduledEventsPaged(ctx context.Context, projectID string, status ScheduleStatus, f *Filter) ([]ScheduledEvent, PaginationData, error)
+	UpdateScheduledEventStatus(ctx context.Context, projectID string, id string, status ScheduleStatus) error
+	RestoreScheduledEvent(ctx context.Context, projectID string, id string) error
+	RescheduleEvent(ctx context.Context, projectID string, id string, deliverAt time.Time) error
+}
+
+type DeadLetterRepository interface {
+	CreateDeadLetter(context.Context, *DeadLetter) error
+	FindDeadLetterByID(ctx context.Context, projectID string, id string) (*DeadLetter, error)
+	LoadDeadLettersPaged(ctx context.Context, projectID string, filter *DeadLetterFilter, pageable Pageable) ([]DeadLetter, PaginationData, error)
+	FindDeadLettersForRedrive(ctx context.Context, projectID string, filter *DeadLetterFilter, limit int) ([]DeadLetter, error)
+	MarkDeadLettersRedriven(ctx context.Context, projectID string, ids []string) error
+}
+
+type AuditLogRepository interface {
+	CreateAuditLog(context.Context, *AuditLog) error
+	LoadAuditLogsPaged(ctx context.Context, orgID string, filter *AuditLogFilter, pageable Pageable) ([]AuditLog, PaginationData, error)
+	ExportAuditLogs(ctx context.Context, orgID string, filter *AuditLogFilter, w io.Writer) (int64, error)
+}
+
+type EndpointProbeRepository interface {
+	CreateEndpointProbe(ctx context.Context, probe *EndpointProbe) error
+	LoadEndpointProbes(ctx context.Context, projectID, endpointID string, limit int) ([]EndpointProbe, error)
+	DeleteEndpointProbes(ctx context.Context, before time.Time) error
+}
+
 type ExportRepository interface {
 	ExportRecords(ctx context.Context, projectID string, createdAt time.Time, w io.Writer) (int64, error)
 }
@@ -226,4 +274,6 @@ type EventTypesRepository interface {
 	DeprecateEventType(context.Context, string, string) (*ProjectEventType, error)
 	FetchEventTypeById(context.Context, string, string) (*ProjectEventType, error)
 	FetchAllEventTypes(context.Context, string) ([]ProjectEventType, error)
+	FetchEventTypeByName(ctx context.Context, name, projectId string) (*ProjectEventType, error)
+	FetchEventTypeSchemas(ctx context.Context, id, projectId string) ([]EventTypeSchema, error)
 }
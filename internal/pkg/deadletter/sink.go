@@ -0,0 +1,144 @@
+package deadletter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	objectstore "github.com/frain-dev/convoy/internal/pkg/object-store"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/kafka"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/sqs"
+)
+
+var ErrUnsupportedSink = errors.New("unsupported dead letter sink")
+
+// Message is what is forwarded to the sink for every dead-lettered event delivery.
+type Message struct {
+	DeadLetter *datastore.DeadLetter `json:"dead_letter"`
+	Payload    json.RawMessage       `json:"payload"`
+}
+
+// NewMessage builds the message for the dead letter, payloads that aren't json
+// are forwarded as a json string.
+func NewMessage(deadLetter *datastore.DeadLetter, eventDelivery *datastore.EventDelivery) (*Message, error) {
+	m := &Message{DeadLetter: deadLetter, Payload: json.RawMessage("null")}
+	if eventDelivery.Metadata == nil {
+		return m, nil
+	}
+
+	if len(eventDelivery.Metadata.Data) > 0 && json.Valid(eventDelivery.Metadata.Data) {
+		m.Payload = eventDelivery.Metadata.Data
+		return m, nil
+	}
+
+	raw, err := json.Marshal(eventDelivery.Metadata.Raw)
+	if err != nil {
+		return nil, err
+	}
+	m.Payload = raw
+
+	return m, nil
+}
+
+// Sink forwards dead-lettered event deliveries out of convoy.
+type Sink interface {
+	Forward(ctx context.Context, m *Message) error
+}
+
+// NewSink returns the sink for the configuration, it returns nil when no sink is configured.
+func NewSink(cfg config.DeadLetterSinkConfiguration) (Sink, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case config.KafkaDeadLetterSink:
+		k := &kafka.Kafka{Cfg: &datastore.KafkaPubSubConfig{
+			Brokers:   cfg.Kafka.Brokers,
+			TopicName: cfg.Kafka.Topic,
+		}}
+
+		if len(cfg.Kafka.AuthType) > 0 {
+			k.Cfg.Auth = &datastore.KafkaAuth{
+				Type:     cfg.Kafka.AuthType,
+				Hash:     cfg.Kafka.Hash,
+				TLS:      cfg.Kafka.TLS,
+				Username: cfg.Kafka.Username,
+				Password: cfg.Kafka.Password,
+			}
+		}
+
+		return &kafkaSink{client: k}, nil
+	case config.SQSDeadLetterSink:
+		return &sqsSink{client: &sqs.Sqs{Cfg: &datastore.SQSPubSubConfig{
+			AccessKeyID:   cfg.SQS.AccessKeyID,
+			SecretKey:     cfg.SQS.SecretKey,
+			DefaultRegion: cfg.SQS.DefaultRegion,
+			QueueName:     cfg.SQS.QueueName,
+		}}}, nil
+	case config.S3DeadLetterSink:
+		store, err := objectstore.NewS3Client(objectstore.ObjectStoreOptions{
+			Prefix:       cfg.S3.Prefix,
+			Bucket:       cfg.S3.Bucket,
+			AccessKey:    cfg.S3.AccessKey,
+			SecretKey:    cfg.S3.SecretKey,
+			Region:       cfg.S3.Region,
+			SessionToken: cfg.S3.SessionToken,
+			Endpoint:     cfg.S3.Endpoint,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &s3Sink{client: store.(*objectstore.S3Client)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSink, cfg.Type)
+	}
+}
+
+type kafkaSink struct {
+	client *kafka.Kafka
+}
+
+func (k *kafkaSink) Forward(ctx context.Context, m *Message) error {
+	value, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return k.client.Publish(ctx, m.DeadLetter.EventDeliveryID, value)
+}
+
+type sqsSink struct {
+	client *sqs.Sqs
+}
+
+func (s *sqsSink) Forward(ctx context.Context, m *Message) error {
+	value, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Publish(ctx, value)
+}
+
+type s3Sink struct {
+	client interface {
+		Put(ctx context.Context, key string, body io.Reader) error
+	}
+}
+
+// Forward uploads the message as dead-letters/<project>/<endpoint>/<event delivery>.json
+func (s *s3Sink) Forward(ctx context.Context, m *Message) error {
+	value, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	d := m.DeadLetter
+	key := fmt.Sprintf("dead-letters/%s/%s/%s.json", d.ProjectID, d.EndpointID, d.EventDeliveryID)
+	return s.client.Put(ctx, key, bytes.NewReader(value))
+}
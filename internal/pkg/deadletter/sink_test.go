@@ -0,0 +1,35 @@
+package deadletter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessage(t *testing.T) {
+	deadLetter := &datastore.DeadLetter{UID: "dead-letter-1"}
+
+	m, err := NewMessage(deadLetter, &datastore.EventDelivery{Metadata: &datastore.Metadata{Data: []byte(`{"id": 1}`)}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": 1}`, string(m.Payload))
+
+	m, err = NewMessage(deadLetter, &datastore.EventDelivery{Metadata: &datastore.Metadata{Raw: "id=1"}})
+	require.NoError(t, err)
+	require.JSONEq(t, `"id=1"`, string(m.Payload))
+}
+
+func TestNewSink(t *testing.T) {
+	sink, err := NewSink(config.DeadLetterSinkConfiguration{})
+	require.NoError(t, err)
+	require.Nil(t, sink)
+
+	_, err = NewSink(config.DeadLetterSinkConfiguration{Type: "gcs"})
+	require.True(t, errors.Is(err, ErrUnsupportedSink))
+
+	sink, err = NewSink(config.DeadLetterSinkConfiguration{Type: config.KafkaDeadLetterSink})
+	require.NoError(t, err)
+	require.IsType(t, &kafkaSink{}, sink)
+}
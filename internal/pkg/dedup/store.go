@@ -0,0 +1,102 @@
+package dedup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// Store remembers keys until their ttl runs out.
+type Store interface {
+	// SetIfAbsent stores key for ttl and reports whether it was stored,
+	// it returns false while an earlier copy of key hasn't expired.
+	SetIfAbsent(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Delete forgets key.
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	memoryStore     *MemoryStore
+	memoryStoreOnce sync.Once
+)
+
+// NewStore returns the store configured in cfg, the memory store is shared
+// by the whole process so that every caller sees the same keys.
+func NewStore(cfg config.DedupConfiguration, client redis.UniversalClient) Store {
+	if cfg.Store == config.MemoryDedupStore {
+		memoryStoreOnce.Do(func() {
+			memoryStore = NewMemoryStore()
+		})
+		return memoryStore
+	}
+
+	return NewRedisStore(client)
+}
+
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) SetIfAbsent(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, 1, ttl).Result()
+}
+
+func (r *RedisStore) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+// sweepInterval is how often the memory store drops expired keys.
+const sweepInterval = time.Minute
+
+type MemoryStore struct {
+	mu        sync.Mutex
+	keys      map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		keys:      map[string]time.Time{},
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (m *MemoryStore) SetIfAbsent(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for k, expiresAt := range m.keys {
+			if !now.Before(expiresAt) {
+				delete(m.keys, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	if expiresAt, ok := m.keys[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	m.keys[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.keys, key)
+	return nil
+}
@@ -0,0 +1,72 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+)
+
+// MaxWindow is the longest dedup window a source or event can set.
+const MaxWindow = 30 * 24 * time.Hour
+
+var ErrInvalidWindow = errors.New("invalid dedup window")
+
+// ParseWindow parses a dedup window such as 24h or 90m, an empty window
+// means idempotency keys are compared with every earlier event.
+func ParseWindow(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidWindow, err)
+	}
+
+	if d <= 0 || d > MaxWindow {
+		return 0, fmt.Errorf("%w: it must be between 1s and %s", ErrInvalidWindow, MaxWindow)
+	}
+
+	return d, nil
+}
+
+// Window dedupes idempotency keys seen within a window of time, unlike
+// DeDuper it doesn't look the key up in the events table.
+type Window struct {
+	store   Store
+	metrics *metrics.Metrics
+}
+
+func NewWindow(store Store, m *metrics.Metrics) *Window {
+	return &Window{store: store, metrics: m}
+}
+
+// IsDuplicate reports whether key was already seen in the project within
+// window, the first call for a key claims it until the window closes. The
+// claim must be released when the event it was claimed for isn't queued.
+func (w *Window) IsDuplicate(ctx context.Context, projectID, key string, window time.Duration) (bool, error) {
+	stored, err := w.store.SetIfAbsent(ctx, claimKey(projectID, key), window)
+	if err != nil {
+		return false, err
+	}
+
+	if w.metrics != nil {
+		w.metrics.RecordDedupCheck(projectID, !stored)
+	}
+
+	return !stored, nil
+}
+
+// Release gives up the claim on key, so a retry of the event isn't taken
+// for a duplicate.
+func (w *Window) Release(ctx context.Context, projectID, key string) error {
+	return w.store.Delete(ctx, claimKey(projectID, key))
+}
+
+func claimKey(projectID, key string) string {
+	return convoy.DedupCacheKey.Get(projectID + ":" + key).String()
+}
@@ -0,0 +1,102 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := map[string]struct {
+		window  string
+		want    time.Duration
+		wantErr bool
+	}{
+		"empty":          {window: "", want: 0},
+		"hours":          {window: "24h", want: 24 * time.Hour},
+		"minutes":        {window: "90m", want: 90 * time.Minute},
+		"max":            {window: "720h", want: MaxWindow},
+		"too_long":       {window: "721h", wantErr: true},
+		"negative":       {window: "-1h", wantErr: true},
+		"zero":           {window: "0s", wantErr: true},
+		"not_a_duration": {window: "1 day", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseWindow(tc.window)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidWindow)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMemoryStore_SetIfAbsent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	stored, err := s.SetIfAbsent(ctx, "key", time.Hour)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	stored, err = s.SetIfAbsent(ctx, "key", time.Hour)
+	require.NoError(t, err)
+	require.False(t, stored)
+
+	stored, err = s.SetIfAbsent(ctx, "other-key", time.Minute)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	// the key is claimed again once it expires
+	now = now.Add(time.Hour)
+	stored, err = s.SetIfAbsent(ctx, "key", time.Hour)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	// expired keys are swept
+	require.NotContains(t, s.keys, "other-key")
+}
+
+func TestWindow_IsDuplicate(t *testing.T) {
+	ctx := context.Background()
+	w := NewWindow(NewMemoryStore(), nil)
+
+	isDuplicate, err := w.IsDuplicate(ctx, "project-1", "key", time.Hour)
+	require.NoError(t, err)
+	require.False(t, isDuplicate)
+
+	isDuplicate, err = w.IsDuplicate(ctx, "project-1", "key", time.Hour)
+	require.NoError(t, err)
+	require.True(t, isDuplicate)
+
+	// keys are scoped to the project
+	isDuplicate, err = w.IsDuplicate(ctx, "project-2", "key", time.Hour)
+	require.NoError(t, err)
+	require.False(t, isDuplicate)
+}
+
+func TestWindow_Release(t *testing.T) {
+	ctx := context.Background()
+	w := NewWindow(NewMemoryStore(), nil)
+
+	isDuplicate, err := w.IsDuplicate(ctx, "project-1", "key", time.Hour)
+	require.NoError(t, err)
+	require.False(t, isDuplicate)
+
+	// the event wasn't queued, so a retry isn't a duplicate
+	require.NoError(t, w.Release(ctx, "project-1", "key"))
+
+	isDuplicate, err = w.IsDuplicate(ctx, "project-1", "key", time.Hour)
+	require.NoError(t, err)
+	require.False(t, isDuplicate)
+}
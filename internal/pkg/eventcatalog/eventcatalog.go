@@ -0,0 +1,458 @@
+// Package eventcatalog describes the webhooks a project sends as AsyncAPI and
+// OpenAPI documents, so receivers can generate code for them. Every event
+// type is a webhook, its JSON Schema is the payload when it has one.
+package eventcatalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/ghodss/yaml"
+)
+
+type Spec string
+
+const (
+	AsyncAPI2 Spec = "asyncapi-2.6"
+	AsyncAPI3 Spec = "asyncapi-3.0"
+	OpenAPI   Spec = "openapi-3.1"
+)
+
+type Format string
+
+const (
+	JSONFormat Format = "json"
+	YAMLFormat Format = "yaml"
+)
+
+const (
+	defaultVersion = "1.0.0"
+	contentType    = "application/json"
+)
+
+var (
+	ErrUnsupportedSpec   = errors.New("spec must be one of asyncapi-2.6, asyncapi-3.0 or openapi-3.1")
+	ErrUnsupportedFormat = errors.New("format must be one of json or yaml")
+
+	invalidKeyChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+)
+
+func ParseSpec(s string) (Spec, error) {
+	switch Spec(s) {
+	case "":
+		return AsyncAPI3, nil
+	case AsyncAPI2, AsyncAPI3, OpenAPI:
+		return Spec(s), nil
+	default:
+		return "", ErrUnsupportedSpec
+	}
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "":
+		return JSONFormat, nil
+	case JSONFormat:
+		return JSONFormat, nil
+	case YAMLFormat, "yml":
+		return YAMLFormat, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Options describe the document.
+type Options struct {
+	// Version is the version of the document, it defaults to 1.0.0.
+	Version string
+}
+
+// Generate renders the document of spec for the project's event types.
+func Generate(spec Spec, project *datastore.Project, eventTypes []datastore.ProjectEventType, opts Options) (map[string]interface{}, error) {
+	if opts.Version == "" {
+		opts.Version = defaultVersion
+	}
+
+	webhooks, err := newWebhooks(eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &catalog{project: project, webhooks: webhooks, opts: opts}
+
+	switch spec {
+	case AsyncAPI2:
+		return c.asyncAPI2(), nil
+	case AsyncAPI3:
+		return c.asyncAPI3(), nil
+	case OpenAPI:
+		return c.openAPI(), nil
+	default:
+		return nil, ErrUnsupportedSpec
+	}
+}
+
+// Encode marshals a document, the content type of the result is returned
+// with it.
+func Encode(doc map[string]interface{}, format Format) ([]byte, string, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format != YAMLFormat {
+		return b, "application/json", nil
+	}
+
+	b, err = yaml.JSONToYAML(b)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return b, "application/yaml", nil
+}
+
+type webhook struct {
+	key       string
+	eventType datastore.ProjectEventType
+	schema    interface{}
+	defs      map[string]interface{}
+}
+
+// newWebhooks sorts the event types by name and gives each a component key.
+// The catch-all * event type isn't a webhook, and event type names aren't
+// unique so only the first of a name is kept, preferring the ones that
+// aren't deprecated.
+func newWebhooks(eventTypes []datastore.ProjectEventType) ([]webhook, error) {
+	sorted := make([]datastore.ProjectEventType, len(eventTypes))
+	copy(sorted, eventTypes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return !sorted[i].DeprecatedAt.Valid && sorted[j].DeprecatedAt.Valid
+	})
+
+	var webhooks []webhook
+	names := map[string]bool{}
+	keys := map[string]int{}
+
+	for _, et := range sorted {
+		if et.Name == "*" || names[et.Name] {
+			continue
+		}
+		names[et.Name] = true
+
+		key := invalidKeyChars.ReplaceAllString(et.Name, "_")
+		if n := keys[key]; n > 0 {
+			key = fmt.Sprintf("%s_%d", key, n+1)
+		}
+		keys[key]++
+
+		schema, defs, err := payloadSchema(key, &et)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook{key: key, eventType: et, schema: schema, defs: defs})
+	}
+
+	return webhooks, nil
+}
+
+// payloadSchema returns the event type's schema to be placed at
+// #/components/schemas/<key>. Its definitions are hoisted to their own
+// components named <key>.<definition>, since most code generators only
+// resolve refs to components, and its local $refs are rewritten to match.
+func payloadSchema(key string, et *datastore.ProjectEventType) (interface{}, map[string]interface{}, error) {
+	if !et.HasSchema() {
+		return map[string]interface{}{}, nil, nil
+	}
+
+	var schema interface{}
+	if err := json.Unmarshal(et.JSONSchema, &schema); err != nil {
+		return nil, nil, fmt.Errorf("the schema of %s is invalid: %v", et.Name, err)
+	}
+
+	defs := map[string]interface{}{}
+	if m, ok := schema.(map[string]interface{}); ok {
+		// the dialect is the document's
+		delete(m, "$schema")
+
+		for _, keyword := range []string{"definitions", "$defs"} {
+			d, ok := m[keyword].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for name, def := range d {
+				defs[key+"."+invalidKeyChars.ReplaceAllString(name, "_")] = def
+			}
+			delete(m, keyword)
+		}
+	}
+
+	rewriteRefs(schema, key)
+	for _, def := range defs {
+		rewriteRefs(def, key)
+	}
+
+	return schema, defs, nil
+}
+
+func rewriteRefs(v interface{}, key string) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if ref, ok := child.(string); ok && k == "$ref" && strings.HasPrefix(ref, "#") {
+				t[k] = componentRef(key, ref)
+				continue
+			}
+			rewriteRefs(child, key)
+		}
+	case []interface{}:
+		for _, child := range t {
+			rewriteRefs(child, key)
+		}
+	}
+}
+
+// componentRef resolves ref, a pointer into the schema of key, from the
+// root of the document.
+func componentRef(key, ref string) string {
+	for _, keyword := range []string{"definitions", "$defs"} {
+		prefix := "#/" + keyword + "/"
+		if !strings.HasPrefix(ref, prefix) {
+			continue
+		}
+
+		name, rest, _ := strings.Cut(strings.TrimPrefix(ref, prefix), "/")
+		ref = "#/components/schemas/" + key + "." + invalidKeyChars.ReplaceAllString(name, "_")
+		if rest != "" {
+			ref += "/" + rest
+		}
+		return ref
+	}
+
+	return "#/components/schemas/" + key + ref[1:]
+}
+
+type header struct {
+	name        string
+	description string
+}
+
+type catalog struct {
+	project  *datastore.Project
+	webhooks []webhook
+	opts     Options
+}
+
+// headers are the headers webhooks are signed with.
+func (c *catalog) headers() []header {
+	var signature *datastore.SignatureConfiguration
+	if c.project.Config != nil {
+		signature = c.project.Config.Signature
+	}
+
+	if signature.IsStandardWebhooks() {
+		return []header{
+			{name: "webhook-id", description: "The unique id of the message, it is the same across retries"},
+			{name: "webhook-timestamp", description: "When the message was signed in seconds since the epoch"},
+			{name: "webhook-signature", description: "Space separated signatures of the message"},
+		}
+	}
+
+	name := config.DefaultSignatureHeader.String()
+	if signature != nil && signature.Header != "" {
+		name = signature.Header.String()
+	}
+
+	return []header{{name: name, description: "The signature of the payload"}}
+}
+
+func (c *catalog) info() map[string]interface{} {
+	return map[string]interface{}{
+		"title":       fmt.Sprintf("%s webhooks", c.project.Name),
+		"version":     c.opts.Version,
+		"description": fmt.Sprintf("The webhooks sent by the %s project.", c.project.Name),
+	}
+}
+
+func (c *catalog) schemas() map[string]interface{} {
+	schemas := map[string]interface{}{}
+	for _, w := range c.webhooks {
+		schemas[w.key] = w.schema
+		for k, def := range w.defs {
+			schemas[k] = def
+		}
+	}
+	return schemas
+}
+
+func (c *catalog) headersSchema() map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	for _, h := range c.headers() {
+		properties[h.name] = map[string]interface{}{"type": "string", "description": h.description}
+		required = append(required, h.name)
+	}
+
+	return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+}
+
+func (c *catalog) messages() map[string]interface{} {
+	messages := map[string]interface{}{}
+	for _, w := range c.webhooks {
+		m := map[string]interface{}{
+			"name":        w.eventType.Name,
+			"title":       w.eventType.Name,
+			"contentType": contentType,
+			"headers":     c.headersSchema(),
+			"payload":     map[string]interface{}{"$ref": "#/components/schemas/" + w.key},
+		}
+
+		if w.eventType.Description != "" {
+			m["summary"] = w.eventType.Description
+		}
+
+		if w.eventType.Category != "" {
+			m["tags"] = []interface{}{map[string]interface{}{"name": w.eventType.Category}}
+		}
+
+		if w.eventType.DeprecatedAt.Valid {
+			m["x-deprecated"] = true
+		}
+
+		if w.eventType.HasSchema() {
+			m["x-schema-version"] = w.eventType.SchemaVersion
+		}
+
+		messages[w.key] = m
+	}
+
+	return messages
+}
+
+// asyncAPI2 describes the webhooks from the project's point of view, so
+// receivers subscribe to the channels.
+func (c *catalog) asyncAPI2() map[string]interface{} {
+	channels := map[string]interface{}{}
+	for _, w := range c.webhooks {
+		channels[w.eventType.Name] = map[string]interface{}{
+			"subscribe": map[string]interface{}{
+				"operationId": "receive_" + w.key,
+				"message":     map[string]interface{}{"$ref": "#/components/messages/" + w.key},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"asyncapi":           "2.6.0",
+		"id":                 "urn:convoy:project:" + c.project.UID,
+		"info":               c.info(),
+		"defaultContentType": contentType,
+		"channels":           channels,
+		"components": map[string]interface{}{
+			"messages": c.messages(),
+			"schemas":  c.schemas(),
+		},
+	}
+}
+
+func (c *catalog) asyncAPI3() map[string]interface{} {
+	channels := map[string]interface{}{}
+	operations := map[string]interface{}{}
+	for _, w := range c.webhooks {
+		channels[w.key] = map[string]interface{}{
+			"address": w.eventType.Name,
+			"messages": map[string]interface{}{
+				w.key: map[string]interface{}{"$ref": "#/components/messages/" + w.key},
+			},
+		}
+
+		operations["send_"+w.key] = map[string]interface{}{
+			"action":   "send",
+			"channel":  map[string]interface{}{"$ref": "#/channels/" + w.key},
+			"messages": []interface{}{map[string]interface{}{"$ref": "#/channels/" + w.key + "/messages/" + w.key}},
+		}
+	}
+
+	return map[string]interface{}{
+		"asyncapi":           "3.0.0",
+		"id":                 "urn:convoy:project:" + c.project.UID,
+		"info":               c.info(),
+		"defaultContentType": contentType,
+		"channels":           channels,
+		"operations":         operations,
+		"components": map[string]interface{}{
+			"messages": c.messages(),
+			"schemas":  c.schemas(),
+		},
+	}
+}
+
+func (c *catalog) openAPI() map[string]interface{} {
+	var parameters []interface{}
+	for _, h := range c.headers() {
+		parameters = append(parameters, map[string]interface{}{
+			"name":        h.name,
+			"in":          "header",
+			"required":    true,
+			"description": h.description,
+			"schema":      map[string]interface{}{"type": "string"},
+		})
+	}
+
+	webhooks := map[string]interface{}{}
+	for _, w := range c.webhooks {
+		op := map[string]interface{}{
+			"operationId": "receive_" + w.key,
+			"summary":     w.eventType.Name,
+			"parameters":  parameters,
+			"requestBody": map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					contentType: map[string]interface{}{
+						"schema": map[string]interface{}{"$ref": "#/components/schemas/" + w.key},
+					},
+				},
+			},
+			"responses": map[string]interface{}{
+				"2XX": map[string]interface{}{"description": "The webhook was received, other responses are retried"},
+			},
+		}
+
+		if w.eventType.Description != "" {
+			op["description"] = w.eventType.Description
+		}
+
+		if w.eventType.Category != "" {
+			op["tags"] = []interface{}{w.eventType.Category}
+		}
+
+		if w.eventType.DeprecatedAt.Valid {
+			op["deprecated"] = true
+		}
+
+		webhooks[w.eventType.Name] = map[string]interface{}{"post": op}
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info":    c.info(),
+		// there are no endpoints to call, an empty paths keeps tools
+		// that still require it happy
+		"paths":    map[string]interface{}{},
+		"webhooks": webhooks,
+		"components": map[string]interface{}{
+			"schemas": c.schemas(),
+		},
+	}
+}
@@ -0,0 +1,154 @@
+package eventcatalog
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+const invoiceSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"properties": {
+		"id": {"type": "string"},
+		"amount": {"$ref": "#/definitions/amount"}
+	},
+	"definitions": {
+		"amount": {"type": "integer"}
+	}
+}`
+
+func testProject() *datastore.Project {
+	return &datastore.Project{
+		UID:  "project-1",
+		Name: "Billing",
+		Config: &datastore.ProjectConfig{
+			Signature: &datastore.SignatureConfiguration{Header: "X-Billing-Signature"},
+		},
+	}
+}
+
+func testEventTypes() []datastore.ProjectEventType {
+	return []datastore.ProjectEventType{
+		{UID: "1", Name: "*"},
+		{UID: "2", Name: "invoice.paid", Category: "invoices", Description: "An invoice was paid", JSONSchema: json.RawMessage(invoiceSchema), SchemaVersion: 2},
+		{UID: "3", Name: "invoice.paid", DeprecatedAt: null.NewTime(time.Now(), true)},
+		{UID: "4", Name: "customer created", DeprecatedAt: null.NewTime(time.Now(), true), JSONSchema: json.RawMessage("null")},
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec("")
+	require.NoError(t, err)
+	require.Equal(t, AsyncAPI3, spec)
+
+	spec, err = ParseSpec("openapi-3.1")
+	require.NoError(t, err)
+	require.Equal(t, OpenAPI, spec)
+
+	_, err = ParseSpec("swagger-2.0")
+	require.ErrorIs(t, err, ErrUnsupportedSpec)
+}
+
+func TestGenerate_OpenAPI(t *testing.T) {
+	doc, err := Generate(OpenAPI, testProject(), testEventTypes(), Options{})
+	require.NoError(t, err)
+
+	// kin-openapi doesn't know 3.1 webhooks yet, so they are validated as paths
+	paths := map[string]interface{}{}
+	for name, item := range doc["webhooks"].(map[string]interface{}) {
+		paths["/"+name] = item
+	}
+
+	b, _, err := Encode(map[string]interface{}{
+		"openapi":    "3.0.3",
+		"info":       doc["info"],
+		"paths":      paths,
+		"components": doc["components"],
+	}, JSONFormat)
+	require.NoError(t, err)
+
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData(b)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+	require.Equal(t, "3.1.0", doc["openapi"])
+
+	require.Equal(t, "Billing webhooks", spec.Info.Title)
+	require.Equal(t, "1.0.0", spec.Info.Version)
+
+	webhooks := doc["webhooks"].(map[string]interface{})
+	require.Len(t, webhooks, 2)
+	require.NotContains(t, webhooks, "*")
+
+	paid := webhooks["invoice.paid"].(map[string]interface{})["post"].(map[string]interface{})
+	require.NotContains(t, paid, "deprecated")
+	require.Equal(t, "An invoice was paid", paid["description"])
+	require.Equal(t, "X-Billing-Signature", paid["parameters"].([]interface{})[0].(map[string]interface{})["name"])
+
+	created := webhooks["customer created"].(map[string]interface{})["post"].(map[string]interface{})
+	require.Equal(t, true, created["deprecated"])
+	require.Equal(t, "receive_customer_created", created["operationId"])
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	invoice := schemas["invoice.paid"].(map[string]interface{})
+	require.NotContains(t, invoice, "$schema")
+
+	amount := invoice["properties"].(map[string]interface{})["amount"].(map[string]interface{})
+	require.Equal(t, "#/components/schemas/invoice.paid.amount", amount["$ref"])
+	require.NotContains(t, invoice, "definitions")
+	require.Equal(t, map[string]interface{}{"type": "integer"}, schemas["invoice.paid.amount"])
+	require.Equal(t, map[string]interface{}{}, schemas["customer_created"])
+}
+
+func TestGenerate_AsyncAPI(t *testing.T) {
+	project := testProject()
+	project.Config.Signature.Scheme = datastore.StandardWebhooksSignatureScheme
+
+	doc, err := Generate(AsyncAPI3, project, testEventTypes(), Options{Version: "2.1.0"})
+	require.NoError(t, err)
+	require.Equal(t, "3.0.0", doc["asyncapi"])
+	require.Equal(t, "2.1.0", doc["info"].(map[string]interface{})["version"])
+
+	channels := doc["channels"].(map[string]interface{})
+	require.Len(t, channels, 2)
+	require.Equal(t, "invoice.paid", channels["invoice.paid"].(map[string]interface{})["address"])
+
+	operations := doc["operations"].(map[string]interface{})
+	require.Equal(t, "send", operations["send_invoice.paid"].(map[string]interface{})["action"])
+
+	messages := doc["components"].(map[string]interface{})["messages"].(map[string]interface{})
+	paid := messages["invoice.paid"].(map[string]interface{})
+	require.Equal(t, 2, paid["x-schema-version"])
+
+	headers := paid["headers"].(map[string]interface{})["properties"].(map[string]interface{})
+	require.Contains(t, headers, "webhook-signature")
+	require.NotContains(t, headers, "X-Billing-Signature")
+
+	doc, err = Generate(AsyncAPI2, project, testEventTypes(), Options{})
+	require.NoError(t, err)
+	require.Equal(t, "2.6.0", doc["asyncapi"])
+
+	channel := doc["channels"].(map[string]interface{})["customer created"].(map[string]interface{})
+	require.Equal(t, "#/components/messages/customer_created", channel["subscribe"].(map[string]interface{})["message"].(map[string]interface{})["$ref"])
+}
+
+func TestEncode_YAML(t *testing.T) {
+	doc, err := Generate(AsyncAPI3, testProject(), testEventTypes(), Options{})
+	require.NoError(t, err)
+
+	b, contentType, err := Encode(doc, YAMLFormat)
+	require.NoError(t, err)
+	require.Equal(t, "application/yaml", contentType)
+
+	var decoded map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(b, &decoded))
+	require.Equal(t, "3.0.0", decoded["asyncapi"])
+}
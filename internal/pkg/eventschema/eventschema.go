@@ -0,0 +1,177 @@
+// Package eventschema validates event payloads against the JSON Schema
+// of their event type.
+package eventschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// maxCachedSchemas bounds the number of compiled schemas kept in memory.
+const maxCachedSchemas = 1000
+
+var (
+	ErrInvalidSchema = errors.New("json_schema is not a valid JSON Schema")
+	ErrRemoteRef     = errors.New("json_schema can only reference definitions in the same document")
+)
+
+// ValidationError is returned when an event's payload doesn't match the
+// schema of its event type and the event type rejects invalid payloads.
+type ValidationError struct {
+	EventType string
+	Version   int
+	Errors    []string
+}
+
+func (v *ValidationError) Error() string {
+	return fmt.Sprintf("event data does not match version %d of the %s schema: %s", v.Version, v.EventType, strings.Join(v.Errors, "; "))
+}
+
+// Compile parses schema and checks that it is a valid JSON Schema. Schemas
+// are supplied by users, so $refs outside the document are rejected to keep
+// the loader from fetching urls or reading files.
+func Compile(schema json.RawMessage) (*gojsonschema.Schema, error) {
+	var doc interface{}
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, ErrInvalidSchema
+	}
+
+	if _, ok := doc.(map[string]interface{}); !ok {
+		return nil, ErrInvalidSchema
+	}
+
+	if hasRemoteRef(doc) {
+		return nil, ErrRemoteRef
+	}
+
+	s, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+
+	return s, nil
+}
+
+func hasRemoteRef(v interface{}) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if k == "$ref" {
+				if ref, ok := child.(string); !ok || !strings.HasPrefix(ref, "#") {
+					return true
+				}
+			}
+
+			if hasRemoteRef(child) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range t {
+			if hasRemoteRef(child) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Validate returns why data doesn't match schema, it is empty when it does.
+func Validate(schema *gojsonschema.Schema, data []byte) ([]string, error) {
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []string
+	for _, e := range result.Errors() {
+		errs = append(errs, e.String())
+	}
+
+	return errs, nil
+}
+
+// Validator validates payloads against the current schema of their event type.
+type Validator struct {
+	eventTypeRepo datastore.EventTypesRepository
+}
+
+func NewValidator(eventTypeRepo datastore.EventTypesRepository) *Validator {
+	return &Validator{eventTypeRepo: eventTypeRepo}
+}
+
+// Check validates data against the schema of eventType. Events whose type has
+// no schema aren't validated, so the status is empty. A *ValidationError is
+// returned for invalid payloads when the event type rejects them, otherwise
+// they are flagged with datastore.InvalidSchemaStatus.
+func (v *Validator) Check(ctx context.Context, projectID, eventType string, data []byte) (datastore.EventSchemaStatus, error) {
+	et, err := v.eventTypeRepo.FetchEventTypeByName(ctx, eventType, projectID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventTypeNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if !et.HasSchema() {
+		return "", nil
+	}
+
+	schema, err := compileCached(et)
+	if err != nil {
+		return "", err
+	}
+
+	errs, err := Validate(schema, data)
+	if err != nil {
+		// the payload isn't json, so it can't match any schema
+		errs = []string{err.Error()}
+	}
+
+	if len(errs) == 0 {
+		return datastore.ValidSchemaStatus, nil
+	}
+
+	if et.SchemaValidation == datastore.FlagSchemaValidation {
+		return datastore.InvalidSchemaStatus, nil
+	}
+
+	return "", &ValidationError{EventType: eventType, Version: et.SchemaVersion, Errors: errs}
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]*gojsonschema.Schema{}
+)
+
+// compileCached compiles the event type's schema once per version.
+func compileCached(et *datastore.ProjectEventType) (*gojsonschema.Schema, error) {
+	key := fmt.Sprintf("%s:%d", et.UID, et.SchemaVersion)
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if s, ok := cache[key]; ok {
+		return s, nil
+	}
+
+	s, err := Compile(et.JSONSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cache) >= maxCachedSchemas {
+		cache = map[string]*gojsonschema.Schema{}
+	}
+	cache[key] = s
+
+	return s, nil
+}
@@ -0,0 +1,144 @@
+package eventschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const invoiceSchema = `{
+	"type": "object",
+	"required": ["id", "amount"],
+	"properties": {
+		"id": {"type": "string"},
+		"amount": {"$ref": "#/definitions/amount"}
+	},
+	"definitions": {
+		"amount": {"type": "integer", "minimum": 0}
+	}
+}`
+
+func TestCompile(t *testing.T) {
+	tests := map[string]struct {
+		schema  string
+		wantErr error
+	}{
+		"valid_schema": {
+			schema: invoiceSchema,
+		},
+		"not_json": {
+			schema:  `{"type":`,
+			wantErr: ErrInvalidSchema,
+		},
+		"not_an_object": {
+			schema:  `["string"]`,
+			wantErr: ErrInvalidSchema,
+		},
+		"invalid_keyword_value": {
+			schema:  `{"type": "money"}`,
+			wantErr: ErrInvalidSchema,
+		},
+		"remote_ref": {
+			schema:  `{"properties": {"id": {"$ref": "https://example.com/schema.json"}}}`,
+			wantErr: ErrRemoteRef,
+		},
+		"file_ref": {
+			schema:  `{"allOf": [{"$ref": "file:///etc/passwd"}]}`,
+			wantErr: ErrRemoteRef,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile(json.RawMessage(tc.schema))
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestValidator_Check(t *testing.T) {
+	tests := map[string]struct {
+		eventType  *datastore.ProjectEventType
+		repoErr    error
+		data       string
+		wantStatus datastore.EventSchemaStatus
+		wantErr    bool
+	}{
+		"valid_payload": {
+			eventType:  &datastore.ProjectEventType{UID: "et-valid", SchemaVersion: 1, JSONSchema: json.RawMessage(invoiceSchema), SchemaValidation: datastore.RejectSchemaValidation},
+			data:       `{"id": "inv_1", "amount": 100}`,
+			wantStatus: datastore.ValidSchemaStatus,
+		},
+		"invalid_payload_is_rejected": {
+			eventType: &datastore.ProjectEventType{UID: "et-reject", SchemaVersion: 1, JSONSchema: json.RawMessage(invoiceSchema), SchemaValidation: datastore.RejectSchemaValidation},
+			data:      `{"id": "inv_1", "amount": -1}`,
+			wantErr:   true,
+		},
+		"invalid_payload_is_flagged": {
+			eventType:  &datastore.ProjectEventType{UID: "et-flag", SchemaVersion: 2, JSONSchema: json.RawMessage(invoiceSchema), SchemaValidation: datastore.FlagSchemaValidation},
+			data:       `{"id": 1}`,
+			wantStatus: datastore.InvalidSchemaStatus,
+		},
+		"non_json_payload_is_flagged": {
+			eventType:  &datastore.ProjectEventType{UID: "et-flag", SchemaVersion: 2, JSONSchema: json.RawMessage(invoiceSchema), SchemaValidation: datastore.FlagSchemaValidation},
+			data:       `id=1&amount=2`,
+			wantStatus: datastore.InvalidSchemaStatus,
+		},
+		"event_type_without_schema": {
+			eventType: &datastore.ProjectEventType{UID: "et-none", JSONSchema: json.RawMessage("null")},
+			data:      `{"id": 1}`,
+		},
+		"unknown_event_type": {
+			repoErr: datastore.ErrEventTypeNotFound,
+			data:    `{"id": 1}`,
+		},
+		"repo_error": {
+			repoErr: errors.New("connection refused"),
+			data:    `{"id": 1}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEventTypesRepository(ctrl)
+			repo.EXPECT().FetchEventTypeByName(gomock.Any(), "invoice.paid", "project-1").Return(tc.eventType, tc.repoErr)
+
+			status, err := NewValidator(repo).Check(context.Background(), "project-1", "invoice.paid", []byte(tc.data))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantStatus, status)
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	s, err := Compile(json.RawMessage(invoiceSchema))
+	require.NoError(t, err)
+
+	errs, err := Validate(s, []byte(`{"id": "inv_1"}`))
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+
+	verr := &ValidationError{EventType: "invoice.paid", Version: 3, Errors: errs}
+	require.Contains(t, verr.Error(), "version 3 of the invoice.paid schema")
+	require.Contains(t, verr.Error(), "amount")
+}
@@ -0,0 +1,62 @@
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	fetchCurrentDataKey = `
+	SELECT wrapped_key FROM convoy.encryption_keys
+	WHERE provider = $1
+	ORDER BY version DESC LIMIT 1;
+	`
+
+	initDataKey = `
+	INSERT INTO convoy.encryption_keys (id, provider, version, wrapped_key)
+	VALUES ($1, $2, 1, $3)
+	ON CONFLICT (provider, version) DO NOTHING;
+	`
+
+	saveDataKey = `
+	INSERT INTO convoy.encryption_keys (id, provider, version, wrapped_key)
+	SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3
+	FROM convoy.encryption_keys WHERE provider = $2;
+	`
+)
+
+// dbDataKeyStore keeps wrapped data keys in the encryption_keys table, keys
+// are versioned per provider so switching providers never hands a wrapper
+// a key it didn't wrap.
+type dbDataKeyStore struct {
+	db       database.Database
+	provider string
+}
+
+func NewDataKeyStore(db database.Database, provider string) DataKeyStore {
+	return &dbDataKeyStore{db: db, provider: provider}
+}
+
+func (d *dbDataKeyStore) Current(ctx context.Context) (string, error) {
+	var wrapped string
+	err := d.db.GetDB().GetContext(ctx, &wrapped, fetchCurrentDataKey, d.provider)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	return wrapped, nil
+}
+
+func (d *dbDataKeyStore) Init(ctx context.Context, wrapped string) error {
+	_, err := d.db.GetDB().ExecContext(ctx, initDataKey, ulid.Make().String(), d.provider, wrapped)
+	return err
+}
+
+func (d *dbDataKeyStore) Save(ctx context.Context, wrapped string) error {
+	_, err := d.db.GetDB().ExecContext(ctx, saveDataKey, ulid.Make().String(), d.provider, wrapped)
+	return err
+}
@@ -21,6 +21,13 @@ func InitEncryption(lo log.StdLogger, db database.Database, km KeyManager, encry
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	version, err := KeyVersion(ctx, db, encryptionKey)
+	if err != nil {
+		rollback(lo, tx)
+		lo.WithError(err).Error("failed to find the version of the key")
+		return err
+	}
+
 	for table, columns := range tablesAndColumns {
 		lo.Infof("Processing table: %s", table)
 
@@ -50,7 +57,7 @@ func InitEncryption(lo log.StdLogger, db database.Database, km KeyManager, encry
 			}
 		}
 
-		if err := markTableEncrypted(ctx, tx, table); err != nil {
+		if err := markTableEncrypted(ctx, tx, table, version); err != nil {
 			rollback(lo, tx)
 			lo.WithError(err).Error("failed to mark table")
 			return fmt.Errorf("failed to mark encryption status for table %s: %w", table, err)
@@ -75,12 +82,12 @@ func rollback(lo log.StdLogger, tx *sqlx.Tx) {
 }
 
 // checkEncryptionStatus checks if the column is already encrypted.
-func checkEncryptionStatus(ctx context.Context, tx *sqlx.Tx, table string) (bool, error) {
+func checkEncryptionStatus(ctx context.Context, q sqlx.QueryerContext, table string) (bool, error) {
 	checkQuery := fmt.Sprintf(
 		"SELECT is_encrypted FROM convoy.%s WHERE is_encrypted=TRUE LIMIT 1;", table,
 	)
 	var isEncrypted bool
-	err := tx.GetContext(ctx, &isEncrypted, checkQuery)
+	err := sqlx.GetContext(ctx, q, &isEncrypted, checkQuery)
 	if err != nil && err.Error() != "sql: no rows in result set" {
 		return false, fmt.Errorf("failed to check encryption status of table %s: %w", table, err)
 	}
@@ -111,7 +118,7 @@ func encryptColumn(lo log.StdLogger, ctx context.Context, tx *sqlx.Tx, table, co
 		return err
 	}
 	encryptQuery := fmt.Sprintf(
-		"UPDATE convoy.%s SET %s = pgp_sym_encrypt(%s::text, $1), %s = %s WHERE %s IS NOT NULL;",
+		"UPDATE convoy.%s SET %s = convoy.envelope_encrypt(%s::text, $1), %s = %s WHERE %s IS NOT NULL;",
 		table, cipherColumn, column, column, columnZero, column,
 	)
 	_, err = tx.ExecContext(ctx, encryptQuery, encryptionKey)
@@ -150,12 +157,13 @@ func getColumnZero(lo log.StdLogger, ctx context.Context, tx *sqlx.Tx, table, co
 	return NULL, nil
 }
 
-// markTableEncrypted sets the `is_encrypted` column to true.
-func markTableEncrypted(ctx context.Context, tx *sqlx.Tx, table string) error {
+// markTableEncrypted sets the `is_encrypted` column to true and records the
+// version of the key the table was encrypted with.
+func markTableEncrypted(ctx context.Context, tx *sqlx.Tx, table, keyVersion string) error {
 	markQuery := fmt.Sprintf(
-		"UPDATE convoy.%s SET is_encrypted = TRUE;", table,
+		"UPDATE convoy.%s SET is_encrypted = TRUE, key_version = $1;", table,
 	)
-	_, err := tx.ExecContext(ctx, markQuery)
+	_, err := tx.ExecContext(ctx, markQuery, keyVersion)
 	if err != nil {
 		return fmt.Errorf("failed to mark table %s as encrypted: %w", table, err)
 	}
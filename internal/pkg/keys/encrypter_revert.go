@@ -18,6 +18,13 @@ func RevertEncryption(lo log.StdLogger, db database.Database, encryptionKey stri
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	version, err := KeyVersion(ctx, db, encryptionKey)
+	if err != nil {
+		rollback(lo, tx)
+		lo.WithError(err).Error("failed to find the version of the key")
+		return err
+	}
+
 	for table, columns := range tablesAndColumns {
 		lo.Infof("Processing table: %s", table)
 
@@ -37,6 +44,11 @@ func RevertEncryption(lo log.StdLogger, db database.Database, encryptionKey stri
 			continue
 		}
 
+		if err := checkKeyVersion(ctx, tx, table, version); err != nil {
+			rollback(lo, tx)
+			return err
+		}
+
 		for column, cipherColumn := range columns {
 			if err := decryptAndRestoreColumn(lo, ctx, tx, table, column, cipherColumn, encryptionKey); err != nil {
 				rollback(lo, tx)
@@ -68,7 +80,7 @@ func decryptAndRestoreColumn(lo log.StdLogger, ctx context.Context, tx *sqlx.Tx,
 		return err
 	}
 	revertQuery := fmt.Sprintf(
-		"UPDATE convoy.%s SET %s = pgp_sym_decrypt(%s::bytea, $1)::%s WHERE %s IS NOT NULL;",
+		"UPDATE convoy.%s SET %s = convoy.envelope_decrypt(%s::bytea, $1)::%s WHERE %s IS NOT NULL;",
 		table, column, cipherColumn, columnType, cipherColumn,
 	)
 	_, err = tx.ExecContext(ctx, revertQuery, encryptionKey)
@@ -100,10 +112,30 @@ func getColumnType(lo log.StdLogger, ctx context.Context, tx *sqlx.Tx, table, co
 	return columnType, nil
 }
 
+// checkKeyVersion ensures no row of the table is encrypted with a key other
+// than the one of version, which is the case while a key is rotated.
+func checkKeyVersion(ctx context.Context, tx *sqlx.Tx, table, version string) error {
+	query := fmt.Sprintf(
+		"SELECT COUNT(*) FROM convoy.%s WHERE is_encrypted AND key_version IS NOT NULL AND key_version <> $1;", table,
+	)
+
+	var count int64
+	err := tx.GetContext(ctx, &count, query, version)
+	if err != nil {
+		return fmt.Errorf("failed to check key version of table %s: %w", table, err)
+	}
+
+	if count > 0 {
+		return ErrKeyRotationInProgress
+	}
+
+	return nil
+}
+
 // markTableDecrypted sets the `is_encrypted` column to false.
 func markTableDecrypted(ctx context.Context, tx *sqlx.Tx, table string) error {
 	markQuery := fmt.Sprintf(
-		"UPDATE convoy.%s SET is_encrypted = FALSE;", table,
+		"UPDATE convoy.%s SET is_encrypted = FALSE, key_version = NULL;", table,
 	)
 	_, err := tx.ExecContext(ctx, markQuery)
 	if err != nil {
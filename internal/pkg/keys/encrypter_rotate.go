@@ -2,82 +2,377 @@ package keys
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/log"
-	"github.com/jmoiron/sqlx"
+	"github.com/oklog/ulid/v2"
 )
 
-func RotateEncryptionKey(lo log.StdLogger, db database.Database, km KeyManager, oldKey, newKey string, timeout int) error {
+const (
+	// KeyRotationBatchSize is the number of rows re-encrypted in a transaction.
+	KeyRotationBatchSize = 500
+
+	// a running rotation that hasn't saved progress for this long is
+	// assumed to have crashed and may be resumed.
+	staleKeyRotationTimeout = 5 * time.Minute
 
-	tx, err := db.GetDB().Beginx()
+	// rows written with the previous key while a pass runs are picked up by
+	// the next one, there should never be a need for more than two.
+	maxKeyRotationPasses = 3
+)
+
+var (
+	ErrEncryptionKeyMismatch = errors.New("provided old key does not match the current encryption key")
+	ErrSameEncryptionKey     = errors.New("the new key must be different from the old key")
+	ErrKeyRotationStateLost  = errors.New("the previous encryption key of the rotation is no longer available, run rotate-key with the old and new keys again to resume it")
+	ErrKeyChangedInRotation  = errors.New("the encryption key was changed while it was being rotated")
+)
+
+// StartKeyRotation makes newKey the current encryption key and creates the
+// job that re-encrypts credentials with it, it is run by RunKeyRotation.
+// Until the job completes, credentials are read with the key they were
+// encrypted with. A rotation from oldKey to newKey that failed or crashed
+// is resumed rather than started again.
+func StartKeyRotation(ctx context.Context, db database.Database, jobRepo datastore.JobRepository, km KeyManager, oldKey, newKey string) (*datastore.Job, error) {
+	if oldKey == newKey {
+		return nil, ErrSameEncryptionKey
+	}
+
+	for _, table := range sortedTables() {
+		isEncrypted, err := checkEncryptionStatus(ctx, db.GetDB(), table)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isEncrypted {
+			return nil, fmt.Errorf("table %s has not been encrypted. Please initialize encryption first", table)
+		}
+	}
+
+	currentKey, err := km.GetCurrentKey()
 	if err != nil {
-		lo.WithError(err).Error("failed to begin transaction")
-		return err
+		return nil, err
+	}
+
+	fromVersion, err := KeyVersion(ctx, db, oldKey)
+	if err != nil {
+		return nil, err
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 
-	for table, columns := range tablesAndColumns {
-		lo.Infof("Processing table: %s", table)
+	toVersion, err := KeyVersion(ctx, db, newKey)
+	if err != nil {
+		return nil, err
+	}
 
-		err = lockTable(ctx, tx, table, timeout)
+	job, err := jobRepo.FetchLatestJobByType(ctx, datastore.JobTypeKeyRotation, "")
+	if err != nil && !errors.Is(err, datastore.ErrJobNotFound) {
+		return nil, err
+	}
+
+	if job != nil && job.Status != datastore.JobStatusCompleted {
+		if job.Progress == nil || job.Progress.FromVersion != fromVersion || job.Progress.ToVersion != toVersion {
+			return nil, ErrKeyRotationInProgress
+		}
+
+		if job.Status == datastore.JobStatusRunning && time.Since(job.UpdatedAt) < staleKeyRotationTimeout {
+			return nil, ErrKeyRotationInProgress
+		}
+
+		// the key may not have been set when the rotation was started
+		if currentKey != newKey && currentKey != oldKey {
+			return nil, ErrEncryptionKeyMismatch
+		}
+	} else {
+		if currentKey != oldKey {
+			return nil, ErrEncryptionKeyMismatch
+		}
+
+		// the job holds the previous key until the rotation completes, it
+		// is only stored encrypted with the new key
+		previousKey, err := wrapPreviousKey(ctx, db, oldKey, newKey)
 		if err != nil {
-			rollback(lo, tx)
-			lo.WithError(err).Error("failed to lock table")
-			return err
+			return nil, err
 		}
 
-		isEncrypted, err := checkEncryptionStatus(ctx, tx, table)
+		job = &datastore.Job{
+			UID:    ulid.Make().String(),
+			Type:   datastore.JobTypeKeyRotation,
+			Status: datastore.JobStatusReady,
+			Progress: &datastore.JobProgress{
+				FromVersion: fromVersion,
+				ToVersion:   toVersion,
+				PreviousKey: previousKey,
+			},
+		}
+
+		err = jobRepo.CreateJob(ctx, job)
 		if err != nil {
-			rollback(lo, tx)
-			lo.WithError(err).Error("failed to check encryption status")
-			return err
+			return nil, fmt.Errorf("failed to create key rotation job: %w", err)
 		}
+	}
 
-		if !isEncrypted {
-			rollback(lo, tx)
-			return fmt.Errorf("table %s has not been encrypted. Please initialize encryption first", table)
+	// every instance must be able to read credentials encrypted with the
+	// previous key before the new key is set
+	err = setKeyRotation(ctx, &KeyRotation{JobID: job.UID, PreviousVersion: fromVersion, PreviousKey: job.Progress.PreviousKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save key rotation: %w", err)
+	}
+
+	if currentKey != newKey {
+		err = km.SetKey(newKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update encryption key: %w", err)
 		}
+	}
+
+	return job, nil
+}
+
+// RunKeyRotation re-encrypts the credentials that aren't encrypted with the
+// current key in batches, saving the job's progress after each one so it
+// resumes from there when it is run again.
+func RunKeyRotation(ctx context.Context, lo log.StdLogger, db database.Database, jobRepo datastore.JobRepository, km KeyManager, jobID string) error {
+	job, err := jobRepo.FetchJobById(ctx, jobID, "")
+	if err != nil {
+		return err
+	}
+
+	if job.Status == datastore.JobStatusCompleted {
+		return nil
+	}
+
+	progress := job.Progress
+	if progress == nil {
+		progress = &datastore.JobProgress{}
+	}
+
+	rotation, err := GetKeyRotation(ctx, db)
+	if err != nil {
+		return err
+	}
 
-		for plainColumn, cipherColumn := range columns {
-			lo.Infof("Re-encrypting column %s (%s) in table %s", plainColumn, cipherColumn, table)
+	if rotation == nil || rotation.JobID != job.UID || rotation.PreviousKey == "" {
+		return failKeyRotation(ctx, jobRepo, job, ErrKeyRotationStateLost)
+	}
 
-			err = reEncryptColumn(ctx, tx, table, cipherColumn, oldKey, newKey)
-			if err != nil {
-				rollback(lo, tx)
-				lo.WithError(err).Error("failed to re-encrypt column")
-				return err
+	currentKey, err := km.GetCurrentKey()
+	if err != nil {
+		return err
+	}
+
+	version, err := KeyVersion(ctx, db, currentKey)
+	if err != nil {
+		return err
+	}
+
+	if version != progress.ToVersion {
+		return failKeyRotation(ctx, jobRepo, job, ErrKeyChangedInRotation)
+	}
+
+	previousKey, err := unwrapPreviousKey(ctx, db, rotation.PreviousKey, currentKey)
+	if err != nil {
+		return failKeyRotation(ctx, jobRepo, job, err)
+	}
+
+	keyring, err := NewKeyRing(version, currentKey, rotation.PreviousVersion, previousKey).String()
+	if err != nil {
+		return err
+	}
+
+	err = jobRepo.MarkJobAsStarted(ctx, job.UID, "")
+	if err != nil {
+		return err
+	}
+
+	// the counts are taken again on every run, so they stay right when a
+	// batch committed but its progress wasn't saved
+	progress.Error = ""
+	progress.Total, progress.Processed, err = countRotatedRows(ctx, db, progress.ToVersion)
+	if err != nil {
+		return failKeyRotation(ctx, jobRepo, job, err)
+	}
+
+	err = jobRepo.UpdateJobProgress(ctx, job.UID, "", progress)
+	if err != nil {
+		return err
+	}
+
+	for pass := 1; ; pass++ {
+		for _, table := range sortedTables() {
+			// tables before the one the last run stopped at are done
+			if progress.Table != "" && table < progress.Table {
+				continue
+			}
+
+			if table != progress.Table {
+				progress.Table, progress.Cursor = table, ""
+			}
+
+			lo.Infof("Re-encrypting table %s", table)
+
+			for {
+				n, cursor, err := reEncryptBatch(ctx, db, table, keyring, progress.ToVersion, progress.Cursor)
+				if err != nil {
+					return failKeyRotation(ctx, jobRepo, job, err)
+				}
+
+				if n > 0 {
+					progress.Cursor = cursor
+					progress.Processed += n
+
+					err = jobRepo.UpdateJobProgress(ctx, job.UID, "", progress)
+					if err != nil {
+						return failKeyRotation(ctx, jobRepo, job, err)
+					}
+				}
+
+				if n < KeyRotationBatchSize {
+					break
+				}
 			}
 		}
+
+		total, rotated, err := countRotatedRows(ctx, db, progress.ToVersion)
+		if err != nil {
+			return failKeyRotation(ctx, jobRepo, job, err)
+		}
+
+		progress.Table, progress.Cursor = "", ""
+		progress.Total, progress.Processed = total, rotated
+
+		if rotated == total {
+			break
+		}
+
+		if pass == maxKeyRotationPasses {
+			return failKeyRotation(ctx, jobRepo, job, fmt.Errorf("%d rows are still encrypted with the previous key", total-rotated))
+		}
 	}
 
-	err = km.SetKey(newKey)
+	err = jobRepo.UpdateJobProgress(ctx, job.UID, "", progress)
 	if err != nil {
-		rollback(lo, tx)
-		return fmt.Errorf("failed to update encryption key: %w", err)
+		return err
 	}
 
-	err = tx.Commit()
+	err = jobRepo.MarkJobAsCompleted(ctx, job.UID, "")
 	if err != nil {
-		lo.WithError(err).Error("failed to commit transaction")
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return err
+	}
+
+	err = clearKeyRotation(ctx)
+	if err != nil {
+		return err
 	}
 
 	lo.Infof("Key rotation completed successfully.")
 	return nil
 }
 
-func reEncryptColumn(ctx context.Context, tx *sqlx.Tx, table, cipherColumn, oldKey, newKey string) error {
-	// Re-encrypt the cipher column with the new key
-	reEncryptQuery := fmt.Sprintf(
-		"UPDATE convoy.%s SET %s = pgp_sym_encrypt(pgp_sym_decrypt(%s::bytea, $1), $2) WHERE %s IS NOT NULL;",
-		table, cipherColumn, cipherColumn, cipherColumn,
+// failKeyRotation records err in the job's progress and marks it as failed,
+// the job still resumes when it is retried.
+func failKeyRotation(ctx context.Context, jobRepo datastore.JobRepository, job *datastore.Job, err error) error {
+	// the job is marked even when the rotation failed because it timed out
+	ctx = context.WithoutCancel(ctx)
+
+	progress := job.Progress
+	if progress == nil {
+		progress = &datastore.JobProgress{}
+	}
+	progress.Error = err.Error()
+
+	if pErr := jobRepo.UpdateJobProgress(ctx, job.UID, "", progress); pErr != nil {
+		return errors.Join(err, pErr)
+	}
+
+	if mErr := jobRepo.MarkJobAsFailed(ctx, job.UID, ""); mErr != nil {
+		return errors.Join(err, mErr)
+	}
+
+	return err
+}
+
+// reEncryptBatch re-encrypts the next batch of rows after cursor that aren't
+// encrypted with the key of version, it returns the number of rows it
+// re-encrypted and the id of the last one.
+func reEncryptBatch(ctx context.Context, db database.Database, table, keyring, version, cursor string) (int64, string, error) {
+	columns := tablesAndColumns[table]
+
+	cipherColumns := make([]string, 0, len(columns))
+	for _, cipherColumn := range columns {
+		cipherColumns = append(cipherColumns, cipherColumn)
+	}
+	sort.Strings(cipherColumns)
+
+	sets := make([]string, 0, len(cipherColumns)+1)
+	for _, c := range cipherColumns {
+		sets = append(sets, fmt.Sprintf(
+			"%[1]s = convoy.keyring_encrypt(convoy.keyring_decrypt(t.%[1]s::bytea, t.key_version, $3), $1, $3)", c,
+		))
+	}
+	sets = append(sets, "key_version = $1")
+
+	query := fmt.Sprintf(`
+	WITH batch AS (
+		SELECT id FROM convoy.%[1]s
+		WHERE is_encrypted AND key_version IS DISTINCT FROM $1 AND id > $2
+		ORDER BY id LIMIT %[3]d
+		FOR UPDATE
+	), updated AS (
+		UPDATE convoy.%[1]s AS t SET %[2]s
+		FROM batch WHERE t.id = batch.id
+		RETURNING t.id
 	)
-	_, err := tx.ExecContext(ctx, reEncryptQuery, oldKey, newKey)
+	SELECT COUNT(*), COALESCE(MAX(id), '') FROM updated;`, table, strings.Join(sets, ", "), KeyRotationBatchSize)
+
+	var (
+		n    int64
+		last string
+	)
+
+	err := db.GetDB().QueryRowxContext(ctx, query, version, cursor, keyring).Scan(&n, &last)
 	if err != nil {
-		return fmt.Errorf("failed to re-encrypt column %s in table %s: %w", cipherColumn, table, err)
+		return 0, "", fmt.Errorf("failed to re-encrypt table %s: %w", table, err)
 	}
 
-	return nil
+	return n, last, nil
+}
+
+// countRotatedRows returns the number of encrypted rows and how many of them
+// are encrypted with the key of version.
+func countRotatedRows(ctx context.Context, db database.Database, version string) (int64, int64, error) {
+	var total, rotated int64
+	for _, table := range sortedTables() {
+		var t, r int64
+
+		query := fmt.Sprintf(
+			"SELECT COUNT(*), COUNT(*) FILTER (WHERE key_version = $1) FROM convoy.%s WHERE is_encrypted;", table,
+		)
+
+		err := db.GetDB().QueryRowxContext(ctx, query, version).Scan(&t, &r)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to count encrypted rows of table %s: %w", table, err)
+		}
+
+		total += t
+		rotated += r
+	}
+
+	return total, rotated, nil
+}
+
+// sortedTables returns the encrypted tables in the order they are rotated in.
+func sortedTables() []string {
+	tables := make([]string, 0, len(tablesAndColumns))
+	for table := range tablesAndColumns {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	return tables
 }
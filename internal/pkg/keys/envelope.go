@@ -0,0 +1,229 @@
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+)
+
+const EnvelopeCacheKey = "EnvelopeRedisKey"
+
+const dataKeySize = 32
+
+var ErrNoDataKey = errors.New("no data key has been generated, run init-encryption first")
+
+// KeyWrapper encrypts data keys with a master key that never leaves it.
+type KeyWrapper interface {
+	Wrap(ctx context.Context, dataKey []byte) (string, error)
+	Unwrap(ctx context.Context, wrapped string) ([]byte, error)
+}
+
+// DataKeyStore persists wrapped data keys.
+type DataKeyStore interface {
+	// Current returns the most recent wrapped data key, it is empty when no
+	// key has been stored.
+	Current(ctx context.Context) (string, error)
+
+	// Init stores the first wrapped data key, it does nothing when a key
+	// has already been stored.
+	Init(ctx context.Context, wrapped string) error
+
+	// Save stores a new wrapped data key which becomes the current one.
+	Save(ctx context.Context, wrapped string) error
+}
+
+// EnvelopeKeyManager hands out a data key that is only stored wrapped by a
+// master key, so reading the database and its backups isn't enough to read
+// the credentials. The data key never encrypts a credential itself, every
+// value gets its own random row key which is stored with it wrapped by the
+// data key (see convoy.envelope_encrypt). The first data key is generated
+// when encryption is initialised.
+//
+// The shared cache holds the wrapped key, not the data key, which is kept
+// unwrapped in memory only.
+type EnvelopeKeyManager struct {
+	wrapper KeyWrapper
+	store   DataKeyStore
+
+	licenser license.Licenser
+	cache    cache.Cache
+
+	mu      sync.Mutex
+	wrapped string
+	dataKey string
+
+	isSet bool
+}
+
+func NewEnvelopeKeyManager(wrapper KeyWrapper, store DataKeyStore, licenser license.Licenser, cache cache.Cache) *EnvelopeKeyManager {
+	return &EnvelopeKeyManager{
+		wrapper:  wrapper,
+		store:    store,
+		licenser: licenser,
+		cache:    cache,
+		isSet:    true,
+	}
+}
+
+func (e *EnvelopeKeyManager) IsSet() bool {
+	return e.isSet
+}
+
+func (e *EnvelopeKeyManager) Unset() {
+	e.isSet = false
+}
+
+// GetCurrentKeyFromCache retrieves the current data key, it is only
+// unwrapped again when the cached wrapped key changes.
+func (e *EnvelopeKeyManager) GetCurrentKeyFromCache() (string, error) {
+	if !e.isSet {
+		return "", nil
+	}
+
+	if !e.licenser.CredentialEncryption() {
+		return "", ErrCredentialEncryptionFeatureUnavailable
+	}
+
+	var wrapped *string
+	err := e.cache.Get(context.Background(), EnvelopeCacheKey, &wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	if wrapped != nil && *wrapped != "" {
+		return e.unwrap(context.Background(), *wrapped)
+	}
+
+	return e.GetCurrentKey()
+}
+
+// GetCurrentKey retrieves the current data key from the store.
+func (e *EnvelopeKeyManager) GetCurrentKey() (string, error) {
+	if !e.licenser.CredentialEncryption() {
+		return "", ErrCredentialEncryptionFeatureUnavailable
+	}
+	return e.GetDataKey()
+}
+
+// GetDataKey unwraps the current data key, it fails with ErrNoDataKey when
+// none has been generated.
+func (e *EnvelopeKeyManager) GetDataKey() (string, error) {
+	ctx := context.Background()
+	wrapped, err := e.store.Current(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if wrapped == "" {
+		return "", ErrNoDataKey
+	}
+
+	if err = e.cache.Set(ctx, EnvelopeCacheKey, &wrapped, oneYear); err != nil {
+		return "", err
+	}
+
+	return e.unwrap(ctx, wrapped)
+}
+
+// InitDataKey unwraps the current data key, generating the first one if
+// there is none. It is only called when encryption is initialised.
+func (e *EnvelopeKeyManager) InitDataKey() (string, error) {
+	if !e.licenser.CredentialEncryption() {
+		return "", ErrCredentialEncryptionFeatureUnavailable
+	}
+
+	ctx := context.Background()
+	wrapped, err := e.store.Current(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if wrapped == "" {
+		_, err = e.initDataKey(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return e.GetDataKey()
+}
+
+// SetKey wraps newKey and stores it as the current data key.
+func (e *EnvelopeKeyManager) SetKey(newKey string) error {
+	if !e.isSet {
+		return nil
+	}
+
+	if !e.licenser.CredentialEncryption() {
+		return ErrCredentialEncryptionFeatureUnavailable
+	}
+
+	ctx := context.Background()
+	wrapped, err := e.wrapper.Wrap(ctx, []byte(newKey))
+	if err != nil {
+		return fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	if err = e.store.Save(ctx, wrapped); err != nil {
+		return fmt.Errorf("failed to save data key: %w", err)
+	}
+
+	e.mu.Lock()
+	e.wrapped, e.dataKey = wrapped, newKey
+	e.mu.Unlock()
+
+	return e.cache.Set(ctx, EnvelopeCacheKey, &wrapped, oneYear)
+}
+
+// initDataKey generates and stores the first data key. Another instance
+// may store one first, so the stored key is read back.
+func (e *EnvelopeKeyManager) initDataKey(ctx context.Context) (string, error) {
+	dataKey, err := GenerateDataKey()
+	if err != nil {
+		return "", err
+	}
+
+	wrapped, err := e.wrapper.Wrap(ctx, []byte(dataKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	if err = e.store.Init(ctx, wrapped); err != nil {
+		return "", fmt.Errorf("failed to save data key: %w", err)
+	}
+
+	return e.store.Current(ctx)
+}
+
+func (e *EnvelopeKeyManager) unwrap(ctx context.Context, wrapped string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if wrapped == e.wrapped {
+		return e.dataKey, nil
+	}
+
+	dataKey, err := e.wrapper.Unwrap(ctx, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	e.wrapped, e.dataKey = wrapped, string(dataKey)
+	return e.dataKey, nil
+}
+
+// GenerateDataKey returns a random data key.
+func GenerateDataKey() (string, error) {
+	b := make([]byte, dataKeySize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
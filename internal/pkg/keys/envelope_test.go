@@ -0,0 +1,203 @@
+package keys
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type memoryDataKeyStore struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (m *memoryDataKeyStore) Current(context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.keys) == 0 {
+		return "", nil
+	}
+	return m.keys[len(m.keys)-1], nil
+}
+
+func (m *memoryDataKeyStore) Init(_ context.Context, wrapped string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.keys) == 0 {
+		m.keys = append(m.keys, wrapped)
+	}
+	return nil
+}
+
+func (m *memoryDataKeyStore) Save(_ context.Context, wrapped string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys = append(m.keys, wrapped)
+	return nil
+}
+
+func newTestFileKeyWrapper(t *testing.T) *FileKeyWrapper {
+	path := filepath.Join(t.TempDir(), "master.key")
+	masterKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", masterKeySize)))
+	require.NoError(t, os.WriteFile(path, []byte(masterKey+"\n"), 0o600))
+
+	w, err := NewFileKeyWrapper(config.FileKeyManagerConfiguration{MasterKeyPath: path})
+	require.NoError(t, err)
+	return w
+}
+
+func TestFileKeyWrapper(t *testing.T) {
+	ctx := context.Background()
+	w := newTestFileKeyWrapper(t)
+
+	wrapped, err := w.Wrap(ctx, []byte("data-key"))
+	require.NoError(t, err)
+	require.NotContains(t, wrapped, "data-key")
+
+	dataKey, err := w.Unwrap(ctx, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, "data-key", string(dataKey))
+
+	// a different master key can't unwrap it
+	other, err := newFileKeyWrapper([]byte(strings.Repeat("o", masterKeySize)))
+	require.NoError(t, err)
+	_, err = other.Unwrap(ctx, wrapped)
+	require.Error(t, err)
+
+	_, err = newFileKeyWrapper([]byte("too-short"))
+	require.ErrorIs(t, err, ErrInvalidMasterKey)
+}
+
+func TestTransitKeyWrapper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/convoy":
+			_, _ = w.Write([]byte(`{"data":{"ciphertext":"vault:v1:` + body["plaintext"] + `"}}`))
+		case "/v1/transit/decrypt/convoy":
+			_, _ = w.Write([]byte(`{"data":{"plaintext":"` + strings.TrimPrefix(body["ciphertext"], "vault:v1:") + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	w, err := NewTransitKeyWrapper(config.VaultConfiguration{Address: server.URL, Token: "vault-token", TransitKey: "convoy"})
+	require.NoError(t, err)
+
+	wrapped, err := w.Wrap(context.Background(), []byte("data-key"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(wrapped, "vault:v1:"))
+
+	dataKey, err := w.Unwrap(context.Background(), wrapped)
+	require.NoError(t, err)
+	require.Equal(t, "data-key", string(dataKey))
+
+	_, err = NewTransitKeyWrapper(config.VaultConfiguration{Address: server.URL, Token: "vault-token"})
+	require.ErrorIs(t, err, ErrMissingVaultConfig)
+}
+
+func TestEnvelopeKeyManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ll := mocks.NewMockLicenser(ctrl)
+	ll.EXPECT().CredentialEncryption().Return(true).AnyTimes()
+
+	store := &memoryDataKeyStore{}
+	wrapper := newTestFileKeyWrapper(t)
+	c := mcache.NewMemoryCache()
+
+	km := NewEnvelopeKeyManager(wrapper, store, ll, c)
+
+	// reading the key doesn't generate one
+	_, err := km.GetCurrentKeyFromCache()
+	require.ErrorIs(t, err, ErrNoDataKey)
+	require.Empty(t, store.keys)
+
+	_, err = GetKeyForRevert(km)
+	require.ErrorIs(t, err, ErrNoDataKey)
+	require.Empty(t, store.keys)
+
+	// the first data key is generated and stored wrapped when encryption is initialised
+	key, err := GetKeyForInit(km)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+	require.Len(t, store.keys, 1)
+	require.NotEqual(t, key, store.keys[0])
+
+	initKey, err := km.InitDataKey()
+	require.NoError(t, err)
+	require.Equal(t, key, initKey)
+	require.Len(t, store.keys, 1)
+
+	// another instance sharing the store and cache gets the same key
+	other := NewEnvelopeKeyManager(wrapper, store, ll, c)
+	otherKey, err := other.GetCurrentKeyFromCache()
+	require.NoError(t, err)
+	require.Equal(t, key, otherKey)
+
+	// and picks up a new key once it is set
+	require.NoError(t, km.SetKey("new-key"))
+	require.Len(t, store.keys, 2)
+
+	otherKey, err = other.GetCurrentKeyFromCache()
+	require.NoError(t, err)
+	require.Equal(t, "new-key", otherKey)
+
+	require.NoError(t, c.Delete(context.Background(), EnvelopeCacheKey))
+	otherKey, err = other.GetCurrentKey()
+	require.NoError(t, err)
+	require.Equal(t, "new-key", otherKey)
+}
+
+func TestEnvelopeKeyManager_FeatureUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ll := mocks.NewMockLicenser(ctrl)
+	ll.EXPECT().CredentialEncryption().Return(false).AnyTimes()
+
+	wrapper := newTestFileKeyWrapper(t)
+	wrapped, err := wrapper.Wrap(context.Background(), []byte("data-key"))
+	require.NoError(t, err)
+
+	store := &memoryDataKeyStore{keys: []string{wrapped}}
+	km := NewEnvelopeKeyManager(wrapper, store, ll, mcache.NewMemoryCache())
+
+	_, err = km.GetCurrentKeyFromCache()
+	require.ErrorIs(t, err, ErrCredentialEncryptionFeatureUnavailable)
+
+	_, err = GetKeyForInit(km)
+	require.ErrorIs(t, err, ErrCredentialEncryptionFeatureUnavailable)
+
+	// the key can still be read to revert encryption
+	key, err := GetKeyForRevert(km)
+	require.NoError(t, err)
+	require.Equal(t, "data-key", key)
+
+	km.Unset()
+	key, err = km.GetCurrentKeyFromCache()
+	require.NoError(t, err)
+	require.Empty(t, key)
+}
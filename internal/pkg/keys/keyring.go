@@ -0,0 +1,336 @@
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+	"github.com/oklog/ulid/v2"
+)
+
+const KeyRotationCacheKey = "KeyRotationRedisKey"
+
+var ErrKeyRotationInProgress = errors.New("an encryption key rotation is in progress")
+
+const (
+	findKeyVersion = `SELECT COALESCE(convoy.find_key_version($1), '');`
+
+	createKeyVersion = `
+	INSERT INTO convoy.key_versions (id, key_check)
+	VALUES ($1, pgp_sym_encrypt($1, $2));
+	`
+
+	wrapKey = `SELECT encode(pgp_sym_encrypt($1, $2), 'base64');`
+
+	unwrapKey = `SELECT pgp_sym_decrypt(decode($1, 'base64'), $2);`
+
+	fetchLatestKeyRotation = `
+	SELECT id, status, COALESCE(progress, 'null') FROM convoy.jobs
+	WHERE type = $1 AND project_id IS NULL AND deleted_at IS NULL
+	ORDER BY id DESC LIMIT 1;
+	`
+)
+
+var (
+	// a key's version never changes once it is recorded, and neither does
+	// the key a wrapped key unwraps to, so both are only looked up once.
+	keyVersions   sync.Map
+	unwrappedKeys sync.Map
+)
+
+type wrappedKey struct {
+	wrapped     string
+	wrappingKey string
+}
+
+// KeyVersion returns the version of key, which identifies the key
+// credentials were encrypted with. Versions are random ids, a version is
+// recorded the first time a key is used.
+func KeyVersion(ctx context.Context, db database.Database, key string) (string, error) {
+	if version, ok := keyVersions.Load(key); ok {
+		return version.(string), nil
+	}
+
+	version, err := findVersion(ctx, db.GetDB(), key)
+	if err != nil {
+		return "", err
+	}
+
+	if version == "" {
+		version, err = recordKeyVersion(ctx, db, key)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	keyVersions.Store(key, version)
+	return version, nil
+}
+
+// recordKeyVersion records a version for key, unless another instance did
+// while it waited for the lock.
+func recordKeyVersion(ctx context.Context, db database.Database, key string) (string, error) {
+	tx, err := db.GetDB().BeginTxx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, "LOCK TABLE convoy.key_versions IN EXCLUSIVE MODE;")
+	if err != nil {
+		return "", fmt.Errorf("failed to lock key versions: %w", err)
+	}
+
+	version, err := findVersion(ctx, tx, key)
+	if err != nil || version != "" {
+		return version, err
+	}
+
+	version = ulid.Make().String()
+	_, err = tx.ExecContext(ctx, createKeyVersion, version, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to record key version: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("failed to record key version: %w", err)
+	}
+
+	return version, nil
+}
+
+func findVersion(ctx context.Context, q sqlx.QueryerContext, key string) (string, error) {
+	var version string
+	err := q.QueryRowxContext(ctx, findKeyVersion, key).Scan(&version)
+	if err != nil {
+		return "", fmt.Errorf("failed to find key version: %w", err)
+	}
+
+	return version, nil
+}
+
+// wrapPreviousKey encrypts previousKey with key, so it can be stored with
+// the rotation away from it.
+func wrapPreviousKey(ctx context.Context, db database.Database, previousKey, key string) (string, error) {
+	var wrapped string
+	err := db.GetDB().QueryRowxContext(ctx, wrapKey, previousKey, key).Scan(&wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap previous key: %w", err)
+	}
+
+	return wrapped, nil
+}
+
+// unwrapPreviousKey decrypts a key encrypted by wrapPreviousKey.
+func unwrapPreviousKey(ctx context.Context, db database.Database, wrapped, key string) (string, error) {
+	k := wrappedKey{wrapped: wrapped, wrappingKey: key}
+	if previousKey, ok := unwrappedKeys.Load(k); ok {
+		return previousKey.(string), nil
+	}
+
+	var previousKey string
+	err := db.GetDB().QueryRowxContext(ctx, unwrapKey, wrapped, key).Scan(&previousKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap previous key: %w", err)
+	}
+
+	unwrappedKeys.Store(k, previousKey)
+	return previousKey, nil
+}
+
+// KeyRing holds the keys credentials may be encrypted with, by key version.
+// The SQL functions convoy.keyring_encrypt and convoy.keyring_decrypt pick
+// the key of each row from it.
+type KeyRing struct {
+	// Version is the version of the key new credentials are encrypted with.
+	Version string `json:"version"`
+
+	// Legacy is the version of the key rows encrypted before key versions
+	// were recorded are encrypted with.
+	Legacy string            `json:"legacy"`
+	Keys   map[string]string `json:"keys"`
+}
+
+// NewKeyRing returns the keyring of currentKey whose version is version,
+// previousVersion and previousKey are those of the key being rotated away
+// from, they are empty when no rotation is in progress.
+func NewKeyRing(version, currentKey, previousVersion, previousKey string) *KeyRing {
+	k := &KeyRing{
+		Version: version,
+		Legacy:  version,
+		Keys:    map[string]string{version: currentKey},
+	}
+
+	if previousVersion != "" && previousVersion != version {
+		k.Legacy = previousVersion
+		k.Keys[previousVersion] = previousKey
+	}
+
+	return k
+}
+
+func (k *KeyRing) String() (string, error) {
+	b, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// KeyRotation is the state of a key rotation shared by every instance. It
+// is cached from the rotation's job, which holds the previous key encrypted
+// with the new one until all credentials are encrypted with the new key.
+type KeyRotation struct {
+	JobID           string `json:"job_id"`
+	PreviousVersion string `json:"previous_version"`
+	PreviousKey     string `json:"previous_key"`
+}
+
+var rotationCache atomic.Value
+
+// SetCache sets the cache key rotations are shared in, instances that don't
+// set it read the rotation from its job every time.
+func SetCache(c cache.Cache) {
+	rotationCache.Store(&c)
+}
+
+func getCache() (cache.Cache, bool) {
+	c, ok := rotationCache.Load().(*cache.Cache)
+	if !ok {
+		return nil, false
+	}
+
+	return *c, true
+}
+
+// GetKeyRotation returns the key rotation in progress, it is nil when there's
+// none. The job is read when the rotation isn't cached, so losing the cache
+// never loses the previous key.
+func GetKeyRotation(ctx context.Context, db database.Database) (*KeyRotation, error) {
+	c, ok := getCache()
+	if ok {
+		var rotation *KeyRotation
+		err := c.Get(ctx, KeyRotationCacheKey, &rotation)
+		if err != nil {
+			return nil, err
+		}
+
+		if rotation != nil {
+			if rotation.JobID == "" {
+				return nil, nil
+			}
+
+			return rotation, nil
+		}
+	}
+
+	rotation, err := fetchKeyRotation(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		// no rotation is cached too, so the job isn't read on every call
+		cached := rotation
+		if cached == nil {
+			cached = &KeyRotation{}
+		}
+
+		err = c.Set(ctx, KeyRotationCacheKey, cached, oneYear)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rotation, nil
+}
+
+// fetchKeyRotation returns the rotation of the latest key rotation job, it
+// is nil when the job has completed.
+func fetchKeyRotation(ctx context.Context, db database.Database) (*KeyRotation, error) {
+	var (
+		jobID    string
+		status   datastore.JobStatus
+		progress datastore.JobProgress
+	)
+
+	err := db.GetReadDB().QueryRowxContext(ctx, fetchLatestKeyRotation, datastore.JobTypeKeyRotation).Scan(&jobID, &status, &progress)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to fetch key rotation: %w", err)
+	}
+
+	if status == datastore.JobStatusCompleted {
+		return nil, nil
+	}
+
+	return &KeyRotation{JobID: jobID, PreviousVersion: progress.FromVersion, PreviousKey: progress.PreviousKey}, nil
+}
+
+func setKeyRotation(ctx context.Context, rotation *KeyRotation) error {
+	c, ok := getCache()
+	if !ok {
+		return nil
+	}
+
+	return c.Set(ctx, KeyRotationCacheKey, rotation, oneYear)
+}
+
+func clearKeyRotation(ctx context.Context) error {
+	return setKeyRotation(ctx, &KeyRotation{})
+}
+
+// CurrentKeyRing returns the keyring credentials are read and written with,
+// it is empty when no key manager or key has been set.
+func CurrentKeyRing(ctx context.Context, db database.Database) (string, error) {
+	km, err := Get()
+	if err != nil {
+		return "", nil
+	}
+
+	// encryption hasn't been initialised until there's a data key
+	currentKey, err := km.GetCurrentKeyFromCache()
+	if errors.Is(err, ErrNoDataKey) {
+		return "", nil
+	}
+
+	if err != nil || currentKey == "" {
+		return "", err
+	}
+
+	version, err := KeyVersion(ctx, db, currentKey)
+	if err != nil {
+		return "", err
+	}
+
+	rotation, err := GetKeyRotation(ctx, db)
+	if err != nil {
+		return "", err
+	}
+
+	var previousVersion, previousKey string
+
+	// until an instance sees the new key, its current key is the previous one
+	if rotation != nil && rotation.PreviousVersion != version && rotation.PreviousKey != "" {
+		previousVersion = rotation.PreviousVersion
+		previousKey, err = unwrapPreviousKey(ctx, db, rotation.PreviousKey, currentKey)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return NewKeyRing(version, currentKey, previousVersion, previousKey).String()
+}
@@ -0,0 +1,173 @@
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewKeyRing(t *testing.T) {
+	k := NewKeyRing("new-version", "new-key", "", "")
+	require.Equal(t, "new-version", k.Version)
+	require.Equal(t, k.Version, k.Legacy)
+	require.Equal(t, map[string]string{"new-version": "new-key"}, k.Keys)
+
+	// rows without a version were encrypted with the previous key
+	k = NewKeyRing("new-version", "new-key", "old-version", "old-key")
+	require.Equal(t, "new-version", k.Version)
+	require.Equal(t, "old-version", k.Legacy)
+	require.Equal(t, map[string]string{"new-version": "new-key", "old-version": "old-key"}, k.Keys)
+
+	s, err := k.String()
+	require.NoError(t, err)
+
+	var decoded KeyRing
+	require.NoError(t, json.Unmarshal([]byte(s), &decoded))
+	require.Equal(t, *k, decoded)
+}
+
+func TestCurrentKeyRing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("CONVOY_LOCAL_ENCRYPTION_KEY", "new-key")
+	km, err := NewLocalKeyManager()
+	require.NoError(t, err)
+	require.NoError(t, Set(km))
+
+	SetCache(mcache.NewMemoryCache())
+	require.NoError(t, clearKeyRotation(ctx))
+
+	keyVersions.Store("new-key", "new-version")
+	unwrappedKeys.Store(wrappedKey{wrapped: "wrapped-old-key", wrappingKey: "new-key"}, "old-key")
+
+	keyring, err := CurrentKeyRing(ctx, nil)
+	require.NoError(t, err)
+	requireKeyRing(t, NewKeyRing("new-version", "new-key", "", ""), keyring)
+
+	rotation := &KeyRotation{JobID: "job-id", PreviousVersion: "old-version", PreviousKey: "wrapped-old-key"}
+	require.NoError(t, setKeyRotation(ctx, rotation))
+
+	keyring, err = CurrentKeyRing(ctx, nil)
+	require.NoError(t, err)
+	requireKeyRing(t, NewKeyRing("new-version", "new-key", "old-version", "old-key"), keyring)
+
+	// an instance that hasn't seen the new key yet only has the previous one
+	keyVersions.Store("new-key", "old-version")
+
+	keyring, err = CurrentKeyRing(ctx, nil)
+	require.NoError(t, err)
+	requireKeyRing(t, NewKeyRing("old-version", "new-key", "", ""), keyring)
+
+	keyVersions.Store("new-key", "new-version")
+	require.NoError(t, clearKeyRotation(ctx))
+
+	keyring, err = CurrentKeyRing(ctx, nil)
+	require.NoError(t, err)
+	requireKeyRing(t, NewKeyRing("new-version", "new-key", "", ""), keyring)
+}
+
+func TestCurrentKeyRing_NoDataKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ll := mocks.NewMockLicenser(ctrl)
+	ll.EXPECT().CredentialEncryption().Return(true).AnyTimes()
+
+	store := &memoryDataKeyStore{}
+	require.NoError(t, Set(NewEnvelopeKeyManager(newTestFileKeyWrapper(t), store, ll, mcache.NewMemoryCache())))
+
+	// credentials aren't encrypted until encryption is initialised
+	keyring, err := CurrentKeyRing(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, keyring)
+	require.Empty(t, store.keys)
+}
+
+func TestRunKeyRotation_Fails(t *testing.T) {
+	ctx := context.Background()
+	lo := log.NewLogger(os.Stdout)
+
+	t.Setenv("CONVOY_LOCAL_ENCRYPTION_KEY", "new-key")
+	km, err := NewLocalKeyManager()
+	require.NoError(t, err)
+
+	keyVersions.Store("new-key", "new-version")
+
+	tests := []struct {
+		name     string
+		rotation *KeyRotation
+		progress *datastore.JobProgress
+		wantErr  error
+	}{
+		{
+			name:     "previous key is no longer available",
+			rotation: &KeyRotation{JobID: "job-id", PreviousVersion: "old-version"},
+			progress: &datastore.JobProgress{FromVersion: "old-version", ToVersion: "new-version"},
+			wantErr:  ErrKeyRotationStateLost,
+		},
+		{
+			name:     "rotation belongs to another job",
+			rotation: &KeyRotation{JobID: "other-job", PreviousVersion: "old-version", PreviousKey: "wrapped-old-key"},
+			progress: &datastore.JobProgress{FromVersion: "old-version", ToVersion: "new-version"},
+			wantErr:  ErrKeyRotationStateLost,
+		},
+		{
+			name:     "key was changed",
+			rotation: &KeyRotation{JobID: "job-id", PreviousVersion: "old-version", PreviousKey: "wrapped-old-key"},
+			progress: &datastore.JobProgress{FromVersion: "old-version", ToVersion: "another-version"},
+			wantErr:  ErrKeyChangedInRotation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			SetCache(mcache.NewMemoryCache())
+			require.NoError(t, setKeyRotation(ctx, tt.rotation))
+
+			job := &datastore.Job{UID: "job-id", Type: datastore.JobTypeKeyRotation, Status: datastore.JobStatusRunning, Progress: tt.progress}
+
+			jobRepo := mocks.NewMockJobRepository(ctrl)
+			jobRepo.EXPECT().FetchJobById(gomock.Any(), "job-id", "").Return(job, nil)
+			jobRepo.EXPECT().UpdateJobProgress(gomock.Any(), "job-id", "", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, progress *datastore.JobProgress) error {
+					require.Equal(t, tt.wantErr.Error(), progress.Error)
+					return nil
+				})
+			jobRepo.EXPECT().MarkJobAsFailed(gomock.Any(), "job-id", "").Return(nil)
+
+			err := RunKeyRotation(ctx, lo, nil, jobRepo, km, "job-id")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRunKeyRotation_Completed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobRepo := mocks.NewMockJobRepository(ctrl)
+	jobRepo.EXPECT().FetchJobById(gomock.Any(), "job-id", "").
+		Return(&datastore.Job{UID: "job-id", Type: datastore.JobTypeKeyRotation, Status: datastore.JobStatusCompleted}, nil)
+
+	err := RunKeyRotation(context.Background(), log.NewLogger(os.Stdout), nil, jobRepo, &LocalKeyManager{}, "job-id")
+	require.NoError(t, err)
+}
+
+func requireKeyRing(t *testing.T, expected *KeyRing, keyring string) {
+	t.Helper()
+
+	var k KeyRing
+	require.NoError(t, json.Unmarshal([]byte(keyring), &k))
+	require.Equal(t, *expected, k)
+}
@@ -3,6 +3,11 @@ package keys
 import (
 	"errors"
 	"sync/atomic"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/internal/pkg/license"
 )
 
 var (
@@ -11,6 +16,22 @@ var (
 		"endpoints": {
 			"secrets": "secrets_cipher",
 			"authentication_type_api_key_header_value": "authentication_type_api_key_header_value_cipher",
+			"mtls_client_cert":                         "mtls_client_cert_cipher",
+			"authentication_oauth2":                    "authentication_oauth2_cipher",
+		},
+		"source_verifiers": {
+			"basic_password":       "basic_password_cipher",
+			"api_key_header_value": "api_key_header_value_cipher",
+			"hmac_secret":          "hmac_secret_cipher",
+		},
+		"sources": {
+			"pub_sub":       "pub_sub_cipher",
+			"change_stream": "change_stream_cipher",
+			"rest_api":      "rest_api_cipher",
+		},
+		"project_configurations": {
+			"meta_events_secret":  "meta_events_secret_cipher",
+			"meta_events_pub_sub": "meta_events_pub_sub_cipher",
 		},
 	}
 )
@@ -20,6 +41,61 @@ type KeyManager interface {
 	GetCurrentKey() (string, error)
 	GetCurrentKeyFromCache() (string, error)
 	SetKey(newKey string) error
+	Unset()
+}
+
+// NewKeyManagerFromConfig returns the key manager of the configured
+// provider, db stores the wrapped data keys of the envelope providers.
+func NewKeyManagerFromConfig(cfg config.Configuration, licenser license.Licenser, cache cache.Cache, db database.Database) (KeyManager, error) {
+	switch cfg.KeyManager.Provider {
+	case config.VaultKVKeyManagerProvider:
+		return NewVaultKVKeyManagerFromConfig(cfg.KeyManager.Vault, licenser, cache)
+	case config.VaultTransitKeyManagerProvider:
+		wrapper, err := NewTransitKeyWrapper(cfg.KeyManager.Vault)
+		if err != nil {
+			return nil, err
+		}
+
+		store := NewDataKeyStore(db, string(config.VaultTransitKeyManagerProvider))
+		return NewEnvelopeKeyManager(wrapper, store, licenser, cache), nil
+	case config.FileKeyManagerProvider:
+		wrapper, err := NewFileKeyWrapper(cfg.KeyManager.File)
+		if err != nil {
+			return nil, err
+		}
+
+		store := NewDataKeyStore(db, string(config.FileKeyManagerProvider))
+		return NewEnvelopeKeyManager(wrapper, store, licenser, cache), nil
+	case config.LocalKeyManagerProvider:
+		return NewLocalKeyManager()
+	default:
+		return NewHCPVaultKeyManagerFromConfig(cfg.HCPVault, licenser, cache), nil
+	}
+}
+
+// GetKeyForInit retrieves the current key encryption is initialised with,
+// envelope key managers generate their first data key.
+func GetKeyForInit(km KeyManager) (string, error) {
+	if k, ok := km.(*EnvelopeKeyManager); ok {
+		return k.InitDataKey()
+	}
+
+	return km.GetCurrentKey()
+}
+
+// GetKeyForRevert retrieves the current key without checking the licence,
+// so encryption can still be reverted once the feature is unavailable.
+func GetKeyForRevert(km KeyManager) (string, error) {
+	switch k := km.(type) {
+	case *HCPVaultKeyManager:
+		return k.GetHCPSecretKey()
+	case *VaultKVKeyManager:
+		return k.GetVaultSecretKey()
+	case *EnvelopeKeyManager:
+		return k.GetDataKey()
+	default:
+		return km.GetCurrentKey()
+	}
 }
 
 var kmSingleton atomic.Value
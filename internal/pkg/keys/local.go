@@ -25,6 +25,10 @@ func (l *LocalKeyManager) IsSet() bool {
 	return l.isSet
 }
 
+func (l *LocalKeyManager) Unset() {
+	l.isSet = false
+}
+
 func (l *LocalKeyManager) GetCurrentKey() (string, error) {
 	if l.currentKey == "" {
 		return "", fmt.Errorf("no current key configured")
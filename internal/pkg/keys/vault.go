@@ -1,64 +1,153 @@
 package keys
 
 import (
+	"context"
+	"errors"
 	"fmt"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/internal/pkg/license"
 	"github.com/hashicorp/vault/api"
 )
 
-type VaultKeyManager struct {
+const VaultKVCacheKey = "VaultKVRedisKey"
+
+const defaultVaultKVMount = "secret"
+
+var ErrMissingVaultConfig = errors.New("missing required vault configuration")
+
+// VaultKVKeyManager keeps the encryption key in the KV v2 secrets engine of a
+// self-hosted HashiCorp Vault, under the key field of the secret at path.
+type VaultKVKeyManager struct {
 	client *api.Client
+	mount  string
+	path   string
+
+	licenser license.Licenser
+	cache    cache.Cache
+
+	isSet bool
 }
 
-// NewVaultKeyManager initializes the Vault client
-func NewVaultKeyManager(vaultAddr, token string) (*VaultKeyManager, error) {
-	// Configure Vault client
-	config := &api.Config{
-		Address: vaultAddr,
+// newVaultClient creates a client for the vault at cfg.Address.
+func newVaultClient(cfg config.VaultConfiguration) (*api.Client, error) {
+	if cfg.Address == "" || cfg.Token == "" {
+		return nil, ErrMissingVaultConfig
 	}
-	client, err := api.NewClient(config)
+
+	client, err := api.NewClient(&api.Config{Address: cfg.Address})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Vault client: %w", err)
+		return nil, fmt.Errorf("failed to create vault client: %w", err)
 	}
 
-	// Set token for authentication
-	client.SetToken(token)
+	client.SetToken(cfg.Token)
+	if cfg.Namespace != "" {
+		client.SetNamespace(cfg.Namespace)
+	}
 
-	return &VaultKeyManager{client: client}, nil
+	return client, nil
 }
 
-// GetCurrentKey retrieves the current encryption key from Vault
-func (vkm *VaultKeyManager) GetCurrentKey() (string, error) {
-	secret, err := vkm.client.Logical().Read("encryption/data/current_key")
+func NewVaultKVKeyManagerFromConfig(cfg config.VaultConfiguration, licenser license.Licenser, cache cache.Cache) (*VaultKVKeyManager, error) {
+	if cfg.Path == "" {
+		return nil, ErrMissingVaultConfig
+	}
+
+	client, err := newVaultClient(cfg)
 	if err != nil {
-		return "", fmt.Errorf("failed to read key from Vault: %w", err)
+		return nil, err
 	}
-	if secret == nil || secret.Data == nil {
-		return "", fmt.Errorf("no encryption key found in Vault")
+
+	mount := cfg.Mount
+	if mount == "" {
+		mount = defaultVaultKVMount
 	}
 
-	data, ok := secret.Data["data"].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("malformed key data in Vault")
+	return &VaultKVKeyManager{
+		client:   client,
+		mount:    mount,
+		path:     cfg.Path,
+		licenser: licenser,
+		cache:    cache,
+		isSet:    true,
+	}, nil
+}
+
+func (v *VaultKVKeyManager) IsSet() bool {
+	return v.isSet
+}
+
+func (v *VaultKVKeyManager) Unset() {
+	v.isSet = false
+}
+
+// GetCurrentKeyFromCache retrieves the current key from the Cache.
+func (v *VaultKVKeyManager) GetCurrentKeyFromCache() (string, error) {
+	if !v.isSet {
+		return "", nil
 	}
 
-	key, ok := data["key"].(string)
+	if !v.licenser.CredentialEncryption() {
+		return "", ErrCredentialEncryptionFeatureUnavailable
+	}
+
+	var currentKey *string
+	err := v.cache.Get(context.Background(), VaultKVCacheKey, &currentKey)
+	if err != nil {
+		return "", err
+	}
+
+	if currentKey != nil && *currentKey != "" {
+		return *currentKey, nil
+	}
+
+	return v.GetCurrentKey()
+}
+
+// GetCurrentKey retrieves the current key from Vault.
+func (v *VaultKVKeyManager) GetCurrentKey() (string, error) {
+	if !v.licenser.CredentialEncryption() {
+		return "", ErrCredentialEncryptionFeatureUnavailable
+	}
+	return v.GetVaultSecretKey()
+}
+
+// GetVaultSecretKey reads the current key from the KV secret.
+func (v *VaultKVKeyManager) GetVaultSecretKey() (string, error) {
+	ctx := context.Background()
+	secret, err := v.client.KVv2(v.mount).Get(ctx, v.path)
+	if err != nil {
+		// a missing secret means no key has been set yet
+		if errors.Is(err, api.ErrSecretNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read key from vault: %w", err)
+	}
+
+	key, ok := secret.Data["key"].(string)
 	if !ok {
-		return "", fmt.Errorf("key not found in Vault response")
+		return "", errors.New("key not found in vault secret")
 	}
 
-	return key, nil
+	return key, v.cache.Set(ctx, VaultKVCacheKey, &key, oneYear)
 }
 
-// SetKey updates the current encryption key in Vault
-func (vkm *VaultKeyManager) SetKey(newKey string) error {
-	data := map[string]interface{}{
-		"data": map[string]interface{}{
-			"key": newKey,
-		},
+// SetKey writes a new version of the secret holding the key.
+func (v *VaultKVKeyManager) SetKey(newKey string) error {
+	if !v.isSet {
+		return nil
 	}
-	_, err := vkm.client.Logical().Write("encryption/data/current_key", data)
+
+	if !v.licenser.CredentialEncryption() {
+		return ErrCredentialEncryptionFeatureUnavailable
+	}
+
+	ctx := context.Background()
+	_, err := v.client.KVv2(v.mount).Put(ctx, v.path, map[string]interface{}{"key": newKey})
 	if err != nil {
-		return fmt.Errorf("failed to write key to Vault: %w", err)
+		return fmt.Errorf("failed to write key to vault: %w", err)
 	}
-	return nil
+
+	return v.cache.Set(ctx, VaultKVCacheKey, &newKey, oneYear)
 }
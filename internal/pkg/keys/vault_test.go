@@ -0,0 +1,83 @@
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVaultKVKeyManager(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		key string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+		require.Equal(t, "/v1/kv/data/convoy/encryption", r.URL.Path)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			if key == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     map[string]interface{}{"key": key},
+					"metadata": map[string]interface{}{"version": 1},
+				},
+			})
+		case http.MethodPut, http.MethodPost:
+			var body struct {
+				Data map[string]string `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			key = body.Data["key"]
+			_, _ = w.Write([]byte(`{"data":{"version":1}}`))
+		}
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ll := mocks.NewMockLicenser(ctrl)
+	ll.EXPECT().CredentialEncryption().Return(true).AnyTimes()
+
+	cfg := config.VaultConfiguration{Address: server.URL, Token: "vault-token", Mount: "kv", Path: "convoy/encryption"}
+	km, err := NewVaultKVKeyManagerFromConfig(cfg, ll, mcache.NewMemoryCache())
+	require.NoError(t, err)
+	require.True(t, km.IsSet())
+
+	// no key has been set yet
+	current, err := km.GetCurrentKey()
+	require.NoError(t, err)
+	require.Empty(t, current)
+
+	require.NoError(t, km.SetKey("vault-key"))
+
+	current, err = km.GetCurrentKeyFromCache()
+	require.NoError(t, err)
+	require.Equal(t, "vault-key", current)
+
+	require.NoError(t, km.cache.Delete(context.Background(), VaultKVCacheKey))
+	current, err = km.GetCurrentKeyFromCache()
+	require.NoError(t, err)
+	require.Equal(t, "vault-key", current)
+
+	_, err = NewVaultKVKeyManagerFromConfig(config.VaultConfiguration{Address: server.URL, Path: "convoy/encryption"}, ll, mcache.NewMemoryCache())
+	require.ErrorIs(t, err, ErrMissingVaultConfig)
+}
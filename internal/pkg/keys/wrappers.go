@@ -0,0 +1,156 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/hashicorp/vault/api"
+)
+
+const (
+	defaultVaultTransitMount = "transit"
+	masterKeySize            = 32
+)
+
+var ErrInvalidMasterKey = errors.New("master key must be 32 bytes, raw or base64 encoded")
+
+// TransitKeyWrapper wraps data keys with a key of Vault's transit secrets
+// engine. Vault keeps every version of the transit key, so data keys
+// wrapped before it was rotated can still be unwrapped.
+type TransitKeyWrapper struct {
+	client *api.Client
+	mount  string
+	key    string
+}
+
+func NewTransitKeyWrapper(cfg config.VaultConfiguration) (*TransitKeyWrapper, error) {
+	if cfg.TransitKey == "" {
+		return nil, ErrMissingVaultConfig
+	}
+
+	client, err := newVaultClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	mount := cfg.Mount
+	if mount == "" {
+		mount = defaultVaultTransitMount
+	}
+
+	return &TransitKeyWrapper{client: client, mount: mount, key: cfg.TransitKey}, nil
+}
+
+func (t *TransitKeyWrapper) Wrap(ctx context.Context, dataKey []byte) (string, error) {
+	secret, err := t.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/encrypt/%s", t.mount, t.key), map[string]interface{}{
+		"plaintext": base64.StdEncoding.EncodeToString(dataKey),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if secret == nil {
+		return "", errors.New("empty response from vault transit")
+	}
+
+	ciphertext, ok := secret.Data["ciphertext"].(string)
+	if !ok {
+		return "", errors.New("ciphertext not found in vault transit response")
+	}
+
+	return ciphertext, nil
+}
+
+func (t *TransitKeyWrapper) Unwrap(ctx context.Context, wrapped string) ([]byte, error) {
+	secret, err := t.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/decrypt/%s", t.mount, t.key), map[string]interface{}{
+		"ciphertext": wrapped,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == nil {
+		return nil, errors.New("empty response from vault transit")
+	}
+
+	plaintext, ok := secret.Data["plaintext"].(string)
+	if !ok {
+		return nil, errors.New("plaintext not found in vault transit response")
+	}
+
+	return base64.StdEncoding.DecodeString(plaintext)
+}
+
+// FileKeyWrapper wraps data keys with AES-256-GCM using a master key read
+// from a file, for deployments that can't reach a key management service.
+type FileKeyWrapper struct {
+	aead cipher.AEAD
+}
+
+func NewFileKeyWrapper(cfg config.FileKeyManagerConfiguration) (*FileKeyWrapper, error) {
+	if cfg.MasterKeyPath == "" {
+		return nil, errors.New("missing required master key path")
+	}
+
+	b, err := os.ReadFile(cfg.MasterKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key: %w", err)
+	}
+
+	return newFileKeyWrapper(b)
+}
+
+func newFileKeyWrapper(b []byte) (*FileKeyWrapper, error) {
+	masterKey := bytes.TrimSpace(b)
+	if len(masterKey) != masterKeySize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(masterKey)))
+		if err != nil || len(decoded) != masterKeySize {
+			return nil, ErrInvalidMasterKey
+		}
+		masterKey = decoded
+	}
+
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileKeyWrapper{aead: aead}, nil
+}
+
+func (f *FileKeyWrapper) Wrap(_ context.Context, dataKey []byte) (string, error) {
+	nonce := make([]byte, f.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(f.aead.Seal(nonce, nonce, dataKey, nil)), nil
+}
+
+func (f *FileKeyWrapper) Unwrap(_ context.Context, wrapped string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) < f.aead.NonceSize() {
+		return nil, errors.New("wrapped data key is too short")
+	}
+
+	nonce, ciphertext := b[:f.aead.NonceSize()], b[f.aead.NonceSize():]
+	return f.aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -18,6 +18,7 @@ const (
 	projectLabel  = "project"
 	sourceLabel   = "source"
 	endpointLabel = "endpoint"
+	resultLabel   = "result"
 )
 
 // Metrics for the data plane
@@ -28,6 +29,7 @@ type Metrics struct {
 	IngestErrorsTotal    *prometheus.CounterVec
 	IngestLatency        *prometheus.HistogramVec
 	EventDeliveryLatency *prometheus.HistogramVec
+	DedupChecksTotal     *prometheus.CounterVec
 }
 
 func GetDPInstance(licenser license.Licenser) *Metrics {
@@ -46,6 +48,7 @@ func newMetrics(pr prometheus.Registerer, licenser license.Licenser) *Metrics {
 			m.IngestConsumedTotal,
 			m.IngestErrorsTotal,
 			m.EventDeliveryLatency,
+			m.DedupChecksTotal,
 		)
 	}
 	return m
@@ -104,6 +107,13 @@ func InitMetrics(licenser license.Licenser) *Metrics {
 			},
 			[]string{projectLabel, endpointLabel},
 		),
+		DedupChecksTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "convoy_dedup_checks_total",
+				Help: "Total number of idempotency keys checked against a dedup window, by whether they were duplicates",
+			},
+			[]string{projectLabel, resultLabel},
+		),
 	}
 	return m
 }
@@ -142,3 +152,17 @@ func (m *Metrics) IncrementIngestErrorsTotal(source *datastore.Source) {
 	}
 	m.IngestErrorsTotal.With(prometheus.Labels{projectLabel: source.ProjectID, sourceLabel: source.UID}).Inc()
 }
+
+// RecordDedupCheck counts a check of an idempotency key, the hit rate is the
+// share of checks that found a duplicate.
+func (m *Metrics) RecordDedupCheck(projectID string, isDuplicate bool) {
+	if !m.IsEnabled {
+		return
+	}
+
+	result := "miss"
+	if isDuplicate {
+		result = "hit"
+	}
+	m.DedupChecksTotal.With(prometheus.Labels{projectLabel: projectID, resultLabel: result}).Inc()
+}
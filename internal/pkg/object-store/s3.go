@@ -1,7 +1,10 @@
 package objectstore
 
 import (
+	"context"
+	"io"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/frain-dev/convoy/util"
@@ -72,3 +75,20 @@ func (s3 *S3Client) Save(filename string) error {
 	log.Printf("Successfully saved %q to %q\n", filename, s3.opts.Bucket)
 	return nil
 }
+
+// Put uploads the body to the bucket under the key, the key is prefixed with
+// the configured prefix.
+func (s3 *S3Client) Put(ctx context.Context, key string, body io.Reader) error {
+	if !util.IsStringEmpty(s3.opts.Prefix) {
+		key = path.Join(s3.opts.Prefix, key)
+	}
+
+	uploader := s3manager.NewUploader(s3.session)
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s3.opts.Bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+
+	return err
+}
@@ -0,0 +1,144 @@
+package changestream
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Record is a row read from the replication slot, Data is a wal2json
+// (format version 2) document.
+type Record struct {
+	LSN  string
+	Data string
+}
+
+type walColumn struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+type walChange struct {
+	Action   string      `json:"action"`
+	Schema   string      `json:"schema"`
+	Table    string      `json:"table"`
+	Columns  []walColumn `json:"columns"`
+	Identity []walColumn `json:"identity"`
+}
+
+// Change is the event data written for every row change.
+type Change struct {
+	Schema    string                     `json:"schema"`
+	Table     string                     `json:"table"`
+	Operation string                     `json:"operation"`
+	LSN       string                     `json:"lsn"`
+	Data      map[string]json.RawMessage `json:"data"`
+	OldData   map[string]json.RawMessage `json:"old_data,omitempty"`
+}
+
+// EventType derives the event type from the table and operation e.g.
+// orders.updated, tables outside the public schema are prefixed with the
+// schema e.g. billing.invoices.created.
+func (c *Change) EventType() string {
+	table := c.Table
+	if c.Schema != "" && c.Schema != "public" {
+		table = fmt.Sprintf("%s.%s", c.Schema, c.Table)
+	}
+
+	return fmt.Sprintf("%s.%s", table, c.Operation)
+}
+
+// Transaction holds the changes of a committed transaction.
+type Transaction struct {
+	CommitLSN string
+	Changes   []Change
+}
+
+// Message builds the ingest payload for the change at index i.
+func (t *Transaction) Message(slotName string, i int) (string, error) {
+	change := t.Changes[i]
+
+	b, err := json.Marshal(struct {
+		EventType      string  `json:"event_type"`
+		Data           *Change `json:"data"`
+		IdempotencyKey string  `json:"idempotency_key"`
+	}{
+		EventType:      change.EventType(),
+		Data:           &change,
+		IdempotencyKey: fmt.Sprintf("%s:%s:%d", slotName, t.CommitLSN, i),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// ParseTransactions groups the records into the transactions they belong to,
+// the changes of a transaction that isn't committed in the records are dropped.
+func ParseTransactions(records []Record) ([]Transaction, error) {
+	var txns []Transaction
+	var current *Transaction
+
+	for _, record := range records {
+		var wc walChange
+		err := json.Unmarshal([]byte(record.Data), &wc)
+		if err != nil {
+			return nil, err
+		}
+
+		switch wc.Action {
+		case "B":
+			current = &Transaction{}
+		case "C":
+			if current == nil {
+				continue
+			}
+
+			current.CommitLSN = record.LSN
+			txns = append(txns, *current)
+			current = nil
+		case "I", "U", "D":
+			if current == nil {
+				continue
+			}
+
+			change := Change{
+				Schema:    wc.Schema,
+				Table:     wc.Table,
+				Operation: operation(wc.Action),
+				LSN:       record.LSN,
+				Data:      columnMap(wc.Columns),
+			}
+
+			if wc.Action == "D" {
+				change.Data = columnMap(wc.Identity)
+			} else if len(wc.Identity) > 0 {
+				change.OldData = columnMap(wc.Identity)
+			}
+
+			current.Changes = append(current.Changes, change)
+		}
+	}
+
+	return txns, nil
+}
+
+func operation(action string) string {
+	switch action {
+	case "I":
+		return "created"
+	case "U":
+		return "updated"
+	default:
+		return "deleted"
+	}
+}
+
+func columnMap(columns []walColumn) map[string]json.RawMessage {
+	m := make(map[string]json.RawMessage, len(columns))
+	for _, column := range columns {
+		m[column.Name] = column.Value
+	}
+
+	return m
+}
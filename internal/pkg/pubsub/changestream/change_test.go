@@ -0,0 +1,90 @@
+package changestream
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransactions(t *testing.T) {
+	records := []Record{
+		{LSN: "0/16B3740", Data: `{"action":"B"}`},
+		{LSN: "0/16B3748", Data: `{"action":"I","schema":"public","table":"orders","columns":[{"name":"id","type":"integer","value":1},{"name":"status","type":"text","value":"new"}]}`},
+		{LSN: "0/16B3800", Data: `{"action":"U","schema":"public","table":"orders","columns":[{"name":"id","type":"integer","value":1},{"name":"status","type":"text","value":"paid"}],"identity":[{"name":"id","type":"integer","value":1}]}`},
+		{LSN: "0/16B3900", Data: `{"action":"C"}`},
+		{LSN: "0/16B3A00", Data: `{"action":"B"}`},
+		{LSN: "0/16B3A08", Data: `{"action":"D","schema":"billing","table":"invoices","identity":[{"name":"id","type":"integer","value":7}]}`},
+		{LSN: "0/16B3B00", Data: `{"action":"C"}`},
+		{LSN: "0/16B3C00", Data: `{"action":"B"}`},
+		{LSN: "0/16B3C08", Data: `{"action":"I","schema":"public","table":"orders","columns":[{"name":"id","type":"integer","value":2}]}`},
+	}
+
+	txns, err := ParseTransactions(records)
+	require.NoError(t, err)
+
+	// the last transaction isn't committed in the records
+	require.Len(t, txns, 2)
+
+	require.Equal(t, "0/16B3900", txns[0].CommitLSN)
+	require.Len(t, txns[0].Changes, 2)
+	require.Equal(t, "orders.created", txns[0].Changes[0].EventType())
+	require.Equal(t, json.RawMessage(`"new"`), txns[0].Changes[0].Data["status"])
+	require.Nil(t, txns[0].Changes[0].OldData)
+
+	require.Equal(t, "orders.updated", txns[0].Changes[1].EventType())
+	require.Equal(t, json.RawMessage(`"paid"`), txns[0].Changes[1].Data["status"])
+	require.Equal(t, json.RawMessage(`1`), txns[0].Changes[1].OldData["id"])
+
+	require.Equal(t, "0/16B3B00", txns[1].CommitLSN)
+	require.Len(t, txns[1].Changes, 1)
+	require.Equal(t, "billing.invoices.deleted", txns[1].Changes[0].EventType())
+	require.Equal(t, json.RawMessage(`7`), txns[1].Changes[0].Data["id"])
+}
+
+func TestParseTransactions_InvalidRecord(t *testing.T) {
+	_, err := ParseTransactions([]Record{{LSN: "0/1", Data: "not json"}})
+	require.Error(t, err)
+}
+
+func TestTransaction_Message(t *testing.T) {
+	txn := Transaction{
+		CommitLSN: "0/16B3900",
+		Changes: []Change{
+			{Schema: "public", Table: "orders", Operation: "created", LSN: "0/16B3748"},
+			{Schema: "public", Table: "orders", Operation: "updated", LSN: "0/16B3800"},
+		},
+	}
+
+	msg, err := txn.Message("convoy_slot", 1)
+	require.NoError(t, err)
+
+	var event struct {
+		EventType      string `json:"event_type"`
+		IdempotencyKey string `json:"idempotency_key"`
+		Data           Change `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(msg), &event))
+
+	require.Equal(t, "orders.updated", event.EventType)
+	require.Equal(t, "convoy_slot:0/16B3900:1", event.IdempotencyKey)
+	require.Equal(t, "0/16B3800", event.Data.LSN)
+}
+
+func TestNormalizeTables(t *testing.T) {
+	tables, err := NormalizeTables([]string{"orders", " billing.invoices "})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public.orders", "billing.invoices"}, tables)
+
+	_, err = NormalizeTables([]string{"orders,users"})
+	require.Error(t, err)
+
+	_, err = NormalizeTables([]string{"a.b.c"})
+	require.Error(t, err)
+}
+
+func TestValidateSlotName(t *testing.T) {
+	require.NoError(t, ValidateSlotName(DefaultSlotName("01H0JA5MEES38RRK3HTEJC647K")))
+	require.Error(t, ValidateSlotName("Convoy-Slot"))
+	require.Error(t, ValidateSlotName(""))
+}
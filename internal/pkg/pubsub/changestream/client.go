@@ -0,0 +1,363 @@
+package changestream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/internal/pkg/limiter"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/util"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// batchSize is the number of changes peeked from the slot at once, the
+	// slot only stops at transaction boundaries so a batch can be larger.
+	batchSize = 500
+
+	defaultPollInterval = 1
+
+	peekChanges = `
+	SELECT lsn::text, data FROM pg_logical_slot_peek_changes($1, NULL, $2, 'format-version', '2', 'add-tables', $3);
+	`
+
+	advanceSlot = `SELECT pg_replication_slot_advance($1, $2::pg_lsn);`
+
+	slotExists = `SELECT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = $1);`
+
+	createSlot = `SELECT pg_create_logical_replication_slot($1, 'wal2json');`
+
+	dropSlot = `SELECT pg_drop_replication_slot(slot_name) FROM pg_replication_slots WHERE slot_name = $1;`
+
+	// the advisory lock makes sure only one ingest instance reads from the slot.
+	lockSlot = `SELECT pg_try_advisory_lock(hashtext($1));`
+)
+
+var (
+	ErrInvalidWalLevel = errors.New("the database wal_level must be set to logical to stream changes")
+	ErrSlotDropped     = errors.New("the replication slot was dropped")
+
+	slotNameRegex = regexp.MustCompile(`^[a-z0-9_]{1,63}$`)
+	tableRegex    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)?$`)
+)
+
+type ChangeStream struct {
+	Cfg         *datastore.ChangeStreamConfig
+	source      *datastore.Source
+	ctx         context.Context
+	handler     datastore.PubSubHandler
+	log         log.StdLogger
+	rateLimiter limiter.RateLimiter
+	licenser    license.Licenser
+	instanceId  string
+
+	// slotCreated is set once the slot is known to exist, a slot that
+	// disappears after that was dropped with its source and isn't recreated.
+	slotCreated bool
+}
+
+func New(source *datastore.Source, handler datastore.PubSubHandler, log log.StdLogger, rateLimiter limiter.RateLimiter, licenser license.Licenser, instanceId string) *ChangeStream {
+	return &ChangeStream{
+		Cfg:         source.ChangeStream,
+		source:      source,
+		handler:     handler,
+		log:         log,
+		rateLimiter: rateLimiter,
+		licenser:    licenser,
+		instanceId:  instanceId,
+	}
+}
+
+// DefaultSlotName returns the replication slot name used for a source when
+// none is specified.
+func DefaultSlotName(sourceID string) string {
+	return fmt.Sprintf("convoy_%s", strings.ToLower(sourceID))
+}
+
+// NormalizeTables qualifies the tables with the public schema when the
+// schema isn't set, and fails when a table name is invalid.
+func NormalizeTables(tables []string) ([]string, error) {
+	normalized := make([]string, 0, len(tables))
+	for _, table := range tables {
+		table = strings.TrimSpace(table)
+		if !tableRegex.MatchString(table) {
+			return nil, fmt.Errorf("%s is not a valid table name", table)
+		}
+
+		if !strings.Contains(table, ".") {
+			table = "public." + table
+		}
+
+		normalized = append(normalized, table)
+	}
+
+	return normalized, nil
+}
+
+// ValidateSlotName checks that name can be used as a replication slot name.
+func ValidateSlotName(name string) error {
+	if !slotNameRegex.MatchString(name) {
+		return fmt.Errorf("%s is not a valid slot name, it may only contain lower case letters, numbers and underscores", name)
+	}
+
+	return nil
+}
+
+func (c *ChangeStream) Start(ctx context.Context) {
+	c.ctx = ctx
+	go c.consume()
+}
+
+// Verify ensures the database can be reached and supports logical decoding.
+func (c *ChangeStream) Verify() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := pgx.Connect(ctx, c.Cfg.DSN)
+	if err != nil {
+		log.WithError(err).Error("failed to connect to database - change stream")
+		return fmt.Errorf("failed to connect to the database: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	var walLevel string
+	err = conn.QueryRow(ctx, "SHOW wal_level;").Scan(&walLevel)
+	if err != nil {
+		return err
+	}
+
+	if walLevel != "logical" {
+		return ErrInvalidWalLevel
+	}
+
+	return nil
+}
+
+func (c *ChangeStream) consume() {
+	defer c.handleError()
+
+	cfg, err := config.Get()
+	if err != nil {
+		log.WithError(err).Errorf("failed to load config.Get() in change stream source %s with id %s", c.source.Name, c.source.UID)
+		return
+	}
+
+	interval := c.Cfg.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	var conn *pgx.Conn
+	defer func() {
+		if conn != nil {
+			_ = conn.Close(context.Background())
+		}
+	}()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			if !util.IsStringEmpty(c.instanceId) {
+				err = c.rateLimiter.Allow(c.ctx, c.instanceId, cfg.InstanceIngestRate)
+				if err != nil {
+					continue
+				}
+			}
+
+			if conn == nil {
+				conn, err = c.acquire()
+				if err != nil {
+					c.log.WithError(err).Errorf("failed to open change stream for source %s", c.source.UID)
+					continue
+				}
+
+				// another instance is reading from the slot
+				if conn == nil {
+					continue
+				}
+			}
+
+			err = c.poll(conn)
+			if err != nil {
+				c.log.WithError(err).Errorf("failed to read changes for source %s", c.source.UID)
+				_ = conn.Close(context.Background())
+				conn = nil
+			}
+		}
+	}
+}
+
+// acquire connects to the database and locks the replication slot, it returns
+// a nil connection when the slot is locked by another instance.
+func (c *ChangeStream) acquire() (*pgx.Conn, error) {
+	conn, err := pgx.Connect(c.ctx, c.Cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked bool
+	err = conn.QueryRow(c.ctx, lockSlot, c.Cfg.SlotName).Scan(&locked)
+	if err != nil || !locked {
+		_ = conn.Close(context.Background())
+		return nil, err
+	}
+
+	var exists bool
+	err = conn.QueryRow(c.ctx, slotExists, c.Cfg.SlotName).Scan(&exists)
+	if err != nil {
+		_ = conn.Close(context.Background())
+		return nil, err
+	}
+
+	if !exists {
+		if c.slotCreated {
+			_ = conn.Close(context.Background())
+			return nil, ErrSlotDropped
+		}
+
+		_, err = conn.Exec(c.ctx, createSlot, c.Cfg.SlotName)
+		if err != nil {
+			_ = conn.Close(context.Background())
+			return nil, err
+		}
+	}
+
+	c.slotCreated = true
+	return conn, nil
+}
+
+// DropSlot drops the replication slot so the database stops retaining WAL
+// for it, it is called once no source reads from the slot anymore.
+func DropSlot(ctx context.Context, cfg *datastore.ChangeStreamConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	conn, err := pgx.Connect(ctx, cfg.DSN)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, dropSlot, cfg.SlotName)
+	return err
+}
+
+// poll reads the pending changes from the slot and writes them to the handler.
+// The slot is only advanced past transactions whose changes were all handled,
+// so the slot's confirmed LSN is the checkpoint of the source.
+func (c *ChangeStream) poll(conn *pgx.Conn) error {
+	tables, err := NormalizeTables(c.Cfg.Tables)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn.Query(c.ctx, peekChanges, c.Cfg.SlotName, batchSize, strings.Join(tables, ","))
+	if err != nil {
+		return err
+	}
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		err = rows.Scan(&record.LSN, &record.Data)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		records = append(records, record)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	txns, err := ParseTransactions(records)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := msgpack.EncodeMsgPack(map[string]string{"x-convoy-message-type": "broadcast"})
+	if err != nil {
+		return err
+	}
+
+	mm := metrics.GetDPInstance(c.licenser)
+	mm.IncrementIngestTotal(c.source.UID, c.source.ProjectID)
+
+	var checkpoint string
+	err = c.handle(txns, metadata, &checkpoint)
+	if err != nil {
+		c.log.WithError(err).Error("failed to write change to create event queue - change stream")
+		mm.IncrementIngestErrorsTotal(c.source)
+	}
+
+	if util.IsStringEmpty(checkpoint) {
+		return nil
+	}
+
+	_, err = conn.Exec(c.ctx, advanceSlot, c.Cfg.SlotName, checkpoint)
+	return err
+}
+
+// handle writes the changes to the handler and sets checkpoint to the commit
+// LSN of the last transaction whose changes were all handled. The next poll
+// reads the failed transaction again, the changes that were already handled
+// carry the same idempotency key. Changes that can never be ingested are
+// skipped, so they don't hold back the checkpoint.
+func (c *ChangeStream) handle(txns []Transaction, metadata []byte, checkpoint *string) error {
+	mm := metrics.GetDPInstance(c.licenser)
+
+	for _, txn := range txns {
+		for i := range txn.Changes {
+			msg, err := txn.Message(c.Cfg.SlotName, i)
+			if err != nil {
+				c.log.WithError(err).Errorf("skipped change %s of source %s, it can't be built into a message", txn.Changes[i].LSN, c.source.UID)
+				mm.IncrementIngestErrorsTotal(c.source)
+				continue
+			}
+
+			err = c.handler(c.ctx, c.source, msg, metadata)
+			if errors.Is(err, datastore.ErrPubSubMessageRejected) {
+				c.log.WithError(err).Errorf("skipped change %s of source %s, it was rejected", txn.Changes[i].LSN, c.source.UID)
+				mm.IncrementIngestErrorsTotal(c.source)
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			mm.IncrementIngestConsumedTotal(c.source)
+		}
+
+		*checkpoint = txn.CommitLSN
+	}
+
+	return nil
+}
+
+func (c *ChangeStream) handleError() {
+	if err := recover(); err != nil {
+		c.log.WithError(fmt.Errorf("sourceID: %s, Error: %s", c.source.UID, err)).Error("change stream source crashed")
+	}
+}
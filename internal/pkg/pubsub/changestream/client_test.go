@@ -0,0 +1,77 @@
+package changestream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeStream_handle(t *testing.T) {
+	txns := []Transaction{
+		{CommitLSN: "0/100", Changes: []Change{{Schema: "public", Table: "orders", Operation: "created", LSN: "0/90"}}},
+		{CommitLSN: "0/200", Changes: []Change{{Schema: "public", Table: "orders", Operation: "updated", LSN: "0/190"}}},
+		{CommitLSN: "0/300", Changes: []Change{{Schema: "public", Table: "orders", Operation: "deleted", LSN: "0/290"}}},
+	}
+
+	tests := []struct {
+		name           string
+		handlerErrs    []error
+		wantErr        bool
+		wantCheckpoint string
+		wantHandled    int
+	}{
+		{
+			name:           "should_advance_past_all_handled_transactions",
+			handlerErrs:    []error{nil, nil, nil},
+			wantCheckpoint: "0/300",
+			wantHandled:    3,
+		},
+		{
+			name:           "should_skip_rejected_changes",
+			handlerErrs:    []error{nil, fmt.Errorf("%w: bad payload", datastore.ErrPubSubMessageRejected), nil},
+			wantCheckpoint: "0/300",
+			wantHandled:    3,
+		},
+		{
+			name:           "should_stop_at_failed_changes",
+			handlerErrs:    []error{nil, errors.New("queue is unavailable"), nil},
+			wantErr:        true,
+			wantCheckpoint: "0/100",
+			wantHandled:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := 0
+			c := &ChangeStream{
+				Cfg:    &datastore.ChangeStreamConfig{SlotName: "convoy_slot"},
+				source: &datastore.Source{UID: "source-1"},
+				ctx:    context.Background(),
+				log:    log.NewLogger(os.Stdout),
+				handler: func(_ context.Context, _ *datastore.Source, _ string, _ []byte) error {
+					err := tt.handlerErrs[handled]
+					handled++
+					return err
+				},
+			}
+
+			var checkpoint string
+			err := c.handle(txns, nil, &checkpoint)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.wantCheckpoint, checkpoint)
+			require.Equal(t, tt.wantHandled, handled)
+		})
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/frain-dev/convoy/internal/pkg/dedup"
+	"github.com/frain-dev/convoy/internal/pkg/eventschema"
 	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/internal/pkg/limiter"
@@ -42,9 +45,11 @@ type Ingest struct {
 	log         log.StdLogger
 	instanceId  string
 	licenser    license.Licenser
+	schemas     *eventschema.Validator
+	dedup       *dedup.Window
 }
 
-func NewIngest(ctx context.Context, table *memorystore.Table, queue queue.Queuer, log log.StdLogger, rateLimiter limiter.RateLimiter, licenser license.Licenser, instanceId string) (*Ingest, error) {
+func NewIngest(ctx context.Context, table *memorystore.Table, queue queue.Queuer, log log.StdLogger, rateLimiter limiter.RateLimiter, licenser license.Licenser, instanceId string, eventTypeRepo datastore.EventTypesRepository, dedupWindow *dedup.Window) (*Ingest, error) {
 	ctx = context.WithValue(ctx, ingestCtx, nil)
 	i := &Ingest{
 		ctx:         ctx,
@@ -54,6 +59,8 @@ func NewIngest(ctx context.Context, table *memorystore.Table, queue queue.Queuer
 		rateLimiter: rateLimiter,
 		instanceId:  instanceId,
 		licenser:    licenser,
+		schemas:     eventschema.NewValidator(eventTypeRepo),
+		dedup:       dedupWindow,
 		sources:     make(map[memorystore.Key]*PubSubSource),
 		ticker:      time.NewTicker(time.Duration(1) * time.Second),
 	}
@@ -133,13 +140,13 @@ func (i *Ingest) run() error {
 	return nil
 }
 
-func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string, metadata []byte) error {
+func (i *Ingest) handler(ctx context.Context, source *datastore.Source, msg string, metadata []byte) error {
 	defer handlePanic(source)
 
 	// unmarshal to an interface{} struct
 	var raw any
 	if err := json.Unmarshal([]byte(msg), &raw); err != nil {
-		return err
+		return rejectMessage(err)
 	}
 
 	type ConvoyEvent struct {
@@ -156,7 +163,7 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 		t := transform.NewTransformer()
 		p, _, err := t.Transform(*source.BodyFunction, raw)
 		if err != nil {
-			return err
+			return rejectMessage(err)
 		}
 
 		payload = p
@@ -167,7 +174,7 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 	// transform to required payload
 	pBytes, err := json.Marshal(payload)
 	if err != nil {
-		return err
+		return rejectMessage(err)
 	}
 
 	var convoyEvent ConvoyEvent
@@ -178,18 +185,33 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 	if err = decoder.Decode(&convoyEvent); err != nil {
 		log.WithError(err).Errorf("the payload for %s with id (%s) is badly formatted, please refer to the documentation or"+
 			" use transfrom functions to properly format it, got: %+v", source.Name, source.UID, payload)
-		return err
+		return rejectMessage(err)
 	}
 
 	if util.IsStringEmpty(convoyEvent.EventType) {
 		err := fmt.Errorf("the payload for %s with id (%s) doesn't include an event type, please refer to the documentation or"+
 			" use transfrom functions to properly format it, got: %+v", source.Name, source.UID, convoyEvent)
-		return err
+		return rejectMessage(err)
 	}
 
 	if len(convoyEvent.Data) == 0 {
 		err := fmt.Errorf("the payload for %s with id (%s) doesn't include any data, please refer to the documentation or"+
 			" use transfrom functions to properly format it, got: %+v", source.Name, source.UID, convoyEvent)
+		return rejectMessage(err)
+	}
+
+	schemaStatus, err := i.schemas.Check(ctx, source.ProjectID, convoyEvent.EventType, convoyEvent.Data)
+	if err != nil {
+		// a message that fails validation fails it every time, so it is
+		// acknowledged and dropped instead of being redelivered
+		var validationErr *eventschema.ValidationError
+		if errors.As(err, &validationErr) {
+			log.WithError(err).Errorf("dropped the message for %s with id (%s), the payload failed schema validation", source.Name, source.UID)
+			metrics.GetDPInstance(i.licenser).IncrementIngestErrorsTotal(source)
+			return nil
+		}
+
+		log.WithError(err).Errorf("failed to validate the payload for %s with id (%s)", source.Name, source.UID)
 		return err
 	}
 
@@ -206,7 +228,7 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 		t := transform.NewTransformer()
 		h, _, transErr := t.Transform(*source.HeaderFunction, headerMap)
 		if transErr != nil {
-			return transErr
+			return rejectMessage(transErr)
 		}
 
 		switch castedH := h.(type) {
@@ -217,7 +239,7 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 				}
 
 				if _, ok := v.(string); !ok {
-					return fmt.Errorf("headers values for %s with id (%s) should be strings, want: type of string, got: %+v, of type %T", source.Name, source.UID, v, v)
+					return rejectMessage(fmt.Errorf("headers values for %s with id (%s) should be strings, want: type of string, got: %+v, of type %T", source.Name, source.UID, v, v))
 				}
 
 				headers[k] = v.(string)
@@ -225,12 +247,32 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 		case map[string]string:
 			headers = castedH
 		default:
-			return fmt.Errorf("the headers for %s with id (%s) are badly formatted, want: type of map[string]any or map[string]string, got: %+v, of type %T", source.Name, source.UID, castedH, castedH)
+			return rejectMessage(fmt.Errorf("the headers for %s with id (%s) are badly formatted, want: type of map[string]any or map[string]string, got: %+v, of type %T", source.Name, source.UID, castedH, castedH))
 		}
 	} else {
 		headers = headerMap
 	}
 
+	isDuplicate, err := i.checkDuplicate(ctx, source, convoyEvent.IdempotencyKey)
+	if err != nil {
+		return err
+	}
+
+	// the key is given up when the event isn't queued, so the redelivered
+	// message isn't a duplicate
+	var queued bool
+	if isDuplicate != nil && !*isDuplicate {
+		defer func() {
+			if queued {
+				return
+			}
+
+			if err := i.dedup.Release(ctx, source.ProjectID, convoyEvent.IdempotencyKey); err != nil {
+				log.WithError(err).Errorf("failed to release the idempotency key for %s with id (%s)", source.Name, source.UID)
+			}
+		}()
+	}
+
 	messageType := headers[ConvoyMessageTypeHeader]
 	switch messageType {
 	case "single":
@@ -245,13 +287,15 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 				CustomHeaders:  headers,
 				IdempotencyKey: convoyEvent.IdempotencyKey,
 				AcknowledgedAt: time.Now(),
+				SchemaStatus:   schemaStatus,
+				IsDuplicate:    isDuplicate,
 			},
 			CreateSubscription: !util.IsStringEmpty(convoyEvent.EndpointID),
 		}
 
 		if util.IsStringEmpty(ce.Params.EndpointID) {
-			return fmt.Errorf("the payload with message type %s for %s with id (%s) doesn't include an endpoint id, please refer to the documentation or"+
-				" use transfrom functions to properly format it, got: %+v", messageType, source.Name, source.UID, convoyEvent)
+			return rejectMessage(fmt.Errorf("the payload with message type %s for %s with id (%s) doesn't include an endpoint id, please refer to the documentation or"+
+				" use transfrom functions to properly format it, got: %+v", messageType, source.Name, source.UID, convoyEvent))
 		}
 
 		eventByte, err := msgpack.EncodeMsgPack(ce)
@@ -283,13 +327,15 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 				EndpointID:     convoyEvent.EndpointID,
 				IdempotencyKey: convoyEvent.IdempotencyKey,
 				AcknowledgedAt: time.Now(),
+				SchemaStatus:   schemaStatus,
+				IsDuplicate:    isDuplicate,
 			},
 			CreateSubscription: !util.IsStringEmpty(convoyEvent.EndpointID),
 		}
 
 		if util.IsStringEmpty(ce.Params.OwnerID) {
-			return fmt.Errorf("the payload with message type %s for %s with id (%s) doesn't include an owner id, please refer to the documentation or"+
-				" use transfrom functions to properly format it, got: %+v", messageType, source.Name, source.UID, convoyEvent)
+			return rejectMessage(fmt.Errorf("the payload with message type %s for %s with id (%s) doesn't include an owner id, please refer to the documentation or"+
+				" use transfrom functions to properly format it, got: %+v", messageType, source.Name, source.UID, convoyEvent))
 		}
 
 		eventByte, err := msgpack.EncodeMsgPack(ce)
@@ -320,6 +366,8 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 			CustomHeaders:  headers,
 			IdempotencyKey: convoyEvent.IdempotencyKey,
 			AcknowledgedAt: time.Now(),
+			SchemaStatus:   schemaStatus,
+			IsDuplicate:    isDuplicate,
 		}
 
 		eventByte, err := msgpack.EncodeMsgPack(broadcastEvent)
@@ -340,9 +388,10 @@ func (i *Ingest) handler(_ context.Context, source *datastore.Source, msg string
 	default:
 		err := fmt.Errorf("%s isn't a valid pubsub message type, it should be one of single, fanout or broadcast", messageType)
 		log.Error(err)
-		return err
+		return rejectMessage(err)
 	}
 
+	queued = true
 	return nil
 }
 
@@ -373,8 +422,35 @@ func mergeHeaders(dest map[string]string, src map[string]string) {
 	}
 }
 
+// rejectMessage marks err as caused by the message itself, so handling the
+// message again fails the same way.
+func rejectMessage(err error) error {
+	return fmt.Errorf("%w: %w", datastore.ErrPubSubMessageRejected, err)
+}
+
 func handlePanic(source *datastore.Source) {
 	if err := recover(); err != nil {
 		log.Error(fmt.Errorf("recovered from panic, source %s with id: %s crashed with error: %s", source.Name, source.UID, err))
 	}
 }
+
+// checkDuplicate checks the idempotency key against the source's dedup
+// window, it returns nil for sources without a window so the key is looked
+// up in the events table instead.
+func (i *Ingest) checkDuplicate(ctx context.Context, source *datastore.Source, idempotencyKey string) (*bool, error) {
+	if i.dedup == nil || util.IsStringEmpty(idempotencyKey) {
+		return nil, nil
+	}
+
+	window, err := dedup.ParseWindow(source.DedupWindow)
+	if err != nil || window == 0 {
+		return nil, err
+	}
+
+	isDuplicate, err := i.dedup.IsDuplicate(ctx, source.ProjectID, idempotencyKey, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return &isDuplicate, nil
+}
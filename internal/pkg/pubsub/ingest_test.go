@@ -0,0 +1,46 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIngest_handler_SchemaRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eventTypeRepo := mocks.NewMockEventTypesRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().CanExportPrometheusMetrics().AnyTimes().Return(false)
+
+	eventTypeRepo.EXPECT().FetchEventTypeByName(gomock.Any(), "invoice.paid", "project-1").Return(&datastore.ProjectEventType{
+		Name:             "invoice.paid",
+		JSONSchema:       json.RawMessage(`{"type":"object","required":["amount"]}`),
+		SchemaValidation: datastore.RejectSchemaValidation,
+	}, nil)
+
+	// the message is dropped, nothing is queued
+	q.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	i, err := NewIngest(context.Background(), nil, q, log.NewLogger(io.Discard), nil, licenser, "instance-1", eventTypeRepo, nil)
+	require.NoError(t, err)
+
+	metadata, err := msgpack.EncodeMsgPack(map[string]string{})
+	require.NoError(t, err)
+
+	source := &datastore.Source{UID: "source-1", Name: "billing", ProjectID: "project-1"}
+	msg := `{"endpoint_id":"endpoint-1","event_type":"invoice.paid","data":{"currency":"usd"}}`
+
+	// acknowledging the message stops the broker from redelivering it
+	require.NoError(t, i.handler(context.Background(), source, msg, metadata))
+}
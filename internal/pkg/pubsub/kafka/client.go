@@ -113,6 +113,27 @@ func (k *Kafka) Verify() error {
 	return nil
 }
 
+// Publish writes the message to the configured topic.
+func (k *Kafka) Publish(ctx context.Context, key string, value []byte) error {
+	dialer, err := k.dialer()
+	if err != nil {
+		return err
+	}
+
+	w := &kafka.Writer{
+		Addr:  kafka.TCP(k.Cfg.Brokers...),
+		Topic: k.Cfg.TopicName,
+		Transport: &kafka.Transport{
+			SASL: dialer.SASLMechanism,
+			TLS:  dialer.TLS,
+		},
+		RequiredAcks: kafka.RequireAll,
+	}
+	defer w.Close()
+
+	return w.WriteMessages(ctx, kafka.Message{Key: []byte(key), Value: value})
+}
+
 func (k *Kafka) consume() {
 	dialer, err := k.dialer()
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/frain-dev/convoy/datastore"
 	rqm "github.com/frain-dev/convoy/internal/pkg/pubsub/amqp"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/changestream"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/google"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/kafka"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/sqs"
@@ -65,6 +66,14 @@ func (p *PubSubSource) Stop() {
 }
 
 func createClient(source *datastore.Source, handler datastore.PubSubHandler, log log.StdLogger, rateLimiter limiter.RateLimiter, licenser license.Licenser, instanceId string) (PubSub, error) {
+	if source.Type == datastore.DBChangeStream {
+		if source.ChangeStream == nil {
+			return nil, fmt.Errorf("change stream config for source %s is missing", source.UID)
+		}
+
+		return changestream.New(source, handler, log, rateLimiter, licenser, instanceId), nil
+	}
+
 	if source.PubSub.Type == datastore.SqsPubSub {
 		return sqs.New(source, handler, log, rateLimiter, licenser, instanceId), nil
 	}
@@ -87,6 +96,15 @@ func createClient(source *datastore.Source, handler datastore.PubSubHandler, log
 func generateSourceKey(source *datastore.Source) memorystore.Key {
 	var hash string
 
+	if source.Type == datastore.DBChangeStream {
+		if cs := source.ChangeStream; cs != nil {
+			hash = fmt.Sprintf("%s,%s,%v,%v", cs.DSN, cs.SlotName, cs.Tables, cs.PollInterval)
+		}
+
+		h := md5.Sum([]byte(hash))
+		return memorystore.NewKey(source.ProjectID, hex.EncodeToString(h[:]))
+	}
+
 	if source.PubSub.Type == datastore.SqsPubSub {
 		sq := source.PubSub.Sqs
 		hash = fmt.Sprintf("%s,%s,%s,%s,%v", sq.AccessKeyID, sq.SecretKey, sq.DefaultRegion, sq.QueueName, source.PubSub.Workers)
@@ -82,6 +82,32 @@ func (s *Sqs) Verify() error {
 	return nil
 }
 
+// Publish sends the message to the configured queue.
+func (s *Sqs) Publish(ctx context.Context, value []byte) error {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(s.Cfg.DefaultRegion),
+		Credentials: credentials.NewStaticCredentials(s.Cfg.AccessKeyID, s.Cfg.SecretKey, ""),
+	})
+	if err != nil {
+		return err
+	}
+
+	svc := sqs.New(sess)
+	url, err := svc.GetQueueUrlWithContext(ctx, &sqs.GetQueueUrlInput{
+		QueueName: &s.Cfg.QueueName,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.SendMessageWithContext(ctx, &sqs.SendMessageInput{
+		QueueUrl:    url.QueueUrl,
+		MessageBody: aws.String(string(value)),
+	})
+
+	return err
+}
+
 func (s *Sqs) consume() {
 	sess, err := session.NewSession(&aws.Config{
 		Region:      aws.String(s.Cfg.DefaultRegion),
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	rqm "github.com/frain-dev/convoy/internal/pkg/pubsub/amqp"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/changestream"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/kafka"
 
 	"github.com/frain-dev/convoy/datastore"
@@ -57,6 +58,12 @@ type KafkaAuth struct {
 	TLS      bool   `json:"tls"`
 }
 
+type ChangeStream struct {
+	DSN      string   `json:"dsn" valid:"required~dsn is required"`
+	SlotName string   `json:"slot_name" valid:"required~slot name is required"`
+	Tables   []string `json:"tables" valid:"required~tables list is required"`
+}
+
 type PS struct {
 	Type    datastore.PubSubType `json:"type" valid:"required~type is required,supported_pub_sub~unsupported pub sub type"`
 	Workers int                  `json:"workers" valid:"required"`
@@ -195,3 +202,38 @@ func Validate(cfg *datastore.PubSubConfig) error {
 		return nil
 	}
 }
+
+func ValidateChangeStream(cfg *datastore.ChangeStreamConfig) error {
+	if cfg == nil {
+		return errors.New("change stream config is required")
+	}
+
+	cs := &ChangeStream{
+		DSN:      cfg.DSN,
+		SlotName: cfg.SlotName,
+		Tables:   cfg.Tables,
+	}
+
+	if err := util.Validate(cs); err != nil {
+		return err
+	}
+
+	if cfg.PollInterval < 0 {
+		return errors.New("poll interval cannot be negative")
+	}
+
+	if err := changestream.ValidateSlotName(cfg.SlotName); err != nil {
+		return err
+	}
+
+	if _, err := changestream.NormalizeTables(cfg.Tables); err != nil {
+		return err
+	}
+
+	c := &changestream.ChangeStream{Cfg: cfg}
+	if err := c.Verify(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+// Package restapi polls REST APIs that don't send webhooks, every item in a
+// response becomes an event.
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/util"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	DefaultPollInterval = 5 * time.Minute
+	MinPollInterval     = time.Minute
+)
+
+var ErrInvalidResponse = errors.New("the response is not valid json")
+
+// Item is an entry of the items array in a response.
+type Item struct {
+	ID   string
+	Data []byte
+}
+
+// Page is a parsed response.
+type Page struct {
+	Items []Item
+
+	// Cursor is the next page cursor, it is empty on the last page.
+	Cursor string
+}
+
+// Poller builds the requests for a rest_api source and parses the responses.
+type Poller struct {
+	cfg *datastore.RestApiConfig
+
+	itemsPath  string
+	idPath     string
+	cursorPath string
+}
+
+func NewPoller(cfg *datastore.RestApiConfig) (*Poller, error) {
+	if cfg == nil {
+		return nil, errors.New("rest api config is required")
+	}
+
+	if util.IsStringEmpty(cfg.URL) {
+		return nil, errors.New("please provide the url to poll")
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("please provide a valid http url to poll")
+	}
+
+	if util.IsStringEmpty(cfg.IDPath) {
+		return nil, errors.New("please provide the id path of the items")
+	}
+
+	if util.IsStringEmpty(cfg.EventType) {
+		return nil, errors.New("please provide the event type of the items")
+	}
+
+	if util.IsStringEmpty(cfg.CursorPath) != util.IsStringEmpty(cfg.CursorParam) {
+		return nil, errors.New("cursor path and cursor param must be set together")
+	}
+
+	if cfg.PollInterval != 0 && time.Duration(cfg.PollInterval)*time.Second < MinPollInterval {
+		return nil, fmt.Errorf("poll interval cannot be less than %d seconds", int(MinPollInterval.Seconds()))
+	}
+
+	if err = validateAuthentication(cfg.Authentication); err != nil {
+		return nil, err
+	}
+
+	p := &Poller{cfg: cfg}
+
+	if p.itemsPath, err = gjsonPath(cfg.ItemsPath); err != nil {
+		return nil, fmt.Errorf("invalid items path: %v", err)
+	}
+
+	if p.idPath, err = gjsonPath(cfg.IDPath); err != nil {
+		return nil, fmt.Errorf("invalid id path: %v", err)
+	}
+
+	if p.cursorPath, err = gjsonPath(cfg.CursorPath); err != nil {
+		return nil, fmt.Errorf("invalid cursor path: %v", err)
+	}
+
+	return p, nil
+}
+
+// Validate checks that the source can be polled.
+func Validate(cfg *datastore.RestApiConfig) error {
+	_, err := NewPoller(cfg)
+	return err
+}
+
+func validateAuthentication(auth *datastore.EndpointAuthentication) error {
+	if auth == nil {
+		return nil
+	}
+
+	switch auth.Type {
+	case datastore.APIKeyAuthentication:
+		if auth.ApiKey == nil || util.IsStringEmpty(auth.ApiKey.HeaderName) || util.IsStringEmpty(auth.ApiKey.HeaderValue) {
+			return errors.New("please provide the api key header name and value")
+		}
+	case datastore.OAuth2Authentication:
+		if auth.OAuth2 == nil {
+			return errors.New("please provide the oauth2 config")
+		}
+		return util.Validate(auth.OAuth2)
+	case "":
+	default:
+		return errors.New("unsupported authentication type")
+	}
+
+	return nil
+}
+
+func (p *Poller) EventType() string {
+	return p.cfg.EventType
+}
+
+// Interval is how long to wait between polls.
+func (p *Poller) Interval() time.Duration {
+	if p.cfg.PollInterval == 0 {
+		return DefaultPollInterval
+	}
+
+	return time.Duration(p.cfg.PollInterval) * time.Second
+}
+
+// URL returns the url of the page at cursor.
+func (p *Poller) URL(cursor string) (string, error) {
+	if util.IsStringEmpty(p.cfg.CursorParam) || util.IsStringEmpty(cursor) {
+		return p.cfg.URL, nil
+	}
+
+	u, err := url.Parse(p.cfg.URL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set(p.cfg.CursorParam, cursor)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Headers are sent with every request, they include the api key when the
+// source authenticates with one.
+func (p *Poller) Headers() httpheader.HTTPHeader {
+	header := httpheader.HTTPHeader{}
+	for k, v := range p.cfg.Headers {
+		header[k] = []string{v}
+	}
+
+	auth := p.cfg.Authentication
+	if auth != nil && auth.Type == datastore.APIKeyAuthentication && auth.ApiKey != nil {
+		header[auth.ApiKey.HeaderName] = []string{auth.ApiKey.HeaderValue}
+	}
+
+	return header
+}
+
+// Parse extracts the items and the next page cursor from a response. Items
+// without an id are skipped since they can't be told apart across polls.
+func (p *Poller) Parse(body []byte) (*Page, error) {
+	if !gjson.ValidBytes(body) {
+		return nil, ErrInvalidResponse
+	}
+
+	items := get(body, p.itemsPath)
+	if !items.IsArray() {
+		return nil, fmt.Errorf("%s is not an array in the response", p.displayPath(p.cfg.ItemsPath))
+	}
+
+	page := &Page{}
+	for _, item := range items.Array() {
+		id := get([]byte(item.Raw), p.idPath)
+		if !id.Exists() || id.Type == gjson.Null || util.IsStringEmpty(id.String()) {
+			continue
+		}
+
+		page.Items = append(page.Items, Item{ID: id.String(), Data: []byte(item.Raw)})
+	}
+
+	if !util.IsStringEmpty(p.cfg.CursorPath) {
+		cursor := get(body, p.cursorPath)
+		if cursor.Exists() && cursor.Type != gjson.Null {
+			page.Cursor = cursor.String()
+		}
+	}
+
+	return page, nil
+}
+
+func (p *Poller) displayPath(path string) string {
+	if util.IsStringEmpty(path) {
+		return "$"
+	}
+	return path
+}
+
+func get(body []byte, path string) gjson.Result {
+	if path == "" {
+		return gjson.ParseBytes(body)
+	}
+	return gjson.GetBytes(body, path)
+}
+
+// gjsonPath converts a JSONPath e.g. $.data[0]['id'] to the gjson path
+// data.0.id, only the root, child and index selectors are supported. The
+// root is converted to an empty path.
+func gjsonPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" || path == "$" {
+		return "", nil
+	}
+
+	if path[0] != '$' {
+		return "", errors.New("path must start with $")
+	}
+
+	var parts []string
+	rest := path[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+
+			name := rest[:end]
+			if name == "" || name == "*" {
+				return "", errors.New("only child and index selectors are supported")
+			}
+
+			parts = append(parts, escape(name))
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return "", errors.New("unterminated [")
+			}
+
+			selector := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			if n := len(selector); n >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[n-1] == selector[0] {
+				parts = append(parts, escape(selector[1:n-1]))
+				continue
+			}
+
+			if _, err := strconv.ParseUint(selector, 10, 64); err != nil {
+				return "", errors.New("only child and index selectors are supported")
+			}
+
+			parts = append(parts, selector)
+		default:
+			return "", fmt.Errorf("unexpected %q in path", rest[0])
+		}
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// escape escapes the characters gjson gives a meaning to in a key.
+func escape(key string) string {
+	var b strings.Builder
+	for _, c := range key {
+		if strings.ContainsRune(`.*?|#@\!=<>%`, c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package restapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gjsonPath(t *testing.T) {
+	tests := map[string]struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		"root":             {path: "$", want: ""},
+		"empty":            {path: "", want: ""},
+		"child":            {path: "$.data.items", want: "data.items"},
+		"index":            {path: "$.data[0].id", want: "data.0.id"},
+		"quoted_child":     {path: "$['user.name']", want: `user\.name`},
+		"wildcard":         {path: "$.data[*]", wantErr: true},
+		"recursive":        {path: "$..id", wantErr: true},
+		"missing_root":     {path: "data.id", wantErr: true},
+		"unterminated":     {path: "$.data[0", wantErr: true},
+		"filter_predicate": {path: "$.data[?(@.id)]", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := gjsonPath(tc.path)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPoller_Parse(t *testing.T) {
+	p, err := NewPoller(&datastore.RestApiConfig{
+		URL:         "https://api.example.com/items?limit=10",
+		ItemsPath:   "$.data",
+		IDPath:      "$.meta['id']",
+		EventType:   "item.created",
+		CursorPath:  "$.paging.next",
+		CursorParam: "after",
+	})
+	require.NoError(t, err)
+
+	page, err := p.Parse([]byte(`{"data":[{"meta":{"id":"a"}},{"meta":{"id":7}},{"meta":{}}],"paging":{"next":"c2"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "c2", page.Cursor)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, "a", page.Items[0].ID)
+	require.Equal(t, "7", page.Items[1].ID)
+	require.JSONEq(t, `{"meta":{"id":7}}`, string(page.Items[1].Data))
+
+	_, err = p.Parse([]byte(`{"data":{}}`))
+	require.Error(t, err)
+
+	_, err = p.Parse([]byte(`not json`))
+	require.Equal(t, ErrInvalidResponse, err)
+
+	url, err := p.URL("c2")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/items?after=c2&limit=10", url)
+
+	url, err = p.URL("")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/items?limit=10", url)
+}
+
+func TestPoller_RootItems(t *testing.T) {
+	p, err := NewPoller(&datastore.RestApiConfig{URL: "https://api.example.com", IDPath: "$.id", EventType: "item.created"})
+	require.NoError(t, err)
+	require.Equal(t, DefaultPollInterval, p.Interval())
+
+	page, err := p.Parse([]byte(`[{"id":1},{"id":2}]`))
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.Empty(t, page.Cursor)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *datastore.RestApiConfig {
+		return &datastore.RestApiConfig{URL: "https://api.example.com", IDPath: "$.id", EventType: "item.created"}
+	}
+
+	tests := map[string]struct {
+		fn      func(cfg *datastore.RestApiConfig)
+		wantErr string
+	}{
+		"valid": {},
+		"missing_url": {
+			fn:      func(cfg *datastore.RestApiConfig) { cfg.URL = "" },
+			wantErr: "please provide the url to poll",
+		},
+		"non_http_url": {
+			fn:      func(cfg *datastore.RestApiConfig) { cfg.URL = "ftp://api.example.com" },
+			wantErr: "please provide a valid http url to poll",
+		},
+		"missing_id_path": {
+			fn:      func(cfg *datastore.RestApiConfig) { cfg.IDPath = "" },
+			wantErr: "please provide the id path of the items",
+		},
+		"cursor_path_without_param": {
+			fn:      func(cfg *datastore.RestApiConfig) { cfg.CursorPath = "$.next" },
+			wantErr: "cursor path and cursor param must be set together",
+		},
+		"short_poll_interval": {
+			fn:      func(cfg *datastore.RestApiConfig) { cfg.PollInterval = int((30 * time.Second).Seconds()) },
+			wantErr: "poll interval cannot be less than 60 seconds",
+		},
+		"api_key_without_value": {
+			fn: func(cfg *datastore.RestApiConfig) {
+				cfg.Authentication = &datastore.EndpointAuthentication{Type: datastore.APIKeyAuthentication, ApiKey: &datastore.ApiKey{HeaderName: "X-Api-Key"}}
+			},
+			wantErr: "please provide the api key header name and value",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid()
+			if tc.fn != nil {
+				tc.fn(cfg)
+			}
+
+			err := Validate(cfg)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
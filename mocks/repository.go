@@ -185,6 +185,21 @@ func (m *MockEventDeliveryRepository) EXPECT() *MockEventDeliveryRepositoryMockR
 	return m.recorder
 }
 
+// ClaimScheduledEndpointDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) ClaimScheduledEndpointDeliveries(ctx context.Context, projectID, endpointID, eventDeliveryID string, limit int) ([]datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduledEndpointDeliveries", ctx, projectID, endpointID, eventDeliveryID, limit)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimScheduledEndpointDeliveries indicates an expected call of ClaimScheduledEndpointDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) ClaimScheduledEndpointDeliveries(ctx, projectID, endpointID, eventDeliveryID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduledEndpointDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).ClaimScheduledEndpointDeliveries), ctx, projectID, endpointID, eventDeliveryID, limit)
+}
+
 // CountDeliveriesByStatus mocks base method.
 func (m *MockEventDeliveryRepository) CountDeliveriesByStatus(ctx context.Context, projectID string, status datastore.EventDeliveryStatus, params datastore.SearchParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -215,6 +230,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) CountEventDeliveries(ctx, pro
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CountEventDeliveries), ctx, projectID, endpointIDs, eventID, status, params)
 }
 
+// CountScheduledEndpointDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) CountScheduledEndpointDeliveries(ctx context.Context, projectID, endpointID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountScheduledEndpointDeliveries", ctx, projectID, endpointID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountScheduledEndpointDeliveries indicates an expected call of CountScheduledEndpointDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) CountScheduledEndpointDeliveries(ctx, projectID, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountScheduledEndpointDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CountScheduledEndpointDeliveries), ctx, projectID, endpointID)
+}
+
 // CreateEventDeliveries mocks base method.
 func (m *MockEventDeliveryRepository) CreateEventDeliveries(arg0 context.Context, arg1 []*datastore.EventDelivery) error {
 	m.ctrl.T.Helper()
@@ -362,6 +392,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) FindStuckEventDeliveriesBySta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStuckEventDeliveriesByStatus", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindStuckEventDeliveriesByStatus), ctx, status)
 }
 
+// HasPendingPredecessor mocks base method.
+func (m *MockEventDeliveryRepository) HasPendingPredecessor(ctx context.Context, projectID string, eventDelivery *datastore.EventDelivery) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPendingPredecessor", ctx, projectID, eventDelivery)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPendingPredecessor indicates an expected call of HasPendingPredecessor.
+func (mr *MockEventDeliveryRepositoryMockRecorder) HasPendingPredecessor(ctx, projectID, eventDelivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingPredecessor", reflect.TypeOf((*MockEventDeliveryRepository)(nil).HasPendingPredecessor), ctx, projectID, eventDelivery)
+}
+
 // LoadEventDeliveriesIntervals mocks base method.
 func (m *MockEventDeliveryRepository) LoadEventDeliveriesIntervals(ctx context.Context, projectID string, params datastore.SearchParams, period datastore.Period) ([]datastore.EventInterval, error) {
 	m.ctrl.T.Helper()
@@ -1280,6 +1325,117 @@ func (mr *MockOrganisationMemberRepositoryMockRecorder) UpdateOrganisationMember
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganisationMember", reflect.TypeOf((*MockOrganisationMemberRepository)(nil).UpdateOrganisationMember), ctx, member)
 }
 
+// MockSCIMUserRepository is a mock of SCIMUserRepository interface.
+type MockSCIMUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSCIMUserRepositoryMockRecorder
+}
+
+// MockSCIMUserRepositoryMockRecorder is the mock recorder for MockSCIMUserRepository.
+type MockSCIMUserRepositoryMockRecorder struct {
+	mock *MockSCIMUserRepository
+}
+
+// NewMockSCIMUserRepository creates a new mock instance.
+func NewMockSCIMUserRepository(ctrl *gomock.Controller) *MockSCIMUserRepository {
+	mock := &MockSCIMUserRepository{ctrl: ctrl}
+	mock.recorder = &MockSCIMUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSCIMUserRepository) EXPECT() *MockSCIMUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSCIMUser mocks base method.
+func (m *MockSCIMUserRepository) CreateSCIMUser(ctx context.Context, user *datastore.SCIMUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSCIMUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSCIMUser indicates an expected call of CreateSCIMUser.
+func (mr *MockSCIMUserRepositoryMockRecorder) CreateSCIMUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSCIMUser", reflect.TypeOf((*MockSCIMUserRepository)(nil).CreateSCIMUser), ctx, user)
+}
+
+// DeleteSCIMUser mocks base method.
+func (m *MockSCIMUserRepository) DeleteSCIMUser(ctx context.Context, id, orgID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSCIMUser", ctx, id, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSCIMUser indicates an expected call of DeleteSCIMUser.
+func (mr *MockSCIMUserRepositoryMockRecorder) DeleteSCIMUser(ctx, id, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSCIMUser", reflect.TypeOf((*MockSCIMUserRepository)(nil).DeleteSCIMUser), ctx, id, orgID)
+}
+
+// FetchSCIMUserByID mocks base method.
+func (m *MockSCIMUserRepository) FetchSCIMUserByID(ctx context.Context, id, orgID string) (*datastore.SCIMUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSCIMUserByID", ctx, id, orgID)
+	ret0, _ := ret[0].(*datastore.SCIMUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSCIMUserByID indicates an expected call of FetchSCIMUserByID.
+func (mr *MockSCIMUserRepositoryMockRecorder) FetchSCIMUserByID(ctx, id, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSCIMUserByID", reflect.TypeOf((*MockSCIMUserRepository)(nil).FetchSCIMUserByID), ctx, id, orgID)
+}
+
+// FetchSCIMUserByUserID mocks base method.
+func (m *MockSCIMUserRepository) FetchSCIMUserByUserID(ctx context.Context, userID, orgID string) (*datastore.SCIMUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSCIMUserByUserID", ctx, userID, orgID)
+	ret0, _ := ret[0].(*datastore.SCIMUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSCIMUserByUserID indicates an expected call of FetchSCIMUserByUserID.
+func (mr *MockSCIMUserRepositoryMockRecorder) FetchSCIMUserByUserID(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSCIMUserByUserID", reflect.TypeOf((*MockSCIMUserRepository)(nil).FetchSCIMUserByUserID), ctx, userID, orgID)
+}
+
+// LoadSCIMUsers mocks base method.
+func (m *MockSCIMUserRepository) LoadSCIMUsers(ctx context.Context, orgID string, filter *datastore.SCIMUserFilter) ([]datastore.SCIMUser, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSCIMUsers", ctx, orgID, filter)
+	ret0, _ := ret[0].([]datastore.SCIMUser)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadSCIMUsers indicates an expected call of LoadSCIMUsers.
+func (mr *MockSCIMUserRepositoryMockRecorder) LoadSCIMUsers(ctx, orgID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSCIMUsers", reflect.TypeOf((*MockSCIMUserRepository)(nil).LoadSCIMUsers), ctx, orgID, filter)
+}
+
+// UpdateSCIMUser mocks base method.
+func (m *MockSCIMUserRepository) UpdateSCIMUser(ctx context.Context, user *datastore.SCIMUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSCIMUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSCIMUser indicates an expected call of UpdateSCIMUser.
+func (mr *MockSCIMUserRepositoryMockRecorder) UpdateSCIMUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSCIMUser", reflect.TypeOf((*MockSCIMUserRepository)(nil).UpdateSCIMUser), ctx, user)
+}
+
 // MockEndpointRepository is a mock of EndpointRepository interface.
 type MockEndpointRepository struct {
 	ctrl     *gomock.Controller
@@ -1435,6 +1591,21 @@ func (mr *MockEndpointRepositoryMockRecorder) FindEndpointsByOwnerID(ctx, projec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEndpointsByOwnerID", reflect.TypeOf((*MockEndpointRepository)(nil).FindEndpointsByOwnerID), ctx, projectID, ownerID)
 }
 
+// FindHealthCheckEndpoints mocks base method.
+func (m *MockEndpointRepository) FindHealthCheckEndpoints(ctx context.Context) ([]datastore.Endpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHealthCheckEndpoints", ctx)
+	ret0, _ := ret[0].([]datastore.Endpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHealthCheckEndpoints indicates an expected call of FindHealthCheckEndpoints.
+func (mr *MockEndpointRepositoryMockRecorder) FindHealthCheckEndpoints(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHealthCheckEndpoints", reflect.TypeOf((*MockEndpointRepository)(nil).FindHealthCheckEndpoints), ctx)
+}
+
 // LoadEndpointsPaged mocks base method.
 func (m *MockEndpointRepository) LoadEndpointsPaged(ctx context.Context, projectID string, filter *datastore.Filter, pageable datastore.Pageable) ([]datastore.Endpoint, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
@@ -1451,6 +1622,20 @@ func (mr *MockEndpointRepositoryMockRecorder) LoadEndpointsPaged(ctx, projectID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEndpointsPaged", reflect.TypeOf((*MockEndpointRepository)(nil).LoadEndpointsPaged), ctx, projectID, filter, pageable)
 }
 
+// MarkEndpointVerified mocks base method.
+func (m *MockEndpointRepository) MarkEndpointVerified(ctx context.Context, projectID, endpointID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEndpointVerified", ctx, projectID, endpointID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEndpointVerified indicates an expected call of MarkEndpointVerified.
+func (mr *MockEndpointRepositoryMockRecorder) MarkEndpointVerified(ctx, projectID, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEndpointVerified", reflect.TypeOf((*MockEndpointRepository)(nil).MarkEndpointVerified), ctx, projectID, endpointID)
+}
+
 // UpdateEndpoint mocks base method.
 func (m *MockEndpointRepository) UpdateEndpoint(ctx context.Context, endpoint *datastore.Endpoint, projectID string) error {
 	m.ctrl.T.Helper()
@@ -1851,6 +2036,21 @@ func (mr *MockSourceRepositoryMockRecorder) LoadPubSubSourcesByProjectIDs(ctx, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPubSubSourcesByProjectIDs", reflect.TypeOf((*MockSourceRepository)(nil).LoadPubSubSourcesByProjectIDs), ctx, projectIds, pageable)
 }
 
+// LoadRestApiSources mocks base method.
+func (m *MockSourceRepository) LoadRestApiSources(ctx context.Context) ([]datastore.Source, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRestApiSources", ctx)
+	ret0, _ := ret[0].([]datastore.Source)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRestApiSources indicates an expected call of LoadRestApiSources.
+func (mr *MockSourceRepositoryMockRecorder) LoadRestApiSources(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRestApiSources", reflect.TypeOf((*MockSourceRepository)(nil).LoadRestApiSources), ctx)
+}
+
 // LoadSourcesPaged mocks base method.
 func (m *MockSourceRepository) LoadSourcesPaged(ctx context.Context, projectId string, filter *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
@@ -1881,6 +2081,20 @@ func (mr *MockSourceRepositoryMockRecorder) UpdateSource(ctx, projectId, source
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSource", reflect.TypeOf((*MockSourceRepository)(nil).UpdateSource), ctx, projectId, source)
 }
 
+// UpdateSourcePollState mocks base method.
+func (m *MockSourceRepository) UpdateSourcePollState(ctx context.Context, projectId, id string, state *datastore.PollState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSourcePollState", ctx, projectId, id, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSourcePollState indicates an expected call of UpdateSourcePollState.
+func (mr *MockSourceRepositoryMockRecorder) UpdateSourcePollState(ctx, projectId, id, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSourcePollState", reflect.TypeOf((*MockSourceRepository)(nil).UpdateSourcePollState), ctx, projectId, id, state)
+}
+
 // MockDeviceRepository is a mock of DeviceRepository interface.
 type MockDeviceRepository struct {
 	ctrl     *gomock.Controller
@@ -2087,6 +2301,21 @@ func (mr *MockJobRepositoryMockRecorder) FetchJobsByProjectId(ctx, projectID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJobsByProjectId", reflect.TypeOf((*MockJobRepository)(nil).FetchJobsByProjectId), ctx, projectID)
 }
 
+// FetchLatestJobByType mocks base method.
+func (m *MockJobRepository) FetchLatestJobByType(ctx context.Context, jobType, projectID string) (*datastore.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchLatestJobByType", ctx, jobType, projectID)
+	ret0, _ := ret[0].(*datastore.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchLatestJobByType indicates an expected call of FetchLatestJobByType.
+func (mr *MockJobRepositoryMockRecorder) FetchLatestJobByType(ctx, jobType, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchLatestJobByType", reflect.TypeOf((*MockJobRepository)(nil).FetchLatestJobByType), ctx, jobType, projectID)
+}
+
 // FetchRunningJobsByProjectId mocks base method.
 func (m *MockJobRepository) FetchRunningJobsByProjectId(ctx context.Context, projectID string) ([]datastore.Job, error) {
 	m.ctrl.T.Helper()
@@ -2160,6 +2389,20 @@ func (mr *MockJobRepositoryMockRecorder) MarkJobAsStarted(ctx, uid, projectID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkJobAsStarted", reflect.TypeOf((*MockJobRepository)(nil).MarkJobAsStarted), ctx, uid, projectID)
 }
 
+// UpdateJobProgress mocks base method.
+func (m *MockJobRepository) UpdateJobProgress(ctx context.Context, uid, projectID string, progress *datastore.JobProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobProgress", ctx, uid, projectID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobProgress indicates an expected call of UpdateJobProgress.
+func (mr *MockJobRepositoryMockRecorder) UpdateJobProgress(ctx, uid, projectID, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobProgress", reflect.TypeOf((*MockJobRepository)(nil).UpdateJobProgress), ctx, uid, projectID, progress)
+}
+
 // MockUserRepository is a mock of UserRepository interface.
 type MockUserRepository struct {
 	ctrl     *gomock.Controller
@@ -2257,6 +2500,21 @@ func (mr *MockUserRepositoryMockRecorder) FindUserByID(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByID", reflect.TypeOf((*MockUserRepository)(nil).FindUserByID), arg0, arg1)
 }
 
+// FindUserByOIDCSubject mocks base method.
+func (m *MockUserRepository) FindUserByOIDCSubject(ctx context.Context, issuer, subject string) (*datastore.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByOIDCSubject", ctx, issuer, subject)
+	ret0, _ := ret[0].(*datastore.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByOIDCSubject indicates an expected call of FindUserByOIDCSubject.
+func (mr *MockUserRepositoryMockRecorder) FindUserByOIDCSubject(ctx, issuer, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByOIDCSubject", reflect.TypeOf((*MockUserRepository)(nil).FindUserByOIDCSubject), ctx, issuer, subject)
+}
+
 // FindUserByToken mocks base method.
 func (m *MockUserRepository) FindUserByToken(arg0 context.Context, arg1 string) (*datastore.User, error) {
 	m.ctrl.T.Helper()
@@ -2560,6 +2818,347 @@ func (mr *MockMetaEventRepositoryMockRecorder) UpdateMetaEvent(ctx, projectID, m
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetaEvent", reflect.TypeOf((*MockMetaEventRepository)(nil).UpdateMetaEvent), ctx, projectID, metaEvent)
 }
 
+// MockScheduledEventRepository is a mock of ScheduledEventRepository interface.
+type MockScheduledEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledEventRepositoryMockRecorder
+}
+
+// MockScheduledEventRepositoryMockRecorder is the mock recorder for MockScheduledEventRepository.
+type MockScheduledEventRepositoryMockRecorder struct {
+	mock *MockScheduledEventRepository
+}
+
+// NewMockScheduledEventRepository creates a new mock instance.
+func NewMockScheduledEventRepository(ctrl *gomock.Controller) *MockScheduledEventRepository {
+	mock := &MockScheduledEventRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduledEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledEventRepository) EXPECT() *MockScheduledEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateScheduledEvent mocks base method.
+func (m *MockScheduledEventRepository) CreateScheduledEvent(arg0 context.Context, arg1 *datastore.ScheduledEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateScheduledEvent indicates an expected call of CreateScheduledEvent.
+func (mr *MockScheduledEventRepositoryMockRecorder) CreateScheduledEvent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledEvent", reflect.TypeOf((*MockScheduledEventRepository)(nil).CreateScheduledEvent), arg0, arg1)
+}
+
+// FindScheduledEventByID mocks base method.
+func (m *MockScheduledEventRepository) FindScheduledEventByID(ctx context.Context, projectID, id string) (*datastore.ScheduledEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindScheduledEventByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.ScheduledEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindScheduledEventByID indicates an expected call of FindScheduledEventByID.
+func (mr *MockScheduledEventRepositoryMockRecorder) FindScheduledEventByID(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindScheduledEventByID", reflect.TypeOf((*MockScheduledEventRepository)(nil).FindScheduledEventByID), ctx, projectID, id)
+}
+
+// LoadScheduledEventsPaged mocks base method.
+func (m *MockScheduledEventRepository) LoadScheduledEventsPaged(ctx context.Context, projectID string, status datastore.ScheduleStatus, f *datastore.Filter) ([]datastore.ScheduledEvent, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadScheduledEventsPaged", ctx, projectID, status, f)
+	ret0, _ := ret[0].([]datastore.ScheduledEvent)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadScheduledEventsPaged indicates an expected call of LoadScheduledEventsPaged.
+func (mr *MockScheduledEventRepositoryMockRecorder) LoadScheduledEventsPaged(ctx, projectID, status, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadScheduledEventsPaged", reflect.TypeOf((*MockScheduledEventRepository)(nil).LoadScheduledEventsPaged), ctx, projectID, status, f)
+}
+
+// RescheduleEvent mocks base method.
+func (m *MockScheduledEventRepository) RescheduleEvent(ctx context.Context, projectID, id string, deliverAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleEvent", ctx, projectID, id, deliverAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleEvent indicates an expected call of RescheduleEvent.
+func (mr *MockScheduledEventRepositoryMockRecorder) RescheduleEvent(ctx, projectID, id, deliverAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleEvent", reflect.TypeOf((*MockScheduledEventRepository)(nil).RescheduleEvent), ctx, projectID, id, deliverAt)
+}
+
+// RestoreScheduledEvent mocks base method.
+func (m *MockScheduledEventRepository) RestoreScheduledEvent(ctx context.Context, projectID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreScheduledEvent", ctx, projectID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreScheduledEvent indicates an expected call of RestoreScheduledEvent.
+func (mr *MockScheduledEventRepositoryMockRecorder) RestoreScheduledEvent(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreScheduledEvent", reflect.TypeOf((*MockScheduledEventRepository)(nil).RestoreScheduledEvent), ctx, projectID, id)
+}
+
+// UpdateScheduledEventStatus mocks base method.
+func (m *MockScheduledEventRepository) UpdateScheduledEventStatus(ctx context.Context, projectID, id string, status datastore.ScheduleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledEventStatus", ctx, projectID, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduledEventStatus indicates an expected call of UpdateScheduledEventStatus.
+func (mr *MockScheduledEventRepositoryMockRecorder) UpdateScheduledEventStatus(ctx, projectID, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledEventStatus", reflect.TypeOf((*MockScheduledEventRepository)(nil).UpdateScheduledEventStatus), ctx, projectID, id, status)
+}
+
+// MockDeadLetterRepository is a mock of DeadLetterRepository interface.
+type MockDeadLetterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterRepositoryMockRecorder
+}
+
+// MockDeadLetterRepositoryMockRecorder is the mock recorder for MockDeadLetterRepository.
+type MockDeadLetterRepositoryMockRecorder struct {
+	mock *MockDeadLetterRepository
+}
+
+// NewMockDeadLetterRepository creates a new mock instance.
+func NewMockDeadLetterRepository(ctrl *gomock.Controller) *MockDeadLetterRepository {
+	mock := &MockDeadLetterRepository{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterRepository) EXPECT() *MockDeadLetterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDeadLetter mocks base method.
+func (m *MockDeadLetterRepository) CreateDeadLetter(arg0 context.Context, arg1 *datastore.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeadLetter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeadLetter indicates an expected call of CreateDeadLetter.
+func (mr *MockDeadLetterRepositoryMockRecorder) CreateDeadLetter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeadLetter", reflect.TypeOf((*MockDeadLetterRepository)(nil).CreateDeadLetter), arg0, arg1)
+}
+
+// FindDeadLetterByID mocks base method.
+func (m *MockDeadLetterRepository) FindDeadLetterByID(ctx context.Context, projectID, id string) (*datastore.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeadLetterByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeadLetterByID indicates an expected call of FindDeadLetterByID.
+func (mr *MockDeadLetterRepositoryMockRecorder) FindDeadLetterByID(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeadLetterByID", reflect.TypeOf((*MockDeadLetterRepository)(nil).FindDeadLetterByID), ctx, projectID, id)
+}
+
+// FindDeadLettersForRedrive mocks base method.
+func (m *MockDeadLetterRepository) FindDeadLettersForRedrive(ctx context.Context, projectID string, filter *datastore.DeadLetterFilter, limit int) ([]datastore.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeadLettersForRedrive", ctx, projectID, filter, limit)
+	ret0, _ := ret[0].([]datastore.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeadLettersForRedrive indicates an expected call of FindDeadLettersForRedrive.
+func (mr *MockDeadLetterRepositoryMockRecorder) FindDeadLettersForRedrive(ctx, projectID, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeadLettersForRedrive", reflect.TypeOf((*MockDeadLetterRepository)(nil).FindDeadLettersForRedrive), ctx, projectID, filter, limit)
+}
+
+// LoadDeadLettersPaged mocks base method.
+func (m *MockDeadLetterRepository) LoadDeadLettersPaged(ctx context.Context, projectID string, filter *datastore.DeadLetterFilter, pageable datastore.Pageable) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDeadLettersPaged", ctx, projectID, filter, pageable)
+	ret0, _ := ret[0].([]datastore.DeadLetter)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadDeadLettersPaged indicates an expected call of LoadDeadLettersPaged.
+func (mr *MockDeadLetterRepositoryMockRecorder) LoadDeadLettersPaged(ctx, projectID, filter, pageable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDeadLettersPaged", reflect.TypeOf((*MockDeadLetterRepository)(nil).LoadDeadLettersPaged), ctx, projectID, filter, pageable)
+}
+
+// MarkDeadLettersRedriven mocks base method.
+func (m *MockDeadLetterRepository) MarkDeadLettersRedriven(ctx context.Context, projectID string, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDeadLettersRedriven", ctx, projectID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDeadLettersRedriven indicates an expected call of MarkDeadLettersRedriven.
+func (mr *MockDeadLetterRepositoryMockRecorder) MarkDeadLettersRedriven(ctx, projectID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDeadLettersRedriven", reflect.TypeOf((*MockDeadLetterRepository)(nil).MarkDeadLettersRedriven), ctx, projectID, ids)
+}
+
+// MockAuditLogRepository is a mock of AuditLogRepository interface.
+type MockAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepositoryMockRecorder
+}
+
+// MockAuditLogRepositoryMockRecorder is the mock recorder for MockAuditLogRepository.
+type MockAuditLogRepositoryMockRecorder struct {
+	mock *MockAuditLogRepository
+}
+
+// NewMockAuditLogRepository creates a new mock instance.
+func NewMockAuditLogRepository(ctrl *gomock.Controller) *MockAuditLogRepository {
+	mock := &MockAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepository) EXPECT() *MockAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockAuditLogRepository) CreateAuditLog(arg0 context.Context, arg1 *datastore.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockAuditLogRepositoryMockRecorder) CreateAuditLog(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockAuditLogRepository)(nil).CreateAuditLog), arg0, arg1)
+}
+
+// ExportAuditLogs mocks base method.
+func (m *MockAuditLogRepository) ExportAuditLogs(ctx context.Context, orgID string, filter *datastore.AuditLogFilter, w io.Writer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAuditLogs", ctx, orgID, filter, w)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAuditLogs indicates an expected call of ExportAuditLogs.
+func (mr *MockAuditLogRepositoryMockRecorder) ExportAuditLogs(ctx, orgID, filter, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAuditLogs", reflect.TypeOf((*MockAuditLogRepository)(nil).ExportAuditLogs), ctx, orgID, filter, w)
+}
+
+// LoadAuditLogsPaged mocks base method.
+func (m *MockAuditLogRepository) LoadAuditLogsPaged(ctx context.Context, orgID string, filter *datastore.AuditLogFilter, pageable datastore.Pageable) ([]datastore.AuditLog, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuditLogsPaged", ctx, orgID, filter, pageable)
+	ret0, _ := ret[0].([]datastore.AuditLog)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadAuditLogsPaged indicates an expected call of LoadAuditLogsPaged.
+func (mr *MockAuditLogRepositoryMockRecorder) LoadAuditLogsPaged(ctx, orgID, filter, pageable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuditLogsPaged", reflect.TypeOf((*MockAuditLogRepository)(nil).LoadAuditLogsPaged), ctx, orgID, filter, pageable)
+}
+
+// MockEndpointProbeRepository is a mock of EndpointProbeRepository interface.
+type MockEndpointProbeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEndpointProbeRepositoryMockRecorder
+}
+
+// MockEndpointProbeRepositoryMockRecorder is the mock recorder for MockEndpointProbeRepository.
+type MockEndpointProbeRepositoryMockRecorder struct {
+	mock *MockEndpointProbeRepository
+}
+
+// NewMockEndpointProbeRepository creates a new mock instance.
+func NewMockEndpointProbeRepository(ctrl *gomock.Controller) *MockEndpointProbeRepository {
+	mock := &MockEndpointProbeRepository{ctrl: ctrl}
+	mock.recorder = &MockEndpointProbeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEndpointProbeRepository) EXPECT() *MockEndpointProbeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEndpointProbe mocks base method.
+func (m *MockEndpointProbeRepository) CreateEndpointProbe(ctx context.Context, probe *datastore.EndpointProbe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEndpointProbe", ctx, probe)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEndpointProbe indicates an expected call of CreateEndpointProbe.
+func (mr *MockEndpointProbeRepositoryMockRecorder) CreateEndpointProbe(ctx, probe any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEndpointProbe", reflect.TypeOf((*MockEndpointProbeRepository)(nil).CreateEndpointProbe), ctx, probe)
+}
+
+// DeleteEndpointProbes mocks base method.
+func (m *MockEndpointProbeRepository) DeleteEndpointProbes(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEndpointProbes", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEndpointProbes indicates an expected call of DeleteEndpointProbes.
+func (mr *MockEndpointProbeRepositoryMockRecorder) DeleteEndpointProbes(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEndpointProbes", reflect.TypeOf((*MockEndpointProbeRepository)(nil).DeleteEndpointProbes), ctx, before)
+}
+
+// LoadEndpointProbes mocks base method.
+func (m *MockEndpointProbeRepository) LoadEndpointProbes(ctx context.Context, projectID, endpointID string, limit int) ([]datastore.EndpointProbe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEndpointProbes", ctx, projectID, endpointID, limit)
+	ret0, _ := ret[0].([]datastore.EndpointProbe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEndpointProbes indicates an expected call of LoadEndpointProbes.
+func (mr *MockEndpointProbeRepositoryMockRecorder) LoadEndpointProbes(ctx, projectID, endpointID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEndpointProbes", reflect.TypeOf((*MockEndpointProbeRepository)(nil).LoadEndpointProbes), ctx, projectID, endpointID, limit)
+}
+
 // MockExportRepository is a mock of ExportRepository interface.
 type MockExportRepository struct {
 	ctrl     *gomock.Controller
@@ -2833,6 +3432,36 @@ func (mr *MockEventTypesRepositoryMockRecorder) FetchEventTypeById(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventTypeById", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchEventTypeById), arg0, arg1, arg2)
 }
 
+// FetchEventTypeByName mocks base method.
+func (m *MockEventTypesRepository) FetchEventTypeByName(ctx context.Context, name, projectId string) (*datastore.ProjectEventType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventTypeByName", ctx, name, projectId)
+	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventTypeByName indicates an expected call of FetchEventTypeByName.
+func (mr *MockEventTypesRepositoryMockRecorder) FetchEventTypeByName(ctx, name, projectId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventTypeByName", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchEventTypeByName), ctx, name, projectId)
+}
+
+// FetchEventTypeSchemas mocks base method.
+func (m *MockEventTypesRepository) FetchEventTypeSchemas(ctx context.Context, id, projectId string) ([]datastore.EventTypeSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventTypeSchemas", ctx, id, projectId)
+	ret0, _ := ret[0].([]datastore.EventTypeSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventTypeSchemas indicates an expected call of FetchEventTypeSchemas.
+func (mr *MockEventTypesRepositoryMockRecorder) FetchEventTypeSchemas(ctx, id, projectId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventTypeSchemas", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchEventTypeSchemas), ctx, id, projectId)
+}
+
 // UpdateEventType mocks base method.
 func (m *MockEventTypesRepository) UpdateEventType(arg0 context.Context, arg1 *datastore.ProjectEventType) error {
 	m.ctrl.T.Helper()
@@ -20,6 +20,7 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/license"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
@@ -43,14 +44,21 @@ type Dispatcher struct {
 	transport *http.Transport
 	client    *http.Client
 	rules     *netjail.Rules
+
+	// mtlsClients caches a client per mutual tls client certificate
+	mtlsClients *mtlsClientCache
+
+	// tokenCache stores oauth2 access tokens
+	tokenCache cache.Cache
 }
 
 func NewDispatcher(l license.Licenser, ff *fflag.FFlag, options ...DispatcherOption) (*Dispatcher, error) {
 	d := &Dispatcher{
-		l:      l,
-		ff:     ff,
-		client: &http.Client{},
-		rules:  &netjail.Rules{},
+		l:           l,
+		ff:          ff,
+		client:      &http.Client{},
+		rules:       &netjail.Rules{},
+		mtlsClients: newMTLSClientCache(maxMTLSClients),
 		transport: &http.Transport{
 			MaxIdleConns:          100,
 			IdleConnTimeout:       10 * time.Second,
@@ -204,7 +212,7 @@ func (d *Dispatcher) validateProxy(proxyURL string) (*url.URL, bool, error) {
 	return nil, false, nil
 }
 
-func (d *Dispatcher) SendRequest(ctx context.Context, endpoint, method string, jsonData json.RawMessage, signatureHeader string, hmac string, maxResponseSize int64, headers httpheader.HTTPHeader, idempotencyKey string, timeout time.Duration) (*Response, error) {
+func (d *Dispatcher) SendRequest(ctx context.Context, endpoint, method string, jsonData json.RawMessage, contentType string, signatureHeader string, hmac string, maxResponseSize int64, headers httpheader.HTTPHeader, idempotencyKey string, timeout time.Duration) (*Response, error) {
 	d.logger.Debugf("rules: %+v", d.rules)
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
@@ -229,7 +237,11 @@ func (d *Dispatcher) SendRequest(ctx context.Context, endpoint, method string, j
 	}
 
 	req.Header.Set(signatureHeader, hmac)
-	req.Header.Add("Content-Type", "application/json")
+	if util.IsStringEmpty(contentType) {
+		contentType = "application/json"
+	}
+
+	req.Header.Add("Content-Type", contentType)
 	req.Header.Set("Accept-Encoding", "gzip")
 	req.Header.Add("User-Agent", defaultUserAgent())
 	if len(idempotencyKey) > 0 {
@@ -245,7 +257,94 @@ func (d *Dispatcher) SendRequest(ctx context.Context, endpoint, method string, j
 	r.URL = req.URL
 	r.Method = req.Method
 
-	err = d.do(req, r, maxResponseSize)
+	return d.send(ctx, req, r, maxResponseSize)
+}
+
+// Fetch sends a GET request to url, it is authenticated the same
+// way SendRequest is but isn't signed.
+func (d *Dispatcher) Fetch(ctx context.Context, url string, headers httpheader.HTTPHeader, maxResponseSize int64, timeout time.Duration) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d.ff.CanAccessFeature(fflag.IpRules) && d.l.IpRules() {
+		ctx = netjail.ContextWithRules(ctx, d.rules)
+	}
+
+	r := &Response{}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		d.logger.WithError(err).Error("error occurred while creating request")
+		r.Error = err.Error()
+		return r, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Add("User-Agent", defaultUserAgent())
+
+	header := httpheader.HTTPHeader(req.Header)
+	header.MergeHeaders(headers)
+
+	req.Header = http.Header(header)
+
+	r.RequestHeader = req.Header
+	r.URL = req.URL
+	r.Method = req.Method
+
+	return d.send(ctx, req, r, maxResponseSize)
+}
+
+// send sends the request with the client certificate and oauth2
+// access token in the context.
+func (d *Dispatcher) send(ctx context.Context, req *http.Request, r *Response, maxResponseSize int64) (*Response, error) {
+	var err error
+
+	client := d.client
+	if cert := clientCertificateFromContext(ctx); cert != nil && d.l.MutualTLS() {
+		client, err = d.mtlsClient(cert)
+		if err != nil {
+			d.logger.WithError(err).Error("error loading mutual tls client certificate")
+			r.Error = err.Error()
+			return r, err
+		}
+	}
+
+	oauth2Config := oauth2ConfigFromContext(ctx)
+	if oauth2Config != nil {
+		token, err := d.accessToken(ctx, client, oauth2Config, false)
+		if err != nil {
+			d.logger.WithError(err).Error("error fetching oauth2 access token")
+			r.Error = err.Error()
+			return r, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	err = d.do(client, req, r, maxResponseSize)
+	if err != nil || oauth2Config == nil || r.StatusCode != http.StatusUnauthorized {
+		return r, err
+	}
+
+	// the access token may have been revoked before it expired,
+	// so it is refreshed and the request is sent once more.
+	token, err := d.accessToken(ctx, client, oauth2Config, true)
+	if err != nil {
+		d.logger.WithError(err).Error("error refreshing oauth2 access token")
+		return r, nil
+	}
+
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return r, err
+		}
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+
+	r = &Response{RequestHeader: retry.Header, URL: retry.URL, Method: retry.Method}
+	err = d.do(client, retry, r, maxResponseSize)
 
 	return r, err
 }
@@ -272,7 +371,7 @@ func defaultUserAgent() string {
 	return "Convoy/" + convoy.GetVersion()
 }
 
-func (d *Dispatcher) do(req *http.Request, res *Response, maxResponseSize int64) error {
+func (d *Dispatcher) do(client *http.Client, req *http.Request, res *Response, maxResponseSize int64) error {
 	trace := &httptrace.ClientTrace{
 		GotConn: func(connInfo httptrace.GotConnInfo) {
 			res.IP = connInfo.Conn.RemoteAddr().String()
@@ -282,7 +381,7 @@ func (d *Dispatcher) do(req *http.Request, res *Response, maxResponseSize int64)
 
 	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
 
-	response, err := d.client.Do(req)
+	response, err := client.Do(req)
 	if err != nil {
 		d.logger.WithError(err).Error("error sending request to API endpoint")
 		res.Error = err.Error()
@@ -3,11 +3,20 @@ package net
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/stealthrocket/netjail"
+	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -16,6 +25,8 @@ import (
 
 	"github.com/frain-dev/convoy/internal/pkg/license"
 
+	mcache "github.com/frain-dev/convoy/cache/memory"
+
 	"github.com/frain-dev/convoy/mocks"
 	"go.uber.org/mock/gomock"
 
@@ -292,7 +303,7 @@ func TestDispatcher_SendRequest(t *testing.T) {
 				defer deferFn()
 			}
 
-			got, err := d.SendRequest(context.Background(), tt.args.endpoint, tt.args.method, tt.args.jsonData, tt.args.project.Config.Signature.Header.String(), tt.args.hmac, config.MaxResponseSize, tt.args.headers, "", time.Minute)
+			got, err := d.SendRequest(context.Background(), tt.args.endpoint, tt.args.method, tt.args.jsonData, "", tt.args.project.Config.Signature.Header.String(), tt.args.hmac, config.MaxResponseSize, tt.args.headers, "", time.Minute)
 			if tt.wantErr {
 				require.NotNil(t, err)
 				require.Contains(t, err.Error(), tt.want.Error)
@@ -429,6 +440,7 @@ func TestDispatcherSendRequest(t *testing.T) {
 		server.URL,
 		"POST",
 		jsonData,
+		"",
 		"X-Signature",
 		"test-hmac",
 		1024,
@@ -444,6 +456,54 @@ func TestDispatcherSendRequest(t *testing.T) {
 	require.Equal(t, "custom-value", resp.RequestHeader.Get("X-Custom-Header"))
 }
 
+func TestDispatcherSendRequestWithContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PUT", r.Method)
+		require.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "key=value", string(body))
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().UseForwardProxy().Times(1).Return(true)
+	licenser.EXPECT().IpRules().Times(4).Return(true)
+
+	dispatcher, err := NewDispatcher(
+		licenser,
+		fflag.NewFFlag([]string{string(fflag.IpRules)}),
+		LoggerOption(log.NewLogger(os.Stdout)),
+		ProxyOption("nil"),
+		AllowListOption([]string{"0.0.0.0/0"}),
+		BlockListOption([]string{"10.0.0.0/8"}),
+	)
+	require.NoError(t, err)
+
+	resp, err := dispatcher.SendRequest(
+		context.Background(),
+		server.URL,
+		"PUT",
+		[]byte("key=value"),
+		"application/x-www-form-urlencoded",
+		"X-Signature",
+		"test-hmac",
+		1024,
+		nil,
+		"",
+		5*time.Second,
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 // TestDispatcherWithTimeout tests the timeout functionality
 func TestDispatcherWithTimeout(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -475,6 +535,7 @@ func TestDispatcherWithTimeout(t *testing.T) {
 		server.URL,
 		"GET",
 		nil,
+		"",
 		"X-Signature",
 		"test-hmac",
 		1024,
@@ -521,6 +582,7 @@ func TestDispatcherWithBlockedIP(t *testing.T) {
 		server.URL,
 		"GET",
 		nil,
+		"",
 		"X-Signature",
 		"test-hmac",
 		1024,
@@ -534,3 +596,183 @@ func TestDispatcherWithBlockedIP(t *testing.T) {
 	require.ErrorIs(t, err, netjail.ErrDenied)
 	require.Contains(t, err.Error(), "127.0.0.1: address not allowed")
 }
+
+func TestDispatcherSendRequestWithClientCertificate(t *testing.T) {
+	clientCert, clientKey := generateClientCertificate(t, "convoy-client")
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Len(t, r.TLS.PeerCertificates, 1)
+		require.Equal(t, "convoy-client", r.TLS.PeerCertificates[0].Subject.CommonName)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().MutualTLS().Times(2).Return(true)
+
+	dispatcher, err := NewDispatcher(
+		licenser,
+		fflag.NewFFlag([]string{}),
+		LoggerOption(log.NewLogger(os.Stdout)),
+	)
+	require.NoError(t, err)
+
+	ctx := ContextWithClientCertificate(context.Background(), &ClientCertificate{
+		ClientCert: string(clientCert),
+		ClientKey:  string(clientKey),
+		CACert:     string(caCert),
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := dispatcher.SendRequest(ctx, server.URL, "POST", json.RawMessage(`{}`), "", "X-Signature", "test-hmac", 1024, httpheader.HTTPHeader{}, "", 5*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// the client is reused for the same certificate
+	require.Equal(t, 1, dispatcher.mtlsClients.len())
+}
+
+func TestMTLSClientCache(t *testing.T) {
+	c := newMTLSClientCache(2)
+
+	first, second, third := &http.Client{}, &http.Client{}, &http.Client{}
+	require.Same(t, first, c.add("first", first))
+	require.Same(t, second, c.add("second", second))
+
+	// the cached client is kept when the same certificate is added again
+	require.Same(t, first, c.add("first", &http.Client{}))
+
+	// the least recently used client is evicted
+	require.Same(t, third, c.add("third", third))
+	require.Equal(t, 2, c.len())
+
+	_, ok := c.get("second")
+	require.False(t, ok)
+
+	client, ok := c.get("first")
+	require.True(t, ok)
+	require.Same(t, first, client)
+}
+
+func TestDispatcherSendRequestWithoutClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	licenser := mocks.NewMockLicenser(ctrl)
+
+	dispatcher, err := NewDispatcher(
+		licenser,
+		fflag.NewFFlag([]string{}),
+		LoggerOption(log.NewLogger(os.Stdout)),
+		InsecureSkipVerifyOption(true),
+	)
+	require.NoError(t, err)
+
+	_, err = dispatcher.SendRequest(context.Background(), server.URL, "POST", json.RawMessage(`{}`), "", "X-Signature", "test-hmac", 1024, httpheader.HTTPHeader{}, "", 5*time.Second)
+	require.Error(t, err)
+}
+
+func generateClientCertificate(t *testing.T, commonName string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestDispatcherSendRequestWithOAuth2(t *testing.T) {
+	tokenRequests := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "client-id", clientID)
+		require.Equal(t, "client-secret", clientSecret)
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		require.Equal(t, "webhooks:write", r.Form.Get("scope"))
+		require.Equal(t, "https://receiver.example.com", r.Form.Get("audience"))
+
+		tokenRequests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 3600}`, tokenRequests)
+	}))
+	defer tokenServer.Close()
+
+	// the first token is rejected as if it was revoked
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"key": "value"}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	licenser := mocks.NewMockLicenser(ctrl)
+
+	dispatcher, err := NewDispatcher(
+		licenser,
+		fflag.NewFFlag([]string{}),
+		LoggerOption(log.NewLogger(os.Stdout)),
+		TokenCacheOption(mcache.NewMemoryCache()),
+	)
+	require.NoError(t, err)
+
+	ctx := ContextWithOAuth2(context.Background(), &OAuth2Config{
+		TokenURL:     tokenServer.URL,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		Scopes:       []string{"webhooks:write"},
+		Audience:     "https://receiver.example.com",
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := dispatcher.SendRequest(ctx, server.URL, "POST", json.RawMessage(`{"key": "value"}`), "", "X-Signature", "test-hmac", 1024, httpheader.HTTPHeader{}, "", 5*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "Bearer token-2", resp.RequestHeader.Get("Authorization"))
+	}
+
+	// the refreshed token is cached
+	require.Equal(t, 2, tokenRequests)
+}
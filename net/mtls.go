@@ -0,0 +1,175 @@
+package net
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/frain-dev/convoy/internal/pkg/fflag"
+	"github.com/stealthrocket/netjail"
+)
+
+var ErrInvalidCACert = errors.New("ca certificate bundle does not contain any valid certificates")
+
+// maxMTLSClients bounds the number of cached mutual tls clients, rotated
+// certificates are evicted once they haven't been used for a while.
+const maxMTLSClients = 256
+
+// ClientCertificate is a PEM encoded client certificate and key presented to
+// endpoints that require mutual TLS. CACert optionally replaces the system
+// roots when verifying the endpoint's certificate.
+type ClientCertificate struct {
+	ClientCert string
+	ClientKey  string
+	CACert     string
+}
+
+// Fingerprint identifies the certificate, it is used to cache its transport.
+func (c *ClientCertificate) Fingerprint() string {
+	h := sha256.New()
+	for _, v := range []string{c.ClientCert, c.ClientKey, c.CACert} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// TLSConfig returns a tls config that presents the client certificate.
+func (c *ClientCertificate) TLSConfig() (*tls.Config, error) {
+	cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if len(c.CACert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, ErrInvalidCACert
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+type clientCertificateKey struct{}
+
+// ContextWithClientCertificate returns a context that makes the dispatcher
+// present the client certificate when sending the request.
+func ContextWithClientCertificate(ctx context.Context, cert *ClientCertificate) context.Context {
+	return context.WithValue(ctx, clientCertificateKey{}, cert)
+}
+
+func clientCertificateFromContext(ctx context.Context) *ClientCertificate {
+	cert, _ := ctx.Value(clientCertificateKey{}).(*ClientCertificate)
+	return cert
+}
+
+// mtlsClient returns the http client that presents the client certificate,
+// clients are cached per certificate fingerprint so connections are reused.
+func (d *Dispatcher) mtlsClient(cert *ClientCertificate) (*http.Client, error) {
+	fingerprint := cert.Fingerprint()
+	if client, ok := d.mtlsClients.get(fingerprint); ok {
+		return client, nil
+	}
+
+	tlsConfig, err := cert.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.transport.TLSClientConfig != nil {
+		tlsConfig.InsecureSkipVerify = d.transport.TLSClientConfig.InsecureSkipVerify
+	}
+
+	transport := d.transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	client := &http.Client{Transport: transport}
+	if d.ff.CanAccessFeature(fflag.IpRules) && d.l.IpRules() {
+		client.Transport = &netjail.Transport{
+			New: func() *http.Transport {
+				return transport.Clone()
+			},
+		}
+	}
+
+	return d.mtlsClients.add(fingerprint, client), nil
+}
+
+type mtlsClientEntry struct {
+	fingerprint string
+	client      *http.Client
+}
+
+// mtlsClientCache is a least recently used cache of mutual tls clients
+// keyed by their certificate's fingerprint.
+type mtlsClientCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newMTLSClientCache(size int) *mtlsClientCache {
+	return &mtlsClientCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *mtlsClientCache) get(fingerprint string) (*http.Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[fingerprint]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	return e.Value.(*mtlsClientEntry).client, true
+}
+
+// add caches the client unless another one was cached for the fingerprint
+// in the meantime, the cached client is returned.
+func (c *mtlsClientCache) add(fingerprint string, client *http.Client) *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[fingerprint]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*mtlsClientEntry).client
+	}
+
+	c.entries[fingerprint] = c.order.PushFront(&mtlsClientEntry{fingerprint: fingerprint, client: client})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		entry := c.order.Remove(oldest).(*mtlsClientEntry)
+		delete(c.entries, entry.fingerprint)
+		entry.client.CloseIdleConnections()
+	}
+
+	return client
+}
+
+func (c *mtlsClientCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,154 @@
+package net
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/cache"
+)
+
+// tokenExpirySkew is how long before it expires a cached access token is refreshed
+const tokenExpirySkew = 30 * time.Second
+
+var ErrEmptyAccessToken = errors.New("oauth2 token endpoint did not return an access token")
+
+// OAuth2Config is an OAuth2 client credentials grant used to authenticate
+// requests to an endpoint.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Audience     string
+}
+
+// cacheKey identifies the token issued for the config, it includes the secret
+// so that changing it invalidates cached tokens.
+func (c *OAuth2Config) cacheKey() string {
+	h := sha256.New()
+	for _, v := range []string{c.TokenURL, c.ClientID, c.ClientSecret, strings.Join(c.Scopes, " "), c.Audience} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return "oauth2_tokens:" + hex.EncodeToString(h.Sum(nil))
+}
+
+type oauth2Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type oauth2ConfigKey struct{}
+
+// ContextWithOAuth2 returns a context that makes the dispatcher authenticate
+// the request with an access token issued for the config.
+func ContextWithOAuth2(ctx context.Context, cfg *OAuth2Config) context.Context {
+	return context.WithValue(ctx, oauth2ConfigKey{}, cfg)
+}
+
+func oauth2ConfigFromContext(ctx context.Context) *OAuth2Config {
+	cfg, _ := ctx.Value(oauth2ConfigKey{}).(*OAuth2Config)
+	return cfg
+}
+
+// TokenCacheOption sets the cache access tokens are stored in until shortly before they expire.
+func TokenCacheOption(c cache.Cache) DispatcherOption {
+	return func(d *Dispatcher) error {
+		d.tokenCache = c
+		return nil
+	}
+}
+
+// accessToken returns a cached access token for the config, a new token is
+// requested when none is cached or refresh is true.
+func (d *Dispatcher) accessToken(ctx context.Context, client *http.Client, cfg *OAuth2Config, refresh bool) (string, error) {
+	key := cfg.cacheKey()
+
+	if !refresh && d.tokenCache != nil {
+		var token oauth2Token
+		err := d.tokenCache.Get(ctx, key, &token)
+		if err != nil {
+			d.logger.WithError(err).Error("failed to load oauth2 access token from cache")
+		}
+
+		if len(token.AccessToken) > 0 {
+			return token.AccessToken, nil
+		}
+	}
+
+	token, err := d.requestAccessToken(ctx, client, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	ttl := time.Duration(token.ExpiresIn)*time.Second - tokenExpirySkew
+	if d.tokenCache != nil && ttl > 0 {
+		err = d.tokenCache.Set(ctx, key, token, ttl)
+		if err != nil {
+			d.logger.WithError(err).Error("failed to cache oauth2 access token")
+		}
+	}
+
+	return token.AccessToken, nil
+}
+
+// requestAccessToken performs the client credentials grant, see
+// https://datatracker.ietf.org/doc/html/rfc6749#section-4.4
+func (d *Dispatcher) requestAccessToken(ctx context.Context, client *http.Client, cfg *OAuth2Config) (*oauth2Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	if len(cfg.Audience) > 0 {
+		form.Set("audience", cfg.Audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", defaultUserAgent())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request oauth2 access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("oauth2 token endpoint responded with status code %d: %s", resp.StatusCode, body)
+	}
+
+	token := &oauth2Token{}
+	err = json.Unmarshal(body, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode oauth2 access token: %w", err)
+	}
+
+	if len(token.AccessToken) == 0 {
+		return nil, ErrEmptyAccessToken
+	}
+
+	return token, nil
+}
@@ -50,6 +50,11 @@ type Signature struct {
 	// or a complex header.
 	Advanced bool
 
+	// Raw signs the payload as is instead of JSON encoding it
+	// first. It is used for payloads that aren't JSON, like
+	// form encoded request bodies.
+	Raw bool
+
 	// This function is used to generate a timestamp for signing
 	// your payload. It is only added to aid testing.
 	generateTimestampFn func() string
@@ -181,6 +186,10 @@ func (s *Signature) getHashFunction(algo string) (func() hash.Hash, error) {
 }
 
 func (s *Signature) encodePayload() ([]byte, error) {
+	if s.Raw {
+		return s.Payload, nil
+	}
+
 	buf := bytes.NewBuffer([]byte{})
 	encoder := json.NewEncoder(buf)
 	encoder.SetEscapeHTML(false)
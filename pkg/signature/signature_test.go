@@ -171,6 +171,22 @@ func Test_Simple_Signatures(t *testing.T) {
 			assertion: require.Equal,
 			expected:  "xdz+2j9aMVQUUjSy0KUz/CsjD4jaD6wHJGGf1c3eZzrWxHTf1cAjZ3aL07O9NZXMhg5gajfi+TYuBU1aoU18xA==",
 		},
+		"should_generate_simple_signature_for_raw_payload": {
+			signature: &Signature{
+				Payload: []byte("a=1&e=123"),
+				Schemes: []Scheme{
+					{
+						Secret:   []string{"secret"},
+						Hash:     "SHA256",
+						Encoding: "hex",
+					},
+				},
+				Advanced: false,
+				Raw:      true,
+			},
+			assertion: require.Equal,
+			expected:  "89a0446c65fa65ef0a5324b7e57119d27a03c5a6e6b7ec233d70eb025d47ca7d",
+		},
 	}
 
 	for name, tc := range tests {
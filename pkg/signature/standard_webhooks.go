@@ -0,0 +1,130 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Standard Webhooks headers, see https://www.standardwebhooks.com
+const (
+	StandardWebhooksIDHeader        = "webhook-id"
+	StandardWebhooksTimestampHeader = "webhook-timestamp"
+	StandardWebhooksSignatureHeader = "webhook-signature"
+
+	standardWebhooksSecretPrefix    = "whsec_"
+	standardWebhooksPublicKeyPrefix = "whpk_"
+)
+
+var ErrMissingMessageID = errors.New("standard webhooks message id is required")
+
+// StandardWebhooks signs payloads following the Standard Webhooks spec.
+// Symmetric signatures are HMAC-SHA256 (v1), asymmetric signatures
+// are Ed25519 (v1a) with a key derived from the secret.
+type StandardWebhooks struct {
+	// ID is the message id, it must stay the same across retries.
+	ID      string
+	Payload []byte
+
+	// Secrets represents a list of active secrets, a signature is
+	// generated for each one to support rolled secrets.
+	Secrets []string
+
+	Asymmetric bool
+
+	// This function is used to generate a timestamp for signing
+	// your payload. It is only added to aid testing.
+	generateTimestampFn func() string
+}
+
+// ComputeHeaders returns the webhook-id, webhook-timestamp and
+// webhook-signature header values.
+func (s *StandardWebhooks) ComputeHeaders() (map[string]string, error) {
+	if len(s.ID) == 0 {
+		return nil, ErrMissingMessageID
+	}
+
+	if len(s.Secrets) == 0 {
+		return nil, errors.New("signature secret cannot be empty")
+	}
+
+	var ts string
+	if s.generateTimestampFn != nil {
+		ts = s.generateTimestampFn()
+	} else {
+		ts = fmt.Sprintf("%d", time.Now().Unix())
+	}
+
+	signedContent := []byte(fmt.Sprintf("%s.%s.%s", s.ID, ts, s.Payload))
+
+	signatures := make([]string, 0, len(s.Secrets))
+	for _, secret := range s.Secrets {
+		key, err := standardWebhooksKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.Asymmetric {
+			sig := ed25519.Sign(ed25519PrivateKey(key), signedContent)
+			signatures = append(signatures, "v1a,"+base64.StdEncoding.EncodeToString(sig))
+			continue
+		}
+
+		h := hmac.New(sha256.New, key)
+		h.Write(signedContent)
+		signatures = append(signatures, "v1,"+base64.StdEncoding.EncodeToString(h.Sum(nil)))
+	}
+
+	return map[string]string{
+		StandardWebhooksIDHeader:        s.ID,
+		StandardWebhooksTimestampHeader: ts,
+		StandardWebhooksSignatureHeader: strings.Join(signatures, " "),
+	}, nil
+}
+
+// StandardWebhooksSecret returns the secret in the whsec_ format
+// Standard Webhooks verifier libraries expect.
+func StandardWebhooksSecret(secret string) string {
+	if strings.HasPrefix(secret, standardWebhooksSecretPrefix) {
+		return secret
+	}
+
+	return standardWebhooksSecretPrefix + base64.StdEncoding.EncodeToString([]byte(secret))
+}
+
+// StandardWebhooksPublicKey returns the whpk_ prefixed Ed25519 public key
+// receivers use to verify asymmetric signatures generated with secret.
+func StandardWebhooksPublicKey(secret string) (string, error) {
+	key, err := standardWebhooksKey(secret)
+	if err != nil {
+		return "", err
+	}
+
+	pub := ed25519PrivateKey(key).Public().(ed25519.PublicKey)
+	return standardWebhooksPublicKeyPrefix + base64.StdEncoding.EncodeToString(pub), nil
+}
+
+// standardWebhooksKey decodes whsec_ prefixed secrets, other
+// secrets are used as is.
+func standardWebhooksKey(secret string) ([]byte, error) {
+	if !strings.HasPrefix(secret, standardWebhooksSecretPrefix) {
+		return []byte(secret), nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, standardWebhooksSecretPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid standard webhooks secret: %v", err)
+	}
+
+	return key, nil
+}
+
+func ed25519PrivateKey(key []byte) ed25519.PrivateKey {
+	seed := sha256.Sum256(key)
+	return ed25519.NewKeyFromSeed(seed[:])
+}
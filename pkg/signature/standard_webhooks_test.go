@@ -0,0 +1,106 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StandardWebhooks_ComputeHeaders(t *testing.T) {
+	tests := map[string]struct {
+		signature *StandardWebhooks
+		expected  string
+		wantErr   bool
+	}{
+		"should_generate_symmetric_signature": {
+			signature: &StandardWebhooks{
+				ID:      "msg_p5jXN8AQM9LWM0D4loKWxJek",
+				Payload: []byte(`{"test": 2432232314}`),
+				Secrets: []string{"whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"},
+				generateTimestampFn: func() string {
+					return "1614265330"
+				},
+			},
+			expected: "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=",
+		},
+		"should_generate_signature_per_secret": {
+			signature: &StandardWebhooks{
+				ID:      "msg_p5jXN8AQM9LWM0D4loKWxJek",
+				Payload: []byte(`{"test": 2432232314}`),
+				Secrets: []string{"whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw", "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"},
+				generateTimestampFn: func() string {
+					return "1614265330"
+				},
+			},
+			expected: "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE= v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=",
+		},
+		"should_error_without_message_id": {
+			signature: &StandardWebhooks{
+				Payload: []byte(`{"test": 2432232314}`),
+				Secrets: []string{"secret"},
+			},
+			wantErr: true,
+		},
+		"should_error_without_secrets": {
+			signature: &StandardWebhooks{
+				ID:      "msg_p5jXN8AQM9LWM0D4loKWxJek",
+				Payload: []byte(`{"test": 2432232314}`),
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			headers, err := tc.signature.ComputeHeaders()
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.signature.ID, headers[StandardWebhooksIDHeader])
+			require.Equal(t, "1614265330", headers[StandardWebhooksTimestampHeader])
+			require.Equal(t, tc.expected, headers[StandardWebhooksSignatureHeader])
+		})
+	}
+}
+
+func Test_StandardWebhooks_Asymmetric(t *testing.T) {
+	s := &StandardWebhooks{
+		ID:         "msg_p5jXN8AQM9LWM0D4loKWxJek",
+		Payload:    []byte(`{"test": 2432232314}`),
+		Secrets:    []string{"endpoint-secret"},
+		Asymmetric: true,
+		generateTimestampFn: func() string {
+			return "1614265330"
+		},
+	}
+
+	headers, err := s.ComputeHeaders()
+	require.NoError(t, err)
+
+	sig := headers[StandardWebhooksSignatureHeader]
+	require.True(t, strings.HasPrefix(sig, "v1a,"))
+
+	rawSig, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(sig, "v1a,"))
+	require.NoError(t, err)
+
+	publicKey, err := StandardWebhooksPublicKey("endpoint-secret")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(publicKey, "whpk_"))
+
+	pub, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(publicKey, "whpk_"))
+	require.NoError(t, err)
+
+	signedContent := []byte(`msg_p5jXN8AQM9LWM0D4loKWxJek.1614265330.{"test": 2432232314}`)
+	require.True(t, ed25519.Verify(pub, signedContent, rawSig))
+}
+
+func Test_StandardWebhooksSecret(t *testing.T) {
+	require.Equal(t, "whsec_c2VjcmV0", StandardWebhooksSecret("secret"))
+	require.Equal(t, "whsec_c2VjcmV0", StandardWebhooksSecret("whsec_c2VjcmV0"))
+}
@@ -0,0 +1,369 @@
+package transform
+
+import (
+	"container/list"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/console"
+	"github.com/dop251/goja_nodejs/require"
+)
+
+const (
+	DefaultTimeout          = 10 * time.Second
+	DefaultMaxCallStackSize = 1024
+	DefaultCacheSize        = 1000
+	DefaultMaxPayloadSize   = 5 << 20
+)
+
+// underscore is bundled so runtimes never fetch it over the network,
+// it is available to every function as _.
+//
+//go:embed libs/underscore-min.js
+var underscoreJS string
+
+var underscoreProgram = goja.MustCompile("underscore-min.js", underscoreJS, false)
+
+// lockdownProgram freezes the globals and everything reachable from them,
+// runtimes are shared by every project so a function must not be able to
+// leave anything behind for the next one.
+var lockdownProgram = goja.MustCompile("lockdown.js", `(function () {
+	var seen = new Set();
+	function harden(o) {
+		if (o === null || (typeof o !== "object" && typeof o !== "function") || seen.has(o)) {
+			return;
+		}
+		seen.add(o);
+		Object.freeze(o);
+		Reflect.ownKeys(o).forEach(function (k) {
+			var d = Object.getOwnPropertyDescriptor(o, k);
+			if (d === undefined) {
+				return;
+			}
+			harden(d.value);
+			harden(d.get);
+			harden(d.set);
+		});
+		harden(Object.getPrototypeOf(o));
+	}
+	harden(globalThis);
+})();`, false)
+
+// evalProgram runs a script with the payload in scope, for RunStringUnsafe.
+var evalProgram = goja.MustCompile("eval.js", `(function (payload, script) { return eval(script); })`, false)
+
+// functions are run in their own scope, which returns the transform
+// function they declare.
+const functionWrapper = "(function () {\n%s\n;return typeof transform === \"function\" ? transform : undefined;\n})()"
+
+// Options configures a Pool, zero values use the defaults.
+type Options struct {
+	// PoolSize is the number of runtimes kept warm, it defaults to GOMAXPROCS.
+	PoolSize int
+
+	// Timeout is how long a function can run before it is interrupted.
+	// goja can't count instructions, so this is the cpu budget of a call.
+	Timeout time.Duration
+
+	// MaxCallStackSize bounds the depth of recursive calls.
+	MaxCallStackSize int
+
+	// CacheSize is the number of compiled functions kept.
+	CacheSize int
+
+	// MaxPayloadSize is the largest payload in bytes, encoded as json, a
+	// function is run on or can return. goja can't limit the memory of a
+	// runtime, so this bounds what a function works with.
+	MaxPayloadSize int
+}
+
+func (o Options) withDefaults() Options {
+	if o.PoolSize <= 0 {
+		o.PoolSize = runtime.GOMAXPROCS(0)
+	}
+
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+
+	if o.MaxCallStackSize <= 0 {
+		o.MaxCallStackSize = DefaultMaxCallStackSize
+	}
+
+	if o.CacheSize <= 0 {
+		o.CacheSize = DefaultCacheSize
+	}
+
+	if o.MaxPayloadSize <= 0 {
+		o.MaxPayloadSize = DefaultMaxPayloadSize
+	}
+
+	return o
+}
+
+// Pool runs transform functions on pre-warmed runtimes. Functions are
+// compiled once and cached by their source, so changing a subscription or
+// source function compiles the new version on its next run.
+type Pool struct {
+	opts     Options
+	runtimes chan *vm
+	programs *programCache
+}
+
+func NewPool(opts Options) *Pool {
+	opts = opts.withDefaults()
+
+	p := &Pool{
+		opts:     opts,
+		runtimes: make(chan *vm, opts.PoolSize),
+		programs: newProgramCache(opts.CacheSize),
+	}
+
+	for i := 0; i < opts.PoolSize; i++ {
+		p.runtimes <- newVM(opts)
+	}
+
+	return p
+}
+
+var (
+	defaultOptions  Options
+	defaultPool     *Pool
+	defaultPoolOnce sync.Once
+)
+
+// Configure sets the options of the pool used by NewTransformer, it has no
+// effect once a function has been transformed.
+func Configure(opts Options) {
+	defaultOptions = opts
+}
+
+func getDefaultPool() *Pool {
+	defaultPoolOnce.Do(func() {
+		defaultPool = NewPool(defaultOptions)
+	})
+
+	return defaultPool
+}
+
+// Transform runs the transform function declared by function on payload,
+// it returns the function's result and what it logged.
+func (p *Pool) Transform(function string, payload interface{}) (interface{}, []string, error) {
+	if err := p.checkSize(payload); err != nil {
+		return nil, []string{}, err
+	}
+
+	program, err := p.programs.get(function)
+	if err != nil {
+		return nil, []string{}, err
+	}
+
+	return p.run(func(v *vm) (goja.Value, error) {
+		fn, err := v.rt.RunProgram(program)
+		if err != nil {
+			return nil, err
+		}
+
+		transform, ok := goja.AssertFunction(fn)
+		if !ok {
+			return nil, ErrFunctionNotFound
+		}
+
+		return transform(goja.Undefined(), v.rt.ToValue(payload))
+	})
+}
+
+// RunStringUnsafe evaluates script with payload in scope and returns the
+// value of its last statement. The script isn't cached.
+func (p *Pool) RunStringUnsafe(script string, payload interface{}) (interface{}, []string, error) {
+	if err := p.checkSize(payload); err != nil {
+		return nil, []string{}, err
+	}
+
+	return p.run(func(v *vm) (goja.Value, error) {
+		fn, err := v.rt.RunProgram(evalProgram)
+		if err != nil {
+			return nil, err
+		}
+
+		eval, _ := goja.AssertFunction(fn)
+		return eval(goja.Undefined(), v.rt.ToValue(payload), v.rt.ToValue(script))
+	})
+}
+
+func (p *Pool) run(fn func(v *vm) (goja.Value, error)) (interface{}, []string, error) {
+	v := p.get()
+
+	printer := NewBufferPrinter()
+	v.printer = printer
+
+	timer := time.AfterFunc(p.opts.Timeout, func() {
+		v.rt.Interrupt(ErrMaxExecutionTimeElapsed)
+	})
+
+	value, err := fn(v)
+
+	var stackOverflow *goja.StackOverflowError
+	if errors.As(err, &stackOverflow) {
+		err = ErrMaxCallStackSizeExceeded
+	}
+
+	// a runtime that was interrupted or overflowed isn't reused
+	if timer.Stop() && stackOverflow == nil {
+		p.put(v)
+	}
+
+	if err != nil {
+		return nil, []string{}, err
+	}
+
+	logs := printer.Format()
+
+	var result interface{}
+	if value != nil {
+		result = value.Export()
+	}
+
+	if err = p.checkSize(result); err != nil {
+		return nil, []string{}, err
+	}
+
+	return result, logs[:len(logs)-1], nil
+}
+
+// checkSize returns ErrMaxPayloadSizeExceeded when v encodes to more than
+// MaxPayloadSize bytes of json. Values that can't be encoded aren't checked.
+func (p *Pool) checkSize(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	if len(b) > p.opts.MaxPayloadSize {
+		return ErrMaxPayloadSizeExceeded
+	}
+
+	return nil
+}
+
+func (p *Pool) get() *vm {
+	select {
+	case v := <-p.runtimes:
+		return v
+	default:
+		return newVM(p.opts)
+	}
+}
+
+func (p *Pool) put(v *vm) {
+	v.printer = nil
+
+	select {
+	case p.runtimes <- v:
+	default:
+	}
+}
+
+// vm is a runtime with the bundled libraries loaded and its globals frozen.
+type vm struct {
+	rt      *goja.Runtime
+	printer *BuffPrinter
+}
+
+func newVM(opts Options) *vm {
+	v := &vm{rt: goja.New()}
+	v.rt.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
+	v.rt.SetMaxCallStackSize(opts.MaxCallStackSize)
+
+	// modules can't be loaded from the filesystem
+	registry := require.NewRegistry(require.WithLoader(func(string) ([]byte, error) {
+		return nil, require.ModuleFileDoesNotExistError
+	}))
+	registry.RegisterNativeModule(console.ModuleName, console.RequireWithPrinter(v))
+	registry.Enable(v.rt)
+	console.Enable(v.rt)
+
+	// the bundled programs are known to run, so a failure is a bug
+	for _, program := range []*goja.Program{underscoreProgram, lockdownProgram} {
+		if _, err := v.rt.RunProgram(program); err != nil {
+			panic(fmt.Sprintf("transform: failed to prepare runtime: %v", err))
+		}
+	}
+
+	return v
+}
+
+// Log, Warn and Error implement console.Printer for the running function.
+func (v *vm) Log(s string) {
+	if v.printer != nil {
+		v.printer.Log(s)
+	}
+}
+
+func (v *vm) Warn(s string) {
+	if v.printer != nil {
+		v.printer.Warn(s)
+	}
+}
+
+func (v *vm) Error(s string) {
+	if v.printer != nil {
+		v.printer.Error(s)
+	}
+}
+
+// programCache is a least recently used cache of compiled functions.
+type programCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type programEntry struct {
+	source  string
+	program *goja.Program
+}
+
+func newProgramCache(size int) *programCache {
+	return &programCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *programCache) get(source string) (*goja.Program, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[source]; ok {
+		c.order.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*programEntry).program, nil
+	}
+	c.mu.Unlock()
+
+	program, err := goja.Compile("transform.js", fmt.Sprintf(functionWrapper, source), false)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[source]; !ok {
+		c.entries[source] = c.order.PushFront(&programEntry{source: source, program: program})
+		if c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*programEntry).source)
+		}
+	}
+
+	return program, nil
+}
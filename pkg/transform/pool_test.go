@@ -0,0 +1,148 @@
+package transform
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_Underscore(t *testing.T) {
+	p := NewPool(Options{PoolSize: 1})
+
+	function := `function transform(payload) {
+		return _.pluck(payload.items, "id");
+	}`
+
+	result, _, err := p.Transform(function, map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{"id": "a"}, map[string]interface{}{"id": "b"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b"}, result)
+}
+
+func TestPool_FunctionsDontShareState(t *testing.T) {
+	p := NewPool(Options{PoolSize: 1})
+
+	// the runtime is reused, so these would leak into the next function
+	function := `
+	leaked = "leaked";
+	function transform(payload) {
+		try { globalThis.leaked = "leaked"; } catch (e) {}
+		try { JSON.stringify = function () { return "stolen"; }; } catch (e) {}
+		try { Object.prototype.leaked = "leaked"; } catch (e) {}
+		return payload;
+	}`
+
+	_, _, err := p.Transform(function, map[string]interface{}{})
+	require.NoError(t, err)
+
+	function = `function transform(payload) {
+		return [typeof leaked, JSON.stringify(payload), ({}).leaked === undefined];
+	}`
+
+	result, _, err := p.Transform(function, map[string]interface{}{"a": 1})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"undefined", `{"a":1}`, true}, result)
+
+	// a function that only leaves a transform behind isn't found
+	_, _, err = p.Transform(`var i = 0; i++`, map[string]interface{}{})
+	require.ErrorIs(t, err, ErrFunctionNotFound)
+}
+
+func TestPool_ConsoleLogs(t *testing.T) {
+	p := NewPool(Options{PoolSize: 1})
+
+	function := `function transform(payload) {
+		console.log("name", payload.name);
+		console.error("done");
+		return payload;
+	}`
+
+	_, logs, err := p.Transform(function, map[string]interface{}{"name": "convoy"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"name convoy", "done"}, logs)
+
+	_, logs, err = p.Transform(`function transform(payload) { return payload; }`, map[string]interface{}{})
+	require.NoError(t, err)
+	require.Empty(t, logs)
+}
+
+func TestPool_Limits(t *testing.T) {
+	p := NewPool(Options{PoolSize: 1, Timeout: 50 * time.Millisecond, MaxCallStackSize: 64})
+
+	_, _, err := p.Transform(`function transform(payload) { for (;;) {} }`, nil)
+	require.ErrorContains(t, err, ErrMaxExecutionTimeElapsed.Error())
+
+	_, _, err = p.Transform(`function transform(payload) { return transform(payload); }`, nil)
+	require.ErrorIs(t, err, ErrMaxCallStackSizeExceeded)
+
+	// the pool still works once a runtime is interrupted
+	result, _, err := p.Transform(`function transform(payload) { return payload + 1; }`, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result)
+}
+
+func TestPool_MaxPayloadSize(t *testing.T) {
+	p := NewPool(Options{PoolSize: 1, MaxPayloadSize: 64})
+
+	_, _, err := p.Transform(`function transform(payload) { return payload }`, map[string]interface{}{"data": strings.Repeat("a", 64)})
+	require.ErrorIs(t, err, ErrMaxPayloadSizeExceeded)
+
+	_, _, err = p.Transform(`function transform(payload) { return "a".repeat(1000) }`, map[string]interface{}{"id": 1})
+	require.ErrorIs(t, err, ErrMaxPayloadSizeExceeded)
+
+	out, _, err := p.Transform(`function transform(payload) { return payload.id }`, map[string]interface{}{"id": 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), out)
+}
+
+func TestPool_ModulesCantBeLoadedFromFiles(t *testing.T) {
+	p := NewPool(Options{PoolSize: 1})
+
+	_, _, err := p.Transform(`function transform(payload) { return require("/etc/hostname"); }`, nil)
+	require.Error(t, err)
+}
+
+func TestPool_ConcurrentTransforms(t *testing.T) {
+	p := NewPool(Options{PoolSize: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			result, logs, err := p.Transform(`function transform(payload) { console.log(payload); return payload * 2; }`, i)
+			require.NoError(t, err)
+			require.Equal(t, int64(i*2), result)
+			require.Len(t, logs, 1)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestProgramCache(t *testing.T) {
+	c := newProgramCache(2)
+
+	a, err := c.get("function transform(p) { return 1 }")
+	require.NoError(t, err)
+
+	again, err := c.get("function transform(p) { return 1 }")
+	require.NoError(t, err)
+	require.Same(t, a, again)
+
+	_, err = c.get("function transform(p) { return 2 }")
+	require.NoError(t, err)
+	_, err = c.get("function transform(p) { return 3 }")
+	require.NoError(t, err)
+
+	require.Len(t, c.entries, 2)
+	require.NotContains(t, c.entries, "function transform(p) { return 1 }")
+
+	_, err = c.get("function transform(p) {")
+	require.Error(t, err)
+	require.Len(t, c.entries, 2)
+}
@@ -0,0 +1,489 @@
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	jwksCacheTTL = 10 * time.Minute
+
+	// jwksRefreshInterval limits how often a key set is fetched again
+	// when a token is signed with a key id it doesn't contain.
+	jwksRefreshInterval = time.Minute
+
+	jwksFetchTimeout = 10 * time.Second
+
+	// maxJWKSSize is the largest key set that is read, the rest of the
+	// response body is dropped.
+	maxJWKSSize = 1 << 20
+
+	// jwtLeeway is how far the clock of a token's issuer may drift from
+	// ours when its exp, nbf and iat claims are checked.
+	jwtLeeway = time.Minute
+)
+
+var (
+	ErrInvalidToken     = errors.New("Invalid token")
+	ErrNoVerifyingKeys  = errors.New("No keys to verify the token with")
+	ErrInvalidPublicKey = errors.New("Invalid public key")
+	ErrNoKeySetFetcher  = errors.New("No fetcher to fetch the key set with")
+
+	jwks = &keySetCache{sets: map[string]*keySet{}}
+)
+
+// KeySetFetcher fetches the key sets tokens are verified with, it is
+// satisfied by *net.Dispatcher which guards the requests against SSRF.
+type KeySetFetcher interface {
+	Fetch(ctx context.Context, url string, headers httpheader.HTTPHeader, maxResponseSize int64, timeout time.Duration) (*net.Response, error)
+}
+
+type JWTOptions struct {
+	// Header is the header the token is read from, it defaults to
+	// the Authorization header with a Bearer token.
+	Header string
+
+	// JWKSURL is the url of the key set the token is verified with.
+	JWKSURL string
+
+	// PublicKeys are PEM encoded public keys or certificates the token
+	// is verified with.
+	PublicKeys string
+
+	// Issuer and Audience are checked against the iss and aud claims
+	// when they are set.
+	Issuer   string
+	Audience string
+}
+
+type JWTVerifier struct {
+	opts    *JWTOptions
+	fetcher KeySetFetcher
+}
+
+// NewJWTVerifier creates the verifier, fetcher fetches the key set at
+// opts.JWKSURL. Key set urls that come from users must be fetched with
+// a *net.Dispatcher.
+func NewJWTVerifier(opts *JWTOptions, fetcher KeySetFetcher) *JWTVerifier {
+	return &JWTVerifier{opts: opts, fetcher: fetcher}
+}
+
+func (jV *JWTVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	token, err := jV.getToken(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = jV.VerifyToken(token)
+	return err
+}
+
+// VerifyToken verifies the token's signature, expiry, issuer and audience
+// and returns its claims. Tokens without an exp claim are rejected since
+// they could be replayed forever.
+func (jV *JWTVerifier) VerifyToken(token string) (jwt.MapClaims, error) {
+	keys, err := jV.keys(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoVerifyingKeys
+	}
+
+	for _, key := range keys {
+		claims := jwt.MapClaims{}
+		parser := &jwt.Parser{SkipClaimsValidation: true}
+		_, err = parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+			if !keyMatchesMethod(key, t.Method) {
+				return nil, ErrInvalidToken
+			}
+
+			return key, nil
+		})
+		if err != nil {
+			continue
+		}
+
+		if !verifyTokenTimes(claims, time.Now()) {
+			return nil, ErrInvalidToken
+		}
+
+		if len(jV.opts.Issuer) > 0 && !claims.VerifyIssuer(jV.opts.Issuer, true) {
+			return nil, ErrInvalidToken
+		}
+
+		if len(jV.opts.Audience) > 0 && !claims.VerifyAudience(jV.opts.Audience, true) {
+			return nil, ErrInvalidToken
+		}
+
+		return claims, nil
+	}
+
+	return nil, ErrInvalidToken
+}
+
+// verifyTokenTimes checks the token has expired no earlier than now and
+// wasn't issued or made valid later than now, within jwtLeeway.
+func verifyTokenTimes(claims jwt.MapClaims, now time.Time) bool {
+	return claims.VerifyExpiresAt(now.Add(-jwtLeeway).Unix(), true) &&
+		claims.VerifyNotBefore(now.Add(jwtLeeway).Unix(), false) &&
+		claims.VerifyIssuedAt(now.Add(jwtLeeway).Unix(), false)
+}
+
+func (jV *JWTVerifier) getToken(r *http.Request) (string, error) {
+	if len(strings.TrimSpace(jV.opts.Header)) > 0 {
+		val := strings.TrimSpace(r.Header.Get(jV.opts.Header))
+		if len(val) == 0 {
+			return "", ErrAuthHeaderCannotBeEmpty
+		}
+
+		if token, found := strings.CutPrefix(val, "Bearer "); found {
+			return token, nil
+		}
+
+		return val, nil
+	}
+
+	val := r.Header.Get("Authorization")
+	if len(strings.TrimSpace(val)) == 0 {
+		return "", ErrAuthHeaderCannotBeEmpty
+	}
+
+	authInfo := strings.Split(val, " ")
+	if len(authInfo) != 2 || !strings.EqualFold(authInfo[0], "Bearer") {
+		return "", ErrInvalidHeaderStructure
+	}
+
+	return authInfo[1], nil
+}
+
+// keys returns the keys the token could be signed with, when the token has
+// a key id only the matching key set key is returned.
+func (jV *JWTVerifier) keys(token string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+
+	if len(strings.TrimSpace(jV.opts.PublicKeys)) > 0 {
+		static, err := ParsePublicKeys(jV.opts.PublicKeys)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, static...)
+	}
+
+	if len(jV.opts.JWKSURL) > 0 {
+		if jV.fetcher == nil {
+			return nil, ErrNoKeySetFetcher
+		}
+
+		t, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		fetched, err := jwks.get(jV.fetcher, jV.opts.JWKSURL, kid)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, fetched...)
+	}
+
+	return keys, nil
+}
+
+// ParsePublicKeys parses PEM encoded public keys and certificates.
+func ParsePublicKeys(data string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, ErrInvalidPublicKey
+			}
+			keys = append(keys, key)
+		case "RSA PUBLIC KEY":
+			key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, ErrInvalidPublicKey
+			}
+			keys = append(keys, key)
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, ErrInvalidPublicKey
+			}
+			keys = append(keys, cert.PublicKey)
+		default:
+			return nil, ErrInvalidPublicKey
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrInvalidPublicKey
+	}
+
+	return keys, nil
+}
+
+// keyMatchesMethod guards against tokens signed with an algorithm that
+// doesn't belong to the key e.g. HS256 with an RSA public key as the secret.
+func keyMatchesMethod(key crypto.PublicKey, method jwt.SigningMethod) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		switch method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			return true
+		}
+	case *ecdsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodECDSA)
+		return ok
+	case ed25519.PublicKey:
+		_, ok := method.(*jwt.SigningMethodEd25519)
+		return ok
+	}
+
+	return false
+}
+
+type keySet struct {
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// keySetCache caches key sets across requests since verifiers
+// are created for every ingested request. A key set is fetched by
+// one request at a time, the lock isn't held while it is fetched.
+type keySetCache struct {
+	mu    sync.Mutex
+	sets  map[string]*keySet
+	group singleflight.Group
+}
+
+func (c *keySetCache) get(fetcher KeySetFetcher, url, kid string) ([]crypto.PublicKey, error) {
+	c.mu.Lock()
+	set, ok := c.sets[url]
+	c.mu.Unlock()
+
+	stale := !ok || time.Since(set.fetchedAt) > jwksCacheTTL
+	if ok && !stale && len(kid) > 0 {
+		_, found := set.keys[kid]
+		stale = !found && time.Since(set.fetchedAt) > jwksRefreshInterval
+	}
+
+	if stale {
+		fetched, err, _ := c.group.Do(url, func() (interface{}, error) {
+			fetched, err := c.fetch(fetcher, url)
+			if err != nil {
+				return nil, err
+			}
+
+			c.mu.Lock()
+			c.sets[url] = fetched
+			c.mu.Unlock()
+
+			return fetched, nil
+		})
+		if err != nil {
+			if !ok {
+				return nil, err
+			}
+		} else {
+			set = fetched.(*keySet)
+		}
+	}
+
+	if len(kid) > 0 {
+		if key, found := set.keys[kid]; found {
+			return []crypto.PublicKey{key}, nil
+		}
+
+		return nil, nil
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(set.keys))
+	for _, key := range set.keys {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (c *keySetCache) fetch(fetcher KeySetFetcher, url string) (*keySet, error) {
+	resp, err := fetcher.Fetch(context.Background(), url, nil, maxJWKSSize, jwksFetchTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: unexpected status code %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	err = json.Unmarshal(resp.Body, &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %v", err)
+	}
+
+	set := &keySet{keys: map[string]crypto.PublicKey{}, fetchedAt: time.Now()}
+	for i, jwk := range body.Keys {
+		if len(jwk.Use) > 0 && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// skip keys we can't use instead of failing the whole set
+			continue
+		}
+
+		kid := jwk.Kid
+		if len(kid) == 0 {
+			kid = fmt.Sprintf("#%d", i)
+		}
+
+		set.keys[kid] = key
+	}
+
+	return set, nil
+}
+
+// NewHTTPKeySetFetcher returns a fetcher that fetches key sets with client,
+// it doesn't guard against SSRF so it's only for urls operators configure.
+func NewHTTPKeySetFetcher(client *http.Client) KeySetFetcher {
+	return &httpFetcher{client: client}
+}
+
+type httpFetcher struct {
+	client *http.Client
+}
+
+func (f *httpFetcher) Fetch(ctx context.Context, url string, headers httpheader.HTTPHeader, maxResponseSize int64, timeout time.Duration) (*net.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &net.Response{StatusCode: resp.StatusCode, Body: body}, nil
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrInvalidPublicKey
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, ErrInvalidPublicKey
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, ErrInvalidPublicKey
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, ErrInvalidPublicKey
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, ErrInvalidPublicKey
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,243 @@
+package verifier
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func Test_JWTVerifier_VerifyRequest(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKeys := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "key-1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	valid := jwt.MapClaims{"iss": "https://issuer.example.com", "aud": "convoy", "exp": time.Now().Add(time.Hour).Unix()}
+
+	tests := map[string]struct {
+		opts          *JWTOptions
+		header        string
+		value         string
+		expectedError error
+	}{
+		"valid_token_with_static_key": {
+			opts:   &JWTOptions{PublicKeys: publicKeys},
+			header: "Authorization",
+			value:  "Bearer " + signToken(t, key, "", valid),
+		},
+		"valid_token_with_jwks": {
+			opts:   &JWTOptions{JWKSURL: server.URL, Issuer: "https://issuer.example.com", Audience: "convoy"},
+			header: "Authorization",
+			value:  "Bearer " + signToken(t, key, "key-1", valid),
+		},
+		"valid_token_in_custom_header": {
+			opts:   &JWTOptions{PublicKeys: publicKeys, Header: "X-Token"},
+			header: "X-Token",
+			value:  signToken(t, key, "", valid),
+		},
+		"token_signed_with_another_key": {
+			opts:          &JWTOptions{JWKSURL: server.URL},
+			header:        "Authorization",
+			value:         "Bearer " + signToken(t, otherKey, "key-1", valid),
+			expectedError: ErrInvalidToken,
+		},
+		"unknown_key_id": {
+			opts:          &JWTOptions{JWKSURL: server.URL},
+			header:        "Authorization",
+			value:         "Bearer " + signToken(t, key, "key-2", valid),
+			expectedError: ErrNoVerifyingKeys,
+		},
+		"expired_token": {
+			opts:          &JWTOptions{PublicKeys: publicKeys},
+			header:        "Authorization",
+			value:         "Bearer " + signToken(t, key, "", jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}),
+			expectedError: ErrInvalidToken,
+		},
+		"token_without_expiry": {
+			opts:          &JWTOptions{PublicKeys: publicKeys},
+			header:        "Authorization",
+			value:         "Bearer " + signToken(t, key, "", jwt.MapClaims{"iss": "https://issuer.example.com"}),
+			expectedError: ErrInvalidToken,
+		},
+		"token_not_valid_yet": {
+			opts:          &JWTOptions{PublicKeys: publicKeys},
+			header:        "Authorization",
+			value:         "Bearer " + signToken(t, key, "", jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix(), "nbf": time.Now().Add(10 * time.Minute).Unix()}),
+			expectedError: ErrInvalidToken,
+		},
+		"token_issued_in_the_future": {
+			opts:          &JWTOptions{PublicKeys: publicKeys},
+			header:        "Authorization",
+			value:         "Bearer " + signToken(t, key, "", jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix(), "iat": time.Now().Add(10 * time.Minute).Unix()}),
+			expectedError: ErrInvalidToken,
+		},
+		"token_issued_within_clock_skew": {
+			opts:   &JWTOptions{PublicKeys: publicKeys},
+			header: "Authorization",
+			value:  "Bearer " + signToken(t, key, "", jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix(), "iat": time.Now().Add(30 * time.Second).Unix(), "nbf": time.Now().Add(30 * time.Second).Unix()}),
+		},
+		"wrong_audience": {
+			opts:          &JWTOptions{PublicKeys: publicKeys, Audience: "someone-else"},
+			header:        "Authorization",
+			value:         "Bearer " + signToken(t, key, "", valid),
+			expectedError: ErrInvalidToken,
+		},
+		"hmac_token_signed_with_public_key": {
+			opts:   &JWTOptions{PublicKeys: publicKeys},
+			header: "Authorization",
+			value: func() string {
+				token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, valid).SignedString([]byte(publicKeys))
+				require.NoError(t, err)
+				return "Bearer " + token
+			}(),
+			expectedError: ErrInvalidToken,
+		},
+		"missing_token": {
+			opts:          &JWTOptions{PublicKeys: publicKeys},
+			expectedError: ErrAuthHeaderCannotBeEmpty,
+		},
+		"basic_auth_header": {
+			opts:          &JWTOptions{PublicKeys: publicKeys},
+			header:        "Authorization",
+			value:         "Basic dXNlcjpwYXNz",
+			expectedError: ErrInvalidHeaderStructure,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			if tc.header != "" {
+				req.Header.Add(tc.header, tc.value)
+			}
+
+			err = NewJWTVerifier(tc.opts, NewHTTPKeySetFetcher(server.Client())).VerifyRequest(req, nil)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func Test_keySetCache_get(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "key-1",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	cache := &keySetCache{sets: map[string]*keySet{}}
+	fetcher := NewHTTPKeySetFetcher(server.Client())
+
+	// concurrent requests for the same key set share one fetch
+	var wg sync.WaitGroup
+	found := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			keys, _ := cache.get(fetcher, server.URL, "key-1")
+			found <- len(keys)
+		}()
+	}
+
+	// the cache isn't locked while the key set is fetched
+	require.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, 10*time.Millisecond)
+	keys, err := cache.get(fetcher, "http://127.0.0.1:0/unreachable", "")
+	require.Error(t, err)
+	require.Empty(t, keys)
+
+	close(release)
+	wg.Wait()
+	close(found)
+
+	for n := range found {
+		require.Equal(t, 1, n)
+	}
+	require.Equal(t, int32(1), fetches.Load())
+}
+
+func Test_keySetCache_get_LargeKeySet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"keys": [`))
+		_, _ = w.Write([]byte(strings.Repeat(`{"kty": "RSA"},`, maxJWKSSize/10)))
+		_, _ = w.Write([]byte(`{"kty": "RSA"}]}`))
+	}))
+	defer server.Close()
+
+	cache := &keySetCache{sets: map[string]*keySet{}}
+	fetcher := NewHTTPKeySetFetcher(server.Client())
+
+	_, err := cache.get(fetcher, server.URL, "")
+	require.ErrorContains(t, err, "failed to decode jwks")
+}
+
+func Test_ParsePublicKeys(t *testing.T) {
+	_, err := ParsePublicKeys("not a pem")
+	require.Equal(t, ErrInvalidPublicKey, err)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys, err := ParsePublicKeys(string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)})))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+}
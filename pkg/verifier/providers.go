@@ -0,0 +1,392 @@
+package verifier
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTolerance is how old a signed timestamp can be before a request
+// is rejected, it guards against replayed requests.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrTimestampOutOfTolerance = errors.New("Timestamp is outside the tolerance window")
+	ErrInvalidTimestamp        = errors.New("Invalid timestamp")
+	ErrBodyHashDoesNotMatch    = errors.New("Body hash does not match")
+)
+
+// StripeVerifier verifies the Stripe-Signature header, see
+// https://docs.stripe.com/webhooks#verify-manually
+type StripeVerifier struct {
+	secret    string
+	tolerance time.Duration
+}
+
+// NewStripeVerifier creates the verifier, a zero tolerance defaults to DefaultTolerance.
+func NewStripeVerifier(secret string, tolerance time.Duration) *StripeVerifier {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	return &StripeVerifier{secret: secret, tolerance: tolerance}
+}
+
+func (sV *StripeVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	header := r.Header.Get("Stripe-Signature")
+	if len(strings.TrimSpace(header)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, pair := range strings.Split(header, ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return ErrInvalidHeaderStructure
+		}
+
+		switch k {
+		case "t":
+			timestamp = v
+		case "v1":
+			signatures = append(signatures, v)
+		}
+	}
+
+	if len(timestamp) == 0 || len(signatures) == 0 {
+		return ErrInvalidHeaderStructure
+	}
+
+	if err := checkTimestamp(timestamp, sV.tolerance); err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, []byte(sV.secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return matchHexSignature(mac.Sum(nil), signatures)
+}
+
+func (sV *StripeVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	// the last timestamp is the one that's verified
+	var timestamp string
+	for _, pair := range strings.Split(r.Header.Get("Stripe-Signature"), ",") {
+		if k, v, _ := strings.Cut(strings.TrimSpace(pair), "="); k == "t" {
+			timestamp = v
+		}
+	}
+
+	return append([]byte(timestamp+"."), payload...)
+}
+
+func (sV *StripeVerifier) Tolerance() time.Duration {
+	return sV.tolerance
+}
+
+// SlackVerifier verifies the X-Slack-Signature header, see
+// https://api.slack.com/authentication/verifying-requests-from-slack
+type SlackVerifier struct {
+	secret    string
+	tolerance time.Duration
+}
+
+// NewSlackVerifier creates the verifier, a zero tolerance defaults to DefaultTolerance.
+func NewSlackVerifier(secret string, tolerance time.Duration) *SlackVerifier {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	return &SlackVerifier{secret: secret, tolerance: tolerance}
+}
+
+func (sV *SlackVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	timestamp := r.Header.Get("X-Slack-Request-Timestamp")
+	signature := r.Header.Get("X-Slack-Signature")
+
+	if len(strings.TrimSpace(signature)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	signature, found := strings.CutPrefix(signature, "v0=")
+	if !found {
+		return ErrInvalidHeaderStructure
+	}
+
+	if err := checkTimestamp(timestamp, sV.tolerance); err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, []byte(sV.secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(payload)
+
+	return matchHexSignature(mac.Sum(nil), []string{signature})
+}
+
+func (sV *SlackVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	return append([]byte("v0:"+r.Header.Get("X-Slack-Request-Timestamp")+":"), payload...)
+}
+
+func (sV *SlackVerifier) Tolerance() time.Duration {
+	return sV.tolerance
+}
+
+// TwilioVerifier verifies the X-Twilio-Signature header, see
+// https://www.twilio.com/docs/usage/webhooks/webhooks-security
+type TwilioVerifier struct {
+	authToken string
+}
+
+func NewTwilioVerifier(authToken string) *TwilioVerifier {
+	return &TwilioVerifier{authToken: authToken}
+}
+
+func (tV *TwilioVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	signature := r.Header.Get("X-Twilio-Signature")
+	if len(strings.TrimSpace(signature)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	sentMAC, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrCannotDecodeBase64EncodedMACHeader
+	}
+
+	data, err := tV.signedData(r, payload)
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha1.New, []byte(tV.authToken))
+	mac.Write([]byte(data))
+
+	if !hmac.Equal(sentMAC, mac.Sum(nil)) {
+		return ErrHashDoesNotMatch
+	}
+
+	return nil
+}
+
+// signedData returns the url with the form params the signature is computed
+// over, other bodies are signed through the bodySHA256 query param.
+func (tV *TwilioVerifier) signedData(r *http.Request, payload []byte) (string, error) {
+	data := requestURL(r)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/x-www-form-urlencoded" {
+		// form params are appended to the url sorted by name
+		params, err := url.ParseQuery(string(payload))
+		if err != nil {
+			return "", ErrInvalidHeaderStructure
+		}
+
+		keys := make([]string, 0, len(params))
+		for k := range params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var sb strings.Builder
+		sb.WriteString(data)
+		for _, k := range keys {
+			values := params[k]
+			sort.Strings(values)
+			for _, v := range values {
+				sb.WriteString(k + v)
+			}
+		}
+
+		return sb.String(), nil
+	}
+
+	if bodyHash := r.URL.Query().Get("bodySHA256"); len(bodyHash) > 0 {
+		sum := sha256.Sum256(payload)
+		if !hmac.Equal([]byte(hex.EncodeToString(sum[:])), []byte(bodyHash)) {
+			return "", ErrBodyHashDoesNotMatch
+		}
+	}
+
+	return data, nil
+}
+
+// SignedContent returns the url and body, the body is included since it
+// isn't always signed.
+func (tV *TwilioVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	data, err := tV.signedData(r, payload)
+	if err != nil {
+		return nil
+	}
+
+	return append([]byte(data+"\n"), payload...)
+}
+
+// Tolerance is zero since Twilio doesn't sign a timestamp.
+func (tV *TwilioVerifier) Tolerance() time.Duration {
+	return 0
+}
+
+// StandardWebhooksVerifier verifies requests signed following the Standard
+// Webhooks spec, Svix headers are accepted too. whsec_ secrets verify v1
+// signatures and whpk_ public keys verify v1a signatures, see
+// https://www.standardwebhooks.com
+type StandardWebhooksVerifier struct {
+	secret    string
+	tolerance time.Duration
+}
+
+// NewStandardWebhooksVerifier creates the verifier, a zero tolerance defaults to DefaultTolerance.
+func NewStandardWebhooksVerifier(secret string, tolerance time.Duration) *StandardWebhooksVerifier {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	return &StandardWebhooksVerifier{secret: secret, tolerance: tolerance}
+}
+
+func (sV *StandardWebhooksVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	id, timestamp, header := r.Header.Get("webhook-id"), r.Header.Get("webhook-timestamp"), r.Header.Get("webhook-signature")
+	if len(header) == 0 {
+		id, timestamp, header = r.Header.Get("svix-id"), r.Header.Get("svix-timestamp"), r.Header.Get("svix-signature")
+	}
+
+	if len(strings.TrimSpace(header)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	if len(id) == 0 {
+		return ErrInvalidHeaderStructure
+	}
+
+	if err := checkTimestamp(timestamp, sV.tolerance); err != nil {
+		return err
+	}
+
+	signedContent := []byte(fmt.Sprintf("%s.%s.%s", id, timestamp, payload))
+
+	version := "v1"
+	var verify func(sig []byte) bool
+
+	if key, found := strings.CutPrefix(sV.secret, "whpk_"); found {
+		pub, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return ErrCannotDecodeBase64EncodedMACHeader
+		}
+
+		version = "v1a"
+		verify = func(sig []byte) bool {
+			return ed25519.Verify(pub, signedContent, sig)
+		}
+	} else {
+		key := []byte(sV.secret)
+		if encoded, found := strings.CutPrefix(sV.secret, "whsec_"); found {
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return ErrCannotDecodeBase64EncodedMACHeader
+			}
+			key = decoded
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write(signedContent)
+		computedMAC := mac.Sum(nil)
+
+		verify = func(sig []byte) bool {
+			return hmac.Equal(sig, computedMAC)
+		}
+	}
+
+	// the header holds space delimited signatures to support rolled secrets
+	for _, versioned := range strings.Split(header, " ") {
+		v, sig, found := strings.Cut(versioned, ",")
+		if !found || v != version {
+			continue
+		}
+
+		sentMAC, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			continue
+		}
+
+		if verify(sentMAC) {
+			return nil
+		}
+	}
+
+	return ErrHashDoesNotMatch
+}
+
+func (sV *StandardWebhooksVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	id, timestamp := r.Header.Get("webhook-id"), r.Header.Get("webhook-timestamp")
+	if len(r.Header.Get("webhook-signature")) == 0 {
+		id, timestamp = r.Header.Get("svix-id"), r.Header.Get("svix-timestamp")
+	}
+
+	return []byte(fmt.Sprintf("%s.%s.%s", id, timestamp, payload))
+}
+
+func (sV *StandardWebhooksVerifier) Tolerance() time.Duration {
+	return sV.tolerance
+}
+
+func checkTimestamp(timestamp string, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	diff := time.Since(time.Unix(ts, 0))
+	if diff > tolerance || diff < -tolerance {
+		return ErrTimestampOutOfTolerance
+	}
+
+	return nil
+}
+
+func matchHexSignature(computedMAC []byte, signatures []string) error {
+	for _, signature := range signatures {
+		sentMAC, err := hex.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(sentMAC, computedMAC) {
+			return nil
+		}
+	}
+
+	return ErrHashDoesNotMatch
+}
+
+// requestURL rebuilds the url the provider sent the request to, taking
+// reverse proxies into account.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); len(proto) > 0 {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+
+	host := r.Host
+	if forwardedHost := r.Header.Get("X-Forwarded-Host"); len(forwardedHost) > 0 {
+		host = strings.TrimSpace(strings.Split(forwardedHost, ",")[0])
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, host, r.URL.RequestURI())
+}
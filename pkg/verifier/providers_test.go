@@ -0,0 +1,270 @@
+package verifier
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func hmacHex(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func Test_StripeVerifier_VerifyRequest(t *testing.T) {
+	payload := []byte(`{"id":"evt_123"}`)
+	now := fmt.Sprintf("%d", time.Now().Unix())
+	old := fmt.Sprintf("%d", time.Now().Add(-10*time.Minute).Unix())
+
+	tests := map[string]struct {
+		header        string
+		expectedError error
+	}{
+		"valid_signature": {
+			header: fmt.Sprintf("t=%s,v1=%s,v0=abc", now, hmacHex("whsec_test", now+"."+string(payload))),
+		},
+		"valid_rolled_signature": {
+			header: fmt.Sprintf("t=%s,v1=%s,v1=%s", now, hmacHex("old_secret", now+"."+string(payload)), hmacHex("whsec_test", now+"."+string(payload))),
+		},
+		"invalid_signature": {
+			header:        fmt.Sprintf("t=%s,v1=%s", now, hmacHex("wrong", now+"."+string(payload))),
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"expired_timestamp": {
+			header:        fmt.Sprintf("t=%s,v1=%s", old, hmacHex("whsec_test", old+"."+string(payload))),
+			expectedError: ErrTimestampOutOfTolerance,
+		},
+		"missing_timestamp": {
+			header:        fmt.Sprintf("v1=%s", hmacHex("whsec_test", string(payload))),
+			expectedError: ErrInvalidHeaderStructure,
+		},
+		"empty_header": {
+			expectedError: ErrSignatureCannotBeEmpty,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("Stripe-Signature", tc.header)
+
+			err = NewStripeVerifier("whsec_test", 0).VerifyRequest(req, payload)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func Test_SlackVerifier_VerifyRequest(t *testing.T) {
+	payload := []byte(`token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J`)
+	now := fmt.Sprintf("%d", time.Now().Unix())
+
+	tests := map[string]struct {
+		timestamp     string
+		signature     string
+		expectedError error
+	}{
+		"valid_signature": {
+			timestamp: now,
+			signature: "v0=" + hmacHex("8f742231b10e8888abcd99yyyzzz85a5", "v0:"+now+":"+string(payload)),
+		},
+		"invalid_signature": {
+			timestamp:     now,
+			signature:     "v0=" + hmacHex("wrong", "v0:"+now+":"+string(payload)),
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"invalid_version": {
+			timestamp:     now,
+			signature:     "v1=" + hmacHex("8f742231b10e8888abcd99yyyzzz85a5", "v0:"+now+":"+string(payload)),
+			expectedError: ErrInvalidHeaderStructure,
+		},
+		"invalid_timestamp": {
+			timestamp:     "yesterday",
+			signature:     "v0=" + hmacHex("8f742231b10e8888abcd99yyyzzz85a5", "v0:yesterday:"+string(payload)),
+			expectedError: ErrInvalidTimestamp,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("X-Slack-Request-Timestamp", tc.timestamp)
+			req.Header.Add("X-Slack-Signature", tc.signature)
+
+			err = NewSlackVerifier("8f742231b10e8888abcd99yyyzzz85a5", 0).VerifyRequest(req, payload)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func Test_TwilioVerifier_VerifyRequest(t *testing.T) {
+	sign := func(data string) string {
+		mac := hmac.New(sha1.New, []byte("12345"))
+		mac.Write([]byte(data))
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	jsonPayload := []byte(`{"status":"delivered"}`)
+	bodyHash := sha256.Sum256(jsonPayload)
+
+	tests := map[string]struct {
+		url           string
+		contentType   string
+		payload       []byte
+		signature     string
+		headers       map[string]string
+		expectedError error
+	}{
+		"valid_form_signature": {
+			url:         "https://mycompany.com/myapp.php?foo=1&bar=2",
+			contentType: "application/x-www-form-urlencoded",
+			payload:     []byte("To=%2B18005551212&CallSid=CA1234567890ABCDE&Digits=1234&From=%2B14158675310"),
+			signature:   sign("https://mycompany.com/myapp.php?foo=1&bar=2CallSidCA1234567890ABCDEDigits1234From+14158675310To+18005551212"),
+		},
+		"valid_forwarded_signature": {
+			url:         "http://internal:5005/ingest/abc",
+			contentType: "application/x-www-form-urlencoded",
+			payload:     []byte("Digits=1234"),
+			headers:     map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "convoy.example.com"},
+			signature:   sign("https://convoy.example.com/ingest/abcDigits1234"),
+		},
+		"valid_json_signature": {
+			url:         "https://mycompany.com/hook?bodySHA256=" + hex.EncodeToString(bodyHash[:]),
+			contentType: "application/json",
+			payload:     jsonPayload,
+			signature:   sign("https://mycompany.com/hook?bodySHA256=" + hex.EncodeToString(bodyHash[:])),
+		},
+		"tampered_json_body": {
+			url:           "https://mycompany.com/hook?bodySHA256=" + hex.EncodeToString(bodyHash[:]),
+			contentType:   "application/json",
+			payload:       []byte(`{"status":"failed"}`),
+			signature:     sign("https://mycompany.com/hook?bodySHA256=" + hex.EncodeToString(bodyHash[:])),
+			expectedError: ErrBodyHashDoesNotMatch,
+		},
+		"invalid_signature": {
+			url:           "https://mycompany.com/myapp.php",
+			contentType:   "application/x-www-form-urlencoded",
+			payload:       []byte("Digits=1234"),
+			signature:     sign("https://mycompany.com/myapp.phpDigits4321"),
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"empty_signature": {
+			url:           "https://mycompany.com/myapp.php",
+			payload:       []byte("Digits=1234"),
+			expectedError: ErrSignatureCannotBeEmpty,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// httptest sets the request up the way a server receives it
+			req := httptest.NewRequest("POST", tc.url, strings.NewReader(``))
+			req.Header.Add("Content-Type", tc.contentType)
+			req.Header.Add("X-Twilio-Signature", tc.signature)
+			for k, v := range tc.headers {
+				req.Header.Add(k, v)
+			}
+
+			err := NewTwilioVerifier("12345").VerifyRequest(req, tc.payload)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func Test_StandardWebhooksVerifier_VerifyRequest(t *testing.T) {
+	payload := []byte(`{"type":"invoice.paid"}`)
+	now := fmt.Sprintf("%d", time.Now().Unix())
+	key := []byte("standard-webhooks-key")
+	secret := "whsec_" + base64.StdEncoding.EncodeToString(key)
+
+	sign := func(id, ts string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(fmt.Sprintf("%s.%s.%s", id, ts, payload)))
+		return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	publicKey := "whpk_" + base64.StdEncoding.EncodeToString(pub)
+
+	tests := map[string]struct {
+		secret        string
+		prefix        string
+		id            string
+		timestamp     string
+		signature     string
+		expectedError error
+	}{
+		"valid_signature": {
+			secret:    secret,
+			prefix:    "webhook",
+			id:        "msg_1",
+			timestamp: now,
+			signature: sign("msg_1", now),
+		},
+		"valid_svix_signature": {
+			secret:    secret,
+			prefix:    "svix",
+			id:        "msg_1",
+			timestamp: now,
+			signature: "v1,bm90LWEtc2lnbmF0dXJl " + sign("msg_1", now),
+		},
+		"valid_asymmetric_signature": {
+			secret:    publicKey,
+			prefix:    "webhook",
+			id:        "msg_1",
+			timestamp: now,
+			signature: "v1a," + base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(fmt.Sprintf("msg_1.%s.%s", now, payload)))),
+		},
+		"signature_for_another_message": {
+			secret:        secret,
+			prefix:        "webhook",
+			id:            "msg_1",
+			timestamp:     now,
+			signature:     sign("msg_2", now),
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"expired_timestamp": {
+			secret:        secret,
+			prefix:        "webhook",
+			id:            "msg_1",
+			timestamp:     "1600000000",
+			signature:     sign("msg_1", "1600000000"),
+			expectedError: ErrTimestampOutOfTolerance,
+		},
+		"missing_id": {
+			secret:        secret,
+			prefix:        "webhook",
+			timestamp:     now,
+			signature:     sign("", now),
+			expectedError: ErrInvalidHeaderStructure,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add(tc.prefix+"-id", tc.id)
+			req.Header.Add(tc.prefix+"-timestamp", tc.timestamp)
+			req.Header.Add(tc.prefix+"-signature", tc.signature)
+
+			err = NewStandardWebhooksVerifier(tc.secret, 0).VerifyRequest(req, payload)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package verifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+)
+
+// untimedReplayWindow is how long signatures that aren't timestamped are
+// remembered for, replays after it can only be caught with a timestamp.
+const untimedReplayWindow = time.Hour
+
+var ErrReplayedRequest = errors.New("Request has already been received, replayed requests are rejected")
+
+// ReplayVerifier is implemented by verifiers that authenticate requests with
+// a signature, a request whose signed content has been seen before is a
+// replayed request.
+type ReplayVerifier interface {
+	Verifier
+
+	// SignedContent returns the content the request's signature is computed
+	// over. It doesn't depend on how the signature header is formatted, so
+	// a replay can't pass as a new request by rewriting the header.
+	SignedContent(r *http.Request, payload []byte) []byte
+
+	// Tolerance returns how old a signed timestamp can be, it is zero
+	// when requests aren't timestamped.
+	Tolerance() time.Duration
+}
+
+// ReplayGuard remembers the signed content of accepted requests.
+type ReplayGuard struct {
+	cache cache.Cache
+}
+
+func NewReplayGuard(cache cache.Cache) *ReplayGuard {
+	return &ReplayGuard{cache: cache}
+}
+
+// Check returns ErrReplayedRequest when the request has been recorded for
+// the source. Verifiers that don't sign requests are not checked.
+func (g *ReplayGuard) Check(ctx context.Context, sourceID string, v Verifier, r *http.Request, payload []byte) error {
+	key, _, ok := g.key(sourceID, v, r, payload)
+	if !ok {
+		return nil
+	}
+
+	// this isn't atomic, concurrent replays of a request can slip through
+	// but a replay sent after the request is recorded is always caught
+	var seen *string
+	err := g.cache.Get(ctx, key, &seen)
+	if err != nil {
+		return err
+	}
+
+	if seen != nil {
+		return ErrReplayedRequest
+	}
+
+	return nil
+}
+
+// Record remembers the request for as long as it would pass verification,
+// it is called once the request is accepted so a provider can retry a
+// request that failed.
+func (g *ReplayGuard) Record(ctx context.Context, sourceID string, v Verifier, r *http.Request, payload []byte) error {
+	key, ttl, ok := g.key(sourceID, v, r, payload)
+	if !ok {
+		return nil
+	}
+
+	return g.cache.Set(ctx, key, &sourceID, ttl)
+}
+
+func (g *ReplayGuard) key(sourceID string, v Verifier, r *http.Request, payload []byte) (string, time.Duration, bool) {
+	rv, ok := v.(ReplayVerifier)
+	if !ok {
+		return "", 0, false
+	}
+
+	content := rv.SignedContent(r, payload)
+	if content == nil {
+		return "", 0, false
+	}
+
+	// timestamps are accepted on either side of now, so a request
+	// verifies for twice the tolerance
+	ttl := 2 * rv.Tolerance()
+	if ttl == 0 {
+		ttl = untimedReplayWindow
+	}
+
+	sum := sha256.Sum256(content)
+	return convoy.ReplayCacheKey.Get(sourceID + ":" + hex.EncodeToString(sum[:])).String(), ttl, true
+}
@@ -0,0 +1,81 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReplayGuard_Check(t *testing.T) {
+	guard := NewReplayGuard(mcache.NewMemoryCache())
+	ctx := context.Background()
+	payload := []byte(`{"id":"evt_123"}`)
+
+	newRequest := func(signature string) *http.Request {
+		req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+		require.NoError(t, err)
+		req.Header.Add("X-Hub-Signature-256", signature)
+		return req
+	}
+
+	v := NewGithubVerifier("secret")
+	signature := "sha256=" + hmacHex("secret", string(payload))
+
+	// requests are only replays once they're recorded
+	require.NoError(t, guard.Check(ctx, "source-1", v, newRequest(signature), payload))
+	require.NoError(t, guard.Check(ctx, "source-1", v, newRequest(signature), payload))
+	require.NoError(t, guard.Record(ctx, "source-1", v, newRequest(signature), payload))
+
+	require.Equal(t, ErrReplayedRequest, guard.Check(ctx, "source-1", v, newRequest(signature), payload))
+
+	// rewriting the signature header doesn't get a replay through
+	require.Equal(t, ErrReplayedRequest, guard.Check(ctx, "source-1", v, newRequest(strings.ToUpper(signature)), payload))
+
+	// but it isn't a replay for another source or another payload
+	require.NoError(t, guard.Check(ctx, "source-2", v, newRequest(signature), payload))
+	require.NoError(t, guard.Check(ctx, "source-1", v, newRequest(signature), []byte(`{"id":"evt_456"}`)))
+
+	// verifiers without signatures aren't checked
+	basic := NewBasicAuthVerifier("user", "pass")
+	require.NoError(t, guard.Record(ctx, "source-1", basic, newRequest(signature), payload))
+	require.NoError(t, guard.Check(ctx, "source-1", basic, newRequest(signature), payload))
+}
+
+func Test_ReplayGuard_Check_RewrittenHeader(t *testing.T) {
+	payload := []byte(`{"id":"evt_123"}`)
+	now := fmt.Sprintf("%d", time.Now().Unix())
+	signature := hmacHex("whsec_test", now+"."+string(payload))
+
+	tests := map[string]string{
+		"upper_case_hex":    fmt.Sprintf("t=%s,v1=%s", now, strings.ToUpper(signature)),
+		"reordered_pairs":   fmt.Sprintf("v1=%s,t=%s", signature, now),
+		"junk_signature":    fmt.Sprintf("t=%s,v1=%s,v1=%s", now, signature, hmacHex("junk", now)),
+		"padded_with_space": fmt.Sprintf("t=%s, v1=%s ", now, signature),
+	}
+
+	for name, header := range tests {
+		t.Run(name, func(t *testing.T) {
+			guard := NewReplayGuard(mcache.NewMemoryCache())
+			ctx := context.Background()
+			v := NewStripeVerifier("whsec_test", 0)
+
+			original, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			original.Header.Add("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s", now, signature))
+			require.NoError(t, v.VerifyRequest(original, payload))
+			require.NoError(t, guard.Record(ctx, "source-1", v, original, payload))
+
+			replay, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			replay.Header.Add("Stripe-Signature", header)
+			require.NoError(t, v.VerifyRequest(replay, payload))
+			require.Equal(t, ErrReplayedRequest, guard.Check(ctx, "source-1", v, replay, payload))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"hash"
 	"net/http"
 	"strings"
+	"time"
 )
 
 var (
@@ -37,6 +38,14 @@ type HmacOptions struct {
 	Hash         string
 	Secret       string
 	Encoding     string
+
+	// TimestampHeader is the header the signed timestamp is read from, the
+	// signature is then computed over <timestamp>,<payload>. When it is the
+	// signature header, the header is read as t=<timestamp>,v1=<signature>.
+	TimestampHeader string
+
+	// Tolerance is how old the timestamp can be, it defaults to DefaultTolerance.
+	Tolerance time.Duration
 }
 
 type HmacVerifier struct {
@@ -55,42 +64,117 @@ func (hV *HmacVerifier) VerifyRequest(r *http.Request, payload []byte) error {
 		return err
 	}
 
-	signature := r.Header.Get(hV.opts.Header)
-
-	if hV.opts.GetSignature != nil {
-		signature = hV.opts.GetSignature(signature)
-	}
-
-	if len(strings.TrimSpace(signature)) == 0 {
-		return ErrSignatureCannotBeEmpty
+	timestamp, signatures, err := hV.getSignatures(r)
+	if err != nil {
+		return err
 	}
 
 	mac := hmac.New(hash, []byte(hV.opts.Secret))
+	if len(hV.opts.TimestampHeader) > 0 {
+		if err = checkTimestamp(timestamp, hV.Tolerance()); err != nil {
+			return err
+		}
+
+		mac.Write([]byte(timestamp + ","))
+	}
 	mac.Write(payload)
 	computedMAC := mac.Sum(nil)
 
-	var sentMAC []byte
+	for _, signature := range signatures {
+		var sentMAC []byte
+
+		if hV.opts.Encoding == "hex" {
+			sentMAC, err = hex.DecodeString(signature)
+			if err != nil {
+				return ErrCannotDecodeHexEncodedMACHeader
+			}
+		} else if hV.opts.Encoding == "base64" {
+			sentMAC, err = base64.StdEncoding.DecodeString(signature)
+			if err != nil {
+				return ErrCannotDecodeBase64EncodedMACHeader
+			}
+		} else {
+			return ErrInvalidEncoding
+		}
 
-	if hV.opts.Encoding == "hex" {
-		sentMAC, err = hex.DecodeString(signature)
-		if err != nil {
-			return ErrCannotDecodeHexEncodedMACHeader
+		if hmac.Equal(sentMAC, computedMAC) {
+			return nil
 		}
-	} else if hV.opts.Encoding == "base64" {
-		sentMAC, err = base64.StdEncoding.DecodeString(signature)
-		if err != nil {
-			return ErrCannotDecodeBase64EncodedMACHeader
+	}
+
+	return ErrHashDoesNotMatch
+}
+
+// getSignatures returns the timestamp and the signatures sent with the request,
+// more than one signature is sent when the secret is being rolled.
+func (hV *HmacVerifier) getSignatures(r *http.Request) (string, []string, error) {
+	header := r.Header.Get(hV.opts.Header)
+
+	if len(hV.opts.TimestampHeader) == 0 || !strings.EqualFold(hV.opts.TimestampHeader, hV.opts.Header) {
+		signature := header
+		if hV.opts.GetSignature != nil {
+			signature = hV.opts.GetSignature(signature)
+		}
+
+		if len(strings.TrimSpace(signature)) == 0 {
+			return "", nil, ErrSignatureCannotBeEmpty
 		}
-	} else {
-		return ErrInvalidEncoding
+
+		return r.Header.Get(hV.opts.TimestampHeader), []string{signature}, nil
 	}
 
-	validMAC := hmac.Equal(sentMAC, computedMAC)
-	if !validMAC {
-		return ErrHashDoesNotMatch
+	if len(strings.TrimSpace(header)) == 0 {
+		return "", nil, ErrSignatureCannotBeEmpty
 	}
 
-	return nil
+	var timestamp string
+	var signatures []string
+	for _, pair := range strings.Split(header, ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return "", nil, ErrInvalidHeaderStructure
+		}
+
+		if k == "t" {
+			timestamp = v
+		} else if strings.HasPrefix(k, "v") {
+			signatures = append(signatures, v)
+		}
+	}
+
+	if len(timestamp) == 0 || len(signatures) == 0 {
+		return "", nil, ErrInvalidHeaderStructure
+	}
+
+	return timestamp, signatures, nil
+}
+
+// SignedContent returns the timestamp and payload the signature is computed over.
+func (hV *HmacVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	if len(hV.opts.TimestampHeader) == 0 {
+		return payload
+	}
+
+	timestamp, _, err := hV.getSignatures(r)
+	if err != nil {
+		return nil
+	}
+
+	return append([]byte(timestamp+","), payload...)
+}
+
+// Tolerance returns how old a signed timestamp can be, it is zero
+// when requests aren't timestamped.
+func (hV *HmacVerifier) Tolerance() time.Duration {
+	if len(hV.opts.TimestampHeader) == 0 {
+		return 0
+	}
+
+	if hV.opts.Tolerance > 0 {
+		return hV.opts.Tolerance
+	}
+
+	return DefaultTolerance
 }
 
 func (hV *HmacVerifier) getHashFunction(algo string) (func() hash.Hash, error) {
@@ -208,6 +292,14 @@ func (gV *GithubVerifier) VerifyRequest(r *http.Request, payload []byte) error {
 	return v.VerifyRequest(r, payload)
 }
 
+func (gV *GithubVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	return payload
+}
+
+func (gV *GithubVerifier) Tolerance() time.Duration {
+	return 0
+}
+
 func (gV *GithubVerifier) getSignature(sig string) string {
 	values := strings.Split(sig, "sha256=")
 	if len(values) < 2 {
@@ -239,6 +331,14 @@ func (sv *ShopifyVerifier) VerifyRequest(r *http.Request, payload []byte) error
 	return v.VerifyRequest(r, payload)
 }
 
+func (sv *ShopifyVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	return payload
+}
+
+func (sv *ShopifyVerifier) Tolerance() time.Duration {
+	return 0
+}
+
 type TwitterVerifier struct {
 	HmacOpts *HmacOptions
 }
@@ -261,6 +361,14 @@ func (tv *TwitterVerifier) VerifyRequest(r *http.Request, payload []byte) error
 	return v.VerifyRequest(r, payload)
 }
 
+func (tv *TwitterVerifier) SignedContent(r *http.Request, payload []byte) []byte {
+	return payload
+}
+
+func (tv *TwitterVerifier) Tolerance() time.Duration {
+	return 0
+}
+
 func (tV *TwitterVerifier) getSignature(sig string) string {
 	return strings.Split(sig, "sha256=")[1]
 }
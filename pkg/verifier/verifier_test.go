@@ -2,9 +2,11 @@ package verifier
 
 import (
 	"encoding/hex"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -454,3 +456,88 @@ func Test_TwitterVerifier_VerifyRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_HmacVerifier_VerifyTimestampedRequest(t *testing.T) {
+	payload := []byte(`{"id":"evt_123"}`)
+	now := fmt.Sprintf("%d", time.Now().Unix())
+	old := fmt.Sprintf("%d", time.Now().Add(-10*time.Minute).Unix())
+
+	tests := map[string]struct {
+		timestampHeader string
+		tolerance       time.Duration
+		headers         map[string]string
+		expectedError   error
+	}{
+		"valid_timestamp_header": {
+			timestampHeader: "X-Convoy-Timestamp",
+			headers: map[string]string{
+				"X-Convoy-Timestamp": now,
+				"X-Convoy-Signature": hmacHex("Convoy", now+","+string(payload)),
+			},
+		},
+		"valid_signature_header": {
+			timestampHeader: "X-Convoy-Signature",
+			headers: map[string]string{
+				"X-Convoy-Signature": fmt.Sprintf("t=%s,v1=%s,v2=%s", now, hmacHex("old", now+","+string(payload)), hmacHex("Convoy", now+","+string(payload))),
+			},
+		},
+		"valid_with_custom_tolerance": {
+			timestampHeader: "X-Convoy-Timestamp",
+			tolerance:       time.Hour,
+			headers: map[string]string{
+				"X-Convoy-Timestamp": old,
+				"X-Convoy-Signature": hmacHex("Convoy", old+","+string(payload)),
+			},
+		},
+		"unsigned_timestamp": {
+			timestampHeader: "X-Convoy-Timestamp",
+			headers: map[string]string{
+				"X-Convoy-Timestamp": now,
+				"X-Convoy-Signature": hmacHex("Convoy", string(payload)),
+			},
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"expired_timestamp": {
+			timestampHeader: "X-Convoy-Signature",
+			headers: map[string]string{
+				"X-Convoy-Signature": fmt.Sprintf("t=%s,v1=%s", old, hmacHex("Convoy", old+","+string(payload))),
+			},
+			expectedError: ErrTimestampOutOfTolerance,
+		},
+		"missing_timestamp": {
+			timestampHeader: "X-Convoy-Timestamp",
+			headers: map[string]string{
+				"X-Convoy-Signature": hmacHex("Convoy", ","+string(payload)),
+			},
+			expectedError: ErrInvalidTimestamp,
+		},
+		"missing_timestamp_in_signature_header": {
+			timestampHeader: "X-Convoy-Signature",
+			headers: map[string]string{
+				"X-Convoy-Signature": "v1=" + hmacHex("Convoy", string(payload)),
+			},
+			expectedError: ErrInvalidHeaderStructure,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			for k, v := range tc.headers {
+				req.Header.Add(k, v)
+			}
+
+			v := NewHmacVerifier(&HmacOptions{
+				Header:          "X-Convoy-Signature",
+				Hash:            "SHA256",
+				Secret:          "Convoy",
+				Encoding:        "hex",
+				TimestampHeader: tc.timestampHeader,
+				Tolerance:       tc.tolerance,
+			})
+
+			require.Equal(t, tc.expectedError, v.VerifyRequest(req, payload))
+		})
+	}
+}
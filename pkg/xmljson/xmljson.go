@@ -0,0 +1,137 @@
+// Package xmljson converts XML documents to JSON, so XML payloads can be
+// filtered and transformed like JSON ones.
+//
+// Elements become object fields named after their local name, the root
+// element included. Elements with neither attributes nor child elements
+// become their text, others become objects where attributes are prefixed
+// with @ and text is stored as #text. Repeated elements become arrays.
+package xmljson
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// MaxDepth is the deepest element nesting Convert accepts.
+const MaxDepth = 100
+
+var (
+	ErrEmptyDocument = errors.New("xml document has no root element")
+	ErrTooDeep       = errors.New("xml document is nested too deeply")
+)
+
+type element struct {
+	name     string
+	attrs    []xml.Attr
+	children []*element
+	text     strings.Builder
+}
+
+// Convert returns the JSON representation of the XML document in body.
+func Convert(body []byte) (json.RawMessage, error) {
+	root, err := parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{root.name: root.value()})
+}
+
+func parse(body []byte) (*element, error) {
+	d := xml.NewDecoder(bytes.NewReader(body))
+	d.CharsetReader = charset.NewReaderLabel
+
+	var root *element
+	var stack []*element
+
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == MaxDepth {
+				return nil, ErrTooDeep
+			}
+
+			e := &element{name: t.Name.Local}
+			for _, a := range t.Attr {
+				// namespace declarations aren't data
+				if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+					continue
+				}
+				e.attrs = append(e.attrs, a)
+			}
+
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, errors.New("xml document has more than one root element")
+				}
+				root = e
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			}
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, ErrEmptyDocument
+	}
+
+	return root, nil
+}
+
+func (e *element) value() interface{} {
+	text := strings.TrimSpace(e.text.String())
+	if len(e.attrs) == 0 && len(e.children) == 0 {
+		return text
+	}
+
+	obj := make(map[string]interface{}, len(e.attrs)+len(e.children)+1)
+	for _, a := range e.attrs {
+		obj["@"+a.Name.Local] = a.Value
+	}
+
+	for _, c := range e.children {
+		v := c.value()
+
+		existing, ok := obj[c.name]
+		if !ok {
+			obj[c.name] = v
+			continue
+		}
+
+		// values are never arrays, so an array holds the earlier repeats
+		if arr, ok := existing.([]interface{}); ok {
+			obj[c.name] = append(arr, v)
+			continue
+		}
+
+		obj[c.name] = []interface{}{existing, v}
+	}
+
+	if text != "" {
+		obj["#text"] = text
+	}
+
+	return obj
+}
@@ -0,0 +1,74 @@
+package xmljson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := map[string]struct {
+		xml     string
+		want    string
+		wantErr error
+	}{
+		"text_element": {
+			xml:  `<id>evt_1</id>`,
+			want: `{"id":"evt_1"}`,
+		},
+		"attributes_and_text": {
+			xml:  `<amount currency="USD"> 100 </amount>`,
+			want: `{"amount":{"#text":"100","@currency":"USD"}}`,
+		},
+		"repeated_elements": {
+			xml:  `<order><item>a</item><item>b</item><item>c</item><total>3</total></order>`,
+			want: `{"order":{"item":["a","b","c"],"total":"3"}}`,
+		},
+		"soap_envelope": {
+			xml: `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope" xmlns:m="https://example.com/orders">
+  <soap:Body>
+    <m:OrderPlaced m:id="42">
+      <m:Customer><![CDATA[Ada & Co]]></m:Customer>
+      <!-- a comment -->
+    </m:OrderPlaced>
+  </soap:Body>
+</soap:Envelope>`,
+			want: `{"Envelope":{"Body":{"OrderPlaced":{"@id":"42","Customer":"Ada & Co"}}}}`,
+		},
+		"latin1_encoding": {
+			xml:  "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><name>Jos\xe9</name>",
+			want: `{"name":"José"}`,
+		},
+		"empty_document": {
+			xml:     `<?xml version="1.0"?>`,
+			wantErr: ErrEmptyDocument,
+		},
+		"too_deep": {
+			xml:     strings.Repeat("<a>", MaxDepth+1) + strings.Repeat("</a>", MaxDepth+1),
+			wantErr: ErrTooDeep,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Convert([]byte(tc.xml))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestConvert_InvalidXML(t *testing.T) {
+	_, err := Convert([]byte(`<order><item>a</order>`))
+	require.Error(t, err)
+
+	_, err = Convert([]byte(`<a/><b/>`))
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package retrystrategies
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultMaxRetrySeconds = 7200
+
+// RetryAfter returns how long the endpoint asked us to wait before the next
+// attempt. The Retry-After header holds either a number of seconds or an
+// HTTP date, the resulting delay is capped at maxRetrySeconds.
+func RetryAfter(header http.Header, maxRetrySeconds uint64) (time.Duration, bool) {
+	return retryAfter(header, maxRetrySeconds, time.Now())
+}
+
+func retryAfter(header http.Header, maxRetrySeconds uint64, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if maxRetrySeconds == 0 {
+		maxRetrySeconds = defaultMaxRetrySeconds
+	}
+
+	var d time.Duration
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		if uint64(seconds) > maxRetrySeconds {
+			seconds = int64(maxRetrySeconds)
+		}
+
+		d = time.Duration(seconds) * time.Second
+	} else {
+		at, err := http.ParseTime(value)
+		if err != nil {
+			return 0, false
+		}
+
+		d = at.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+
+		if d > time.Duration(maxRetrySeconds)*time.Second {
+			d = time.Duration(maxRetrySeconds) * time.Second
+		}
+	}
+
+	return d, true
+}
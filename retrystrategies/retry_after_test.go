@@ -0,0 +1,78 @@
+package retrystrategies
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.January, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		value           string
+		maxRetrySeconds uint64
+		expected        time.Duration
+		ok              bool
+	}{
+		{
+			name:     "missing header",
+			value:    "",
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "delay in seconds",
+			value:    "120",
+			expected: 120 * time.Second,
+			ok:       true,
+		},
+		{
+			name:            "delay in seconds is capped",
+			value:           "86400",
+			maxRetrySeconds: 3600,
+			expected:        3600 * time.Second,
+			ok:              true,
+		},
+		{
+			name:     "http date",
+			value:    now.Add(90 * time.Second).Format(http.TimeFormat),
+			expected: 90 * time.Second,
+			ok:       true,
+		},
+		{
+			name:     "http date in the past",
+			value:    now.Add(-time.Hour).Format(http.TimeFormat),
+			expected: 0,
+			ok:       true,
+		},
+		{
+			name:     "negative seconds",
+			value:    "-10",
+			expected: 0,
+			ok:       false,
+		},
+		{
+			name:     "invalid value",
+			value:    "soon",
+			expected: 0,
+			ok:       false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.value != "" {
+				header.Set("Retry-After", tc.value)
+			}
+
+			d, ok := retryAfter(header, tc.maxRetrySeconds, now)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, d)
+		})
+	}
+}
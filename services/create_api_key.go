@@ -30,8 +30,9 @@ func (ss *CreateAPIKeyService) Run(ctx context.Context) (*datastore.APIKey, stri
 	}
 
 	role := &auth.Role{
-		Type:    ss.NewApiKey.Role.Type,
-		Project: ss.NewApiKey.Role.Project,
+		Type:        ss.NewApiKey.Role.Type,
+		Project:     ss.NewApiKey.Role.Project,
+		Permissions: ss.NewApiKey.Role.Permissions,
 	}
 
 	err := role.Validate("api key")
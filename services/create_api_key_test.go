@@ -120,6 +120,43 @@ func TestCreateAPIKeyService_Run(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "invalid api key role",
 		},
+		{
+			name: "should_error_for_custom_role_without_permissions",
+			args: args{
+				ctx: ctx,
+				newApiKey: &models.APIKey{
+					Name: "test_api_key",
+					Type: "api",
+					Role: models.Role{
+						Type:    auth.RoleCustom,
+						Project: "1234",
+					},
+					ExpiresAt: expires,
+				},
+				member: nil,
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid api key role",
+		},
+		{
+			name: "should_error_for_unknown_permission",
+			args: args{
+				ctx: ctx,
+				newApiKey: &models.APIKey{
+					Name: "test_api_key",
+					Type: "api",
+					Role: models.Role{
+						Type:        auth.RoleCustom,
+						Project:     "1234",
+						Permissions: auth.Permissions{"events:delete"},
+					},
+					ExpiresAt: expires,
+				},
+				member: nil,
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid api key role",
+		},
 		{
 			name: "should_fail_to_fetch_project",
 			args: args{
@@ -2,12 +2,10 @@ package services
 
 import (
 	"context"
-	"fmt"
 	"github.com/oklog/ulid/v2"
 	"net/http"
 	"time"
 
-	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/log"
@@ -17,43 +15,55 @@ import (
 )
 
 type CreateBroadcastEventService struct {
-	EndpointRepo   datastore.EndpointRepository
-	EventRepo      datastore.EventRepository
-	PortalLinkRepo datastore.PortalLinkRepository
-	Queue          queue.Queuer
+	EndpointRepo       datastore.EndpointRepository
+	EventRepo          datastore.EventRepository
+	PortalLinkRepo     datastore.PortalLinkRepository
+	ScheduledEventRepo datastore.ScheduledEventRepository
+	Queue              queue.Queuer
 
 	BroadcastEvent *models.BroadcastEvent
 	Project        *datastore.Project
 }
 
-func (e *CreateBroadcastEventService) Run(ctx context.Context) error {
+// Run queues the broadcast event, the scheduled event is returned when the
+// event is to be delivered later so it can be cancelled or rescheduled.
+func (e *CreateBroadcastEventService) Run(ctx context.Context) (*datastore.ScheduledEvent, error) {
 	if e.Project == nil {
-		return &ServiceError{ErrMsg: "an error occurred while creating broadcast event - invalid project"}
+		return nil, &ServiceError{ErrMsg: "an error occurred while creating broadcast event - invalid project"}
+	}
+
+	deliverAt, err := e.BroadcastEvent.DeliverTime(time.Now())
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
 	e.BroadcastEvent.EventID = ulid.Make().String()
 	e.BroadcastEvent.ProjectID = e.Project.UID
-	jobId := fmt.Sprintf("broadcast:%s:%s", e.BroadcastEvent.ProjectID, e.BroadcastEvent.EventID)
 	e.BroadcastEvent.AcknowledgedAt = time.Now()
 
-	taskName := convoy.CreateBroadcastEventProcessor
-
 	eventByte, err := msgpack.EncodeMsgPack(e.BroadcastEvent)
 	if err != nil {
-		return util.NewServiceError(http.StatusBadRequest, err)
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
-	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
-		Delay:   0,
+	scheduledEvent := &datastore.ScheduledEvent{
+		ProjectID: e.BroadcastEvent.ProjectID,
+		EventID:   e.BroadcastEvent.EventID,
+		EventType: e.BroadcastEvent.EventType,
+		Kind:      datastore.BroadcastScheduledEvent,
+		Payload:   eventByte,
+		DeliverAt: deliverAt,
 	}
 
-	err = e.Queue.Write(taskName, convoy.CreateEventQueue, job)
+	err = QueueEventCreation(ctx, e.Queue, e.ScheduledEventRepo, scheduledEvent)
 	if err != nil {
 		log.FromContext(ctx).Errorf("Error occurred sending new broadcast event to the queue %s", err)
-		return &ServiceError{ErrMsg: "failed to create dynamic event"}
+		return nil, &ServiceError{ErrMsg: "failed to create dynamic event"}
+	}
+
+	if deliverAt.IsZero() {
+		return nil, nil
 	}
 
-	return nil
+	return scheduledEvent, nil
 }
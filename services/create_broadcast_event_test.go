@@ -17,9 +17,10 @@ import (
 
 func provideCreateBroadcastEventService(ctrl *gomock.Controller, de *models.BroadcastEvent, project *datastore.Project) *CreateBroadcastEventService {
 	return &CreateBroadcastEventService{
-		Queue:          mocks.NewMockQueuer(ctrl),
-		BroadcastEvent: de,
-		Project:        project,
+		ScheduledEventRepo: mocks.NewMockScheduledEventRepository(ctrl),
+		Queue:              mocks.NewMockQueuer(ctrl),
+		BroadcastEvent:     de,
+		Project:            project,
 	}
 }
 
@@ -31,12 +32,13 @@ func TestCreateBroadcastEventService_Run(t *testing.T) {
 		g            *datastore.Project
 	}
 	tests := []struct {
-		name        string
-		dbFn        func(es *CreateBroadcastEventService)
-		args        args
-		wantErr     bool
-		wantErrCode int
-		wantErrMsg  string
+		name          string
+		dbFn          func(es *CreateBroadcastEventService)
+		args          args
+		wantErr       bool
+		wantErrCode   int
+		wantErrMsg    string
+		wantScheduled bool
 	}{
 		{
 			name: "should_create_broadcast_event",
@@ -57,6 +59,26 @@ func TestCreateBroadcastEventService_Run(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_schedule_broadcast_event",
+			dbFn: func(es *CreateBroadcastEventService) {
+				s, _ := es.ScheduledEventRepo.(*mocks.MockScheduledEventRepository)
+				s.EXPECT().CreateScheduledEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				q, _ := es.Queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.ScheduledEventProcessor, convoy.CreateEventQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				dynamicEvent: &models.BroadcastEvent{
+					EventType:     "*",
+					Data:          []byte(`{"name":"daniel"}`),
+					EventSchedule: models.EventSchedule{Delay: 60},
+				},
+				g: &datastore.Project{UID: "12345"},
+			},
+			wantScheduled: true,
+		},
 		{
 			name: "should_error_for_nil_project",
 			dbFn: func(es *CreateBroadcastEventService) {},
@@ -84,7 +106,7 @@ func TestCreateBroadcastEventService_Run(t *testing.T) {
 				tc.dbFn(es)
 			}
 
-			err = es.Run(tc.args.ctx)
+			scheduledEvent, err := es.Run(tc.args.ctx)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrMsg, err.Error())
@@ -92,6 +114,14 @@ func TestCreateBroadcastEventService_Run(t *testing.T) {
 			}
 
 			require.Nil(t, err)
+
+			if !tc.wantScheduled {
+				require.Nil(t, scheduledEvent)
+				return
+			}
+
+			require.NotEmpty(t, scheduledEvent.UID)
+			require.Equal(t, datastore.ScheduleActiveStatus, scheduledEvent.Status)
 		})
 	}
 }
@@ -2,13 +2,11 @@ package services
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/frain-dev/convoy/pkg/msgpack"
 
-	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/log"
@@ -18,44 +16,56 @@ import (
 )
 
 type CreateDynamicEventService struct {
-	Queue queue.Queuer
+	ScheduledEventRepo datastore.ScheduledEventRepository
+	Queue              queue.Queuer
 
 	DynamicEvent *models.DynamicEvent
 	Project      *datastore.Project
 }
 
-func (e *CreateDynamicEventService) Run(ctx context.Context) error {
+// Run queues the dynamic event, the scheduled event is returned when the
+// event is to be delivered later so it can be cancelled or rescheduled.
+func (e *CreateDynamicEventService) Run(ctx context.Context) (*datastore.ScheduledEvent, error) {
 	if e.Project == nil {
-		return &ServiceError{ErrMsg: "an error occurred while creating dynamic event - invalid project"}
+		return nil, &ServiceError{ErrMsg: "an error occurred while creating dynamic event - invalid project"}
+	}
+
+	deliverAt, err := e.DynamicEvent.DeliverTime(time.Now())
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
 	e.DynamicEvent.EventID = uuid.NewString()
 	e.DynamicEvent.ProjectID = e.Project.UID
-	jobId := fmt.Sprintf("dynamic:%s:%s", e.DynamicEvent.ProjectID, e.DynamicEvent.EventID)
 	e.DynamicEvent.AcknowledgedAt = time.Now()
 
 	if len(e.DynamicEvent.EventTypes) == 0 {
 		e.DynamicEvent.EventTypes = []string{"*"}
 	}
 
-	taskName := convoy.CreateDynamicEventProcessor
-
 	eventByte, err := msgpack.EncodeMsgPack(e.DynamicEvent)
 	if err != nil {
-		return util.NewServiceError(http.StatusBadRequest, err)
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
-	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
-		Delay:   0,
+	scheduledEvent := &datastore.ScheduledEvent{
+		ProjectID: e.DynamicEvent.ProjectID,
+		EventID:   e.DynamicEvent.EventID,
+		EventType: e.DynamicEvent.EventType,
+		Kind:      datastore.DynamicScheduledEvent,
+		Payload:   eventByte,
+		DeliverAt: deliverAt,
 	}
 
-	err = e.Queue.Write(taskName, convoy.CreateEventQueue, job)
+	err = QueueEventCreation(ctx, e.Queue, e.ScheduledEventRepo, scheduledEvent)
 	if err != nil {
 		log.FromContext(ctx).Errorf("Error occurred sending new dynamic event to the queue %s", err)
-		return &ServiceError{ErrMsg: "failed to create dynamic event"}
+		return nil, &ServiceError{ErrMsg: "failed to create dynamic event"}
+	}
+
+	if deliverAt.IsZero() {
+		return nil, nil
 	}
 
-	return nil
+	return scheduledEvent, nil
 }
@@ -85,7 +85,7 @@ func TestCreateDynamicEventService_Run(t *testing.T) {
 				tc.dbFn(es)
 			}
 
-			err = es.Run(tc.args.ctx)
+			_, err = es.Run(tc.args.ctx)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrMsg, err.Error())
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/frain-dev/convoy/internal/pkg/keys"
 	"net/http"
 	"time"
@@ -13,16 +14,26 @@ import (
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/signature"
+	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/util"
+	"github.com/frain-dev/convoy/worker/task"
 	"github.com/oklog/ulid/v2"
 )
 
+const (
+	maxBatchSize   = 1000
+	maxBatchWindow = 60000
+)
+
 type CreateEndpointService struct {
 	PortalLinkRepo datastore.PortalLinkRepository
 	EndpointRepo   datastore.EndpointRepository
 	ProjectRepo    datastore.ProjectRepository
 	Licenser       license.Licenser
+	Queue          queue.Queuer
 
 	E         models.CreateEndpoint
 	ProjectID string
@@ -67,6 +78,10 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		AdvancedSignatures: *a.E.AdvancedSignatures,
 		AppID:              a.E.AppID,
 		RateLimitDuration:  a.E.RateLimitDuration,
+		HttpMethod:         a.E.HttpMethod,
+		ContentType:        a.E.ContentType,
+		BodyEncoding:       datastore.EndpointBodyEncoding(a.E.BodyEncoding),
+		OrderingKey:        a.E.OrderingKey,
 		Status:             datastore.ActiveEndpointStatus,
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
@@ -80,6 +95,36 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		endpoint.SlackWebhookURL = ""
 	}
 
+	if a.E.OrderedDelivery != nil {
+		endpoint.OrderedDelivery = *a.E.OrderedDelivery
+	}
+
+	if a.E.BatchDelivery != nil {
+		endpoint.BatchDelivery = *a.E.BatchDelivery
+	}
+	endpoint.BatchSize = a.E.BatchSize
+	endpoint.BatchWindow = a.E.BatchWindow
+
+	if a.E.VerificationRequired != nil {
+		endpoint.VerificationRequired = *a.E.VerificationRequired
+	}
+
+	if a.E.HealthCheck != nil {
+		endpoint.HealthCheck = *a.E.HealthCheck
+	}
+
+	if endpoint.VerificationRequired {
+		endpoint.Status = datastore.PendingEndpointStatus
+	}
+
+	if util.IsStringEmpty(endpoint.HttpMethod) {
+		endpoint.HttpMethod = string(convoy.HttpPost)
+	}
+
+	if util.IsStringEmpty(string(endpoint.BodyEncoding)) {
+		endpoint.BodyEncoding = datastore.JSONBodyEncoding
+	}
+
 	if util.IsStringEmpty(endpoint.AppID) {
 		endpoint.AppID = endpoint.UID
 	}
@@ -107,12 +152,39 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		})
 	}
 
+	for i := range endpoint.Secrets {
+		err = setSecretPublicKey(project, &endpoint.Secrets[i])
+		if err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+	}
+
 	auth, err := ValidateEndpointAuthentication(a.E.Authentication.Transform())
 	if err != nil {
 		return nil, &ServiceError{ErrMsg: err.Error()}
 	}
 
 	endpoint.Authentication = auth
+
+	endpoint.StatusCodePolicies = a.E.StatusCodePolicies.Transform()
+	err = ValidateStatusCodePolicies(endpoint.StatusCodePolicies)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	err = ValidateBatchDelivery(endpoint)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if a.E.MtlsClientCert != nil && a.Licenser.MutualTLS() {
+		endpoint.MtlsClientCert = a.E.MtlsClientCert.Transform()
+		err = ValidateMtlsClientCert(endpoint.MtlsClientCert)
+		if err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+	}
+
 	err = a.EndpointRepo.CreateEndpoint(ctx, endpoint, a.ProjectID)
 	if err != nil {
 		log.FromContext(ctx).WithError(err).Error("failed to create endpoint")
@@ -122,9 +194,24 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		return nil, &ServiceError{ErrMsg: "an error occurred while adding endpoint", Err: err}
 	}
 
+	if endpoint.VerificationRequired {
+		err = queueEndpointVerification(a.Queue, endpoint)
+		if err != nil {
+			// the verification can be requested again from the verify endpoint
+			log.FromContext(ctx).WithError(err).Error("failed to queue endpoint verification")
+		}
+	}
+
 	return endpoint, nil
 }
 
+func queueEndpointVerification(q queue.Queuer, endpoint *datastore.Endpoint) error {
+	return task.QueueEndpointVerification(q, task.EndpointVerification{
+		EndpointID: endpoint.UID,
+		ProjectID:  endpoint.ProjectID,
+	}, 0)
+}
+
 func ValidateEndpointAuthentication(auth *datastore.EndpointAuthentication) (*datastore.EndpointAuthentication, error) {
 	if auth != nil && !util.IsStringEmpty(string(auth.Type)) {
 		if err := util.Validate(auth); err != nil {
@@ -135,8 +222,94 @@ func ValidateEndpointAuthentication(auth *datastore.EndpointAuthentication) (*da
 			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("api key field is required"))
 		}
 
+		if auth.Type == datastore.OAuth2Authentication && auth.OAuth2 == nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("oauth2 field is required"))
+		}
+
 		return auth, nil
 	}
 
 	return nil, nil
 }
+
+// setSecretPublicKey sets the public key receivers use to verify asymmetric
+// standard webhooks signatures generated with the secret.
+func setSecretPublicKey(project *datastore.Project, secret *datastore.Secret) error {
+	if project == nil || project.Config == nil {
+		return nil
+	}
+
+	sc := project.Config.Signature
+	if !sc.IsStandardWebhooks() || !sc.Asymmetric {
+		return nil
+	}
+
+	publicKey, err := signature.StandardWebhooksPublicKey(secret.Value)
+	if err != nil {
+		return err
+	}
+
+	secret.PublicKey = publicKey
+	return nil
+}
+
+func ValidateStatusCodePolicies(policies datastore.StatusCodePolicies) error {
+	for _, policy := range policies {
+		if err := util.Validate(policy); err != nil {
+			return err
+		}
+
+		if len(policy.StatusCodes) == 0 {
+			return errors.New("please provide at least one status code for each status code policy")
+		}
+
+		for _, code := range policy.StatusCodes {
+			if code < 100 || code > 599 {
+				return fmt.Errorf("invalid status code %d in status code policy", code)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ValidateBatchDelivery(endpoint *datastore.Endpoint) error {
+	if !endpoint.BatchDelivery {
+		return nil
+	}
+
+	if endpoint.OrderedDelivery {
+		return errors.New("batch delivery cannot be used with ordered delivery")
+	}
+
+	switch endpoint.BodyEncoding {
+	case datastore.FormBodyEncoding, datastore.RawBodyEncoding, datastore.OriginalBodyEncoding:
+		return fmt.Errorf("batch delivery is not supported with the %s body encoding", endpoint.BodyEncoding)
+	}
+
+	if endpoint.BatchSize > maxBatchSize {
+		return fmt.Errorf("batch size cannot be greater than %d", maxBatchSize)
+	}
+
+	if endpoint.BatchWindow > maxBatchWindow {
+		return fmt.Errorf("batch window cannot be greater than %dms", maxBatchWindow)
+	}
+
+	return nil
+}
+
+// ValidateMtlsClientCert checks that the client certificate matches its key
+// and that the CA bundle contains at least one certificate.
+func ValidateMtlsClientCert(cert *datastore.MtlsClientCert) error {
+	c := &net.ClientCertificate{
+		ClientCert: cert.ClientCert,
+		ClientKey:  cert.ClientKey,
+		CACert:     cert.CACert,
+	}
+
+	if _, err := c.TLSConfig(); err != nil {
+		return fmt.Errorf("invalid mtls client certificate: %v", err)
+	}
+
+	return nil
+}
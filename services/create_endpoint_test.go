@@ -255,3 +255,56 @@ func TestCreateEndpointService_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBatchDelivery(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoint   *datastore.Endpoint
+		wantErrMsg string
+	}{
+		{
+			name:     "should_allow_batching_json_payloads",
+			endpoint: &datastore.Endpoint{BatchDelivery: true, BatchSize: 50, BatchWindow: 500},
+		},
+		{
+			name:       "should_not_allow_batching_with_ordered_delivery",
+			endpoint:   &datastore.Endpoint{BatchDelivery: true, OrderedDelivery: true},
+			wantErrMsg: "batch delivery cannot be used with ordered delivery",
+		},
+		{
+			name:       "should_not_allow_batching_form_payloads",
+			endpoint:   &datastore.Endpoint{BatchDelivery: true, BodyEncoding: datastore.FormBodyEncoding},
+			wantErrMsg: "batch delivery is not supported with the form body encoding",
+		},
+		{
+			name:       "should_not_allow_large_batches",
+			endpoint:   &datastore.Endpoint{BatchDelivery: true, BatchSize: 5000},
+			wantErrMsg: "batch size cannot be greater than 1000",
+		},
+		{
+			name:     "should_ignore_batch_settings_when_batching_is_disabled",
+			endpoint: &datastore.Endpoint{OrderedDelivery: true, BatchSize: 5000},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBatchDelivery(tc.endpoint)
+			if tc.wantErrMsg != "" {
+				require.EqualError(t, err, tc.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateMtlsClientCert(t *testing.T) {
+	err := ValidateMtlsClientCert(&datastore.MtlsClientCert{
+		ClientCert: "not a certificate",
+		ClientKey:  "not a key",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid mtls client certificate")
+}
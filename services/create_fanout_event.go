@@ -4,12 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"github.com/frain-dev/convoy/worker/task"
 	"gopkg.in/guregu/null.v4"
 	"time"
 
-	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/pkg/msgpack"
@@ -22,10 +20,11 @@ import (
 )
 
 type CreateFanoutEventService struct {
-	EndpointRepo   datastore.EndpointRepository
-	EventRepo      datastore.EventRepository
-	PortalLinkRepo datastore.PortalLinkRepository
-	Queue          queue.Queuer
+	EndpointRepo       datastore.EndpointRepository
+	EventRepo          datastore.EventRepository
+	PortalLinkRepo     datastore.PortalLinkRepository
+	ScheduledEventRepo datastore.ScheduledEventRepository
+	Queue              queue.Queuer
 
 	NewMessage *models.FanoutEvent
 	Project    *datastore.Project
@@ -48,22 +47,33 @@ type newEvent struct {
 	IdempotencyKey string
 	IsDuplicate    bool
 	AcknowledgedAt time.Time
+	DeliverAt      time.Time
+	SchemaStatus   datastore.EventSchemaStatus
 }
 
-func (e *CreateFanoutEventService) Run(ctx context.Context) (event *datastore.Event, err error) {
+// Run queues the event for the owner's endpoints, the scheduled event is returned
+// when the event is to be delivered later so it can be cancelled or rescheduled.
+func (e *CreateFanoutEventService) Run(ctx context.Context) (event *datastore.Event, scheduledEvent *datastore.ScheduledEvent, err error) {
 	if e.Project == nil {
-		return nil, &ServiceError{ErrMsg: "an error occurred while creating event - invalid project"}
+		return nil, nil, &ServiceError{ErrMsg: "an error occurred while creating event - invalid project"}
 	}
 
 	if err = util.Validate(e.NewMessage); err != nil {
-		return nil, &ServiceError{ErrMsg: err.Error()}
+		return nil, nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	deliverAt, err := e.NewMessage.DeliverTime(time.Now())
+	if err != nil {
+		return nil, nil, &ServiceError{ErrMsg: err.Error()}
 	}
 
 	var isDuplicate bool
-	if !util.IsStringEmpty(e.NewMessage.IdempotencyKey) {
+	if e.NewMessage.IsDuplicate != nil {
+		isDuplicate = *e.NewMessage.IsDuplicate
+	} else if !util.IsStringEmpty(e.NewMessage.IdempotencyKey) {
 		events, err := e.EventRepo.FindEventsByIdempotencyKey(ctx, e.Project.UID, e.NewMessage.IdempotencyKey)
 		if err != nil {
-			return nil, &ServiceError{ErrMsg: err.Error()}
+			return nil, nil, &ServiceError{ErrMsg: err.Error()}
 		}
 
 		isDuplicate = len(events) > 0
@@ -71,14 +81,14 @@ func (e *CreateFanoutEventService) Run(ctx context.Context) (event *datastore.Ev
 
 	endpoints, err := e.EndpointRepo.FindEndpointsByOwnerID(ctx, e.Project.UID, e.NewMessage.OwnerID)
 	if err != nil {
-		return nil, &ServiceError{ErrMsg: err.Error()}
+		return nil, nil, &ServiceError{ErrMsg: err.Error()}
 	}
 
 	if len(endpoints) == 0 {
 		_, err = e.PortalLinkRepo.FindPortalLinkByOwnerID(ctx, e.Project.UID, e.NewMessage.OwnerID)
 		if err != nil {
 			if !errors.Is(err, datastore.ErrPortalLinkNotFound) {
-				return nil, &ServiceError{ErrMsg: err.Error()}
+				return nil, nil, &ServiceError{ErrMsg: err.Error()}
 			}
 		}
 	}
@@ -92,17 +102,19 @@ func (e *CreateFanoutEventService) Run(ctx context.Context) (event *datastore.Ev
 		CustomHeaders:  e.NewMessage.CustomHeaders,
 		IsDuplicate:    isDuplicate,
 		AcknowledgedAt: time.Now(),
+		DeliverAt:      deliverAt,
+		SchemaStatus:   e.NewMessage.SchemaStatus,
 	}
 
-	event, err = createEvent(ctx, endpoints, ev, e.Project, e.Queue)
+	event, scheduledEvent, err = createEvent(ctx, endpoints, ev, e.Project, e.Queue, e.ScheduledEventRepo)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return event, nil
+	return event, scheduledEvent, nil
 }
 
-func createEvent(ctx context.Context, endpoints []datastore.Endpoint, newMessage *newEvent, g *datastore.Project, queuer queue.Queuer) (*datastore.Event, error) {
+func createEvent(ctx context.Context, endpoints []datastore.Endpoint, newMessage *newEvent, g *datastore.Project, queuer queue.Queuer, scheduledEventRepo datastore.ScheduledEventRepository) (*datastore.Event, *datastore.ScheduledEvent, error) {
 	var endpointIDs []string
 
 	for _, endpoint := range endpoints {
@@ -120,11 +132,12 @@ func createEvent(ctx context.Context, endpoints []datastore.Endpoint, newMessage
 		Endpoints:        endpointIDs,
 		ProjectID:        g.UID,
 		AcknowledgedAt:   null.TimeFrom(time.Now()),
+		SchemaStatus:     newMessage.SchemaStatus,
 	}
 
 	if (g.Config == nil || g.Config.Strategy == nil) ||
 		(g.Config.Strategy != nil && g.Config.Strategy.Type != datastore.LinearStrategyProvider && g.Config.Strategy.Type != datastore.ExponentialStrategyProvider) {
-		return nil, &ServiceError{ErrMsg: "retry strategy not defined in configuration"}
+		return nil, nil, &ServiceError{ErrMsg: "retry strategy not defined in configuration"}
 	}
 
 	e := task.CreateEvent{
@@ -134,21 +147,29 @@ func createEvent(ctx context.Context, endpoints []datastore.Endpoint, newMessage
 
 	eventByte, err := msgpack.EncodeMsgPack(e)
 	if err != nil {
-		return nil, &ServiceError{ErrMsg: err.Error()}
+		return nil, nil, &ServiceError{ErrMsg: err.Error()}
 	}
 
-	jobId := fmt.Sprintf("fanout:%s:%s", event.ProjectID, event.UID)
-	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
-		Delay:   0,
+	scheduledEvent := &datastore.ScheduledEvent{
+		ProjectID: event.ProjectID,
+		EventID:   event.UID,
+		EventType: string(event.EventType),
+		Kind:      datastore.FanoutScheduledEvent,
+		Payload:   eventByte,
+		DeliverAt: newMessage.DeliverAt,
 	}
-	err = queuer.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
+
+	err = QueueEventCreation(ctx, queuer, scheduledEventRepo, scheduledEvent)
 	if err != nil {
 		log.FromContext(ctx).Errorf("Error occurred sending new event to the queue %s", err)
+		return event, nil, nil
+	}
+
+	if newMessage.DeliverAt.IsZero() {
+		return event, nil, nil
 	}
 
-	return event, nil
+	return event, scheduledEvent, nil
 }
 
 func getCustomHeaders(customHeaders map[string]string) httpheader.HTTPHeader {
@@ -200,7 +200,7 @@ func TestCreateFanoutEventService_Run(t *testing.T) {
 				tc.dbFn(es)
 			}
 
-			event, err := es.Run(tc.args.ctx)
+			event, _, err := es.Run(tc.args.ctx)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
@@ -11,6 +11,7 @@ import (
 	"github.com/xdg-go/pbkdf2"
 
 	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
@@ -27,6 +28,11 @@ type CreatePersonalAPIKeyService struct {
 }
 
 func (cpa *CreatePersonalAPIKeyService) Run(ctx context.Context) (*datastore.APIKey, string, error) {
+	err := cpa.NewApiKey.Permissions.Validate()
+	if err != nil {
+		return nil, "", &ServiceError{ErrMsg: err.Error(), Err: err}
+	}
+
 	maskID, key := util.GenerateAPIKey()
 
 	salt, err := util.GenerateSecret()
@@ -50,6 +56,7 @@ func (cpa *CreatePersonalAPIKeyService) Run(ctx context.Context) (*datastore.API
 		MaskID:    maskID,
 		Name:      cpa.NewApiKey.Name,
 		Type:      datastore.PersonalKey,
+		Role:      auth.Role{Permissions: cpa.NewApiKey.Permissions},
 		UserID:    cpa.User.UID,
 		Hash:      encodedKey,
 		Salt:      salt,
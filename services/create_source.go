@@ -13,6 +13,9 @@ import (
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/changestream"
+	"github.com/frain-dev/convoy/internal/pkg/restapi"
+	"github.com/frain-dev/convoy/util"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -30,13 +33,33 @@ func (s *CreateSourceService) Run(ctx context.Context) (*datastore.Source, error
 		}
 	}
 
+	sourceID := ulid.Make().String()
+
+	changeStream := s.NewSource.ChangeStream.Transform()
+	if s.NewSource.Type == datastore.DBChangeStream {
+		if changeStream != nil && util.IsStringEmpty(changeStream.SlotName) {
+			changeStream.SlotName = changestream.DefaultSlotName(sourceID)
+		}
+
+		if err := pubsub.ValidateChangeStream(changeStream); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+	}
+
+	restApi := s.NewSource.RestApi.Transform()
+	if s.NewSource.Type == datastore.RestApiSource {
+		if err := restapi.Validate(restApi); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+	}
+
 	cfg, err := config.Get()
 	if err != nil {
 		return nil, &ServiceError{ErrMsg: "failed to load configuration", Err: err}
 	}
 
 	source := &datastore.Source{
-		UID:             ulid.Make().String(),
+		UID:             sourceID,
 		ProjectID:       s.Project.UID,
 		MaskID:          uniuri.NewLen(16),
 		Name:            s.NewSource.Name,
@@ -44,13 +67,18 @@ func (s *CreateSourceService) Run(ctx context.Context) (*datastore.Source, error
 		Provider:        s.NewSource.Provider,
 		Verifier:        s.NewSource.Verifier.Transform(),
 		PubSub:          s.NewSource.PubSub.Transform(),
+		ChangeStream:    changeStream,
+		RestApi:         restApi,
 		IdempotencyKeys: s.NewSource.IdempotencyKeys,
+		Converters:      s.NewSource.Converters,
+		DedupWindow:     s.NewSource.DedupWindow,
 		CustomResponse: datastore.CustomResponse{
 			Body:        s.NewSource.CustomResponse.Body,
 			ContentType: s.NewSource.CustomResponse.ContentType,
 		},
 		BodyFunction:   s.NewSource.BodyFunction,
 		HeaderFunction: s.NewSource.HeaderFunction,
+		Synchronous:    s.NewSource.Synchronous,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
@@ -43,11 +43,21 @@ func (s *CreateSubscriptionService) Run(ctx context.Context) (*datastore.Subscri
 	}
 
 	if s.Project.Type == datastore.IncomingProject {
-		_, err = s.SourceRepo.FindSourceByID(ctx, s.Project.UID, s.NewSubscription.SourceID)
+		source, err := s.SourceRepo.FindSourceByID(ctx, s.Project.UID, s.NewSubscription.SourceID)
 		if err != nil {
 			log.FromContext(ctx).WithError(err).Error("failed to find source by id")
 			return nil, &ServiceError{ErrMsg: "failed to find source by id"}
 		}
+
+		err = checkSynchronousSource(ctx, s.SubRepo, source, "")
+		if err != nil {
+			if errors.Is(err, ErrSynchronousSourceFanOut) {
+				return nil, &ServiceError{ErrMsg: err.Error()}
+			}
+
+			log.FromContext(ctx).WithError(err).Error("failed to find source subscriptions")
+			return nil, &ServiceError{ErrMsg: "failed to find source subscriptions", Err: err}
+		}
 	}
 
 	if s.Project.Type == datastore.OutgoingProject && !s.Project.Config.MultipleEndpointSubscriptions {
@@ -320,6 +320,33 @@ func TestCreateSubscriptionService_Run(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "should fail to add a second subscription to a synchronous source",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.CreateSubscription{
+					Name:       "sub 1",
+					SourceID:   "source-id-1",
+					EndpointID: "endpoint-id-1",
+				},
+				project: &datastore.Project{UID: "12345", Type: datastore.IncomingProject},
+			},
+			dbFn: func(ss *CreateSubscriptionService) {
+				a, _ := ss.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).
+					Times(1).Return(&datastore.Endpoint{ProjectID: "12345"}, nil)
+
+				sr, _ := ss.SourceRepo.(*mocks.MockSourceRepository)
+				sr.EXPECT().FindSourceByID(gomock.Any(), "12345", "source-id-1").
+					Times(1).Return(&datastore.Source{ProjectID: "12345", UID: "source-id-1", Synchronous: true}, nil)
+
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionsBySourceID(gomock.Any(), "12345", "source-id-1").
+					Times(1).Return([]datastore.Subscription{{UID: "sub-0"}}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: ErrSynchronousSourceFanOut.Error(),
+		},
 		{
 			name: "should fail to find source",
 			args: args{
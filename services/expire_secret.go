@@ -87,6 +87,11 @@ func (a *ExpireSecretService) Run(ctx context.Context) (*datastore.Endpoint, err
 		UpdatedAt: time.Now(),
 	}
 
+	err = setSecretPublicKey(a.Project, &sc)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
 	a.Endpoint.Secrets = append(a.Endpoint.Secrets, sc)
 
 	err = a.EndpointRepo.UpdateSecrets(ctx, a.Endpoint.UID, a.Project.UID, a.Endpoint.Secrets)
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/auth/realm/jwt"
+	"github.com/frain-dev/convoy/auth/realm/oidc"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/util"
+)
+
+// oidcStateTTL is how long users have to sign in with the identity provider.
+const oidcStateTTL = 10 * time.Minute
+
+var (
+	ErrInvalidOIDCState     = errors.New("invalid or expired oidc login state")
+	ErrOIDCAccountNotLinked = errors.New("an account with this email exists but isn't linked to the identity provider")
+)
+
+type oidcLoginState struct {
+	Nonce string `json:"nonce"`
+}
+
+// LoginUserOIDCService signs users in with the OIDC realm's identity
+// provider, their membership of the configured organisation is updated to
+// match their groups on every login.
+type LoginUserOIDCService struct {
+	UserRepo      datastore.UserRepository
+	OrgRepo       datastore.OrganisationRepository
+	OrgMemberRepo datastore.OrganisationMemberRepository
+	JWT           *jwt.Jwt
+	Cache         cache.Cache
+	Licenser      license.Licenser
+	Config        *config.OIDCRealmOptions
+}
+
+// AuthURL returns the url users are sent to to sign in.
+func (u *LoginUserOIDCService) AuthURL(ctx context.Context) (*models.SSOLoginResponse, error) {
+	state, nonce := uniuri.NewLen(32), uniuri.NewLen(32)
+
+	err := u.Cache.Set(ctx, oidcStateCacheKey(state), &oidcLoginState{Nonce: nonce}, oidcStateTTL)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to save oidc login state")
+		return nil, &ServiceError{ErrMsg: "failed to start oidc login", Err: err}
+	}
+
+	redirectURL, err := oidc.NewProvider(u.Config).AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to build oidc login url")
+		return nil, &ServiceError{ErrMsg: "failed to start oidc login", Err: err}
+	}
+
+	return &models.SSOLoginResponse{RedirectURL: redirectURL}, nil
+}
+
+// Login redeems the code the identity provider sent the user back with.
+func (u *LoginUserOIDCService) Login(ctx context.Context, code, state string) (*datastore.User, *jwt.Token, error) {
+	if util.IsStringEmpty(code) || util.IsStringEmpty(state) {
+		return nil, nil, util.NewServiceError(http.StatusBadRequest, errors.New("missing code or state"))
+	}
+
+	var loginState *oidcLoginState
+	err := u.Cache.Get(ctx, oidcStateCacheKey(state), &loginState)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to load oidc login state")
+		return nil, nil, &ServiceError{ErrMsg: "failed to load oidc login state", Err: err}
+	}
+
+	if loginState == nil {
+		return nil, nil, util.NewServiceError(http.StatusUnauthorized, ErrInvalidOIDCState)
+	}
+
+	// a state can only be used once
+	err = u.Cache.Delete(ctx, oidcStateCacheKey(state))
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to delete oidc login state")
+	}
+
+	provider := oidc.NewProvider(u.Config)
+
+	idToken, err := provider.Exchange(ctx, code)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to exchange oidc code")
+		return nil, nil, util.NewServiceError(http.StatusUnauthorized, errors.New("failed to exchange oidc code"))
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, nil, util.NewServiceError(http.StatusUnauthorized, err)
+	}
+
+	if claims.Nonce != loginState.Nonce {
+		return nil, nil, util.NewServiceError(http.StatusUnauthorized, oidc.ErrInvalidToken)
+	}
+
+	role, err := provider.Role(claims.Groups)
+	if err != nil {
+		return nil, nil, util.NewServiceError(http.StatusForbidden, err)
+	}
+
+	user, err := u.findUser(ctx, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !util.IsStringEmpty(u.Config.OrganisationID) {
+		org, err := u.OrgRepo.FetchOrganisationByID(ctx, u.Config.OrganisationID)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to find oidc organisation")
+			return nil, nil, &ServiceError{ErrMsg: "failed to find organisation", Err: err}
+		}
+
+		err = syncOrganisationMember(ctx, u.OrgMemberRepo, u.Licenser, org, user, role)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	token, err := u.JWT.GenerateToken(user)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to generate token")
+		return nil, nil, &ServiceError{ErrMsg: "failed to generate token", Err: err}
+	}
+
+	return user, &token, nil
+}
+
+// findUser returns the user linked to the identity provider account. An
+// account is only linked to an existing user with the same email when the
+// user was provisioned by the organisation of the identity provider, e.g.
+// over SCIM, otherwise anyone able to set that email at the identity
+// provider could take over the user.
+func (u *LoginUserOIDCService) findUser(ctx context.Context, claims *oidc.Claims) (*datastore.User, error) {
+	user, err := u.UserRepo.FindUserByOIDCSubject(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		return user, nil
+	}
+
+	if !errors.Is(err, datastore.ErrUserNotFound) {
+		log.FromContext(ctx).WithError(err).Error("failed to find user")
+		return nil, &ServiceError{ErrMsg: "login failed", Err: err}
+	}
+
+	user, err = u.UserRepo.FindUserByEmail(ctx, claims.Email)
+	if err != nil {
+		if !errors.Is(err, datastore.ErrUserNotFound) {
+			log.FromContext(ctx).WithError(err).Error("failed to find user")
+			return nil, &ServiceError{ErrMsg: "login failed", Err: err}
+		}
+
+		if !u.Config.AutoProvision {
+			return nil, util.NewServiceError(http.StatusNotFound, datastore.ErrUserNotFound)
+		}
+
+		return provisionUser(ctx, u.UserRepo, u.Licenser, identityProviderUser{
+			Email:          claims.Email,
+			FirstName:      claims.GivenName,
+			LastName:       claims.FamilyName,
+			OrganisationID: u.Config.OrganisationID,
+			Issuer:         claims.Issuer,
+			Subject:        claims.Subject,
+		})
+	}
+
+	if util.IsStringEmpty(user.ProvisionedBy) || user.ProvisionedBy != u.Config.OrganisationID || !util.IsStringEmpty(user.OIDCSubject) {
+		return nil, util.NewServiceError(http.StatusConflict, ErrOIDCAccountNotLinked)
+	}
+
+	user.OIDCIssuer, user.OIDCSubject = claims.Issuer, claims.Subject
+
+	err = u.UserRepo.UpdateUser(ctx, user)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to link user")
+		return nil, &ServiceError{ErrMsg: "login failed", Err: err}
+	}
+
+	return user, nil
+}
+
+func oidcStateCacheKey(state string) string {
+	return "oidc_login_state:" + state
+}
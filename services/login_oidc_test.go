@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy/auth/realm/oidc"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoginUserOIDCService_Login(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		code        string
+		state       string
+		cacheFn     func(c *mocks.MockCache)
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:        "should_error_for_missing_code",
+			state:       "state",
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "missing code or state",
+		},
+		{
+			name:  "should_error_for_unknown_state",
+			code:  "code",
+			state: "state",
+			cacheFn: func(c *mocks.MockCache) {
+				c.EXPECT().Get(gomock.Any(), "oidc_login_state:state", gomock.Any()).Times(1).Return(nil)
+			},
+			wantErrCode: http.StatusUnauthorized,
+			wantErrMsg:  ErrInvalidOIDCState.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := mocks.NewMockCache(ctrl)
+			if tt.cacheFn != nil {
+				tt.cacheFn(c)
+			}
+
+			u := &LoginUserOIDCService{
+				UserRepo: mocks.NewMockUserRepository(ctrl),
+				Cache:    c,
+				Config:   &config.OIDCRealmOptions{Enabled: true},
+			}
+
+			_, _, err := u.Login(ctx, tt.code, tt.state)
+			require.Error(t, err)
+			require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+			require.Equal(t, tt.wantErrMsg, err.Error())
+		})
+	}
+}
+
+func TestLoginUserOIDCService_findUser(t *testing.T) {
+	ctx := context.Background()
+	claims := &oidc.Claims{Issuer: "https://idp.example.com", Subject: "user-1", Email: "jane@example.com", GivenName: "Jane"}
+
+	tests := []struct {
+		name          string
+		autoProvision bool
+		dbFn          func(u *mocks.MockUserRepository, l *mocks.MockLicenser)
+		wantUserID    string
+		wantErrCode   int
+		wantErrMsg    string
+	}{
+		{
+			name: "should_find_user_by_subject",
+			dbFn: func(u *mocks.MockUserRepository, _ *mocks.MockLicenser) {
+				u.EXPECT().FindUserByOIDCSubject(gomock.Any(), "https://idp.example.com", "user-1").Times(1).
+					Return(&datastore.User{UID: "123"}, nil)
+			},
+			wantUserID: "123",
+		},
+		{
+			name: "should_link_user_provisioned_by_organisation",
+			dbFn: func(u *mocks.MockUserRepository, _ *mocks.MockLicenser) {
+				u.EXPECT().FindUserByOIDCSubject(gomock.Any(), "https://idp.example.com", "user-1").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).
+					Return(&datastore.User{UID: "123", ProvisionedBy: "org-1"}, nil)
+				u.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, user *datastore.User) error {
+					require.Equal(t, "https://idp.example.com", user.OIDCIssuer)
+					require.Equal(t, "user-1", user.OIDCSubject)
+					return nil
+				})
+			},
+			wantUserID: "123",
+		},
+		{
+			name: "should_not_link_user_that_signed_up",
+			dbFn: func(u *mocks.MockUserRepository, _ *mocks.MockLicenser) {
+				u.EXPECT().FindUserByOIDCSubject(gomock.Any(), "https://idp.example.com", "user-1").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).Return(&datastore.User{UID: "123"}, nil)
+			},
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  ErrOIDCAccountNotLinked.Error(),
+		},
+		{
+			name: "should_not_link_user_of_another_organisation",
+			dbFn: func(u *mocks.MockUserRepository, _ *mocks.MockLicenser) {
+				u.EXPECT().FindUserByOIDCSubject(gomock.Any(), "https://idp.example.com", "user-1").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).
+					Return(&datastore.User{UID: "123", ProvisionedBy: "org-2"}, nil)
+			},
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  ErrOIDCAccountNotLinked.Error(),
+		},
+		{
+			name: "should_not_relink_user_of_another_account",
+			dbFn: func(u *mocks.MockUserRepository, _ *mocks.MockLicenser) {
+				u.EXPECT().FindUserByOIDCSubject(gomock.Any(), "https://idp.example.com", "user-1").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).
+					Return(&datastore.User{UID: "123", ProvisionedBy: "org-1", OIDCIssuer: "https://idp.example.com", OIDCSubject: "user-2"}, nil)
+			},
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  ErrOIDCAccountNotLinked.Error(),
+		},
+		{
+			name:          "should_provision_new_user",
+			autoProvision: true,
+			dbFn: func(u *mocks.MockUserRepository, l *mocks.MockLicenser) {
+				u.EXPECT().FindUserByOIDCSubject(gomock.Any(), "https://idp.example.com", "user-1").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, user *datastore.User) error {
+					require.Equal(t, "org-1", user.ProvisionedBy)
+					require.Equal(t, "https://idp.example.com", user.OIDCIssuer)
+					require.Equal(t, "user-1", user.OIDCSubject)
+					user.UID = "123"
+					return nil
+				})
+
+				l.EXPECT().CreateUser(gomock.Any()).Times(1).Return(true, nil)
+			},
+			wantUserID: "123",
+		},
+		{
+			name: "should_error_for_unknown_user_without_auto_provisioning",
+			dbFn: func(u *mocks.MockUserRepository, _ *mocks.MockLicenser) {
+				u.EXPECT().FindUserByOIDCSubject(gomock.Any(), "https://idp.example.com", "user-1").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).Return(nil, datastore.ErrUserNotFound)
+			},
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  datastore.ErrUserNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			licenser := mocks.NewMockLicenser(ctrl)
+			tt.dbFn(userRepo, licenser)
+
+			u := &LoginUserOIDCService{
+				UserRepo: userRepo,
+				Licenser: licenser,
+				Config:   &config.OIDCRealmOptions{Enabled: true, OrganisationID: "org-1", AutoProvision: tt.autoProvision},
+			}
+
+			user, err := u.findUser(ctx, claims)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tt.wantErrMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantUserID, user.UID)
+		})
+	}
+}
@@ -70,11 +70,16 @@ func (ps *ProjectService) CreateProject(ctx context.Context, newProject *models.
 			projectConfig.Strategy = datastore.DefaultProjectConfig.Strategy
 		}
 
+		err := ValidateStatusCodePolicies(projectConfig.Strategy.StatusCodePolicies)
+		if err != nil {
+			return nil, nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+
 		if projectConfig.SSL == nil {
 			projectConfig.SSL = &datastore.DefaultSSLConfig
 		}
 
-		err := validateMetaEvent(projectConfig)
+		err = validateMetaEvent(projectConfig)
 		if err != nil {
 			return nil, nil, util.NewServiceError(http.StatusBadRequest, err)
 		}
@@ -179,6 +184,13 @@ func (ps *ProjectService) UpdateProject(ctx context.Context, project *datastore.
 		if err != nil {
 			return nil, util.NewServiceError(http.StatusBadRequest, err)
 		}
+
+		if project.Config.Strategy != nil {
+			err = ValidateStatusCodePolicies(project.Config.Strategy.StatusCodePolicies)
+			if err != nil {
+				return nil, util.NewServiceError(http.StatusBadRequest, err)
+			}
+		}
 	}
 
 	if !util.IsStringEmpty(update.LogoURL) {
@@ -103,6 +103,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 				Config: &datastore.ProjectConfig{
 					Signature: &datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
+						Scheme: datastore.ConvoySignatureScheme,
 					},
 					Strategy: &datastore.StrategyConfiguration{
 						Type:       "linear",
@@ -180,6 +181,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 				Config: &datastore.ProjectConfig{
 					Signature: &datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
+						Scheme: datastore.ConvoySignatureScheme,
 					},
 					SearchPolicy: "300h",
 					SSL:          &datastore.SSLConfiguration{EnforceSecureEndpoints: false},
@@ -243,6 +245,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 					MaxIngestSize: 51200,
 					Signature: &datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
+						Scheme: datastore.ConvoySignatureScheme,
 						Versions: []datastore.SignatureVersion{
 							{
 								Hash:     "SHA256",
@@ -304,6 +307,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 					MaxIngestSize: 51200,
 					Signature: &datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
+						Scheme: datastore.ConvoySignatureScheme,
 						Versions: []datastore.SignatureVersion{
 							{
 								Hash:     "SHA256",
@@ -378,6 +382,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 				Config: &datastore.ProjectConfig{
 					Signature: &datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
+						Scheme: datastore.ConvoySignatureScheme,
 					},
 					Strategy: &datastore.StrategyConfiguration{
 						Type:       "linear",
@@ -651,6 +656,7 @@ func TestProjectService_UpdateProject(t *testing.T) {
 				Config: &datastore.ProjectConfig{
 					Signature: &datastore.SignatureConfiguration{
 						Header: "X-Convoy-Signature",
+						Scheme: datastore.ConvoySignatureScheme,
 					},
 					Strategy: &datastore.StrategyConfiguration{
 						Type:       "linear",
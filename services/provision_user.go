@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/util"
+	"github.com/oklog/ulid/v2"
+)
+
+var ErrCannotChangeOwner = errors.New("the organisation owner's membership is not managed by the identity provider")
+
+// identityProviderUser is the account of a user at an identity provider.
+type identityProviderUser struct {
+	Email     string
+	FirstName string
+	LastName  string
+
+	// OrganisationID is the organisation whose identity provider provisions
+	// the user, it is empty when no organisation is configured.
+	OrganisationID string
+
+	// Issuer and Subject identify the user's OIDC account, they are empty
+	// for users provisioned over SCIM.
+	Issuer  string
+	Subject string
+}
+
+// provisionUser creates the user of an identity provider account. They sign
+// in through the identity provider, so their password is random.
+func provisionUser(ctx context.Context, userRepo datastore.UserRepository, licenser license.Licenser, account identityProviderUser) (*datastore.User, error) {
+	ok, err := licenser.CreateUser(ctx)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if !ok {
+		return nil, &ServiceError{ErrMsg: ErrUserLimit.Error(), Err: ErrUserLimit}
+	}
+
+	p := datastore.Password{Plaintext: ulid.Make().String()}
+	err = p.GenerateHash()
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to generate hash")
+		return nil, &ServiceError{ErrMsg: "failed to generate hash", Err: err}
+	}
+
+	firstName, lastName, email := account.FirstName, account.LastName, account.Email
+	if util.IsStringEmpty(firstName) && util.IsStringEmpty(lastName) {
+		firstName, lastName = util.ExtractOrGenerateNamesFromEmail(email)
+	}
+
+	user := &datastore.User{
+		UID:                    ulid.Make().String(),
+		FirstName:              firstName,
+		LastName:               lastName,
+		Email:                  email,
+		Password:               string(p.Hash),
+		EmailVerificationToken: ulid.Make().String(),
+		CreatedAt:              time.Now(),
+		UpdatedAt:              time.Now(),
+		EmailVerified:          true,
+		AuthType:               string(datastore.SSOUserType),
+		ProvisionedBy:          account.OrganisationID,
+		OIDCIssuer:             account.Issuer,
+		OIDCSubject:            account.Subject,
+	}
+
+	err = userRepo.CreateUser(ctx, user)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateEmail) {
+			return nil, util.NewServiceError(http.StatusConflict, errors.New("this email is taken"))
+		}
+
+		log.FromContext(ctx).WithError(err).Error("failed to create user")
+		return nil, &ServiceError{ErrMsg: "failed to create user", Err: err}
+	}
+
+	return user, nil
+}
+
+// syncOrganisationMember makes the user a member of the organisation with
+// role, the owner keeps their role.
+func syncOrganisationMember(ctx context.Context, orgMemberRepo datastore.OrganisationMemberRepository, licenser license.Licenser, org *datastore.Organisation, user *datastore.User, role auth.Role) error {
+	oms := NewOrganisationMemberService(orgMemberRepo, licenser)
+
+	member, err := orgMemberRepo.FetchOrganisationMemberByUserID(ctx, user.UID, org.UID)
+	if err != nil {
+		if !errors.Is(err, datastore.ErrOrgMemberNotFound) {
+			log.FromContext(ctx).WithError(err).Error("failed to find organisation member")
+			return &ServiceError{ErrMsg: "failed to find organisation member", Err: err}
+		}
+
+		_, err = oms.CreateOrganisationMember(ctx, org, user, &role)
+		return err
+	}
+
+	if user.UID == org.OwnerID || member.Role.Equal(&role) {
+		return nil
+	}
+
+	_, err = oms.UpdateOrganisationMember(ctx, member, &role)
+	return err
+}
+
+// removeOrganisationMember removes the user's membership of the
+// organisation when they have one.
+func removeOrganisationMember(ctx context.Context, orgMemberRepo datastore.OrganisationMemberRepository, licenser license.Licenser, org *datastore.Organisation, userID string) error {
+	if userID == org.OwnerID {
+		return util.NewServiceError(http.StatusForbidden, ErrCannotChangeOwner)
+	}
+
+	member, err := orgMemberRepo.FetchOrganisationMemberByUserID(ctx, userID, org.UID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrOrgMemberNotFound) {
+			return nil
+		}
+
+		log.FromContext(ctx).WithError(err).Error("failed to find organisation member")
+		return &ServiceError{ErrMsg: "failed to find organisation member", Err: err}
+	}
+
+	return NewOrganisationMemberService(orgMemberRepo, licenser).DeleteOrganisationMember(ctx, member.UID, org)
+}
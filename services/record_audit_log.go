@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+)
+
+const redactedAuditValue = "[REDACTED]"
+
+// fields whose values never make it into an audit log, matched
+// against the json name of the field and those nested under it.
+var sensitiveAuditFields = []string{
+	"secret", "password", "hash", "salt", "token", "header_value", "private_key", "signing_key",
+	"dsn", "service_account", "access_key",
+}
+
+// maps of request headers, their values are redacted and their names kept.
+const auditHeadersField = "headers"
+
+// plaintext api keys are only returned as "key" when they are created.
+const plaintextKeyAuditField = "key"
+
+// fields that change on every write and carry nothing worth auditing.
+var ignoredAuditFields = map[string]bool{"updated_at": true, "deleted_at": true}
+
+// RecordAuditLogService writes an audit log of an action, recording the
+// fields that differ between the resource before and after the action.
+type RecordAuditLogService struct {
+	AuditLogRepo datastore.AuditLogRepository
+
+	AuditLog *datastore.AuditLog
+	Before   interface{}
+	After    interface{}
+}
+
+func (r *RecordAuditLogService) Run(ctx context.Context) error {
+	changes, err := auditChanges(r.Before, r.After)
+	if err != nil {
+		return err
+	}
+
+	r.AuditLog.UID = ulid.Make().String()
+	r.AuditLog.Changes = changes
+
+	return r.AuditLogRepo.CreateAuditLog(ctx, r.AuditLog)
+}
+
+// AuditSnapshot copies the json representation of v, it is used to keep
+// the state of a resource before a service changes it in place.
+func AuditSnapshot(v interface{}) map[string]interface{} {
+	fields, err := auditFields(v)
+	if err != nil {
+		return nil
+	}
+
+	return fields
+}
+
+func auditChanges(before, after interface{}) (datastore.AuditChanges, error) {
+	b, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := datastore.AuditChanges{}
+	for k, v := range b {
+		if ignoredAuditFields[k] || reflect.DeepEqual(v, a[k]) {
+			continue
+		}
+
+		changes[k] = datastore.AuditChange{Before: redactAuditValue(k, v), After: redactAuditValue(k, a[k])}
+	}
+
+	for k, v := range a {
+		if _, ok := b[k]; ok || ignoredAuditFields[k] || v == nil {
+			continue
+		}
+
+		changes[k] = datastore.AuditChange{After: redactAuditValue(k, v)}
+	}
+
+	return changes, nil
+}
+
+func auditFields(v interface{}) (map[string]interface{}, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return map[string]interface{}{}, nil
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if err = json.Unmarshal(buf, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func redactAuditValue(key string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	key = strings.ToLower(key)
+	if key == plaintextKeyAuditField {
+		return redactedAuditValue
+	}
+
+	for _, f := range sensitiveAuditFields {
+		if strings.Contains(key, f) {
+			return redactedAuditValue
+		}
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(value))
+		for k, nv := range value {
+			// header names are free-form, so any header may carry a credential
+			if key == auditHeadersField && nv != nil {
+				redacted[k] = redactedAuditValue
+				continue
+			}
+
+			redacted[k] = redactAuditValue(k, nv)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(value))
+		for i, nv := range value {
+			redacted[i] = redactAuditValue("", nv)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecordAuditLogService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	before := &datastore.Endpoint{
+		UID:     "endpoint-1",
+		Name:    "old-name",
+		Url:     "https://example.com",
+		Secrets: []datastore.Secret{{UID: "secret-1", Value: "old-secret"}},
+	}
+
+	tests := []struct {
+		name        string
+		before      interface{}
+		after       interface{}
+		dbFn        func(r *RecordAuditLogService)
+		wantChanges datastore.AuditChanges
+		wantErr     bool
+	}{
+		{
+			name:   "should_record_changed_fields",
+			before: AuditSnapshot(before),
+			after: &datastore.Endpoint{
+				UID:     "endpoint-1",
+				Name:    "new-name",
+				Url:     "https://example.com",
+				Secrets: []datastore.Secret{{UID: "secret-2", Value: "new-secret"}},
+			},
+			wantChanges: datastore.AuditChanges{
+				"name":    {Before: "old-name", After: "new-name"},
+				"secrets": {Before: redactedAuditValue, After: redactedAuditValue},
+			},
+		},
+		{
+			name:  "should_record_created_resource",
+			after: &datastore.APIKey{UID: "key-1", Name: "ci", Hash: "hash", Salt: "salt"},
+			wantChanges: datastore.AuditChanges{
+				"uid":  {After: "key-1"},
+				"name": {After: "ci"},
+				"role": {After: map[string]interface{}{"type": "", "project": ""}},
+				"hash": {After: redactedAuditValue},
+				"salt": {After: redactedAuditValue},
+			},
+		},
+		{
+			name:   "should_fail_to_create_audit_log",
+			before: before,
+			after:  (*datastore.Endpoint)(nil),
+			dbFn: func(r *RecordAuditLogService) {
+				a, _ := r.AuditLogRepo.(*mocks.MockAuditLogRepository)
+				a.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := &RecordAuditLogService{
+				AuditLogRepo: mocks.NewMockAuditLogRepository(ctrl),
+				AuditLog:     &datastore.AuditLog{OrganisationID: "org-1", ResourceID: "endpoint-1"},
+				Before:       tt.before,
+				After:        tt.after,
+			}
+
+			if tt.dbFn != nil {
+				tt.dbFn(r)
+			} else {
+				a, _ := r.AuditLogRepo.(*mocks.MockAuditLogRepository)
+				a.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			err := r.Run(ctx)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, r.AuditLog.UID)
+
+			for k, change := range tt.wantChanges {
+				require.Equal(t, change, r.AuditLog.Changes[k], k)
+			}
+		})
+	}
+}
+
+func TestAuditChanges_RedactsSourceCredentials(t *testing.T) {
+	const credential = "s3cr3t-credential"
+
+	tests := []struct {
+		name   string
+		source *datastore.Source
+	}{
+		{
+			name: "http_hmac_verifier",
+			source: &datastore.Source{
+				Type:     datastore.HTTPSource,
+				Verifier: &datastore.VerifierConfig{Type: datastore.HMacVerifier, HMac: &datastore.HMac{Header: "X-Signature", Hash: "SHA256", Secret: credential}},
+			},
+		},
+		{
+			name: "http_basic_auth_verifier",
+			source: &datastore.Source{
+				Type:     datastore.HTTPSource,
+				Verifier: &datastore.VerifierConfig{Type: datastore.BasicAuthVerifier, BasicAuth: &datastore.BasicAuth{UserName: "convoy", Password: credential}},
+			},
+		},
+		{
+			name: "http_api_key_verifier",
+			source: &datastore.Source{
+				Type:     datastore.HTTPSource,
+				Verifier: &datastore.VerifierConfig{Type: datastore.APIKeyVerifier, ApiKey: &datastore.ApiKey{HeaderName: "X-Api-Key", HeaderValue: credential}},
+			},
+		},
+		{
+			name: "sqs_pub_sub",
+			source: &datastore.Source{
+				Type:   datastore.PubSubSource,
+				PubSub: &datastore.PubSubConfig{Type: datastore.SqsPubSub, Sqs: &datastore.SQSPubSubConfig{AccessKeyID: credential, SecretKey: credential, QueueName: "events"}},
+			},
+		},
+		{
+			name: "google_pub_sub",
+			source: &datastore.Source{
+				Type:   datastore.PubSubSource,
+				PubSub: &datastore.PubSubConfig{Type: datastore.GooglePubSub, Google: &datastore.GooglePubSubConfig{SubscriptionID: "events", ServiceAccount: []byte(credential)}},
+			},
+		},
+		{
+			name: "kafka_pub_sub",
+			source: &datastore.Source{
+				Type:   datastore.PubSubSource,
+				PubSub: &datastore.PubSubConfig{Type: datastore.KafkaPubSub, Kafka: &datastore.KafkaPubSubConfig{TopicName: "events", Auth: &datastore.KafkaAuth{Username: "convoy", Password: credential}}},
+			},
+		},
+		{
+			name: "amqp_pub_sub",
+			source: &datastore.Source{
+				Type:   datastore.PubSubSource,
+				PubSub: &datastore.PubSubConfig{Type: datastore.AmqpPubSub, Amqp: &datastore.AmqpPubSubConfig{Queue: "events", Auth: &datastore.AmqpCredentials{User: "convoy", Password: credential}}},
+			},
+		},
+		{
+			name: "db_change_stream",
+			source: &datastore.Source{
+				Type:         datastore.DBChangeStream,
+				ChangeStream: &datastore.ChangeStreamConfig{DSN: "postgres://convoy:" + credential + "@localhost:5432/app", SlotName: "convoy_slot"},
+			},
+		},
+		{
+			name: "rest_api_api_key",
+			source: &datastore.Source{
+				Type: datastore.RestApiSource,
+				RestApi: &datastore.RestApiConfig{
+					URL:            "https://example.com/items",
+					Authentication: &datastore.EndpointAuthentication{Type: datastore.APIKeyAuthentication, ApiKey: &datastore.ApiKey{HeaderName: "X-Api-Key", HeaderValue: credential}},
+				},
+			},
+		},
+		{
+			name: "rest_api_oauth2",
+			source: &datastore.Source{
+				Type: datastore.RestApiSource,
+				RestApi: &datastore.RestApiConfig{
+					URL:            "https://example.com/items",
+					Authentication: &datastore.EndpointAuthentication{Type: datastore.OAuth2Authentication, OAuth2: &datastore.OAuth2{TokenURL: "https://example.com/token", ClientID: "convoy", ClientSecret: credential}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := auditChanges(nil, tt.source)
+			require.NoError(t, err)
+
+			b, err := json.Marshal(changes)
+			require.NoError(t, err)
+
+			require.NotContains(t, string(b), credential)
+			require.NotContains(t, string(b), base64.StdEncoding.EncodeToString([]byte(credential)))
+			require.Contains(t, string(b), redactedAuditValue)
+		})
+	}
+}
+
+func TestRecordAuditLogService_Run_RestApiSourceHeaders(t *testing.T) {
+	ctx := context.Background()
+
+	newSource := func(token, apiKey string) *datastore.Source {
+		return &datastore.Source{
+			UID:  "source-1",
+			Name: "orders",
+			Type: datastore.RestApiSource,
+			RestApi: &datastore.RestApiConfig{
+				URL:     "https://example.com/orders",
+				Headers: map[string]string{"Authorization": "Bearer " + token, "X-Api-Key": apiKey},
+			},
+		}
+	}
+
+	created := newSource("created-token", "created-api-key")
+	updated := newSource("updated-token", "updated-api-key")
+
+	tests := []struct {
+		name        string
+		before      interface{}
+		after       interface{}
+		credentials []string
+	}{
+		{
+			name:        "should_redact_headers_of_created_source",
+			after:       created,
+			credentials: []string{"created-token", "created-api-key"},
+		},
+		{
+			name:        "should_redact_headers_of_updated_source",
+			before:      AuditSnapshot(created),
+			after:       updated,
+			credentials: []string{"created-token", "created-api-key", "updated-token", "updated-api-key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mocks.NewMockAuditLogRepository(ctrl)
+			a.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+			r := &RecordAuditLogService{
+				AuditLogRepo: a,
+				AuditLog:     &datastore.AuditLog{OrganisationID: "org-1", ResourceID: "source-1"},
+				Before:       tt.before,
+				After:        tt.after,
+			}
+
+			require.NoError(t, r.Run(ctx))
+
+			change, ok := r.AuditLog.Changes["rest_api"]
+			require.True(t, ok)
+
+			b, err := json.Marshal(r.AuditLog.Changes)
+			require.NoError(t, err)
+
+			for _, credential := range tt.credentials {
+				require.NotContains(t, string(b), credential)
+			}
+
+			after, ok := change.After.(map[string]interface{})
+			require.True(t, ok)
+			require.Equal(t, map[string]interface{}{"Authorization": redactedAuditValue, "X-Api-Key": redactedAuditValue}, after["headers"])
+			require.Equal(t, "https://example.com/orders", after["url"])
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/frain-dev/convoy/worker/task"
+	"github.com/oklog/ulid/v2"
+)
+
+var (
+	ErrEventDeliveryNotFailed = errors.New("event delivery is no longer failed")
+	ErrEndpointPaused         = errors.New("endpoint is currently paused")
+	ErrEndpointNotVerified    = errors.New("endpoint is awaiting verification")
+)
+
+// RedriveDeadLettersService sends dead-lettered event deliveries again. The
+// deliveries are spread out over time so that at most Rate are sent per second.
+type RedriveDeadLettersService struct {
+	DeadLetterRepo    datastore.DeadLetterRepository
+	EventDeliveryRepo datastore.EventDeliveryRepository
+	EndpointRepo      datastore.EndpointRepository
+	Queue             queue.Queuer
+
+	Project *datastore.Project
+	Redrive *models.RedriveDeadLetters
+}
+
+func (r *RedriveDeadLettersService) Run(ctx context.Context) (*models.RedriveDeadLettersResponse, error) {
+	var target *datastore.Endpoint
+	if len(r.Redrive.TargetEndpointID) > 0 {
+		endpoint, err := r.EndpointRepo.FindEndpointByID(ctx, r.Redrive.TargetEndpointID, r.Project.UID)
+		if err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("target endpoint not found"))
+		}
+
+		if endpoint.Status == datastore.PausedEndpointStatus {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("target endpoint is currently paused"))
+		}
+
+		if endpoint.AwaitingVerification() {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("target endpoint is awaiting verification"))
+		}
+
+		target = endpoint
+	}
+
+	deadLetters, err := r.DeadLetterRepo.FindDeadLettersForRedrive(ctx, r.Project.UID, r.Redrive.Filter(), r.Redrive.Limit)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to fetch dead letters")
+		return nil, &ServiceError{ErrMsg: "failed to fetch dead letters", Err: err}
+	}
+
+	interval := time.Second / time.Duration(r.Redrive.Rate)
+	endpoints := map[string]error{}
+	redriven := make([]string, 0, len(deadLetters))
+	failed := 0
+
+	for i := range deadLetters {
+		deadLetter := &deadLetters[i]
+
+		endpointID := deadLetter.EndpointID
+		if target != nil {
+			endpointID = target.UID
+		}
+
+		// each endpoint is checked once, this also reactivates inactive endpoints
+		checkErr, ok := endpoints[endpointID]
+		if !ok {
+			checkErr = r.prepareEndpoint(ctx, endpointID)
+			endpoints[endpointID] = checkErr
+		}
+
+		if checkErr == nil {
+			checkErr = r.redrive(ctx, deadLetter, target, time.Duration(len(redriven))*interval)
+		}
+
+		if checkErr != nil {
+			failed++
+			log.FromContext(ctx).WithError(checkErr).Errorf("failed to redrive dead letter %s", deadLetter.UID)
+			continue
+		}
+
+		redriven = append(redriven, deadLetter.UID)
+	}
+
+	err = r.DeadLetterRepo.MarkDeadLettersRedriven(ctx, r.Project.UID, redriven)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to mark dead letters as redriven")
+		return nil, &ServiceError{ErrMsg: "failed to mark dead letters as redriven", Err: err}
+	}
+
+	return &models.RedriveDeadLettersResponse{Redriven: len(redriven), Failed: failed}, nil
+}
+
+func (r *RedriveDeadLettersService) prepareEndpoint(ctx context.Context, endpointID string) error {
+	endpoint, err := r.EndpointRepo.FindEndpointByID(ctx, endpointID, r.Project.UID)
+	if err != nil {
+		return err
+	}
+
+	if endpoint.AwaitingVerification() {
+		return ErrEndpointNotVerified
+	}
+
+	switch endpoint.Status {
+	case datastore.PausedEndpointStatus:
+		return ErrEndpointPaused
+	case datastore.InactiveEndpointStatus:
+		return r.EndpointRepo.UpdateEndpointStatus(ctx, r.Project.UID, endpoint.UID, datastore.PendingEndpointStatus)
+	}
+
+	return nil
+}
+
+// redrive resets the dead-lettered event delivery so it gets its full retries again
+// and queues it. When redriven to another endpoint, a copy of the event delivery is
+// created for that endpoint and the original is left as it is.
+func (r *RedriveDeadLettersService) redrive(ctx context.Context, deadLetter *datastore.DeadLetter, target *datastore.Endpoint, delay time.Duration) error {
+	eventDelivery, err := r.EventDeliveryRepo.FindEventDeliveryByID(ctx, r.Project.UID, deadLetter.EventDeliveryID)
+	if err != nil {
+		return err
+	}
+
+	if eventDelivery.Status != datastore.FailureEventStatus {
+		return ErrEventDeliveryNotFailed
+	}
+
+	eventDelivery.Status = datastore.ScheduledEventStatus
+	eventDelivery.Description = ""
+	eventDelivery.Metadata.NumTrials = 0
+	eventDelivery.Metadata.NextSendTime = time.Now().Add(delay)
+
+	if target != nil && target.UID != eventDelivery.EndpointID {
+		eventDelivery.UID = ulid.Make().String()
+		eventDelivery.EndpointID = target.UID
+		eventDelivery.DeliveryAttempts = nil
+
+		err = r.EventDeliveryRepo.CreateEventDelivery(ctx, eventDelivery)
+	} else {
+		err = r.EventDeliveryRepo.UpdateEventDeliveryMetadata(ctx, r.Project.UID, eventDelivery)
+	}
+	if err != nil {
+		return err
+	}
+
+	payload, err := msgpack.EncodeMsgPack(task.EventDelivery{
+		EventDeliveryID: eventDelivery.UID,
+		ProjectID:       r.Project.UID,
+	})
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      eventDelivery.UID,
+		Payload: payload,
+		Delay:   delay,
+	}
+
+	return r.Queue.Write(convoy.EventProcessor, convoy.EventQueue, job)
+}
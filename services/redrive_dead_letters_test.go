@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type redriveMocks struct {
+	deadLetterRepo    *mocks.MockDeadLetterRepository
+	eventDeliveryRepo *mocks.MockEventDeliveryRepository
+	endpointRepo      *mocks.MockEndpointRepository
+	queue             *mocks.MockQueuer
+}
+
+func failedEventDelivery(id, endpointID string) *datastore.EventDelivery {
+	return &datastore.EventDelivery{
+		UID:        id,
+		ProjectID:  "project-1",
+		EndpointID: endpointID,
+		Status:     datastore.FailureEventStatus,
+		Metadata:   &datastore.Metadata{NumTrials: 3, RetryLimit: 3},
+	}
+}
+
+func TestRedriveDeadLettersService_Run(t *testing.T) {
+	ctx := context.Background()
+	project := &datastore.Project{UID: "project-1"}
+
+	deadLetters := []datastore.DeadLetter{
+		{UID: "dead-letter-1", EventDeliveryID: "delivery-1", EndpointID: "endpoint-1"},
+		{UID: "dead-letter-2", EventDeliveryID: "delivery-2", EndpointID: "endpoint-1"},
+	}
+
+	tests := []struct {
+		name       string
+		redrive    *models.RedriveDeadLetters
+		dbFn       func(m *redriveMocks)
+		want       *models.RedriveDeadLettersResponse
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "should_redrive_dead_letters_at_the_rate",
+			redrive: &models.RedriveDeadLetters{Rate: 2, Limit: 10},
+			dbFn: func(m *redriveMocks) {
+				m.deadLetterRepo.EXPECT().FindDeadLettersForRedrive(gomock.Any(), "project-1", gomock.Any(), 10).Times(1).Return(deadLetters, nil)
+				m.endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Times(1).
+					Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.InactiveEndpointStatus}, nil)
+				m.endpointRepo.EXPECT().UpdateEndpointStatus(gomock.Any(), "project-1", "endpoint-1", datastore.PendingEndpointStatus).Times(1).Return(nil)
+
+				m.eventDeliveryRepo.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-1").Times(1).Return(failedEventDelivery("delivery-1", "endpoint-1"), nil)
+				m.eventDeliveryRepo.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-2").Times(1).Return(failedEventDelivery("delivery-2", "endpoint-1"), nil)
+				m.eventDeliveryRepo.EXPECT().UpdateEventDeliveryMetadata(gomock.Any(), "project-1", gomock.Any()).Times(2).
+					DoAndReturn(func(_ context.Context, _ string, eventDelivery *datastore.EventDelivery) error {
+						require.Equal(t, datastore.ScheduledEventStatus, eventDelivery.Status)
+						require.Equal(t, uint64(0), eventDelivery.Metadata.NumTrials)
+						return nil
+					})
+
+				var delays []time.Duration
+				m.queue.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(2).
+					DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+						delays = append(delays, job.Delay)
+						if len(delays) == 2 {
+							require.Equal(t, []time.Duration{0, 500 * time.Millisecond}, delays)
+						}
+						return nil
+					})
+
+				m.deadLetterRepo.EXPECT().MarkDeadLettersRedriven(gomock.Any(), "project-1", []string{"dead-letter-1", "dead-letter-2"}).Times(1).Return(nil)
+			},
+			want: &models.RedriveDeadLettersResponse{Redriven: 2},
+		},
+		{
+			name:    "should_redrive_dead_letters_to_target_endpoint",
+			redrive: &models.RedriveDeadLetters{Rate: 10, Limit: 10, TargetEndpointID: "endpoint-2"},
+			dbFn: func(m *redriveMocks) {
+				m.endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-2", "project-1").Times(2).
+					Return(&datastore.Endpoint{UID: "endpoint-2", Status: datastore.ActiveEndpointStatus}, nil)
+				m.deadLetterRepo.EXPECT().FindDeadLettersForRedrive(gomock.Any(), "project-1", gomock.Any(), 10).Times(1).Return(deadLetters[:1], nil)
+
+				m.eventDeliveryRepo.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-1").Times(1).Return(failedEventDelivery("delivery-1", "endpoint-1"), nil)
+				m.eventDeliveryRepo.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, eventDelivery *datastore.EventDelivery) error {
+						require.NotEqual(t, "delivery-1", eventDelivery.UID)
+						require.Equal(t, "endpoint-2", eventDelivery.EndpointID)
+						return nil
+					})
+				m.queue.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+
+				m.deadLetterRepo.EXPECT().MarkDeadLettersRedriven(gomock.Any(), "project-1", []string{"dead-letter-1"}).Times(1).Return(nil)
+			},
+			want: &models.RedriveDeadLettersResponse{Redriven: 1},
+		},
+		{
+			name:    "should_skip_deliveries_that_are_no_longer_failed",
+			redrive: &models.RedriveDeadLetters{Rate: 10, Limit: 10},
+			dbFn: func(m *redriveMocks) {
+				m.deadLetterRepo.EXPECT().FindDeadLettersForRedrive(gomock.Any(), "project-1", gomock.Any(), 10).Times(1).Return(deadLetters[:1], nil)
+				m.endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Times(1).
+					Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil)
+
+				eventDelivery := failedEventDelivery("delivery-1", "endpoint-1")
+				eventDelivery.Status = datastore.SuccessEventStatus
+				m.eventDeliveryRepo.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-1").Times(1).Return(eventDelivery, nil)
+
+				m.deadLetterRepo.EXPECT().MarkDeadLettersRedriven(gomock.Any(), "project-1", []string{}).Times(1).Return(nil)
+			},
+			want: &models.RedriveDeadLettersResponse{Failed: 1},
+		},
+		{
+			name:    "should_not_redrive_to_paused_endpoint",
+			redrive: &models.RedriveDeadLetters{Rate: 10, Limit: 10, TargetEndpointID: "endpoint-2"},
+			dbFn: func(m *redriveMocks) {
+				m.endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-2", "project-1").Times(1).
+					Return(&datastore.Endpoint{UID: "endpoint-2", Status: datastore.PausedEndpointStatus}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "target endpoint is currently paused",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := &redriveMocks{
+				deadLetterRepo:    mocks.NewMockDeadLetterRepository(ctrl),
+				eventDeliveryRepo: mocks.NewMockEventDeliveryRepository(ctrl),
+				endpointRepo:      mocks.NewMockEndpointRepository(ctrl),
+				queue:             mocks.NewMockQueuer(ctrl),
+			}
+			tc.dbFn(m)
+
+			rs := &RedriveDeadLettersService{
+				DeadLetterRepo:    m.deadLetterRepo,
+				EventDeliveryRepo: m.eventDeliveryRepo,
+				EndpointRepo:      m.endpointRepo,
+				Queue:             m.queue,
+				Project:           project,
+				Redrive:           tc.redrive,
+			}
+
+			resp, err := rs.Run(ctx)
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, resp)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/frain-dev/convoy/worker/task"
+	"github.com/oklog/ulid/v2"
+)
+
+var ErrScheduledEventNotActive = errors.New("only scheduled events can be cancelled or rescheduled")
+
+// QueueEventCreation writes the event creation job to the queue. Events with a
+// deliver time in the future are stored as scheduled events and the worker
+// dispatches them once they are due.
+func QueueEventCreation(ctx context.Context, q queue.Queuer, scheduledEventRepo datastore.ScheduledEventRepository, scheduledEvent *datastore.ScheduledEvent) error {
+	if scheduledEvent.DeliverAt.IsZero() {
+		job := &queue.Job{
+			ID:      scheduledEvent.JobID(),
+			Payload: scheduledEvent.Payload,
+			Delay:   0,
+		}
+
+		return q.Write(scheduledEvent.Kind.TaskName(), convoy.CreateEventQueue, job)
+	}
+
+	scheduledEvent.UID = ulid.Make().String()
+	scheduledEvent.Status = datastore.ScheduleActiveStatus
+
+	err := scheduledEventRepo.CreateScheduledEvent(ctx, scheduledEvent)
+	if err != nil {
+		return err
+	}
+
+	return queueScheduledEvent(q, scheduledEvent)
+}
+
+// queueScheduledEvent queues the job that dispatches the scheduled event,
+// writing it again replaces the pending job.
+func queueScheduledEvent(q queue.Queuer, scheduledEvent *datastore.ScheduledEvent) error {
+	payload, err := msgpack.EncodeMsgPack(task.ScheduledEvent{
+		ScheduledEventID: scheduledEvent.UID,
+		ProjectID:        scheduledEvent.ProjectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      fmt.Sprintf("scheduled:%s:%s", scheduledEvent.ProjectID, scheduledEvent.UID),
+		Payload: payload,
+		Delay:   time.Until(scheduledEvent.DeliverAt),
+	}
+
+	return q.Write(convoy.ScheduledEventProcessor, convoy.CreateEventQueue, job)
+}
+
+type CancelScheduledEventService struct {
+	ScheduledEventRepo datastore.ScheduledEventRepository
+	ScheduledEvent     *datastore.ScheduledEvent
+}
+
+func (c *CancelScheduledEventService) Run(ctx context.Context) error {
+	if c.ScheduledEvent.Status != datastore.ScheduleActiveStatus {
+		return util.NewServiceError(http.StatusBadRequest, ErrScheduledEventNotActive)
+	}
+
+	err := c.ScheduledEventRepo.UpdateScheduledEventStatus(ctx, c.ScheduledEvent.ProjectID, c.ScheduledEvent.UID, datastore.ScheduleCancelledStatus)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to cancel scheduled event")
+		return &ServiceError{ErrMsg: "failed to cancel scheduled event", Err: err}
+	}
+
+	c.ScheduledEvent.Status = datastore.ScheduleCancelledStatus
+	return nil
+}
+
+type RescheduleEventService struct {
+	ScheduledEventRepo datastore.ScheduledEventRepository
+	Queue              queue.Queuer
+
+	ScheduledEvent *datastore.ScheduledEvent
+	Update         *models.RescheduleEvent
+}
+
+func (r *RescheduleEventService) Run(ctx context.Context) error {
+	if r.ScheduledEvent.Status != datastore.ScheduleActiveStatus {
+		return util.NewServiceError(http.StatusBadRequest, ErrScheduledEventNotActive)
+	}
+
+	deliverAt, err := r.Update.DeliverTime(time.Now())
+	if err != nil {
+		return util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	err = r.ScheduledEventRepo.RescheduleEvent(ctx, r.ScheduledEvent.ProjectID, r.ScheduledEvent.UID, deliverAt)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to reschedule event")
+		return &ServiceError{ErrMsg: "failed to reschedule event", Err: err}
+	}
+
+	r.ScheduledEvent.DeliverAt = deliverAt
+
+	err = queueScheduledEvent(r.Queue, r.ScheduledEvent)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to queue rescheduled event")
+		return &ServiceError{ErrMsg: "failed to reschedule event", Err: err}
+	}
+
+	return nil
+}
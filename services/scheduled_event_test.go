@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQueueEventCreation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_queue_event_immediately", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		q := mocks.NewMockQueuer(ctrl)
+		scheduledEventRepo := mocks.NewMockScheduledEventRepository(ctrl)
+
+		q.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, &queue.Job{
+			ID:      "single:project-1:event-1",
+			Payload: []byte("payload"),
+		}).Times(1).Return(nil)
+
+		err := QueueEventCreation(ctx, q, scheduledEventRepo, &datastore.ScheduledEvent{
+			ProjectID: "project-1",
+			EventID:   "event-1",
+			Kind:      datastore.SingleScheduledEvent,
+			Payload:   []byte("payload"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("should_schedule_event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		q := mocks.NewMockQueuer(ctrl)
+		scheduledEventRepo := mocks.NewMockScheduledEventRepository(ctrl)
+
+		scheduledEventRepo.EXPECT().CreateScheduledEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		q.EXPECT().Write(convoy.ScheduledEventProcessor, convoy.CreateEventQueue, gomock.Any()).Times(1).
+			DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+				require.InDelta(t, time.Hour, job.Delay, float64(time.Minute))
+				return nil
+			})
+
+		scheduledEvent := &datastore.ScheduledEvent{
+			ProjectID: "project-1",
+			EventID:   "event-1",
+			Kind:      datastore.FanoutScheduledEvent,
+			Payload:   []byte("payload"),
+			DeliverAt: time.Now().Add(time.Hour),
+		}
+
+		err := QueueEventCreation(ctx, q, scheduledEventRepo, scheduledEvent)
+		require.NoError(t, err)
+		require.NotEmpty(t, scheduledEvent.UID)
+		require.Equal(t, datastore.ScheduleActiveStatus, scheduledEvent.Status)
+	})
+}
+
+func TestCancelScheduledEventService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		scheduledEvent *datastore.ScheduledEvent
+		dbFn           func(s *mocks.MockScheduledEventRepository)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:           "should_cancel_scheduled_event",
+			scheduledEvent: &datastore.ScheduledEvent{UID: "scheduled-1", ProjectID: "project-1", Status: datastore.ScheduleActiveStatus},
+			dbFn: func(s *mocks.MockScheduledEventRepository) {
+				s.EXPECT().UpdateScheduledEventStatus(gomock.Any(), "project-1", "scheduled-1", datastore.ScheduleCancelledStatus).Times(1).Return(nil)
+			},
+		},
+		{
+			name:           "should_not_cancel_dispatched_event",
+			scheduledEvent: &datastore.ScheduledEvent{UID: "scheduled-1", ProjectID: "project-1", Status: datastore.ScheduleDispatchedStatus},
+			wantErr:        true,
+			wantErrMsg:     ErrScheduledEventNotActive.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			scheduledEventRepo := mocks.NewMockScheduledEventRepository(ctrl)
+			if tc.dbFn != nil {
+				tc.dbFn(scheduledEventRepo)
+			}
+
+			cs := &CancelScheduledEventService{ScheduledEventRepo: scheduledEventRepo, ScheduledEvent: tc.scheduledEvent}
+			err := cs.Run(ctx)
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, datastore.ScheduleCancelledStatus, tc.scheduledEvent.Status)
+		})
+	}
+}
+
+func TestRescheduleEventService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		scheduledEvent *datastore.ScheduledEvent
+		update         *models.RescheduleEvent
+		dbFn           func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:           "should_reschedule_event",
+			scheduledEvent: &datastore.ScheduledEvent{UID: "scheduled-1", ProjectID: "project-1", Status: datastore.ScheduleActiveStatus},
+			update:         &models.RescheduleEvent{EventSchedule: models.EventSchedule{Delay: 60}},
+			dbFn: func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer) {
+				s.EXPECT().RescheduleEvent(gomock.Any(), "project-1", "scheduled-1", gomock.Any()).Times(1).Return(nil)
+				q.EXPECT().Write(convoy.ScheduledEventProcessor, convoy.CreateEventQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:           "should_not_reschedule_to_the_past",
+			scheduledEvent: &datastore.ScheduledEvent{UID: "scheduled-1", ProjectID: "project-1", Status: datastore.ScheduleActiveStatus},
+			update: &models.RescheduleEvent{EventSchedule: models.EventSchedule{
+				DeliverAt: func() *time.Time { t := time.Now().Add(-time.Hour); return &t }(),
+			}},
+			wantErr:    true,
+			wantErrMsg: "deliver_at must be in the future",
+		},
+		{
+			name:           "should_not_reschedule_cancelled_event",
+			scheduledEvent: &datastore.ScheduledEvent{UID: "scheduled-1", ProjectID: "project-1", Status: datastore.ScheduleCancelledStatus},
+			update:         &models.RescheduleEvent{EventSchedule: models.EventSchedule{Delay: 60}},
+			wantErr:        true,
+			wantErrMsg:     ErrScheduledEventNotActive.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			scheduledEventRepo := mocks.NewMockScheduledEventRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+			if tc.dbFn != nil {
+				tc.dbFn(scheduledEventRepo, q)
+			}
+
+			rs := &RescheduleEventService{
+				ScheduledEventRepo: scheduledEventRepo,
+				Queue:              q,
+				ScheduledEvent:     tc.scheduledEvent,
+				Update:             tc.update,
+			}
+
+			err := rs.Run(ctx)
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.WithinDuration(t, time.Now().Add(time.Minute), tc.scheduledEvent.DeliverAt, 5*time.Second)
+		})
+	}
+}
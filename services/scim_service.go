@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/util"
+	"github.com/oklog/ulid/v2"
+)
+
+var (
+	ErrSCIMUserExists    = errors.New("the user has already been provisioned in this organisation")
+	ErrSCIMRoleNotMapped = errors.New("none of the user's roles are mapped to a convoy role")
+	ErrSCIMEmailTaken    = errors.New("a user with this email exists outside this organisation")
+)
+
+// SCIMService provisions the users of an organisation for its identity
+// provider. Roles sent by the identity provider are mapped with the same
+// group mapping as the OIDC realm.
+type SCIMService struct {
+	UserRepo      datastore.UserRepository
+	OrgMemberRepo datastore.OrganisationMemberRepository
+	SCIMUserRepo  datastore.SCIMUserRepository
+	Licenser      license.Licenser
+	GroupRoles    config.OIDCGroupRoles
+}
+
+func (s *SCIMService) CreateUser(ctx context.Context, org *datastore.Organisation, data *models.SCIMUser) (*datastore.SCIMUser, error) {
+	if err := data.Validate(); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	role, err := s.role(data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepo.FindUserByEmail(ctx, data.Email())
+	switch {
+	case err == nil:
+		err = s.checkAdoptable(ctx, org, user)
+		if err != nil {
+			return nil, err
+		}
+	case errors.Is(err, datastore.ErrUserNotFound):
+		user, err = provisionUser(ctx, s.UserRepo, s.Licenser, identityProviderUser{
+			Email:          data.Email(),
+			FirstName:      data.Name.GivenName,
+			LastName:       data.Name.FamilyName,
+			OrganisationID: org.UID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		log.FromContext(ctx).WithError(err).Error("failed to find user")
+		return nil, &ServiceError{ErrMsg: "failed to find user", Err: err}
+	}
+
+	_, err = s.SCIMUserRepo.FetchSCIMUserByUserID(ctx, user.UID, org.UID)
+	if err == nil {
+		return nil, util.NewServiceError(http.StatusConflict, ErrSCIMUserExists)
+	}
+
+	if !errors.Is(err, datastore.ErrSCIMUserNotFound) {
+		log.FromContext(ctx).WithError(err).Error("failed to find scim user")
+		return nil, &ServiceError{ErrMsg: "failed to find scim user", Err: err}
+	}
+
+	err = s.syncMembership(ctx, org, user, data.IsActive(), role)
+	if err != nil {
+		return nil, err
+	}
+
+	scimUser := &datastore.SCIMUser{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		UserID:         user.UID,
+		ExternalID:     data.ExternalID,
+		Active:         data.IsActive(),
+	}
+
+	err = s.SCIMUserRepo.CreateSCIMUser(ctx, scimUser)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to create scim user")
+		return nil, &ServiceError{ErrMsg: "failed to create scim user", Err: err}
+	}
+
+	return s.FindUser(ctx, org, scimUser.UID)
+}
+
+// ReplaceUser updates the user to match data, the names and email of users
+// the organisation provisioned are updated too.
+func (s *SCIMService) ReplaceUser(ctx context.Context, org *datastore.Organisation, id string, data *models.SCIMUser) (*datastore.SCIMUser, error) {
+	if err := data.Validate(); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	scimUser, err := s.FindUser(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepo.FindUserByID(ctx, scimUser.UserID)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to find user")
+		return nil, &ServiceError{ErrMsg: "failed to find user", Err: err}
+	}
+
+	if user.ProvisionedBy == org.UID {
+		err = s.updateProfile(ctx, user, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var current *auth.Role
+	member, err := s.OrgMemberRepo.FetchOrganisationMemberByUserID(ctx, user.UID, org.UID)
+	if err == nil {
+		current = &member.Role
+	}
+
+	role, err := s.role(data, current)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.syncMembership(ctx, org, user, data.IsActive(), role)
+	if err != nil {
+		return nil, err
+	}
+
+	scimUser.ExternalID = data.ExternalID
+	scimUser.Active = data.IsActive()
+
+	err = s.SCIMUserRepo.UpdateSCIMUser(ctx, scimUser)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to update scim user")
+		return nil, &ServiceError{ErrMsg: "failed to update scim user", Err: err}
+	}
+
+	return s.FindUser(ctx, org, scimUser.UID)
+}
+
+// PatchUser applies the patch to the user's current resource and replaces
+// the user with the result.
+func (s *SCIMService) PatchUser(ctx context.Context, org *datastore.Organisation, id string, patch *models.SCIMPatchOp) (*datastore.SCIMUser, error) {
+	scimUser, err := s.FindUser(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data := models.NewSCIMUser(scimUser, "")
+
+	err = patch.Apply(data)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	return s.ReplaceUser(ctx, org, id, data)
+}
+
+// DeleteUser removes the user from the organisation, the user's account
+// is kept since it may belong to other organisations.
+func (s *SCIMService) DeleteUser(ctx context.Context, org *datastore.Organisation, id string) error {
+	scimUser, err := s.FindUser(ctx, org, id)
+	if err != nil {
+		return err
+	}
+
+	err = removeOrganisationMember(ctx, s.OrgMemberRepo, s.Licenser, org, scimUser.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.SCIMUserRepo.DeleteSCIMUser(ctx, scimUser.UID, org.UID)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to delete scim user")
+		return &ServiceError{ErrMsg: "failed to delete scim user", Err: err}
+	}
+
+	return nil
+}
+
+func (s *SCIMService) FindUser(ctx context.Context, org *datastore.Organisation, id string) (*datastore.SCIMUser, error) {
+	scimUser, err := s.SCIMUserRepo.FetchSCIMUserByID(ctx, id, org.UID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrSCIMUserNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.FromContext(ctx).WithError(err).Error("failed to find scim user")
+		return nil, &ServiceError{ErrMsg: "failed to find scim user", Err: err}
+	}
+
+	return scimUser, nil
+}
+
+func (s *SCIMService) LoadUsers(ctx context.Context, org *datastore.Organisation, filter *datastore.SCIMUserFilter) ([]datastore.SCIMUser, int64, error) {
+	users, count, err := s.SCIMUserRepo.LoadSCIMUsers(ctx, org.UID, filter)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to load scim users")
+		return nil, 0, &ServiceError{ErrMsg: "failed to load scim users", Err: err}
+	}
+
+	return users, count, nil
+}
+
+// role maps the user's roles to a convoy role, users without roles keep
+// their current role or become members.
+func (s *SCIMService) role(data *models.SCIMUser, current *auth.Role) (auth.Role, error) {
+	values := data.RoleValues()
+	if len(values) == 0 {
+		if current != nil {
+			return *current, nil
+		}
+
+		return auth.Role{Type: auth.RoleMember}, nil
+	}
+
+	role, ok := s.GroupRoles.Role(values)
+	if !ok {
+		return auth.Role{}, util.NewServiceError(http.StatusBadRequest, ErrSCIMRoleNotMapped)
+	}
+
+	return role, nil
+}
+
+// checkAdoptable ensures an existing user may be provisioned into the
+// organisation, which is only the case for users it provisioned or that
+// are already its members. Anyone else's account can't be taken over by
+// creating a user with their email.
+func (s *SCIMService) checkAdoptable(ctx context.Context, org *datastore.Organisation, user *datastore.User) error {
+	if user.ProvisionedBy == org.UID {
+		return nil
+	}
+
+	_, err := s.OrgMemberRepo.FetchOrganisationMemberByUserID(ctx, user.UID, org.UID)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, datastore.ErrOrgMemberNotFound) {
+		return util.NewServiceError(http.StatusConflict, ErrSCIMEmailTaken)
+	}
+
+	log.FromContext(ctx).WithError(err).Error("failed to find organisation member")
+	return &ServiceError{ErrMsg: "failed to find organisation member", Err: err}
+}
+
+func (s *SCIMService) syncMembership(ctx context.Context, org *datastore.Organisation, user *datastore.User, active bool, role auth.Role) error {
+	if active {
+		return syncOrganisationMember(ctx, s.OrgMemberRepo, s.Licenser, org, user, role)
+	}
+
+	return removeOrganisationMember(ctx, s.OrgMemberRepo, s.Licenser, org, user.UID)
+}
+
+func (s *SCIMService) updateProfile(ctx context.Context, user *datastore.User, data *models.SCIMUser) error {
+	email := data.Email()
+	if user.FirstName == data.Name.GivenName && user.LastName == data.Name.FamilyName && user.Email == email {
+		return nil
+	}
+
+	if user.Email != email {
+		_, err := s.UserRepo.FindUserByEmail(ctx, email)
+		if err == nil {
+			return util.NewServiceError(http.StatusConflict, datastore.ErrDuplicateEmail)
+		}
+
+		if !errors.Is(err, datastore.ErrUserNotFound) {
+			log.FromContext(ctx).WithError(err).Error("failed to find user")
+			return &ServiceError{ErrMsg: "failed to find user", Err: err}
+		}
+	}
+
+	user.FirstName = data.Name.GivenName
+	user.LastName = data.Name.FamilyName
+	user.Email = email
+
+	err := s.UserRepo.UpdateUser(ctx, user)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to update user")
+		return &ServiceError{ErrMsg: "failed to update user", Err: err}
+	}
+
+	return nil
+}
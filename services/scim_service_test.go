@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func provideSCIMService(ctrl *gomock.Controller) *SCIMService {
+	return &SCIMService{
+		UserRepo:      mocks.NewMockUserRepository(ctrl),
+		OrgMemberRepo: mocks.NewMockOrganisationMemberRepository(ctrl),
+		SCIMUserRepo:  mocks.NewMockSCIMUserRepository(ctrl),
+		Licenser:      mocks.NewMockLicenser(ctrl),
+		GroupRoles: config.OIDCGroupRoles{
+			{Group: "convoy-admins", Role: auth.Role{Type: auth.RoleAdmin, Project: "project-1"}},
+		},
+	}
+}
+
+func TestSCIMService_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	org := &datastore.Organisation{UID: "org-1", OwnerID: "owner"}
+	user := &datastore.User{UID: "user-1", Email: "jane@example.com"}
+
+	tests := []struct {
+		name        string
+		data        *models.SCIMUser
+		dbFn        func(s *SCIMService)
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_provision_new_user",
+			data: &models.SCIMUser{UserName: "Jane@Example.com", ExternalID: "okta-1", Name: models.SCIMName{GivenName: "Jane", FamilyName: "Doe"}},
+			dbFn: func(s *SCIMService) {
+				u, _ := s.UserRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, created *datastore.User) error {
+					require.Equal(t, "Jane", created.FirstName)
+					require.Equal(t, string(datastore.SSOUserType), created.AuthType)
+					require.Equal(t, "org-1", created.ProvisionedBy)
+					require.True(t, created.EmailVerified)
+					created.UID = "user-1"
+					return nil
+				})
+
+				l, _ := s.Licenser.(*mocks.MockLicenser)
+				l.EXPECT().CreateUser(gomock.Any()).Times(1).Return(true, nil)
+				l.EXPECT().MultiPlayerMode().Times(1).Return(true)
+
+				su, _ := s.SCIMUserRepo.(*mocks.MockSCIMUserRepository)
+				su.EXPECT().FetchSCIMUserByUserID(gomock.Any(), "user-1", "org-1").Times(1).Return(nil, datastore.ErrSCIMUserNotFound)
+				su.EXPECT().CreateSCIMUser(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, created *datastore.SCIMUser) error {
+					require.Equal(t, "okta-1", created.ExternalID)
+					require.True(t, created.Active)
+					return nil
+				})
+				su.EXPECT().FetchSCIMUserByID(gomock.Any(), gomock.Any(), "org-1").Times(1).Return(&datastore.SCIMUser{UID: "scim-1"}, nil)
+
+				om, _ := s.OrgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).Return(nil, datastore.ErrOrgMemberNotFound)
+				om.EXPECT().CreateOrganisationMember(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, member *datastore.OrganisationMember) error {
+					require.Equal(t, auth.Role{Type: auth.RoleMember}, member.Role)
+					return nil
+				})
+			},
+		},
+		{
+			name: "should_map_roles_of_existing_user",
+			data: &models.SCIMUser{UserName: "jane@example.com", Roles: []models.SCIMAttribute{{Value: "convoy-admins"}}},
+			dbFn: func(s *SCIMService) {
+				u, _ := s.UserRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).Return(user, nil)
+
+				l, _ := s.Licenser.(*mocks.MockLicenser)
+				l.EXPECT().MultiPlayerMode().Times(1).Return(true)
+
+				om, _ := s.OrgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(2).
+					Return(&datastore.OrganisationMember{UID: "member-1", Role: auth.Role{Type: auth.RoleMember}}, nil)
+
+				su, _ := s.SCIMUserRepo.(*mocks.MockSCIMUserRepository)
+				su.EXPECT().FetchSCIMUserByUserID(gomock.Any(), "user-1", "org-1").Times(1).Return(nil, datastore.ErrSCIMUserNotFound)
+				su.EXPECT().CreateSCIMUser(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				su.EXPECT().FetchSCIMUserByID(gomock.Any(), gomock.Any(), "org-1").Times(1).Return(&datastore.SCIMUser{UID: "scim-1"}, nil)
+
+				om.EXPECT().UpdateOrganisationMember(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, member *datastore.OrganisationMember) error {
+					require.Equal(t, auth.Role{Type: auth.RoleAdmin, Project: "project-1"}, member.Role)
+					return nil
+				})
+			},
+		},
+		{
+			name: "should_error_for_user_of_another_organisation",
+			data: &models.SCIMUser{UserName: "jane@example.com"},
+			dbFn: func(s *SCIMService) {
+				u, _ := s.UserRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).
+					Return(&datastore.User{UID: "user-1", Email: "jane@example.com", ProvisionedBy: "org-2"}, nil)
+
+				om, _ := s.OrgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).Return(nil, datastore.ErrOrgMemberNotFound)
+			},
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  ErrSCIMEmailTaken.Error(),
+		},
+		{
+			name: "should_error_for_provisioned_user",
+			data: &models.SCIMUser{UserName: "jane@example.com"},
+			dbFn: func(s *SCIMService) {
+				u, _ := s.UserRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "jane@example.com").Times(1).
+					Return(&datastore.User{UID: "user-1", Email: "jane@example.com", ProvisionedBy: "org-1"}, nil)
+
+				su, _ := s.SCIMUserRepo.(*mocks.MockSCIMUserRepository)
+				su.EXPECT().FetchSCIMUserByUserID(gomock.Any(), "user-1", "org-1").Times(1).Return(&datastore.SCIMUser{UID: "scim-1"}, nil)
+			},
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  ErrSCIMUserExists.Error(),
+		},
+		{
+			name:        "should_error_for_unmapped_roles",
+			data:        &models.SCIMUser{UserName: "jane@example.com", Roles: []models.SCIMAttribute{{Value: "sales"}}},
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  ErrSCIMRoleNotMapped.Error(),
+		},
+		{
+			name:        "should_error_for_missing_user_name",
+			data:        &models.SCIMUser{},
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  models.ErrSCIMMissingUserName.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := provideSCIMService(ctrl)
+			if tt.dbFn != nil {
+				tt.dbFn(s)
+			}
+
+			got, err := s.CreateUser(ctx, org, tt.data)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tt.wantErrMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "scim-1", got.UID)
+		})
+	}
+}
+
+func TestSCIMService_PatchUser(t *testing.T) {
+	ctx := context.Background()
+	org := &datastore.Organisation{UID: "org-1", OwnerID: "owner"}
+
+	scimUser := func() *datastore.SCIMUser {
+		return &datastore.SCIMUser{
+			UID:            "scim-1",
+			OrganisationID: "org-1",
+			UserID:         "user-1",
+			Active:         true,
+			UserMetadata:   datastore.UserMetadata{UserID: "user-1", FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"},
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideSCIMService(ctrl)
+
+	su, _ := s.SCIMUserRepo.(*mocks.MockSCIMUserRepository)
+	su.EXPECT().FetchSCIMUserByID(gomock.Any(), "scim-1", "org-1").Times(3).DoAndReturn(func(context.Context, string, string) (*datastore.SCIMUser, error) {
+		return scimUser(), nil
+	})
+	su.EXPECT().UpdateSCIMUser(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, updated *datastore.SCIMUser) error {
+		require.False(t, updated.Active)
+		return nil
+	})
+
+	u, _ := s.UserRepo.(*mocks.MockUserRepository)
+	u.EXPECT().FindUserByID(gomock.Any(), "user-1").Times(1).
+		Return(&datastore.User{UID: "user-1", FirstName: "Jane", LastName: "Doe", Email: "jane@example.com", AuthType: string(datastore.SSOUserType)}, nil)
+
+	om, _ := s.OrgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+	member := &datastore.OrganisationMember{UID: "member-1", UserID: "user-1", Role: auth.Role{Type: auth.RoleMember}}
+	om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(2).Return(member, nil)
+	om.EXPECT().FetchOrganisationMemberByID(gomock.Any(), "member-1", "org-1").Times(1).Return(member, nil)
+	om.EXPECT().DeleteOrganisationMember(gomock.Any(), "member-1", "org-1").Times(1).Return(nil)
+
+	// azure sends the op capitalised and booleans as strings
+	patch := &models.SCIMPatchOp{Operations: []models.SCIMPatchOperation{
+		{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+	}}
+
+	_, err := s.PatchUser(ctx, org, "scim-1", patch)
+	require.NoError(t, err)
+}
+
+func TestSCIMService_ReplaceUser_Profile(t *testing.T) {
+	ctx := context.Background()
+	org := &datastore.Organisation{UID: "org-1", OwnerID: "owner"}
+	data := &models.SCIMUser{UserName: "janet@example.com", Name: models.SCIMName{GivenName: "Janet", FamilyName: "Doe"}}
+
+	tests := []struct {
+		name          string
+		provisionedBy string
+		wantUpdated   bool
+	}{
+		{
+			name:          "should_update_profile_of_user_provisioned_by_organisation",
+			provisionedBy: "org-1",
+			wantUpdated:   true,
+		},
+		{
+			name:          "should_not_update_profile_of_user_provisioned_by_another_organisation",
+			provisionedBy: "org-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := provideSCIMService(ctrl)
+
+			su, _ := s.SCIMUserRepo.(*mocks.MockSCIMUserRepository)
+			su.EXPECT().FetchSCIMUserByID(gomock.Any(), "scim-1", "org-1").Times(2).
+				Return(&datastore.SCIMUser{UID: "scim-1", OrganisationID: "org-1", UserID: "user-1", Active: true}, nil)
+			su.EXPECT().UpdateSCIMUser(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+			u, _ := s.UserRepo.(*mocks.MockUserRepository)
+			u.EXPECT().FindUserByID(gomock.Any(), "user-1").Times(1).Return(&datastore.User{
+				UID: "user-1", FirstName: "Jane", LastName: "Doe", Email: "jane@example.com",
+				AuthType: string(datastore.SSOUserType), ProvisionedBy: tt.provisionedBy,
+			}, nil)
+
+			if tt.wantUpdated {
+				u.EXPECT().FindUserByEmail(gomock.Any(), "janet@example.com").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, user *datastore.User) error {
+					require.Equal(t, "janet@example.com", user.Email)
+					require.Equal(t, "Janet", user.FirstName)
+					return nil
+				})
+			}
+
+			om, _ := s.OrgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+			member := &datastore.OrganisationMember{UID: "member-1", UserID: "user-1", Role: auth.Role{Type: auth.RoleMember}}
+			om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(2).Return(member, nil)
+
+			_, err := s.ReplaceUser(ctx, org, "scim-1", data)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSCIMService_DeleteUser(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideSCIMService(ctrl)
+
+	su, _ := s.SCIMUserRepo.(*mocks.MockSCIMUserRepository)
+	su.EXPECT().FetchSCIMUserByID(gomock.Any(), "scim-1", "org-1").Times(1).
+		Return(&datastore.SCIMUser{UID: "scim-1", UserID: "owner"}, nil)
+
+	// the owner's membership isn't managed by the identity provider
+	err := s.DeleteUser(ctx, &datastore.Organisation{UID: "org-1", OwnerID: "owner"}, "scim-1")
+	require.Error(t, err)
+	require.Equal(t, http.StatusForbidden, err.(*util.ServiceError).ErrCode())
+	require.ErrorIs(t, err, ErrCannotChangeOwner)
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/util"
+	"gopkg.in/guregu/null.v4"
 )
 
 type UpdateEndpointService struct {
@@ -21,6 +23,7 @@ type UpdateEndpointService struct {
 	EndpointRepo datastore.EndpointRepository
 	ProjectRepo  datastore.ProjectRepository
 	Licenser     license.Licenser
+	Queue        queue.Queuer
 
 	E        models.UpdateEndpoint
 	Endpoint *datastore.Endpoint
@@ -54,10 +57,24 @@ func (a *UpdateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 
 	}
 
+	if endpoint.AwaitingVerification() {
+		err = queueEndpointVerification(a.Queue, endpoint)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to queue endpoint verification")
+		}
+	}
+
 	return endpoint, nil
 }
 
 func (a *UpdateEndpointService) updateEndpoint(endpoint *datastore.Endpoint, e models.UpdateEndpoint, project *datastore.Project) (*datastore.Endpoint, error) {
+	wasAwaitingVerification := endpoint.AwaitingVerification() && endpoint.Status == datastore.PendingEndpointStatus
+
+	// a verification only holds for the url it was sent to
+	if endpoint.Url != e.URL {
+		endpoint.VerifiedAt = null.Time{}
+	}
+
 	endpoint.Url = e.URL
 	endpoint.Description = e.Description
 
@@ -103,6 +120,79 @@ func (a *UpdateEndpointService) updateEndpoint(endpoint *datastore.Endpoint, e m
 
 	endpoint.Authentication = auth
 
+	if e.StatusCodePolicies != nil {
+		policies := e.StatusCodePolicies.Transform()
+		if err = ValidateStatusCodePolicies(policies); err != nil {
+			return nil, err
+		}
+
+		endpoint.StatusCodePolicies = policies
+	}
+
+	if !util.IsStringEmpty(e.HttpMethod) {
+		endpoint.HttpMethod = e.HttpMethod
+	}
+
+	// a content type set for the previous body encoding is unlikely to
+	// suit the new one, so it is derived again unless it is set too
+	if !util.IsStringEmpty(e.BodyEncoding) && datastore.EndpointBodyEncoding(e.BodyEncoding) != endpoint.BodyEncoding {
+		endpoint.BodyEncoding = datastore.EndpointBodyEncoding(e.BodyEncoding)
+		endpoint.ContentType = ""
+	}
+
+	if e.ContentType != nil {
+		endpoint.ContentType = *e.ContentType
+	}
+
+	if e.OrderedDelivery != nil {
+		endpoint.OrderedDelivery = *e.OrderedDelivery
+	}
+
+	if !util.IsStringEmpty(e.OrderingKey) {
+		endpoint.OrderingKey = e.OrderingKey
+	}
+
+	if e.BatchDelivery != nil {
+		endpoint.BatchDelivery = *e.BatchDelivery
+	}
+
+	if e.BatchSize != 0 {
+		endpoint.BatchSize = e.BatchSize
+	}
+
+	if e.BatchWindow != 0 {
+		endpoint.BatchWindow = e.BatchWindow
+	}
+
+	if err := ValidateBatchDelivery(endpoint); err != nil {
+		return nil, err
+	}
+
+	if e.MtlsClientCert != nil && a.Licenser.MutualTLS() {
+		cert := e.MtlsClientCert.Transform()
+		if err := ValidateMtlsClientCert(cert); err != nil {
+			return nil, err
+		}
+
+		endpoint.MtlsClientCert = cert
+	}
+
+	if e.VerificationRequired != nil {
+		endpoint.VerificationRequired = *e.VerificationRequired
+	}
+
+	if e.HealthCheck != nil {
+		endpoint.HealthCheck = *e.HealthCheck
+	}
+
+	switch {
+	case endpoint.AwaitingVerification():
+		endpoint.Status = datastore.PendingEndpointStatus
+	case wasAwaitingVerification:
+		// verification was turned off before the endpoint was verified
+		endpoint.Status = datastore.ActiveEndpointStatus
+	}
+
 	endpoint.UpdatedAt = time.Now()
 
 	return endpoint, nil
@@ -197,3 +197,76 @@ func TestUpdateEndpointService_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateEndpointService_updateEndpoint_ContentType(t *testing.T) {
+	project := &datastore.Project{UID: "1234567890", Config: &datastore.DefaultProjectConfig}
+
+	tests := []struct {
+		name            string
+		e               models.UpdateEndpoint
+		wantContentType string
+		wantEncoding    datastore.EndpointBodyEncoding
+	}{
+		{
+			name:            "should_keep_content_type_when_not_set",
+			e:               models.UpdateEndpoint{},
+			wantContentType: "application/vnd.acme+json",
+			wantEncoding:    datastore.JSONBodyEncoding,
+		},
+		{
+			name:            "should_set_content_type",
+			e:               models.UpdateEndpoint{ContentType: stringPtr("application/json; charset=utf-8")},
+			wantContentType: "application/json; charset=utf-8",
+			wantEncoding:    datastore.JSONBodyEncoding,
+		},
+		{
+			name:            "should_clear_content_type",
+			e:               models.UpdateEndpoint{ContentType: stringPtr("")},
+			wantContentType: "",
+			wantEncoding:    datastore.JSONBodyEncoding,
+		},
+		{
+			name:            "should_clear_content_type_when_body_encoding_changes",
+			e:               models.UpdateEndpoint{BodyEncoding: "form"},
+			wantContentType: "",
+			wantEncoding:    datastore.FormBodyEncoding,
+		},
+		{
+			name:            "should_keep_content_type_when_body_encoding_is_unchanged",
+			e:               models.UpdateEndpoint{BodyEncoding: "json"},
+			wantContentType: "application/vnd.acme+json",
+			wantEncoding:    datastore.JSONBodyEncoding,
+		},
+		{
+			name:            "should_set_content_type_with_body_encoding",
+			e:               models.UpdateEndpoint{BodyEncoding: "form", ContentType: stringPtr("application/x-www-form-urlencoded; charset=utf-8")},
+			wantContentType: "application/x-www-form-urlencoded; charset=utf-8",
+			wantEncoding:    datastore.FormBodyEncoding,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tt.e.Name = stringPtr("endpoint")
+			tt.e.URL = "https://example.com"
+
+			endpoint := &datastore.Endpoint{
+				UID:          "endpoint-1",
+				Url:          "https://example.com",
+				ContentType:  "application/vnd.acme+json",
+				BodyEncoding: datastore.JSONBodyEncoding,
+			}
+
+			as := provideUpdateEndpointService(ctrl, tt.e, endpoint, project)
+			as.Licenser.(*mocks.MockLicenser).EXPECT().AdvancedEndpointMgmt().Return(true).AnyTimes()
+
+			got, err := as.updateEndpoint(endpoint, tt.e, project)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantContentType, got.ContentType)
+			require.Equal(t, tt.wantEncoding, got.BodyEncoding)
+		})
+	}
+}
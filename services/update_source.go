@@ -5,17 +5,27 @@ import (
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/changestream"
+	"github.com/frain-dev/convoy/internal/pkg/restapi"
 	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/util"
 )
 
 type UpdateSourceService struct {
 	SourceRepo   datastore.SourceRepository
+	SubRepo      datastore.SubscriptionRepository
 	Project      *datastore.Project
 	SourceUpdate *models.UpdateSource
 	Source       *datastore.Source
 }
 
 func (s *UpdateSourceService) Run(ctx context.Context) (*datastore.Source, error) {
+	var previousChangeStream *datastore.ChangeStreamConfig
+	if s.Source.Type == datastore.DBChangeStream && s.Source.ChangeStream != nil {
+		cs := *s.Source.ChangeStream
+		previousChangeStream = &cs
+	}
+
 	s.Source.Name = *s.SourceUpdate.Name
 	s.Source.Verifier = s.SourceUpdate.Verifier.Transform()
 	s.Source.Type = s.SourceUpdate.Type
@@ -36,12 +46,57 @@ func (s *UpdateSourceService) Run(ctx context.Context) (*datastore.Source, error
 		return nil, &ServiceError{ErrMsg: "Invalid verifier config for basic auth"}
 	}
 
+	if s.SourceUpdate.Verifier.Type == datastore.JWTVerifier && s.SourceUpdate.Verifier.JWT == nil {
+		return nil, &ServiceError{ErrMsg: "Invalid verifier config for jwt"}
+	}
+
 	if s.SourceUpdate.Type == datastore.PubSubSource {
 		if err := pubsub.Validate(s.SourceUpdate.PubSub.Transform()); err != nil {
 			return nil, &ServiceError{ErrMsg: err.Error()}
 		}
 	}
 
+	if s.SourceUpdate.Type == datastore.DBChangeStream {
+		changeStream := s.Source.ChangeStream
+		if s.SourceUpdate.ChangeStream != nil {
+			changeStream = s.SourceUpdate.ChangeStream.Transform()
+		}
+
+		if changeStream != nil && util.IsStringEmpty(changeStream.SlotName) {
+			changeStream.SlotName = changestream.DefaultSlotName(s.Source.UID)
+			if s.Source.ChangeStream != nil && !util.IsStringEmpty(s.Source.ChangeStream.SlotName) {
+				changeStream.SlotName = s.Source.ChangeStream.SlotName
+			}
+		}
+
+		if err := pubsub.ValidateChangeStream(changeStream); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+
+		s.Source.ChangeStream = changeStream
+	}
+
+	resetCursor := false
+	if s.SourceUpdate.Type == datastore.RestApiSource {
+		restApi := s.Source.RestApi
+		if s.SourceUpdate.RestApi != nil {
+			restApi = s.SourceUpdate.RestApi.Transform()
+		}
+
+		if err := restapi.Validate(restApi); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+
+		// a cursor from another url can't be used to page through this one
+		if s.Source.RestApi != nil && s.Source.PollState != nil && !util.IsStringEmpty(s.Source.PollState.Cursor) &&
+			(s.Source.RestApi.URL != restApi.URL || s.Source.RestApi.CursorParam != restApi.CursorParam) {
+			s.Source.PollState.Cursor = ""
+			resetCursor = true
+		}
+
+		s.Source.RestApi = restApi
+	}
+
 	if s.SourceUpdate.ForwardHeaders != nil {
 		s.Source.ForwardHeaders = s.SourceUpdate.ForwardHeaders
 	}
@@ -50,6 +105,14 @@ func (s *UpdateSourceService) Run(ctx context.Context) (*datastore.Source, error
 		s.Source.IdempotencyKeys = s.SourceUpdate.IdempotencyKeys
 	}
 
+	if s.SourceUpdate.DedupWindow != nil {
+		s.Source.DedupWindow = *s.SourceUpdate.DedupWindow
+	}
+
+	if s.SourceUpdate.Converters != nil {
+		s.Source.Converters = s.SourceUpdate.Converters
+	}
+
 	if s.SourceUpdate.PubSub != nil {
 		s.Source.PubSub = s.SourceUpdate.PubSub.Transform()
 	}
@@ -70,11 +133,53 @@ func (s *UpdateSourceService) Run(ctx context.Context) (*datastore.Source, error
 		s.Source.HeaderFunction = s.SourceUpdate.HeaderFunction
 	}
 
+	if s.SourceUpdate.Synchronous != nil {
+		// a source that fans out has no single response to relay
+		if *s.SourceUpdate.Synchronous && !s.Source.Synchronous {
+			subscriptions, err := s.SubRepo.FindSubscriptionsBySourceID(ctx, s.Project.UID, s.Source.UID)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Error("failed to find source subscriptions")
+				return nil, &ServiceError{ErrMsg: "failed to find source subscriptions", Err: err}
+			}
+
+			if len(subscriptions) > 1 {
+				return nil, &ServiceError{ErrMsg: ErrSynchronousSourceFanOut.Error()}
+			}
+		}
+
+		s.Source.Synchronous = *s.SourceUpdate.Synchronous
+	}
+
 	err := s.SourceRepo.UpdateSource(ctx, s.Project.UID, s.Source)
 	if err != nil {
 		log.FromContext(ctx).WithError(err).Error("failed to update source")
 		return nil, &ServiceError{ErrMsg: "an error occurred while updating source", Err: err}
 	}
 
+	if resetCursor {
+		err = s.SourceRepo.UpdateSourcePollState(ctx, s.Project.UID, s.Source.UID, s.Source.PollState)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to reset source poll state")
+			return nil, &ServiceError{ErrMsg: "an error occurred while updating source", Err: err}
+		}
+	}
+
+	if previousChangeStream != nil && !readsFromSlot(s.Source, previousChangeStream) {
+		err = changestream.DropSlot(ctx, previousChangeStream)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("failed to drop replication slot %s of source %s", previousChangeStream.SlotName, s.Source.UID)
+		}
+	}
+
 	return s.Source, nil
 }
+
+// readsFromSlot reports whether the source still reads from the replication
+// slot cfg describes, the slot is dropped otherwise.
+func readsFromSlot(source *datastore.Source, cfg *datastore.ChangeStreamConfig) bool {
+	if source.Type != datastore.DBChangeStream || source.ChangeStream == nil {
+		return false
+	}
+
+	return source.ChangeStream.DSN == cfg.DSN && source.ChangeStream.SlotName == cfg.SlotName
+}
@@ -21,6 +21,7 @@ func provideUpdateSourceService(ctrl *gomock.Controller, t *testing.T, sourceUpd
 
 	return &UpdateSourceService{
 		SourceRepo:   mocks.NewMockSourceRepository(ctrl),
+		SubRepo:      mocks.NewMockSubscriptionRepository(ctrl),
 		Project:      project,
 		SourceUpdate: sourceUpdate,
 		Source:       source,
@@ -125,6 +126,35 @@ func TestUpdateSourceService_Run(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "an error occurred while updating source",
 		},
+		{
+			name: "should_fail_to_make_a_source_with_many_subscriptions_synchronous",
+			args: args{
+				ctx:    ctx,
+				source: &datastore.Source{UID: "12345"},
+				update: &models.UpdateSource{
+					Name:        stringPtr("Convoy-Prod"),
+					Type:        datastore.HTTPSource,
+					Synchronous: boolPtr(true),
+					Verifier: models.VerifierConfig{
+						Type: datastore.HMacVerifier,
+						HMac: &models.HMac{
+							Encoding: datastore.Base64Encoding,
+							Header:   "X-Convoy-Header",
+							Hash:     "SHA512",
+							Secret:   "Convoy-Secret",
+						},
+					},
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			dbFn: func(so *UpdateSourceService) {
+				s, _ := so.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionsBySourceID(gomock.Any(), "12345", "12345").
+					Times(1).Return([]datastore.Subscription{{UID: "sub-1"}, {UID: "sub-2"}}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: ErrSynchronousSourceFanOut.Error(),
+		},
 	}
 
 	for _, tc := range tests {
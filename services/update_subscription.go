@@ -46,7 +46,23 @@ func (s *UpdateSubscriptionService) Run(ctx context.Context) (*datastore.Subscri
 		subscription.Name = s.Update.Name
 	}
 
-	if !util.IsStringEmpty(s.Update.SourceID) {
+	if !util.IsStringEmpty(s.Update.SourceID) && s.Update.SourceID != subscription.SourceID {
+		source, err := s.SourceRepo.FindSourceByID(ctx, s.ProjectId, s.Update.SourceID)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to find source by id")
+			return nil, &ServiceError{ErrMsg: "failed to find source by id", Err: err}
+		}
+
+		err = checkSynchronousSource(ctx, s.SubRepo, source, subscription.UID)
+		if err != nil {
+			if errors.Is(err, ErrSynchronousSourceFanOut) {
+				return nil, &ServiceError{ErrMsg: err.Error()}
+			}
+
+			log.FromContext(ctx).WithError(err).Error("failed to find source subscriptions")
+			return nil, &ServiceError{ErrMsg: "failed to find source subscriptions", Err: err}
+		}
+
 		subscription.SourceID = s.Update.SourceID
 	}
 
@@ -68,11 +68,42 @@ func TestUpdateSubscriptionService_Run(t *testing.T) {
 					Type: datastore.SubscriptionTypeAPI,
 				}, nil)
 
+				sr, _ := ss.SourceRepo.(*mocks.MockSourceRepository)
+				sr.EXPECT().FindSourceByID(gomock.Any(), "12345", "source-id-1").
+					Times(1).Return(&datastore.Source{UID: "source-id-1", ProjectID: "12345"}, nil)
+
 				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil)
 			},
 		},
+		{
+			name: "should fail to move subscription to a synchronous source with a subscription",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name:     "sub 1",
+					SourceID: "source-id-1",
+				},
+				project: &datastore.Project{
+					UID: "12345",
+				},
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{UID: "sub-uid-1"}, nil)
+
+				sr, _ := ss.SourceRepo.(*mocks.MockSourceRepository)
+				sr.EXPECT().FindSourceByID(gomock.Any(), "12345", "source-id-1").
+					Times(1).Return(&datastore.Source{UID: "source-id-1", ProjectID: "12345", Synchronous: true}, nil)
+
+				s.EXPECT().FindSubscriptionsBySourceID(gomock.Any(), "12345", "source-id-1").
+					Times(1).Return([]datastore.Subscription{{UID: "sub-uid-2"}}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: ErrSynchronousSourceFanOut.Error(),
+		},
 		{
 			name: "should fail to update subscription",
 			args: args{
@@ -91,6 +122,10 @@ func TestUpdateSubscriptionService_Run(t *testing.T) {
 				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).Return(&datastore.Subscription{}, nil)
 
+				sr, _ := ss.SourceRepo.(*mocks.MockSourceRepository)
+				sr.EXPECT().FindSourceByID(gomock.Any(), "12345", "source-id-1").
+					Times(1).Return(&datastore.Source{UID: "source-id-1", ProjectID: "12345"}, nil)
+
 				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(errors.New("failed"))
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/queue"
+)
+
+// VerifyEndpointService sends the verification challenge to an endpoint that
+// is still to be verified, e.g. after the previous attempts failed.
+type VerifyEndpointService struct {
+	EndpointRepo datastore.EndpointRepository
+	Queue        queue.Queuer
+	ProjectID    string
+	EndpointId   string
+}
+
+func (s *VerifyEndpointService) Run(ctx context.Context) (*datastore.Endpoint, error) {
+	endpoint, err := s.EndpointRepo.FindEndpointByID(ctx, s.EndpointId, s.ProjectID)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to find endpoint")
+		return nil, &ServiceError{ErrMsg: "failed to find endpoint", Err: err}
+	}
+
+	if !endpoint.AwaitingVerification() || endpoint.Status != datastore.PendingEndpointStatus {
+		return nil, &ServiceError{ErrMsg: "the endpoint is not awaiting verification"}
+	}
+
+	err = queueEndpointVerification(s.Queue, endpoint)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to queue endpoint verification")
+		return nil, &ServiceError{ErrMsg: "failed to queue endpoint verification", Err: err}
+	}
+
+	return endpoint, nil
+}
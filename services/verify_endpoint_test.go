@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func provideVerifyEndpointService(ctrl *gomock.Controller, endpointID, projectID string) *VerifyEndpointService {
+	return &VerifyEndpointService{
+		EndpointRepo: mocks.NewMockEndpointRepository(ctrl),
+		Queue:        mocks.NewMockQueuer(ctrl),
+		EndpointId:   endpointID,
+		ProjectID:    projectID,
+	}
+}
+
+func TestVerifyEndpointService_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		dbFn       func(es *VerifyEndpointService)
+		wantErrMsg string
+	}{
+		{
+			name: "should_queue_verification",
+			dbFn: func(es *VerifyEndpointService) {
+				e, _ := es.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Times(1).Return(
+					&datastore.Endpoint{UID: "123", ProjectID: "abc", Status: datastore.PendingEndpointStatus, VerificationRequired: true}, nil,
+				)
+
+				q, _ := es.Queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.EndpointVerificationProcessor, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_fail_for_verified_endpoint",
+			dbFn: func(es *VerifyEndpointService) {
+				e, _ := es.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Times(1).Return(
+					&datastore.Endpoint{UID: "123", Status: datastore.ActiveEndpointStatus, VerificationRequired: true, VerifiedAt: null.TimeFrom(time.Now())}, nil,
+				)
+			},
+			wantErrMsg: "the endpoint is not awaiting verification",
+		},
+		{
+			name: "should_fail_when_verification_is_not_required",
+			dbFn: func(es *VerifyEndpointService) {
+				e, _ := es.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Times(1).Return(
+					&datastore.Endpoint{UID: "123", Status: datastore.PendingEndpointStatus}, nil,
+				)
+			},
+			wantErrMsg: "the endpoint is not awaiting verification",
+		},
+		{
+			name: "should_fail_to_queue_verification",
+			dbFn: func(es *VerifyEndpointService) {
+				e, _ := es.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Times(1).Return(
+					&datastore.Endpoint{UID: "123", ProjectID: "abc", Status: datastore.PendingEndpointStatus, VerificationRequired: true}, nil,
+				)
+
+				q, _ := es.Queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.EndpointVerificationProcessor, convoy.DefaultQueue, gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErrMsg: "failed to queue endpoint verification",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := provideVerifyEndpointService(ctrl, "123", "abc")
+			tt.dbFn(s)
+
+			endpoint, err := s.Run(context.Background())
+			if tt.wantErrMsg != "" {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, "123", endpoint.UID)
+		})
+	}
+}
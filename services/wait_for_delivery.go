@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+const (
+	syncDeliveryPollInterval    = 100 * time.Millisecond
+	maxSyncDeliveryPollInterval = time.Second
+)
+
+var (
+	ErrSyncDeliveryTimeout = errors.New("the endpoint did not respond in time")
+
+	// ErrSynchronousSourceFanOut is returned when a synchronous source would
+	// have more than one subscription, there'd be no single response to relay.
+	ErrSynchronousSourceFanOut = errors.New("a synchronous source can only have one subscription")
+)
+
+// WaitForDeliveryService waits for the first delivery attempt of an event. The
+// attempt is made by a worker, so it is polled for until Timeout elapses, the
+// delivery carries on with the usual retries when it does. The poll interval
+// doubles up to a second, so slow endpoints don't keep the database busy.
+type WaitForDeliveryService struct {
+	EventDeliveryRepo datastore.EventDeliveryRepository
+	AttemptsRepo      datastore.DeliveryAttemptsRepository
+
+	ProjectID string
+	EventID   string
+	Timeout   time.Duration
+}
+
+func (w *WaitForDeliveryService) Run(ctx context.Context) (*datastore.EventDelivery, *datastore.DeliveryAttempt, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	interval := syncDeliveryPollInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		eventDelivery, attempt, err := w.firstAttempt(ctx)
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil, &ServiceError{ErrMsg: "failed to fetch the event delivery attempt", Err: err}
+		}
+
+		if attempt != nil {
+			return eventDelivery, attempt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, nil, ErrSyncDeliveryTimeout
+			}
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = min(2*interval, maxSyncDeliveryPollInterval)
+		timer.Reset(interval)
+	}
+}
+
+func (w *WaitForDeliveryService) firstAttempt(ctx context.Context) (*datastore.EventDelivery, *datastore.DeliveryAttempt, error) {
+	deliveries, err := w.EventDeliveryRepo.FindEventDeliveriesByEventID(ctx, w.ProjectID, w.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range deliveries {
+		attempts, err := w.AttemptsRepo.FindDeliveryAttempts(ctx, deliveries[i].UID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(attempts) > 0 {
+			return &deliveries[i], &attempts[0], nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// checkSynchronousSource returns ErrSynchronousSourceFanOut when a synchronous
+// source already has a subscription other than subscriptionID.
+func checkSynchronousSource(ctx context.Context, subRepo datastore.SubscriptionRepository, source *datastore.Source, subscriptionID string) error {
+	if !source.Synchronous {
+		return nil
+	}
+
+	subscriptions, err := subRepo.FindSubscriptionsBySourceID(ctx, source.ProjectID, source.UID)
+	if err != nil {
+		return err
+	}
+
+	for i := range subscriptions {
+		if subscriptions[i].UID != subscriptionID {
+			return ErrSynchronousSourceFanOut
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWaitForDeliveryService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		dbFn        func(ed *mocks.MockEventDeliveryRepository, da *mocks.MockDeliveryAttemptsRepository)
+		wantAttempt string
+		wantErr     error
+	}{
+		{
+			name: "should_return_first_attempt_once_made",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, da *mocks.MockDeliveryAttemptsRepository) {
+				// the event hasn't been processed on the first poll
+				ed.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "project-1", "event-1").Times(1).Return([]datastore.EventDelivery{}, nil)
+				ed.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "project-1", "event-1").Times(2).
+					Return([]datastore.EventDelivery{{UID: "delivery-1"}}, nil)
+
+				da.EXPECT().FindDeliveryAttempts(gomock.Any(), "delivery-1").Times(1).Return([]datastore.DeliveryAttempt{}, nil)
+				da.EXPECT().FindDeliveryAttempts(gomock.Any(), "delivery-1").Times(1).
+					Return([]datastore.DeliveryAttempt{{UID: "attempt-1"}, {UID: "attempt-2"}}, nil)
+			},
+			wantAttempt: "attempt-1",
+		},
+		{
+			name: "should_time_out_without_an_attempt",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, da *mocks.MockDeliveryAttemptsRepository) {
+				ed.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "project-1", "event-1").MinTimes(1).
+					Return([]datastore.EventDelivery{{UID: "delivery-1"}}, nil)
+				da.EXPECT().FindDeliveryAttempts(gomock.Any(), "delivery-1").MinTimes(1).Return([]datastore.DeliveryAttempt{}, nil)
+			},
+			wantErr: ErrSyncDeliveryTimeout,
+		},
+		{
+			name: "should_fail_to_find_event_deliveries",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, da *mocks.MockDeliveryAttemptsRepository) {
+				ed.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "project-1", "event-1").Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr: &ServiceError{ErrMsg: "failed to fetch the event delivery attempt", Err: errors.New("failed")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+			attemptsRepo := mocks.NewMockDeliveryAttemptsRepository(ctrl)
+			tt.dbFn(eventDeliveryRepo, attemptsRepo)
+
+			w := &WaitForDeliveryService{
+				EventDeliveryRepo: eventDeliveryRepo,
+				AttemptsRepo:      attemptsRepo,
+				ProjectID:         "project-1",
+				EventID:           "event-1",
+				Timeout:           time.Second,
+			}
+
+			eventDelivery, attempt, err := w.Run(ctx)
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "delivery-1", eventDelivery.UID)
+			require.Equal(t, tt.wantAttempt, attempt.UID)
+		})
+	}
+}
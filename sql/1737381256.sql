@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE convoy.project_configurations
+    ADD COLUMN IF NOT EXISTS strategy_status_code_policies JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE convoy.endpoints
+    ADD COLUMN IF NOT EXISTS status_code_policies JSONB NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+ALTER TABLE convoy.project_configurations
+    DROP COLUMN IF EXISTS strategy_status_code_policies;
+
+ALTER TABLE convoy.endpoints
+    DROP COLUMN IF EXISTS status_code_policies;
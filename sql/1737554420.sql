@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS http_method TEXT NOT NULL DEFAULT 'POST';
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS content_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS body_encoding TEXT NOT NULL DEFAULT 'json';
+
+-- +migrate Down
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS http_method;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS content_type;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS body_encoding;
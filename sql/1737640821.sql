@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.project_configurations ADD COLUMN IF NOT EXISTS signature_scheme TEXT NOT NULL DEFAULT 'convoy';
+ALTER TABLE convoy.project_configurations ADD COLUMN IF NOT EXISTS signature_asymmetric BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE convoy.project_configurations DROP COLUMN IF EXISTS signature_scheme;
+ALTER TABLE convoy.project_configurations DROP COLUMN IF EXISTS signature_asymmetric;
@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS ordered_delivery BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS ordering_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE convoy.event_deliveries ADD COLUMN IF NOT EXISTS ordering_key TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_event_deliveries_ordering ON convoy.event_deliveries (project_id, endpoint_id, ordering_key, created_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_event_deliveries_ordering;
+ALTER TABLE convoy.event_deliveries DROP COLUMN IF EXISTS ordering_key;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS ordering_key;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS ordered_delivery;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.scheduled_events (
+	id CHAR(26) PRIMARY KEY,
+
+	project_id CHAR(26) NOT NULL REFERENCES convoy.projects (id),
+	event_id TEXT NOT NULL,
+	event_type TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	payload BYTEA NOT NULL,
+	status TEXT NOT NULL,
+
+	deliver_at TIMESTAMPTZ NOT NULL,
+	dispatched_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_events_project_id_status ON convoy.scheduled_events (project_id, status) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_scheduled_events_project_id_status;
+DROP TABLE IF EXISTS convoy.scheduled_events;
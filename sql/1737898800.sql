@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS batch_delivery BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS batch_size INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS batch_window INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_event_deliveries_batch ON convoy.event_deliveries (project_id, endpoint_id, status, created_at) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_event_deliveries_batch;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS batch_window;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS batch_size;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS batch_delivery;
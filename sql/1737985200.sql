@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS mtls_client_cert JSONB;
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS mtls_client_cert_cipher BYTEA;
+
+-- +migrate Down
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS mtls_client_cert_cipher;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS mtls_client_cert;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS authentication_oauth2 JSONB;
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS authentication_oauth2_cipher BYTEA;
+
+-- +migrate Down
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS authentication_oauth2_cipher;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS authentication_oauth2;
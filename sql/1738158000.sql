@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.dead_letters (
+	id CHAR(26) PRIMARY KEY,
+
+	project_id CHAR(26) NOT NULL REFERENCES convoy.projects (id),
+	event_delivery_id CHAR(26) NOT NULL,
+	event_id CHAR(26) NOT NULL,
+	endpoint_id CHAR(26) NOT NULL,
+	event_type TEXT NOT NULL,
+	reason TEXT NOT NULL,
+
+	last_response_status TEXT,
+	last_response_body TEXT,
+	last_response_error TEXT,
+
+	redrive_count INTEGER NOT NULL DEFAULT 0,
+	dead_lettered_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	redriven_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMPTZ,
+
+	CONSTRAINT dead_letters_event_delivery_id_key UNIQUE (event_delivery_id)
+);
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_dead_letters_project_id ON convoy.dead_letters (project_id) WHERE deleted_at IS NULL;
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_dead_letters_endpoint_id ON convoy.dead_letters (project_id, endpoint_id) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_dead_letters_endpoint_id;
+DROP INDEX IF EXISTS convoy.idx_dead_letters_project_id;
+DROP TABLE IF EXISTS convoy.dead_letters;
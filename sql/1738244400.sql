@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS verification_required BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS verified_at TIMESTAMPTZ;
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS health_check BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.endpoint_probes (
+	id CHAR(26) PRIMARY KEY,
+
+	project_id CHAR(26) NOT NULL REFERENCES convoy.projects (id),
+	endpoint_id CHAR(26) NOT NULL,
+	status BOOLEAN NOT NULL,
+	status_code INTEGER NOT NULL DEFAULT 0,
+	error TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_endpoint_probes_endpoint_id ON convoy.endpoint_probes (project_id, endpoint_id, created_at);
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_endpoint_probes_created_at ON convoy.endpoint_probes (created_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_endpoint_probes_created_at;
+DROP INDEX IF EXISTS convoy.idx_endpoint_probes_endpoint_id;
+DROP TABLE IF EXISTS convoy.endpoint_probes;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS health_check;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS verified_at;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS verification_required;
@@ -0,0 +1,10 @@
+-- +migrate Up
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS change_stream JSONB;
+
+-- +migrate Up
+-- the change stream with its dsn encrypted, once credential encryption is initialised
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS change_stream_cipher bytea;
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.sources DROP COLUMN IF EXISTS change_stream_cipher;
+ALTER TABLE IF EXISTS convoy.sources DROP COLUMN IF EXISTS change_stream;
@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS jwt_header TEXT;
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS jwt_jwks_url TEXT;
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS jwt_public_keys TEXT;
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS jwt_issuer TEXT;
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS jwt_audience TEXT;
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.source_verifiers DROP COLUMN IF EXISTS jwt_header;
+ALTER TABLE IF EXISTS convoy.source_verifiers DROP COLUMN IF EXISTS jwt_jwks_url;
+ALTER TABLE IF EXISTS convoy.source_verifiers DROP COLUMN IF EXISTS jwt_public_keys;
+ALTER TABLE IF EXISTS convoy.source_verifiers DROP COLUMN IF EXISTS jwt_issuer;
+ALTER TABLE IF EXISTS convoy.source_verifiers DROP COLUMN IF EXISTS jwt_audience;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS hmac_timestamp_header TEXT;
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS hmac_tolerance INTEGER;
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.source_verifiers DROP COLUMN IF EXISTS hmac_timestamp_header;
+ALTER TABLE IF EXISTS convoy.source_verifiers DROP COLUMN IF EXISTS hmac_tolerance;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS synchronous BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.sources DROP COLUMN IF EXISTS synchronous;
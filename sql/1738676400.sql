@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS rest_api JSONB;
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS poll_state JSONB;
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.sources DROP COLUMN IF EXISTS rest_api;
+ALTER TABLE IF EXISTS convoy.sources DROP COLUMN IF EXISTS poll_state;
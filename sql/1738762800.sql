@@ -0,0 +1,23 @@
+-- +migrate Up
+ALTER TABLE convoy.event_types ADD COLUMN IF NOT EXISTS json_schema JSONB;
+ALTER TABLE convoy.event_types ADD COLUMN IF NOT EXISTS schema_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE convoy.event_types ADD COLUMN IF NOT EXISTS schema_validation TEXT NOT NULL DEFAULT 'reject';
+
+CREATE TABLE IF NOT EXISTS convoy.event_type_schemas (
+    id            VARCHAR PRIMARY KEY,
+    event_type_id VARCHAR NOT NULL REFERENCES convoy.event_types (id) ON DELETE CASCADE,
+    project_id    VARCHAR NOT NULL REFERENCES convoy.projects (id),
+    version       INTEGER NOT NULL,
+    json_schema   JSONB NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event_type_id, version)
+);
+
+ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS schema_status TEXT;
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.events DROP COLUMN IF EXISTS schema_status;
+DROP TABLE IF EXISTS convoy.event_type_schemas;
+ALTER TABLE IF EXISTS convoy.event_types DROP COLUMN IF EXISTS schema_validation;
+ALTER TABLE IF EXISTS convoy.event_types DROP COLUMN IF EXISTS schema_version;
+ALTER TABLE IF EXISTS convoy.event_types DROP COLUMN IF EXISTS json_schema;
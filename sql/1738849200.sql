@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS content_type TEXT;
+ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS original_raw TEXT;
+ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS raw_encoding TEXT;
+
+-- sources without converters convert form bodies
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS converters TEXT[];
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.sources DROP COLUMN IF EXISTS converters;
+ALTER TABLE IF EXISTS convoy.events DROP COLUMN IF EXISTS raw_encoding;
+ALTER TABLE IF EXISTS convoy.events DROP COLUMN IF EXISTS original_raw;
+ALTER TABLE IF EXISTS convoy.events DROP COLUMN IF EXISTS content_type;
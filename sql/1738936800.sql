@@ -0,0 +1,6 @@
+-- +migrate Up
+-- sources without a dedup window compare idempotency keys with every earlier event
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS dedup_window TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE IF EXISTS convoy.sources DROP COLUMN IF EXISTS dedup_window;
@@ -0,0 +1,90 @@
+-- +migrate Up
+-- data keys of the envelope key managers, wrapped by the provider's master key
+CREATE TABLE IF NOT EXISTS convoy.encryption_keys (
+    id CHAR(26) PRIMARY KEY,
+    provider TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    wrapped_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT encryption_keys_provider_version UNIQUE (provider, version)
+);
+
+ALTER TABLE convoy.source_verifiers
+    ADD COLUMN IF NOT EXISTS is_encrypted BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS basic_password_cipher bytea,
+    ADD COLUMN IF NOT EXISTS api_key_header_value_cipher bytea,
+    ADD COLUMN IF NOT EXISTS hmac_secret_cipher bytea;
+
+ALTER TABLE convoy.sources
+    ADD COLUMN IF NOT EXISTS is_encrypted BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS pub_sub_cipher bytea,
+    ADD COLUMN IF NOT EXISTS rest_api_cipher bytea;
+
+ALTER TABLE convoy.project_configurations
+    ADD COLUMN IF NOT EXISTS is_encrypted BOOLEAN DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS meta_events_secret_cipher bytea,
+    ADD COLUMN IF NOT EXISTS meta_events_pub_sub_cipher bytea;
+
+CREATE INDEX IF NOT EXISTS idx_source_verifiers_is_encrypted ON convoy.source_verifiers (is_encrypted);
+CREATE INDEX IF NOT EXISTS idx_sources_is_encrypted ON convoy.sources (is_encrypted);
+CREATE INDEX IF NOT EXISTS idx_project_configurations_is_encrypted ON convoy.project_configurations (is_encrypted);
+
+-- +migrate StatementBegin
+-- every value is encrypted with its own random row key, which is stored with
+-- it wrapped by key: a zero byte, the length of the wrapped row key as four
+-- bytes, the wrapped row key then the encrypted value. pgp messages never
+-- start with a zero byte, so values encrypted with key itself still decrypt.
+CREATE OR REPLACE FUNCTION convoy.envelope_encrypt(data TEXT, key TEXT) RETURNS BYTEA
+AS $$
+DECLARE
+    row_key TEXT := encode(gen_random_bytes(32), 'base64');
+    wrapped BYTEA := pgp_sym_encrypt(row_key, key);
+BEGIN
+    RETURN '\x00'::bytea || int4send(octet_length(wrapped)) || wrapped || pgp_sym_encrypt(data, row_key);
+END;
+$$ LANGUAGE plpgsql STRICT;
+
+CREATE OR REPLACE FUNCTION convoy.envelope_decrypt(data BYTEA, key TEXT) RETURNS TEXT
+AS $$
+DECLARE
+    wrapped_length INTEGER;
+BEGIN
+    IF get_byte(data, 0) <> 0 THEN
+        RETURN pgp_sym_decrypt(data, key);
+    END IF;
+
+    wrapped_length := (get_byte(data, 1) << 24) | (get_byte(data, 2) << 16) | (get_byte(data, 3) << 8) | get_byte(data, 4);
+    RETURN pgp_sym_decrypt(
+        substring(data FROM 6 + wrapped_length),
+        pgp_sym_decrypt(substring(data FROM 6 FOR wrapped_length), key)
+    );
+END;
+$$ LANGUAGE plpgsql STRICT;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP FUNCTION IF EXISTS convoy.envelope_decrypt(BYTEA, TEXT);
+DROP FUNCTION IF EXISTS convoy.envelope_encrypt(TEXT, TEXT);
+
+DROP INDEX IF EXISTS convoy.idx_source_verifiers_is_encrypted;
+DROP INDEX IF EXISTS convoy.idx_sources_is_encrypted;
+DROP INDEX IF EXISTS convoy.idx_project_configurations_is_encrypted;
+
+ALTER TABLE IF EXISTS convoy.project_configurations
+    DROP COLUMN IF EXISTS is_encrypted,
+    DROP COLUMN IF EXISTS meta_events_secret_cipher,
+    DROP COLUMN IF EXISTS meta_events_pub_sub_cipher;
+
+ALTER TABLE IF EXISTS convoy.sources
+    DROP COLUMN IF EXISTS is_encrypted,
+    DROP COLUMN IF EXISTS pub_sub_cipher,
+    DROP COLUMN IF EXISTS rest_api_cipher;
+
+ALTER TABLE IF EXISTS convoy.source_verifiers
+    DROP COLUMN IF EXISTS is_encrypted,
+    DROP COLUMN IF EXISTS basic_password_cipher,
+    DROP COLUMN IF EXISTS api_key_header_value_cipher,
+    DROP COLUMN IF EXISTS hmac_secret_cipher;
+
+DROP TABLE IF EXISTS convoy.encryption_keys;
@@ -0,0 +1,86 @@
+-- +migrate Up
+-- instance wide jobs like key rotations don't belong to a project
+ALTER TABLE convoy.jobs ALTER COLUMN project_id DROP NOT NULL;
+ALTER TABLE convoy.jobs ADD COLUMN IF NOT EXISTS progress JSONB;
+
+-- the version of the key the credentials in a row were encrypted with, rows
+-- encrypted before versions were recorded have none.
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS key_version TEXT;
+ALTER TABLE convoy.source_verifiers ADD COLUMN IF NOT EXISTS key_version TEXT;
+ALTER TABLE convoy.sources ADD COLUMN IF NOT EXISTS key_version TEXT;
+ALTER TABLE convoy.project_configurations ADD COLUMN IF NOT EXISTS key_version TEXT;
+
+-- keys are identified by a random version, a key's version is found by
+-- decrypting key_check, which is the version encrypted with the key.
+CREATE TABLE IF NOT EXISTS convoy.key_versions (
+    id TEXT PRIMARY KEY,
+    key_check BYTEA NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION convoy.find_key_version(key TEXT) RETURNS TEXT
+AS $$
+DECLARE
+    v RECORD;
+BEGIN
+    FOR v IN SELECT id, key_check FROM convoy.key_versions ORDER BY id LOOP
+        BEGIN
+            IF pgp_sym_decrypt(v.key_check, key) = v.id THEN
+                RETURN v.id;
+            END IF;
+        EXCEPTION WHEN OTHERS THEN
+            -- the check was encrypted with another key
+            NULL;
+        END;
+    END LOOP;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql STRICT;
+
+-- a keyring is the JSON object {"version": "...", "legacy": "...", "keys": {"<version>": "<key>"}},
+-- it holds the previous key as well as the current one while a key is rotated.
+CREATE OR REPLACE FUNCTION convoy.keyring_key(keyring TEXT, version TEXT) RETURNS TEXT
+AS $$
+    SELECT CASE
+        WHEN COALESCE(keyring, '') = '' THEN ''
+        ELSE COALESCE(keyring::jsonb -> 'keys' ->> COALESCE(NULLIF(version, ''), keyring::jsonb ->> 'legacy'), '')
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION convoy.keyring_version(keyring TEXT) RETURNS TEXT
+AS $$
+    SELECT CASE
+        WHEN COALESCE(keyring, '') = '' THEN NULL
+        ELSE keyring::jsonb ->> 'version'
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION convoy.keyring_encrypt(data TEXT, version TEXT, keyring TEXT) RETURNS BYTEA
+AS $$
+    SELECT convoy.envelope_encrypt(data, convoy.keyring_key(keyring, version));
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION convoy.keyring_decrypt(data BYTEA, version TEXT, keyring TEXT) RETURNS TEXT
+AS $$
+    SELECT convoy.envelope_decrypt(data, convoy.keyring_key(keyring, version));
+$$ LANGUAGE sql;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP FUNCTION IF EXISTS convoy.keyring_decrypt(BYTEA, TEXT, TEXT);
+DROP FUNCTION IF EXISTS convoy.keyring_encrypt(TEXT, TEXT, TEXT);
+DROP FUNCTION IF EXISTS convoy.keyring_version(TEXT);
+DROP FUNCTION IF EXISTS convoy.keyring_key(TEXT, TEXT);
+DROP FUNCTION IF EXISTS convoy.find_key_version(TEXT);
+DROP TABLE IF EXISTS convoy.key_versions;
+
+ALTER TABLE convoy.project_configurations DROP COLUMN IF EXISTS key_version;
+ALTER TABLE convoy.sources DROP COLUMN IF EXISTS key_version;
+ALTER TABLE convoy.source_verifiers DROP COLUMN IF EXISTS key_version;
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS key_version;
+
+ALTER TABLE convoy.jobs DROP COLUMN IF EXISTS progress;
+DELETE FROM convoy.jobs WHERE project_id IS NULL;
+ALTER TABLE convoy.jobs ALTER COLUMN project_id SET NOT NULL;
@@ -0,0 +1,39 @@
+-- +migrate Up
+-- users provisioned into an organisation by its identity provider over SCIM
+CREATE TABLE IF NOT EXISTS convoy.scim_users (
+    id CHAR(26) PRIMARY KEY,
+    organisation_id CHAR(26) NOT NULL REFERENCES convoy.organisations (id),
+    user_id CHAR(26) NOT NULL REFERENCES convoy.users (id),
+    external_id TEXT,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scim_users_organisation_id_user_id
+    ON convoy.scim_users (organisation_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_scim_users_organisation_id_external_id
+    ON convoy.scim_users (organisation_id, external_id) WHERE deleted_at IS NULL;
+
+-- the organisation whose identity provider provisioned a user, and the
+-- account at the OIDC identity provider the user signs in with
+ALTER TABLE convoy.users
+    ADD COLUMN IF NOT EXISTS provisioned_by TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS oidc_issuer TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS oidc_subject TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_oidc_issuer_oidc_subject
+    ON convoy.users (oidc_issuer, oidc_subject) WHERE oidc_subject <> '' AND deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_users_oidc_issuer_oidc_subject;
+ALTER TABLE IF EXISTS convoy.users
+    DROP COLUMN IF EXISTS provisioned_by,
+    DROP COLUMN IF EXISTS oidc_issuer,
+    DROP COLUMN IF EXISTS oidc_subject;
+
+DROP INDEX IF EXISTS convoy.idx_scim_users_organisation_id_external_id;
+DROP INDEX IF EXISTS convoy.idx_scim_users_organisation_id_user_id;
+DROP TABLE IF EXISTS convoy.scim_users;
@@ -0,0 +1,10 @@
+-- +migrate Up
+-- permissions narrowing what a role grants, required for custom roles
+ALTER TABLE convoy.organisation_members ADD COLUMN IF NOT EXISTS role_permissions JSONB;
+ALTER TABLE convoy.organisation_invites ADD COLUMN IF NOT EXISTS role_permissions JSONB;
+ALTER TABLE convoy.api_keys ADD COLUMN IF NOT EXISTS role_permissions JSONB;
+
+-- +migrate Down
+ALTER TABLE convoy.api_keys DROP COLUMN IF EXISTS role_permissions;
+ALTER TABLE convoy.organisation_invites DROP COLUMN IF EXISTS role_permissions;
+ALTER TABLE convoy.organisation_members DROP COLUMN IF EXISTS role_permissions;
@@ -0,0 +1,47 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.audit_logs (
+	id CHAR(26) PRIMARY KEY,
+
+	organisation_id CHAR(26) NOT NULL REFERENCES convoy.organisations (id),
+	project_id CHAR(26),
+
+	actor_type TEXT NOT NULL,
+	actor_id TEXT NOT NULL,
+	actor_name TEXT NOT NULL DEFAULT '',
+
+	action TEXT NOT NULL,
+	resource_type TEXT NOT NULL,
+	resource_id TEXT NOT NULL,
+	changes JSONB NOT NULL DEFAULT '{}',
+
+	ip_address TEXT NOT NULL DEFAULT '',
+	user_agent TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_audit_logs_organisation_id ON convoy.audit_logs (organisation_id, id);
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_audit_logs_project_id ON convoy.audit_logs (project_id, id) WHERE project_id IS NOT NULL;
+
+-- +migrate Up
+-- +migrate StatementBegin
+-- audit logs are evidence, once written they can't be changed or removed
+CREATE OR REPLACE FUNCTION convoy.prevent_audit_log_changes() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit logs are append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- +migrate Up
+CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON convoy.audit_logs
+FOR EACH ROW EXECUTE FUNCTION convoy.prevent_audit_log_changes();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS audit_logs_append_only ON convoy.audit_logs;
+DROP FUNCTION IF EXISTS convoy.prevent_audit_log_changes();
+DROP INDEX IF EXISTS convoy.idx_audit_logs_project_id;
+DROP INDEX IF EXISTS convoy.idx_audit_logs_organisation_id;
+DROP TABLE IF EXISTS convoy.audit_logs;
@@ -99,8 +99,18 @@ const (
 	ExpireSecretsProcessor           TaskName = "ExpireSecretsProcessor"
 	DeleteArchivedTasksProcessor     TaskName = "DeleteArchivedTasksProcessor"
 	MatchEventSubscriptionsProcessor TaskName = "MatchEventSubscriptionsProcessor"
-
-	TokenCacheKey CacheKey = "tokens"
+	ScheduledEventProcessor          TaskName = "ScheduledEventProcessor"
+	DeadLetterProcessor              TaskName = "DeadLetterProcessor"
+	EndpointVerificationProcessor    TaskName = "EndpointVerificationProcessor"
+	EndpointProbeProcessor           TaskName = "EndpointProbeProcessor"
+	ProbeEndpoints                   TaskName = "ProbeEndpoints"
+	RestApiSourcePollProcessor       TaskName = "RestApiSourcePollProcessor"
+	PollRestApiSources               TaskName = "PollRestApiSources"
+	KeyRotationProcessor             TaskName = "KeyRotationProcessor"
+
+	TokenCacheKey  CacheKey = "tokens"
+	ReplayCacheKey CacheKey = "replay"
+	DedupCacheKey  CacheKey = "dedup"
 )
 
 // queues
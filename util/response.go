@@ -45,6 +45,10 @@ func (s *ServiceError) ErrCode() int {
 	return s.errCode
 }
 
+func (s *ServiceError) Unwrap() error {
+	return s.errMsg
+}
+
 func NewServiceErrResponse(err error) ServerResponse {
 	msg := ""
 	statusCode := http.StatusBadRequest
@@ -47,6 +47,7 @@ func init() {
 			string(datastore.HMacVerifier):      true,
 			string(datastore.BasicAuthVerifier): true,
 			string(datastore.APIKeyVerifier):    true,
+			string(datastore.JWTVerifier):       true,
 		}
 
 		if _, ok := verifiers[verifier]; !ok {
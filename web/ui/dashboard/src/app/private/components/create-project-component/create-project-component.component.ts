@@ -103,7 +103,7 @@ export class CreateProjectComponent implements OnInit {
 		{ label: 'secrets', svg: 'stroke', icon: 'secret' }
 	];
 	activeTab = this.tabs[0];
-	events = ['endpoint.created', 'endpoint.deleted', 'endpoint.updated', 'eventdelivery.success', 'eventdelivery.failed', 'project.updated'];
+	events = ['endpoint.created', 'endpoint.deleted', 'endpoint.updated', 'eventdelivery.success', 'eventdelivery.failed', 'project.updated', 'audit_log.created'];
 	eventTypes: EVENT_TYPE[] = [];
 	selectedEventType!: EVENT_TYPE;
 
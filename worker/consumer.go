@@ -48,6 +48,18 @@ func NewConsumer(ctx context.Context, consumerPoolSize int, q queue.Queuer, lo l
 					return false
 				}
 
+				if _, ok := err.(*task.OrderingError); ok {
+					return false
+				}
+
+				if _, ok := err.(*task.BatchError); ok {
+					return false
+				}
+
+				if _, ok := err.(*task.VerificationError); ok {
+					return false
+				}
+
 				return true
 			},
 			RetryDelayFunc: task.GetRetryDelay,
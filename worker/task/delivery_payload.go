@@ -0,0 +1,128 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+var ErrUnsupportedFormPayload = errors.New("form body encoding requires a json object or string payload")
+
+// cloudEvent is a structured mode CloudEvent, see
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// encodeDeliveryPayload writes the event delivery payload using the endpoint's
+// body encoding. The boolean return value reports whether the encoded
+// payload is JSON, non-JSON payloads are signed as is.
+func encodeDeliveryPayload(endpoint *datastore.Endpoint, eventDelivery *datastore.EventDelivery) (json.RawMessage, bool, error) {
+	payload := json.RawMessage(eventDelivery.Metadata.Raw)
+
+	switch endpoint.BodyEncoding {
+	case datastore.OriginalBodyEncoding:
+		original := eventDelivery.Metadata.OriginalBody
+		if original == nil {
+			// the event wasn't ingested from a request
+			return payload, true, nil
+		}
+
+		body, err := original.Bytes()
+		return body, false, err
+	case datastore.FormBodyEncoding:
+		body, err := encodeFormPayload(payload)
+		return body, false, err
+	case datastore.RawBodyEncoding:
+		var s string
+		if err := json.Unmarshal(payload, &s); err == nil {
+			return []byte(s), false, nil
+		}
+
+		return payload, false, nil
+	case datastore.CloudEventsBodyEncoding:
+		createdAt := eventDelivery.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		body, err := json.Marshal(cloudEvent{
+			SpecVersion:     "1.0",
+			ID:              eventDelivery.EventID,
+			Source:          fmt.Sprintf("/projects/%s/endpoints/%s", eventDelivery.ProjectID, endpoint.UID),
+			Type:            string(eventDelivery.EventType),
+			Time:            createdAt.UTC().Format(time.RFC3339),
+			DataContentType: "application/json",
+			Data:            payload,
+		})
+		return body, true, err
+	default:
+		return payload, true, nil
+	}
+}
+
+// deliveryContentType returns the content type of the event delivery, the
+// original body encoding sends the content type the event was ingested with.
+func deliveryContentType(endpoint *datastore.Endpoint, eventDelivery *datastore.EventDelivery) string {
+	original := eventDelivery.Metadata.OriginalBody
+	if endpoint.BodyEncoding == datastore.OriginalBodyEncoding && original != nil && util.IsStringEmpty(endpoint.ContentType) {
+		return original.ContentType
+	}
+
+	return endpoint.DeliveryContentType()
+}
+
+// failUnencodableDelivery marks an event delivery whose payload can't be encoded
+// using its endpoint's body encoding as failed, retrying it would fail the same way.
+func failUnencodableDelivery(ctx context.Context, eventDeliveryRepo datastore.EventDeliveryRepository, eventDelivery *datastore.EventDelivery, err error) error {
+	eventDelivery.Status = datastore.FailureEventStatus
+	eventDelivery.Description = fmt.Sprintf("Event delivery payload cannot be encoded for the endpoint: %s", err)
+
+	return eventDeliveryRepo.UpdateEventDeliveryMetadata(ctx, eventDelivery.ProjectID, eventDelivery)
+}
+
+// encodeFormPayload writes the top level fields of a json object as form
+// values, nested objects and arrays are written as json. Json strings are
+// assumed to be form encoded already, e.g. by a subscription function.
+func encodeFormPayload(payload json.RawMessage) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(payload, &s); err == nil {
+		return []byte(s), nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, ErrUnsupportedFormPayload
+	}
+
+	values := url.Values{}
+	for k, v := range fields {
+		switch {
+		case bytes.Equal(v, []byte("null")):
+			values.Set(k, "")
+		case json.Unmarshal(v, &s) == nil:
+			values.Set(k, s)
+		default:
+			buf := &bytes.Buffer{}
+			if err := json.Compact(buf, v); err != nil {
+				return nil, err
+			}
+			values.Set(k, buf.String())
+		}
+	}
+
+	return []byte(values.Encode()), nil
+}
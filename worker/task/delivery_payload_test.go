@@ -0,0 +1,128 @@
+package task
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestEncodeDeliveryPayload(t *testing.T) {
+	createdAt := time.Date(2025, 1, 22, 10, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name     string
+		encoding datastore.EndpointBodyEncoding
+		raw      string
+		original *datastore.OriginalBody
+		want     string
+		isJSON   bool
+		wantErr  error
+	}{
+		{
+			name:   "should_send_json_payload_as_is",
+			raw:    `{"name": "convoy"}`,
+			want:   `{"name": "convoy"}`,
+			isJSON: true,
+		},
+		{
+			name:     "should_form_encode_object_payload",
+			encoding: datastore.FormBodyEncoding,
+			raw:      `{"name": "convoy", "count": 2, "active": true, "tags": ["a", "b"], "owner": null}`,
+			want:     "active=true&count=2&name=convoy&owner=&tags=%5B%22a%22%2C%22b%22%5D",
+		},
+		{
+			name:     "should_send_form_encoded_string_payload_as_is",
+			encoding: datastore.FormBodyEncoding,
+			raw:      `"name=convoy&count=2"`,
+			want:     "name=convoy&count=2",
+		},
+		{
+			name:     "should_fail_to_form_encode_array_payload",
+			encoding: datastore.FormBodyEncoding,
+			raw:      `["convoy"]`,
+			wantErr:  ErrUnsupportedFormPayload,
+		},
+		{
+			name:     "should_unquote_raw_string_payload",
+			encoding: datastore.RawBodyEncoding,
+			raw:      `"<event><name>convoy</name></event>"`,
+			want:     "<event><name>convoy</name></event>",
+		},
+		{
+			name:     "should_send_raw_object_payload_as_is",
+			encoding: datastore.RawBodyEncoding,
+			raw:      `{"name": "convoy"}`,
+			want:     `{"name": "convoy"}`,
+		},
+		{
+			name:     "should_wrap_payload_in_cloud_event",
+			encoding: datastore.CloudEventsBodyEncoding,
+			raw:      `{"name": "convoy"}`,
+			want: `{"specversion":"1.0","id":"event-1","source":"/projects/project-1/endpoints/endpoint-1",` +
+				`"type":"user.created","time":"2025-01-22T10:00:00Z","datacontenttype":"application/json","data":{"name":"convoy"}}`,
+			isJSON: true,
+		},
+		{
+			name:     "should_send_original_body",
+			encoding: datastore.OriginalBodyEncoding,
+			raw:      `{"order":{"id":"1"}}`,
+			original: datastore.NewOriginalBody("text/xml", []byte(`<order><id>1</id></order>`)),
+			want:     `<order><id>1</id></order>`,
+		},
+		{
+			name:     "should_send_binary_original_body",
+			encoding: datastore.OriginalBodyEncoding,
+			raw:      `"/wAB"`,
+			original: datastore.NewOriginalBody("application/octet-stream", []byte{0xff, 0x00, 0x01}),
+			want:     string([]byte{0xff, 0x00, 0x01}),
+		},
+		{
+			name:     "should_send_json_payload_without_original_body",
+			encoding: datastore.OriginalBodyEncoding,
+			raw:      `{"name": "convoy"}`,
+			want:     `{"name": "convoy"}`,
+			isJSON:   true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := &datastore.Endpoint{UID: "endpoint-1", BodyEncoding: tc.encoding}
+			eventDelivery := &datastore.EventDelivery{
+				EventID:   "event-1",
+				ProjectID: "project-1",
+				EventType: "user.created",
+				Metadata:  &datastore.Metadata{Raw: tc.raw, Data: json.RawMessage(tc.raw), OriginalBody: tc.original},
+				CreatedAt: createdAt,
+			}
+
+			payload, isJSON, err := encodeDeliveryPayload(endpoint, eventDelivery)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(payload))
+			require.Equal(t, tc.isJSON, isJSON)
+		})
+	}
+}
+
+func TestDeliveryContentType(t *testing.T) {
+	original := datastore.NewOriginalBody("application/soap+xml; charset=utf-8", []byte("<a/>"))
+
+	endpoint := &datastore.Endpoint{BodyEncoding: datastore.OriginalBodyEncoding}
+	eventDelivery := &datastore.EventDelivery{Metadata: &datastore.Metadata{OriginalBody: original}}
+	require.Equal(t, "application/soap+xml; charset=utf-8", deliveryContentType(endpoint, eventDelivery))
+
+	endpoint.ContentType = "text/xml"
+	require.Equal(t, "text/xml", deliveryContentType(endpoint, eventDelivery))
+
+	endpoint = &datastore.Endpoint{BodyEncoding: datastore.OriginalBodyEncoding}
+	require.Equal(t, "application/json", deliveryContentType(endpoint, &datastore.EventDelivery{Metadata: &datastore.Metadata{}}))
+}
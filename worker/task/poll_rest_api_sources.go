@@ -0,0 +1,236 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/restapi"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	// maxPollPages is the number of pages fetched in a poll, the
+	// remaining pages are fetched in the next poll.
+	maxPollPages = 10
+
+	// pollIntervalSlack makes up for the delay between the scheduler
+	// running and the previous poll completing.
+	pollIntervalSlack = 10 * time.Second
+)
+
+// PollRestApiSources queues a poll for every rest_api source whose poll
+// interval has elapsed.
+func PollRestApiSources(sourceRepo datastore.SourceRepository, q queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		sources, err := sourceRepo.LoadRestApiSources(ctx)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to load rest api sources")
+			return err
+		}
+
+		now := time.Now()
+		for i := range sources {
+			if !isPollDue(&sources[i], now) {
+				continue
+			}
+
+			payload, err := msgpack.EncodeMsgPack(RestApiSourcePoll{
+				SourceID:  sources[i].UID,
+				ProjectID: sources[i].ProjectID,
+			})
+			if err != nil {
+				return err
+			}
+
+			job := &queue.Job{
+				ID:      fmt.Sprintf("rest-api-poll:%s", sources[i].UID),
+				Payload: payload,
+			}
+
+			err = q.Write(convoy.RestApiSourcePollProcessor, convoy.DefaultQueue, job)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Errorf("failed to queue poll for source %s", sources[i].UID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func isPollDue(source *datastore.Source, now time.Time) bool {
+	if source.PollState == nil || source.PollState.PolledAt.IsZero() {
+		return true
+	}
+
+	interval := restapi.DefaultPollInterval
+	if source.RestApi != nil && source.RestApi.PollInterval > 0 {
+		interval = time.Duration(source.RestApi.PollInterval) * time.Second
+	}
+
+	return !source.PollState.PolledAt.Add(interval - pollIntervalSlack).After(now)
+}
+
+// ProcessRestApiSourcePoll fetches the pages of a rest_api source from where
+// the last poll left off, and creates an event from every item that hasn't
+// been seen. Failed polls aren't retried, the error is saved in the poll
+// state and the source is polled again on its next interval.
+func ProcessRestApiSourcePoll(sourceRepo datastore.SourceRepository, q queue.Queuer, dispatch *net.Dispatcher) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data RestApiSourcePoll
+
+		err := msgpack.DecodeMsgPack(t.Payload(), &data)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		source, err := sourceRepo.FindSourceByID(ctx, data.ProjectID, data.SourceID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrSourceNotFound) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		if source.Type != datastore.RestApiSource || source.IsDisabled {
+			return nil
+		}
+
+		state := &datastore.PollState{}
+		if source.PollState != nil {
+			state = source.PollState
+		}
+
+		state.Error = ""
+		err = pollRestApiSource(ctx, source, state, q, dispatch)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("failed to poll source %s", source.UID)
+			state.Error = err.Error()
+		}
+
+		state.PolledAt = time.Now()
+		err = sourceRepo.UpdateSourcePollState(ctx, source.ProjectID, source.UID, state)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		return nil
+	}
+}
+
+func pollRestApiSource(ctx context.Context, source *datastore.Source, state *datastore.PollState, q queue.Queuer, dispatch *net.Dispatcher) error {
+	poller, err := restapi.NewPoller(source.RestApi)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		return err
+	}
+
+	ctx = withRestApiCredentials(ctx, source.RestApi)
+
+	polled := map[string]bool{}
+	defer state.Forget(polled)
+
+	for page := 0; page < maxPollPages; page++ {
+		url, err := poller.URL(state.Cursor)
+		if err != nil {
+			return err
+		}
+
+		resp, err := dispatch.Fetch(ctx, url, poller.Headers(), int64(cfg.MaxResponseSize), convoy.HTTP_TIMEOUT_IN_DURATION)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("%s responded with %s", url, resp.Status)
+		}
+
+		p, err := poller.Parse(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range p.Items {
+			polled[item.ID] = true
+			if state.HasSeen(item.ID) {
+				continue
+			}
+
+			err = queueRestApiEvent(source, poller.EventType(), item, q)
+			if err != nil {
+				return err
+			}
+
+			state.MarkSeen(item.ID)
+		}
+
+		// the cursor only moves once every item on the page is queued
+		if p.Cursor == "" || p.Cursor == state.Cursor {
+			return nil
+		}
+
+		state.Cursor = p.Cursor
+		if len(p.Items) == 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func queueRestApiEvent(source *datastore.Source, eventType string, item restapi.Item, q queue.Queuer) error {
+	event := &datastore.Event{
+		UID:            ulid.Make().String(),
+		EventType:      datastore.EventType(eventType),
+		SourceID:       source.UID,
+		ProjectID:      source.ProjectID,
+		Raw:            string(item.Data),
+		Data:           item.Data,
+		IdempotencyKey: fmt.Sprintf("%s:%s", source.UID, item.ID),
+		Headers:        httpheader.HTTPHeader{"X-Convoy-Source-Id": []string{source.MaskID}},
+		AcknowledgedAt: null.TimeFrom(time.Now()),
+	}
+
+	payload, err := msgpack.EncodeMsgPack(CreateEvent{Event: event})
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      fmt.Sprintf("single:%s:%s", source.ProjectID, event.UID),
+		Payload: payload,
+	}
+
+	return q.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
+}
+
+func withRestApiCredentials(ctx context.Context, cfg *datastore.RestApiConfig) context.Context {
+	auth := cfg.Authentication
+	if auth != nil && auth.Type == datastore.OAuth2Authentication && auth.OAuth2 != nil {
+		ctx = net.ContextWithOAuth2(ctx, &net.OAuth2Config{
+			TokenURL:     auth.OAuth2.TokenURL,
+			ClientID:     auth.OAuth2.ClientID,
+			ClientSecret: auth.OAuth2.ClientSecret,
+			Scopes:       auth.OAuth2.Scopes,
+			Audience:     auth.OAuth2.Audience,
+		})
+	}
+
+	return ctx
+}
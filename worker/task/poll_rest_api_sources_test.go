@@ -0,0 +1,156 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPollRestApiSources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+
+	sourceRepo.EXPECT().LoadRestApiSources(gomock.Any()).Return([]datastore.Source{
+		{UID: "never-polled", ProjectID: "project-1", RestApi: &datastore.RestApiConfig{}},
+		{UID: "due", ProjectID: "project-1", RestApi: &datastore.RestApiConfig{PollInterval: 60}, PollState: &datastore.PollState{PolledAt: time.Now().Add(-time.Minute)}},
+		{UID: "not-due", ProjectID: "project-1", RestApi: &datastore.RestApiConfig{}, PollState: &datastore.PollState{PolledAt: time.Now().Add(-time.Minute)}},
+	}, nil)
+	q.EXPECT().Write(convoy.RestApiSourcePollProcessor, convoy.DefaultQueue, gomock.Any()).Times(2).
+		DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+			require.NotEqual(t, "rest-api-poll:not-due", job.ID)
+			return nil
+		})
+
+	err := PollRestApiSources(sourceRepo, q)(context.Background(), asynq.NewTask(string(convoy.PollRestApiSources), nil))
+	require.NoError(t, err)
+}
+
+func TestProcessRestApiSourcePoll(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      *datastore.PollState
+		statusCode int
+		wantEvents []string
+		wantState  datastore.PollState
+	}{
+		{
+			name:       "should_create_events_from_every_page",
+			statusCode: http.StatusOK,
+			wantEvents: []string{"1", "2", "3"},
+			wantState:  datastore.PollState{Cursor: "page-2", SeenIDs: []string{"1", "2", "3"}},
+		},
+		{
+			name:       "should_skip_seen_items",
+			state:      &datastore.PollState{SeenIDs: []string{"1", "2"}},
+			statusCode: http.StatusOK,
+			wantEvents: []string{"3"},
+			wantState:  datastore.PollState{Cursor: "page-2", SeenIDs: []string{"1", "2", "3"}},
+		},
+		{
+			name:       "should_save_the_error_when_the_api_fails",
+			state:      &datastore.PollState{Cursor: "page-1"},
+			statusCode: http.StatusInternalServerError,
+			wantState:  datastore.PollState{Cursor: "page-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sourceRepo := mocks.NewMockSourceRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+			licenser := mocks.NewMockLicenser(ctrl)
+
+			require.NoError(t, config.LoadConfig("./testdata/Config/basic-convoy.json"))
+			dispatcher := newTestDispatcher(t, licenser)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+				w.WriteHeader(tt.statusCode)
+
+				switch r.URL.Query().Get("cursor") {
+				case "":
+					_, _ = fmt.Fprint(w, `{"data":[{"id":1},{"id":2}],"next":"page-1"}`)
+				case "page-1":
+					_, _ = fmt.Fprint(w, `{"data":[{"id":3},{"name":"no id"}],"next":"page-2"}`)
+				default:
+					_, _ = fmt.Fprint(w, `{"data":[],"next":"page-2"}`)
+				}
+			}))
+			defer server.Close()
+
+			sourceRepo.EXPECT().FindSourceByID(gomock.Any(), "project-1", "source-1").Return(&datastore.Source{
+				UID:       "source-1",
+				ProjectID: "project-1",
+				MaskID:    "mask-1",
+				Type:      datastore.RestApiSource,
+				RestApi: &datastore.RestApiConfig{
+					URL:         server.URL,
+					ItemsPath:   "$.data",
+					IDPath:      "$.id",
+					EventType:   "item.created",
+					CursorPath:  "$.next",
+					CursorParam: "cursor",
+					Authentication: &datastore.EndpointAuthentication{
+						Type:   datastore.APIKeyAuthentication,
+						ApiKey: &datastore.ApiKey{HeaderName: "X-Api-Key", HeaderValue: "secret"},
+					},
+				},
+				PollState: tt.state,
+			}, nil)
+
+			var events []string
+			q.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).AnyTimes().
+				DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+					var createEvent CreateEvent
+					require.NoError(t, msgpack.DecodeMsgPack(job.Payload, &createEvent))
+					require.Equal(t, datastore.EventType("item.created"), createEvent.Event.EventType)
+					require.Equal(t, "source-1", createEvent.Event.SourceID)
+
+					events = append(events, createEvent.Event.IdempotencyKey)
+					return nil
+				})
+
+			sourceRepo.EXPECT().UpdateSourcePollState(gomock.Any(), "project-1", "source-1", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, state *datastore.PollState) error {
+					require.False(t, state.PolledAt.IsZero())
+					require.Equal(t, tt.statusCode != http.StatusOK, state.Error != "")
+
+					state.PolledAt, state.Error = time.Time{}, ""
+					require.Equal(t, tt.wantState, *state)
+					return nil
+				})
+
+			payload, err := msgpack.EncodeMsgPack(RestApiSourcePoll{SourceID: "source-1", ProjectID: "project-1"})
+			require.NoError(t, err)
+
+			fn := ProcessRestApiSourcePoll(sourceRepo, q, dispatcher)
+			err = fn(context.Background(), asynq.NewTask(string(convoy.RestApiSourcePollProcessor), payload))
+			require.NoError(t, err)
+
+			var wantEvents []string
+			for _, id := range tt.wantEvents {
+				wantEvents = append(wantEvents, "source-1:"+id)
+			}
+			require.Equal(t, wantEvents, events)
+		})
+	}
+}
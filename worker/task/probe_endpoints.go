@@ -0,0 +1,159 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// probeFailureThreshold is the number of consecutive failed probes
+	// after which an active endpoint is deactivated.
+	probeFailureThreshold = 3
+
+	probeRetention = 24 * time.Hour
+)
+
+// ProbeEndpoints queues a health probe for every endpoint with health checks
+// turned on, and removes probes that are older than a day.
+func ProbeEndpoints(endpointRepo datastore.EndpointRepository, probeRepo datastore.EndpointProbeRepository, q queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		err := probeRepo.DeleteEndpointProbes(ctx, time.Now().Add(-probeRetention))
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to delete old endpoint probes")
+		}
+
+		endpoints, err := endpointRepo.FindHealthCheckEndpoints(ctx)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to load health check endpoints")
+			return err
+		}
+
+		for i := range endpoints {
+			payload, err := msgpack.EncodeMsgPack(EndpointProbe{
+				EndpointID: endpoints[i].UID,
+				ProjectID:  endpoints[i].ProjectID,
+			})
+			if err != nil {
+				return err
+			}
+
+			job := &queue.Job{
+				ID:      fmt.Sprintf("endpoint-probe:%s", endpoints[i].UID),
+				Payload: payload,
+			}
+
+			err = q.Write(convoy.EndpointProbeProcessor, convoy.DefaultQueue, job)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Errorf("failed to queue probe for endpoint %s", endpoints[i].UID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// ProcessEndpointProbe sends a health check request to the endpoint and records
+// the result, the recorded probes are counted by the circuit breaker. Inactive
+// endpoints are activated when a probe succeeds, and active endpoints are
+// deactivated after probeFailureThreshold consecutive failed probes.
+func ProcessEndpointProbe(endpointRepo datastore.EndpointRepository, projectRepo datastore.ProjectRepository, probeRepo datastore.EndpointProbeRepository, dispatch *net.Dispatcher, licenser license.Licenser) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data EndpointProbe
+
+		err := msgpack.DecodeMsgPack(t.Payload(), &data)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		endpoint, err := endpointRepo.FindEndpointByID(ctx, data.EndpointID, data.ProjectID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrEndpointNotFound) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		if !endpoint.HealthCheck {
+			return nil
+		}
+
+		switch endpoint.Status {
+		case datastore.ActiveEndpointStatus, datastore.InactiveEndpointStatus:
+		default:
+			return nil
+		}
+
+		project, err := projectRepo.FetchProjectByID(ctx, data.ProjectID)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		resp, err := sendEndpointRequest(ctx, dispatch, licenser, project, endpoint, &EndpointRequest{
+			Type:       EndpointHealthCheckType,
+			EndpointID: endpoint.UID,
+			Timestamp:  time.Now().Unix(),
+		})
+
+		probe := &datastore.EndpointProbe{
+			UID:        ulid.Make().String(),
+			ProjectID:  project.UID,
+			EndpointID: endpoint.UID,
+			Status:     err == nil,
+		}
+
+		if resp != nil {
+			probe.StatusCode = resp.StatusCode
+		}
+
+		if err != nil {
+			probe.Error = err.Error()
+		}
+
+		err = probeRepo.CreateEndpointProbe(ctx, probe)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		switch {
+		case probe.Status && endpoint.Status == datastore.InactiveEndpointStatus:
+			err = endpointRepo.UpdateEndpointStatus(ctx, project.UID, endpoint.UID, datastore.ActiveEndpointStatus)
+		case !probe.Status && endpoint.Status == datastore.ActiveEndpointStatus:
+			var probes []datastore.EndpointProbe
+			probes, err = probeRepo.LoadEndpointProbes(ctx, project.UID, endpoint.UID, probeFailureThreshold)
+			if err == nil && consecutiveFailures(probes) >= probeFailureThreshold {
+				err = endpointRepo.UpdateEndpointStatus(ctx, project.UID, endpoint.UID, datastore.InactiveEndpointStatus)
+			}
+		}
+
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("failed to update status of endpoint %s", endpoint.UID)
+		}
+
+		return nil
+	}
+}
+
+func consecutiveFailures(probes []datastore.EndpointProbe) int {
+	n := 0
+	for _, probe := range probes {
+		if probe.Status {
+			break
+		}
+		n++
+	}
+
+	return n
+}
@@ -0,0 +1,149 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProbeEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	probeRepo := mocks.NewMockEndpointProbeRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+
+	probeRepo.EXPECT().DeleteEndpointProbes(gomock.Any(), gomock.Any()).Return(errors.New("failed"))
+	endpointRepo.EXPECT().FindHealthCheckEndpoints(gomock.Any()).Return([]datastore.Endpoint{
+		{UID: "endpoint-1", ProjectID: "project-1"},
+		{UID: "endpoint-2", ProjectID: "project-1"},
+	}, nil)
+	q.EXPECT().Write(convoy.EndpointProbeProcessor, convoy.DefaultQueue, gomock.Any()).Times(2).Return(nil)
+
+	err := ProbeEndpoints(endpointRepo, probeRepo, q)(context.Background(), asynq.NewTask(string(convoy.ProbeEndpoints), nil))
+	require.NoError(t, err)
+}
+
+func TestProcessEndpointProbe(t *testing.T) {
+	failed := datastore.EndpointProbe{Status: false}
+	succeeded := datastore.EndpointProbe{Status: true}
+
+	tests := []struct {
+		name       string
+		status     datastore.EndpointStatus
+		statusCode int
+		dbFn       func(e *mocks.MockEndpointRepository, p *mocks.MockEndpointProbeRepository)
+		wantProbe  bool
+	}{
+		{
+			name:       "should_activate_inactive_endpoint_that_responds",
+			status:     datastore.InactiveEndpointStatus,
+			statusCode: http.StatusOK,
+			wantProbe:  true,
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockEndpointProbeRepository) {
+				e.EXPECT().UpdateEndpointStatus(gomock.Any(), "project-1", "endpoint-1", datastore.ActiveEndpointStatus).Return(nil)
+			},
+		},
+		{
+			name:       "should_keep_active_endpoint_that_responds",
+			status:     datastore.ActiveEndpointStatus,
+			statusCode: http.StatusOK,
+			wantProbe:  true,
+		},
+		{
+			name:       "should_deactivate_endpoint_after_consecutive_failures",
+			status:     datastore.ActiveEndpointStatus,
+			statusCode: http.StatusBadGateway,
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockEndpointProbeRepository) {
+				p.EXPECT().LoadEndpointProbes(gomock.Any(), "project-1", "endpoint-1", probeFailureThreshold).
+					Return([]datastore.EndpointProbe{failed, failed, failed}, nil)
+				e.EXPECT().UpdateEndpointStatus(gomock.Any(), "project-1", "endpoint-1", datastore.InactiveEndpointStatus).Return(nil)
+			},
+		},
+		{
+			name:       "should_keep_endpoint_active_below_the_failure_threshold",
+			status:     datastore.ActiveEndpointStatus,
+			statusCode: http.StatusBadGateway,
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockEndpointProbeRepository) {
+				p.EXPECT().LoadEndpointProbes(gomock.Any(), "project-1", "endpoint-1", probeFailureThreshold).
+					Return([]datastore.EndpointProbe{failed, failed, succeeded}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+			projectRepo := mocks.NewMockProjectRepository(ctrl)
+			probeRepo := mocks.NewMockEndpointProbeRepository(ctrl)
+			licenser := mocks.NewMockLicenser(ctrl)
+
+			require.NoError(t, config.LoadConfig("./testdata/Config/basic-convoy.json"))
+			dispatcher := newTestDispatcher(t, licenser)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(&datastore.Endpoint{
+				UID:         "endpoint-1",
+				ProjectID:   "project-1",
+				Url:         server.URL,
+				Status:      tt.status,
+				HealthCheck: true,
+				Secrets:     datastore.Secrets{{UID: "secret-1", Value: "1234"}},
+			}, nil)
+			projectRepo.EXPECT().FetchProjectByID(gomock.Any(), "project-1").
+				Return(&datastore.Project{UID: "project-1", Config: &datastore.DefaultProjectConfig}, nil)
+			probeRepo.EXPECT().CreateEndpointProbe(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, probe *datastore.EndpointProbe) error {
+					require.Equal(t, tt.wantProbe, probe.Status)
+					require.Equal(t, tt.statusCode, probe.StatusCode)
+					return nil
+				})
+
+			if tt.dbFn != nil {
+				tt.dbFn(endpointRepo, probeRepo)
+			}
+
+			payload, err := msgpack.EncodeMsgPack(EndpointProbe{EndpointID: "endpoint-1", ProjectID: "project-1"})
+			require.NoError(t, err)
+
+			fn := ProcessEndpointProbe(endpointRepo, projectRepo, probeRepo, dispatcher, licenser)
+			err = fn(context.Background(), asynq.NewTask(string(convoy.EndpointProbeProcessor), payload))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProcessEndpointProbe_SkipsEndpointsWithoutHealthCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").
+		Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil)
+
+	payload, err := msgpack.EncodeMsgPack(EndpointProbe{EndpointID: "endpoint-1", ProjectID: "project-1"})
+	require.NoError(t, err)
+
+	fn := ProcessEndpointProbe(endpointRepo, nil, nil, nil, nil)
+	err = fn(context.Background(), asynq.NewTask(string(convoy.EndpointProbeProcessor), payload))
+	require.NoError(t, err)
+}
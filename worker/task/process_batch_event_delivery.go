@@ -0,0 +1,293 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/internal/pkg/tracer"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/retrystrategies"
+	"github.com/oklog/ulid/v2"
+)
+
+var ErrBatchPending = errors.New("event delivery is waiting for its batch to fill up")
+
+// batchPendingDelay returns how much longer the event delivery should wait for other
+// deliveries to its endpoint before the batch is sent, zero means it should be sent now.
+func batchPendingDelay(endpoint *datastore.Endpoint, eventDelivery *datastore.EventDelivery, pending int64, now time.Time) time.Duration {
+	if pending >= int64(endpoint.BatchLimit()) {
+		return 0
+	}
+
+	delay := eventDelivery.CreatedAt.Add(endpoint.BatchWaitDuration()).Sub(now)
+	if delay < 0 {
+		return 0
+	}
+
+	return delay
+}
+
+// encodeBatchPayload writes the payloads of the event deliveries as a json array. Event
+// deliveries whose payload is not json cannot be batched and are returned separately.
+func encodeBatchPayload(endpoint *datastore.Endpoint, eventDeliveries []datastore.EventDelivery) (json.RawMessage, []datastore.EventDelivery, []datastore.EventDelivery, error) {
+	payloads := make([]json.RawMessage, 0, len(eventDeliveries))
+	batched := make([]datastore.EventDelivery, 0, len(eventDeliveries))
+	var rejected []datastore.EventDelivery
+
+	for _, eventDelivery := range eventDeliveries {
+		payload, isJSON, err := encodeDeliveryPayload(endpoint, &eventDelivery)
+		if err != nil || !isJSON || !json.Valid(payload) {
+			rejected = append(rejected, eventDelivery)
+			continue
+		}
+
+		payloads = append(payloads, payload)
+		batched = append(batched, eventDelivery)
+	}
+
+	body, err := json.Marshal(payloads)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return body, batched, rejected, nil
+}
+
+// failRejectedBatchDelivery fails an event delivery that cannot be sent in a batch. The
+// rejection is recorded as an attempt and the delivery is dead-lettered, so it can be
+// redriven once the endpoint stops batching or its payload is fixed.
+func failRejectedBatchDelivery(ctx context.Context, endpoint *datastore.Endpoint, ed *datastore.EventDelivery, eventDeliveryRepo datastore.EventDeliveryRepository, attemptsRepo datastore.DeliveryAttemptsRepository, q queue.Queuer) {
+	ed.Status = datastore.FailureEventStatus
+	ed.Description = "Event delivery payload is not json and cannot be sent in a batch"
+
+	attempt := datastore.DeliveryAttempt{
+		UID:             ulid.Make().String(),
+		URL:             endpoint.Url,
+		Method:          endpoint.DeliveryMethod(),
+		EventDeliveryId: ed.UID,
+		EndpointID:      endpoint.UID,
+		APIVersion:      convoy.GetVersion(),
+		ProjectId:       ed.ProjectID,
+		Error:           ed.Description,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	err := attemptsRepo.CreateDeliveryAttempt(ctx, &attempt)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Errorf("failed to create delivery attempt for event delivery with id: %s", ed.UID)
+	}
+
+	err = eventDeliveryRepo.UpdateEventDeliveryMetadata(ctx, ed.ProjectID, ed)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to update message ", ed.UID)
+		return
+	}
+
+	queueDeadLetter(ctx, q, ed, &attempt)
+}
+
+// sendEventDeliveryBatch sends the event delivery together with the other scheduled
+// deliveries to its endpoint in one request. The response is recorded as an attempt on
+// every event delivery in the batch, failed deliveries are retried individually.
+func sendEventDeliveryBatch(ctx context.Context, cfg config.Configuration, eventDelivery *datastore.EventDelivery, endpoint *datastore.Endpoint, project *datastore.Project, eventDeliveryRepo datastore.EventDeliveryRepository, attemptsRepo datastore.DeliveryAttemptsRepository, q queue.Queuer, dispatch *net.Dispatcher, licenser license.Licenser, tracerBackend tracer.Backend) error {
+	pending, err := eventDeliveryRepo.CountScheduledEndpointDeliveries(ctx, project.UID, endpoint.UID)
+	if err != nil {
+		return &DeliveryError{Err: err}
+	}
+
+	if delay := batchPendingDelay(endpoint, eventDelivery, pending, time.Now()); delay > 0 {
+		log.FromContext(ctx).Debugf("%s is waiting for %s for its batch to %s to fill up", eventDelivery.UID, delay, endpoint.Url)
+		return &BatchError{Err: ErrBatchPending, delay: delay}
+	}
+
+	claimed, err := eventDeliveryRepo.ClaimScheduledEndpointDeliveries(ctx, project.UID, endpoint.UID, eventDelivery.UID, endpoint.BatchLimit())
+	if err != nil {
+		return &DeliveryError{Err: err}
+	}
+
+	if len(claimed) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(claimed, func(i, j int) bool {
+		return claimed[i].CreatedAt.Before(claimed[j].CreatedAt)
+	})
+
+	payload, batch, rejected, err := encodeBatchPayload(endpoint, claimed)
+	if err != nil {
+		return &DeliveryError{Err: err}
+	}
+
+	for i := range rejected {
+		failRejectedBatchDelivery(ctx, endpoint, &rejected[i], eventDeliveryRepo, attemptsRepo, q)
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	// the batch is signed once, it uses the headers of the delivery that triggered it
+	batchDelivery := &datastore.EventDelivery{
+		UID:     ulid.Make().String(),
+		Headers: httpheader.HTTPHeader{},
+	}
+	for k, v := range eventDelivery.Headers {
+		batchDelivery.Headers[k] = v
+	}
+
+	signatureHeader, header, err := signEventDelivery(endpoint, project, batchDelivery, payload, true)
+	if err != nil {
+		return &DeliveryError{Err: err}
+	}
+
+	if project.Config.AddEventIDTraceHeaders {
+		batchDelivery.Headers["X-Convoy-Batch-ID"] = []string{batchDelivery.UID}
+	}
+
+	var httpDuration time.Duration
+	if endpoint.HttpTimeout == 0 || !licenser.AdvancedEndpointMgmt() {
+		httpDuration = convoy.HTTP_TIMEOUT_IN_DURATION
+	} else {
+		httpDuration = time.Duration(endpoint.HttpTimeout) * time.Second
+	}
+
+	start := time.Now()
+	resp, err := dispatch.SendRequest(withEndpointCredentials(ctx, endpoint), endpoint.Url, endpoint.DeliveryMethod(), payload, endpoint.DeliveryContentType(), signatureHeader, header, int64(cfg.MaxResponseSize), batchDelivery.Headers, "", httpDuration)
+
+	status := "-"
+	statusCode := 0
+	if resp != nil {
+		status = resp.Status
+		statusCode = resp.StatusCode
+	}
+
+	duration := time.Since(start)
+	requestLogger := log.FromContext(ctx).WithFields(log.Fields{
+		"status":   status,
+		"uri":      endpoint.Url,
+		"method":   endpoint.DeliveryMethod(),
+		"duration": duration,
+		"batchID":  batchDelivery.UID,
+		"size":     len(batch),
+	})
+
+	success := err == nil && statusCode >= 200 && statusCode <= 299
+	if success {
+		requestLogger.Debugf("batch %s sent", batchDelivery.UID)
+	} else {
+		requestLogger.Errorf("batch %s failed", batchDelivery.UID)
+		if err != nil {
+			log.FromContext(ctx).Errorf("batch %s failed. Reason: %s", batchDelivery.UID, err)
+		}
+	}
+	tracerBackend.Capture(project, endpoint.Url, resp, duration)
+
+	action := datastore.RetryStatusCodeAction
+	if !success {
+		action = resolveStatusCodeAction(endpoint, project, resp)
+	}
+
+	var attemptErr error
+	for i := range batch {
+		ed := &batch[i]
+		ed.Metadata.MaxRetrySeconds = cfg.MaxRetrySeconds
+		delayDuration := retrystrategies.NewRetryStrategyFromMetadata(*ed.Metadata).NextDuration(ed.Metadata.NumTrials)
+
+		switch {
+		case success:
+			ed.Status = datastore.SuccessEventStatus
+			ed.Description = ""
+			ed.LatencySeconds = time.Since(ed.GetLatencyStartTime()).Seconds()
+
+			mm := metrics.GetDPInstance(licenser)
+			mm.RecordEndToEndLatency(ed)
+		case action == datastore.DiscardStatusCodeAction:
+			ed.Status = datastore.DiscardedEventStatus
+			ed.Description = fmt.Sprintf("Endpoint responded with status code %d, event delivery discarded", statusCode)
+		case action == datastore.FailStatusCodeAction:
+			ed.Status = datastore.FailureEventStatus
+			ed.Description = fmt.Sprintf("Endpoint responded with non-retryable status code %d", statusCode)
+		default:
+			if retryAfter, ok := retryAfterDelay(resp, cfg.MaxRetrySeconds); ok {
+				delayDuration = retryAfter
+			}
+
+			ed.Status = datastore.RetryEventStatus
+			ed.Metadata.NextSendTime = time.Now().Add(delayDuration)
+		}
+
+		attempt := parseAttemptFromResponse(ed, endpoint, resp, success)
+
+		ed.Metadata.NumTrials++
+
+		retry := ed.Status == datastore.RetryEventStatus
+		deadLettered := !success && action == datastore.FailStatusCodeAction
+		if retry && ed.Metadata.NumTrials >= ed.Metadata.RetryLimit {
+			log.FromContext(ctx).Errorf("%s retry limit exceeded ", ed.UID)
+			ed.Description = "Retry limit exceeded"
+			ed.Status = datastore.FailureEventStatus
+			retry = false
+			deadLettered = true
+		}
+
+		err = attemptsRepo.CreateDeliveryAttempt(ctx, &attempt)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).
+				Errorf("failed to create delivery attempt for event delivery with id: %s and delivery attempt: %s", ed.UID, attempt.ResponseData)
+			attemptErr = fmt.Errorf("%s, err: %s", ErrDeliveryAttemptFailed, err.Error())
+			continue
+		}
+
+		err = eventDeliveryRepo.UpdateEventDeliveryMetadata(ctx, project.UID, ed)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to update message ", ed.UID)
+			continue
+		}
+
+		if deadLettered {
+			queueDeadLetter(ctx, q, ed, &attempt)
+		}
+
+		if !retry {
+			continue
+		}
+
+		buf, err := msgpack.EncodeMsgPack(EventDelivery{EventDeliveryID: ed.UID, ProjectID: ed.ProjectID})
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to encode event delivery ", ed.UID)
+			continue
+		}
+
+		job := &queue.Job{
+			ID:      ed.UID,
+			Payload: buf,
+			Delay:   delayDuration,
+		}
+
+		err = q.Write(convoy.RetryEventProcessor, convoy.RetryEventQueue, job)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("[asynq]: an error occurred sending event delivery to the retry queue")
+		}
+	}
+
+	// the rest of the batch is recorded before the failure is returned
+	if attemptErr != nil {
+		return &DeliveryError{Err: attemptErr}
+	}
+
+	return nil
+}
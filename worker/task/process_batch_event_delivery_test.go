@@ -0,0 +1,107 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBatchPendingDelay(t *testing.T) {
+	now := time.Now()
+	endpoint := &datastore.Endpoint{BatchDelivery: true, BatchSize: 10, BatchWindow: 2000}
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		pending   int64
+		want      time.Duration
+	}{
+		{
+			name:      "should_wait_for_the_batch_window",
+			createdAt: now.Add(-500 * time.Millisecond),
+			pending:   3,
+			want:      1500 * time.Millisecond,
+		},
+		{
+			name:      "should_send_when_the_batch_window_has_passed",
+			createdAt: now.Add(-3 * time.Second),
+			pending:   3,
+			want:      0,
+		},
+		{
+			name:      "should_send_when_the_batch_is_full",
+			createdAt: now,
+			pending:   10,
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventDelivery := &datastore.EventDelivery{CreatedAt: tt.createdAt}
+			require.Equal(t, tt.want, batchPendingDelay(endpoint, eventDelivery, tt.pending, now))
+		})
+	}
+}
+
+func TestEncodeBatchPayload(t *testing.T) {
+	endpoint := &datastore.Endpoint{BatchDelivery: true, BodyEncoding: datastore.JSONBodyEncoding}
+
+	eventDeliveries := []datastore.EventDelivery{
+		{UID: "ed-1", Metadata: &datastore.Metadata{Raw: `{"id": 1}`}},
+		{UID: "ed-2", Metadata: &datastore.Metadata{Raw: `not json`}},
+		{UID: "ed-3", Metadata: &datastore.Metadata{Raw: `{"id": 3}`}},
+	}
+
+	body, batched, rejected, err := encodeBatchPayload(endpoint, eventDeliveries)
+	require.NoError(t, err)
+
+	require.JSONEq(t, `[{"id": 1}, {"id": 3}]`, string(body))
+	require.Len(t, batched, 2)
+	require.Equal(t, "ed-1", batched[0].UID)
+	require.Equal(t, "ed-3", batched[1].UID)
+	require.Len(t, rejected, 1)
+	require.Equal(t, "ed-2", rejected[0].UID)
+}
+
+func TestFailRejectedBatchDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	attemptsRepo := mocks.NewMockDeliveryAttemptsRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+
+	endpoint := &datastore.Endpoint{UID: "endpoint-1", Url: "https://example.com/webhooks"}
+	ed := &datastore.EventDelivery{UID: "ed-1", ProjectID: "project-1", Status: datastore.ProcessingEventStatus}
+
+	attemptsRepo.EXPECT().CreateDeliveryAttempt(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, attempt *datastore.DeliveryAttempt) error {
+			require.Equal(t, "ed-1", attempt.EventDeliveryId)
+			require.Equal(t, "endpoint-1", attempt.EndpointID)
+			require.False(t, attempt.Status)
+			require.NotEmpty(t, attempt.Error)
+			return nil
+		})
+	eventDeliveryRepo.EXPECT().UpdateEventDeliveryMetadata(gomock.Any(), "project-1", ed).Times(1).Return(nil)
+	q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1).
+		DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+			var deadLetter DeadLetter
+			require.NoError(t, msgpack.DecodeMsgPack(job.Payload, &deadLetter))
+			require.Equal(t, "ed-1", deadLetter.EventDeliveryID)
+			require.Equal(t, ed.Description, deadLetter.Reason)
+			return nil
+		})
+
+	failRejectedBatchDelivery(context.Background(), endpoint, ed, eventDeliveryRepo, attemptsRepo, q)
+
+	require.Equal(t, datastore.FailureEventStatus, ed.Status)
+}
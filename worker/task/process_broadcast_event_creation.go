@@ -53,7 +53,9 @@ func (b *BroadcastEventChannel) CreateEvent(ctx context.Context, t *asynq.Task,
 	}
 
 	var isDuplicate bool
-	if len(broadcastEvent.IdempotencyKey) > 0 {
+	if broadcastEvent.IsDuplicate != nil {
+		isDuplicate = *broadcastEvent.IsDuplicate
+	} else if len(broadcastEvent.IdempotencyKey) > 0 {
 		events, err := args.eventRepo.FindEventsByIdempotencyKey(ctx, broadcastEvent.ProjectID, broadcastEvent.IdempotencyKey)
 		if err != nil {
 			return nil, &EndpointError{Err: fmt.Errorf("CODE: 1004, err: %s", err.Error()), delay: defaultBroadcastDelay}
@@ -74,6 +76,7 @@ func (b *BroadcastEventChannel) CreateEvent(ctx context.Context, t *asynq.Task,
 		Raw:              string(broadcastEvent.Data),
 		Status:           datastore.PendingStatus,
 		AcknowledgedAt:   null.TimeFrom(time.Now()),
+		SchemaStatus:     broadcastEvent.SchemaStatus,
 	}
 	err = updateEventMetadata(channel, event, false)
 	if err != nil {
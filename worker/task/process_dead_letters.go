@@ -1,7 +1,101 @@
 package task
 
-import "github.com/frain-dev/convoy/queue"
+import (
+	"context"
+	"errors"
 
-func ProcessDeadLetters(job *queue.Job) {
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/deadletter"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
+)
 
+// ProcessDeadLetters records an event delivery that exhausted its retries in its
+// project's dead letters and forwards its payload to the dead letter sink if one
+// is configured.
+func ProcessDeadLetters(deadLetterRepo datastore.DeadLetterRepository, eventDeliveryRepo datastore.EventDeliveryRepository, sink deadletter.Sink) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data DeadLetter
+
+		err := msgpack.DecodeMsgPack(t.Payload(), &data)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		eventDelivery, err := eventDeliveryRepo.FindEventDeliveryByID(ctx, data.ProjectID, data.EventDeliveryID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrEventDeliveryNotFound) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		deadLetter := &datastore.DeadLetter{
+			UID:                ulid.Make().String(),
+			ProjectID:          eventDelivery.ProjectID,
+			EventDeliveryID:    eventDelivery.UID,
+			EventID:            eventDelivery.EventID,
+			EndpointID:         eventDelivery.EndpointID,
+			EventType:          eventDelivery.EventType,
+			Reason:             data.Reason,
+			LastResponseStatus: null.NewString(data.ResponseStatus, len(data.ResponseStatus) > 0),
+			LastResponseBody:   null.NewString(data.ResponseBody, len(data.ResponseBody) > 0),
+			LastResponseError:  null.NewString(data.ResponseError, len(data.ResponseError) > 0),
+		}
+
+		err = deadLetterRepo.CreateDeadLetter(ctx, deadLetter)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		if sink == nil {
+			return nil
+		}
+
+		m, err := deadletter.NewMessage(deadLetter, eventDelivery)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("failed to build dead letter message for %s", eventDelivery.UID)
+			return nil
+		}
+
+		err = sink.Forward(ctx, m)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		return nil
+	}
+}
+
+// queueDeadLetter queues the job that dead-letters the event delivery, attempt is
+// the last attempt made before the event delivery was given up on.
+func queueDeadLetter(ctx context.Context, q queue.Queuer, eventDelivery *datastore.EventDelivery, attempt *datastore.DeliveryAttempt) {
+	buf, err := msgpack.EncodeMsgPack(DeadLetter{
+		EventDeliveryID: eventDelivery.UID,
+		ProjectID:       eventDelivery.ProjectID,
+		Reason:          eventDelivery.Description,
+		ResponseStatus:  attempt.HttpResponseCode,
+		ResponseBody:    string(attempt.ResponseData),
+		ResponseError:   attempt.Error,
+	})
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to encode dead letter ", eventDelivery.UID)
+		return
+	}
+
+	job := &queue.Job{
+		ID:      "dead-letter:" + eventDelivery.UID,
+		Payload: buf,
+	}
+
+	err = q.Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, job)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to queue dead letter ", eventDelivery.UID)
+	}
 }
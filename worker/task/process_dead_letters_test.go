@@ -0,0 +1,126 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/deadletter"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeSink struct {
+	messages []*deadletter.Message
+	err      error
+}
+
+func (f *fakeSink) Forward(_ context.Context, m *deadletter.Message) error {
+	f.messages = append(f.messages, m)
+	return f.err
+}
+
+func TestProcessDeadLetters(t *testing.T) {
+	eventDelivery := &datastore.EventDelivery{
+		UID:        "delivery-1",
+		ProjectID:  "project-1",
+		EventID:    "event-1",
+		EndpointID: "endpoint-1",
+		EventType:  "invoice.paid",
+		Metadata:   &datastore.Metadata{Data: []byte(`{"id": 1}`)},
+	}
+
+	tests := []struct {
+		name         string
+		dbFn         func(*mocks.MockDeadLetterRepository, *mocks.MockEventDeliveryRepository)
+		sink         *fakeSink
+		wantErr      bool
+		wantMessages int
+	}{
+		{
+			name: "should_record_dead_letter",
+			dbFn: func(d *mocks.MockDeadLetterRepository, e *mocks.MockEventDeliveryRepository) {
+				e.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-1").Times(1).Return(eventDelivery, nil)
+				d.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, deadLetter *datastore.DeadLetter) error {
+						require.Equal(t, "endpoint-1", deadLetter.EndpointID)
+						require.Equal(t, "Retry limit exceeded", deadLetter.Reason)
+						require.Equal(t, "500", deadLetter.LastResponseStatus.String)
+						require.False(t, deadLetter.LastResponseError.Valid)
+						return nil
+					})
+			},
+		},
+		{
+			name: "should_forward_dead_letter_to_sink",
+			dbFn: func(d *mocks.MockDeadLetterRepository, e *mocks.MockEventDeliveryRepository) {
+				e.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-1").Times(1).Return(eventDelivery, nil)
+				d.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			sink:         &fakeSink{},
+			wantMessages: 1,
+		},
+		{
+			name: "should_retry_when_sink_fails",
+			dbFn: func(d *mocks.MockDeadLetterRepository, e *mocks.MockEventDeliveryRepository) {
+				e.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-1").Times(1).Return(eventDelivery, nil)
+				d.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			sink:         &fakeSink{err: errors.New("sink is unavailable")},
+			wantErr:      true,
+			wantMessages: 1,
+		},
+		{
+			name: "should_drop_missing_event_delivery",
+			dbFn: func(d *mocks.MockDeadLetterRepository, e *mocks.MockEventDeliveryRepository) {
+				e.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "delivery-1").Times(1).Return(nil, datastore.ErrEventDeliveryNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			deadLetterRepo := mocks.NewMockDeadLetterRepository(ctrl)
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(deadLetterRepo, eventDeliveryRepo)
+			}
+
+			buf, err := msgpack.EncodeMsgPack(DeadLetter{
+				EventDeliveryID: "delivery-1",
+				ProjectID:       "project-1",
+				Reason:          "Retry limit exceeded",
+				ResponseStatus:  "500",
+				ResponseBody:    "internal server error",
+			})
+			require.NoError(t, err)
+
+			var sink deadletter.Sink
+			if tt.sink != nil {
+				sink = tt.sink
+			}
+
+			task := asynq.NewTask(string(convoy.DeadLetterProcessor), buf, asynq.Queue(string(convoy.DefaultQueue)))
+			err = ProcessDeadLetters(deadLetterRepo, eventDeliveryRepo, sink)(context.Background(), task)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.sink != nil {
+				require.Len(t, tt.sink.messages, tt.wantMessages)
+				require.JSONEq(t, `{"id": 1}`, string(tt.sink.messages[0].Payload))
+			}
+		})
+	}
+}
@@ -61,7 +61,9 @@ func (d *DynamicEventChannel) CreateEvent(ctx context.Context, t *asynq.Task, ch
 	}
 
 	var isDuplicate bool
-	if len(dynamicEvent.IdempotencyKey) > 0 {
+	if dynamicEvent.IsDuplicate != nil {
+		isDuplicate = *dynamicEvent.IsDuplicate
+	} else if len(dynamicEvent.IdempotencyKey) > 0 {
 		events, err := args.eventRepo.FindEventsByIdempotencyKey(ctx, dynamicEvent.ProjectID, dynamicEvent.IdempotencyKey)
 		if err != nil {
 			return nil, &EndpointError{Err: err, delay: 10 * time.Second}
@@ -92,6 +94,7 @@ func (d *DynamicEventChannel) CreateEvent(ctx context.Context, t *asynq.Task, ch
 		Metadata:         string(m),
 		Raw:              string(dynamicEvent.Data),
 		AcknowledgedAt:   null.TimeFrom(time.Now()),
+		SchemaStatus:     dynamicEvent.SchemaStatus,
 	}
 
 	err = args.eventRepo.CreateEvent(ctx, event)
@@ -0,0 +1,213 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/hibiken/asynq"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	EndpointVerificationType = "endpoint.verification"
+	EndpointHealthCheckType  = "endpoint.health_check"
+
+	maxVerificationAttempts = 5
+	verificationRetryDelay  = 30 * time.Second
+)
+
+var ErrChallengeMismatch = errors.New("endpoint did not answer the verification challenge")
+
+// EndpointRequest is the body of the requests sent to endpoints to verify
+// them and check their health.
+type EndpointRequest struct {
+	Type       string `json:"type"`
+	EndpointID string `json:"endpoint_id"`
+	Challenge  string `json:"challenge,omitempty"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+// ProcessEndpointVerification sends the endpoint a signed challenge and
+// activates it once it responds with the challenge's HMAC. Failed verifications are
+// retried with a growing delay, after the last attempt the endpoint stays
+// pending until verification is requested again.
+func ProcessEndpointVerification(endpointRepo datastore.EndpointRepository, projectRepo datastore.ProjectRepository, q queue.Queuer, dispatch *net.Dispatcher, licenser license.Licenser) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data EndpointVerification
+
+		err := msgpack.DecodeMsgPack(t.Payload(), &data)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		endpoint, err := endpointRepo.FindEndpointByID(ctx, data.EndpointID, data.ProjectID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrEndpointNotFound) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		if !endpoint.AwaitingVerification() || endpoint.Status != datastore.PendingEndpointStatus {
+			return nil
+		}
+
+		project, err := projectRepo.FetchProjectByID(ctx, data.ProjectID)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		challenge, err := util.GenerateSecret()
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		resp, err := sendEndpointRequest(ctx, dispatch, licenser, project, endpoint, &EndpointRequest{
+			Type:       EndpointVerificationType,
+			EndpointID: endpoint.UID,
+			Challenge:  challenge,
+			Timestamp:  time.Now().Unix(),
+		})
+		if err == nil && !answersChallenge(resp.Body, challenge, endpoint) {
+			err = ErrChallengeMismatch
+		}
+
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("failed to verify endpoint %s", endpoint.UID)
+
+			data.Attempt++
+			if data.Attempt >= maxVerificationAttempts {
+				return nil
+			}
+
+			return QueueEndpointVerification(q, data, verificationRetryDelay*time.Duration(1<<(data.Attempt-1)))
+		}
+
+		err = endpointRepo.MarkEndpointVerified(ctx, project.UID, endpoint.UID)
+		if err != nil {
+			// the endpoint left the pending status while it was being verified
+			if errors.Is(err, postgres.ErrEndpointNotUpdated) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		return nil
+	}
+}
+
+// QueueEndpointVerification queues the endpoint's verification, replacing any
+// verification of the endpoint that is already queued.
+func QueueEndpointVerification(q queue.Queuer, data EndpointVerification, delay time.Duration) error {
+	payload, err := msgpack.EncodeMsgPack(data)
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      fmt.Sprintf("endpoint-verification:%s", data.EndpointID),
+		Payload: payload,
+		Delay:   delay,
+	}
+
+	return q.Write(convoy.EndpointVerificationProcessor, convoy.DefaultQueue, job)
+}
+
+// answersChallenge reports whether body holds the hex encoded HMAC-SHA256 of
+// the challenge keyed with one of the endpoint's secrets, which proves the
+// endpoint holds the secret. Both a bare HMAC and a json object with the HMAC
+// in its challenge_response field are accepted.
+func answersChallenge(body []byte, challenge string, endpoint *datastore.Endpoint) bool {
+	var answer struct {
+		ChallengeResponse string `json:"challenge_response"`
+	}
+
+	response := string(bytes.TrimSpace(body))
+	if json.Unmarshal(body, &answer) == nil {
+		response = answer.ChallengeResponse
+	}
+
+	mac, err := hex.DecodeString(response)
+	if err != nil || len(mac) == 0 {
+		return false
+	}
+
+	for _, secret := range endpoint.Secrets {
+		if !secret.DeletedAt.IsZero() {
+			continue
+		}
+
+		if hmac.Equal(mac, challengeResponse(secret.Value, challenge)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// challengeResponse is the HMAC-SHA256 of the challenge keyed with secret.
+func challengeResponse(secret, challenge string) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(challenge))
+	return h.Sum(nil)
+}
+
+// sendEndpointRequest sends a request convoy generates itself to the endpoint.
+// It is signed and authenticated the same way the endpoint's event deliveries are.
+func sendEndpointRequest(ctx context.Context, dispatch *net.Dispatcher, licenser license.Licenser, project *datastore.Project, endpoint *datastore.Endpoint, r *EndpointRequest) (*net.Response, error) {
+	cfg, err := config.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &datastore.EventDelivery{UID: ulid.Make().String(), Headers: httpheader.HTTPHeader{}}
+	if endpoint.Authentication != nil && endpoint.Authentication.Type == datastore.APIKeyAuthentication {
+		delivery.Headers[endpoint.Authentication.ApiKey.HeaderName] = []string{endpoint.Authentication.ApiKey.HeaderValue}
+	}
+
+	signatureHeader, header, err := signEventDelivery(endpoint, project, delivery, payload, true)
+	if err != nil {
+		return nil, err
+	}
+
+	httpDuration := convoy.HTTP_TIMEOUT_IN_DURATION
+	if endpoint.HttpTimeout != 0 && licenser.AdvancedEndpointMgmt() {
+		httpDuration = time.Duration(endpoint.HttpTimeout) * time.Second
+	}
+
+	resp, err := dispatch.SendRequest(withEndpointCredentials(ctx, endpoint), endpoint.Url, string(convoy.HttpPost), payload, "application/json", signatureHeader, header, int64(cfg.MaxResponseSize), delivery.Headers, "", httpDuration)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, fmt.Errorf("endpoint responded with status code %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,215 @@
+package task
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/fflag"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/guregu/null.v4"
+)
+
+func newTestDispatcher(t *testing.T, licenser *mocks.MockLicenser) *net.Dispatcher {
+	licenser.EXPECT().UseForwardProxy().AnyTimes().Return(true)
+	licenser.EXPECT().IpRules().AnyTimes().Return(true)
+	licenser.EXPECT().AdvancedEndpointMgmt().AnyTimes().Return(true)
+
+	dispatcher, err := net.NewDispatcher(
+		licenser,
+		fflag.NewFFlag([]string{string(fflag.IpRules)}),
+		net.LoggerOption(log.NewLogger(os.Stdout)),
+		net.ProxyOption("nil"),
+		net.AllowListOption([]string{"0.0.0.0/0"}),
+		net.BlockListOption([]string{"10.0.0.0/8"}),
+	)
+	require.NoError(t, err)
+
+	return dispatcher
+}
+
+func pendingVerificationEndpoint(url string) *datastore.Endpoint {
+	return &datastore.Endpoint{
+		UID:                  "endpoint-1",
+		ProjectID:            "project-1",
+		Url:                  url,
+		Status:               datastore.PendingEndpointStatus,
+		VerificationRequired: true,
+		Secrets:              datastore.Secrets{{UID: "secret-1", Value: "1234"}},
+	}
+}
+
+// answerChallenge responds with the HMAC of the verification challenge the
+// request carried, keyed with the endpoint's secret.
+func answerChallenge(w http.ResponseWriter, r *http.Request) {
+	var body EndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"challenge_response": hex.EncodeToString(challengeResponse("1234", body.Challenge))})
+}
+
+func TestProcessEndpointVerification(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		dbFn    func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string)
+		handler http.HandlerFunc
+	}{
+		{
+			name: "should_activate_endpoint_that_answers_challenge",
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string) {
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(pendingVerificationEndpoint(url), nil)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-1").
+					Return(&datastore.Project{UID: "project-1", Config: &datastore.DefaultProjectConfig}, nil)
+				e.EXPECT().MarkEndpointVerified(gomock.Any(), "project-1", "endpoint-1").Return(nil)
+			},
+			handler: answerChallenge,
+		},
+		{
+			name: "should_accept_challenge_response_as_plain_body",
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string) {
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(pendingVerificationEndpoint(url), nil)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-1").
+					Return(&datastore.Project{UID: "project-1", Config: &datastore.DefaultProjectConfig}, nil)
+				e.EXPECT().MarkEndpointVerified(gomock.Any(), "project-1", "endpoint-1").Return(nil)
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var body EndpointRequest
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				_, _ = w.Write([]byte(hex.EncodeToString(challengeResponse("1234", body.Challenge)) + "\n"))
+			},
+		},
+		{
+			name: "should_retry_when_challenge_is_echoed_without_hmac",
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string) {
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(pendingVerificationEndpoint(url), nil)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-1").
+					Return(&datastore.Project{UID: "project-1", Config: &datastore.DefaultProjectConfig}, nil)
+				q.EXPECT().Write(convoy.EndpointVerificationProcessor, convoy.DefaultQueue, gomock.Any()).Return(nil)
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var body EndpointRequest
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				_ = json.NewEncoder(w).Encode(map[string]string{"challenge": body.Challenge, "challenge_response": body.Challenge})
+			},
+		},
+		{
+			name: "should_retry_when_challenge_does_not_match",
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string) {
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(pendingVerificationEndpoint(url), nil)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-1").
+					Return(&datastore.Project{UID: "project-1", Config: &datastore.DefaultProjectConfig}, nil)
+
+				q.EXPECT().Write(convoy.EndpointVerificationProcessor, convoy.DefaultQueue, gomock.Any()).
+					DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+						var data EndpointVerification
+						require.NoError(t, msgpack.DecodeMsgPack(job.Payload, &data))
+						require.Equal(t, 1, data.Attempt)
+						require.Equal(t, verificationRetryDelay, job.Delay)
+						return nil
+					})
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			},
+		},
+		{
+			name:    "should_give_up_after_the_last_attempt",
+			attempt: maxVerificationAttempts - 1,
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string) {
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(pendingVerificationEndpoint(url), nil)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-1").
+					Return(&datastore.Project{UID: "project-1", Config: &datastore.DefaultProjectConfig}, nil)
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+		{
+			name: "should_skip_verified_endpoint",
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string) {
+				endpoint := pendingVerificationEndpoint(url)
+				endpoint.Status = datastore.ActiveEndpointStatus
+				endpoint.VerifiedAt = null.TimeFrom(time.Now())
+
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(endpoint, nil)
+			},
+		},
+		{
+			name: "should_skip_endpoint_that_left_pending_status",
+			dbFn: func(e *mocks.MockEndpointRepository, p *mocks.MockProjectRepository, q *mocks.MockQueuer, url string) {
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(pendingVerificationEndpoint(url), nil)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-1").
+					Return(&datastore.Project{UID: "project-1", Config: &datastore.DefaultProjectConfig}, nil)
+				e.EXPECT().MarkEndpointVerified(gomock.Any(), "project-1", "endpoint-1").Return(postgres.ErrEndpointNotUpdated)
+			},
+			handler: answerChallenge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+			projectRepo := mocks.NewMockProjectRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+			licenser := mocks.NewMockLicenser(ctrl)
+
+			require.NoError(t, config.LoadConfig("./testdata/Config/basic-convoy.json"))
+			dispatcher := newTestDispatcher(t, licenser)
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			tt.dbFn(endpointRepo, projectRepo, q, server.URL)
+
+			payload, err := msgpack.EncodeMsgPack(EndpointVerification{EndpointID: "endpoint-1", ProjectID: "project-1", Attempt: tt.attempt})
+			require.NoError(t, err)
+
+			fn := ProcessEndpointVerification(endpointRepo, projectRepo, q, dispatcher, licenser)
+			err = fn(context.Background(), asynq.NewTask(string(convoy.EndpointVerificationProcessor), payload))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAnswersChallenge(t *testing.T) {
+	endpoint := &datastore.Endpoint{Secrets: datastore.Secrets{
+		{UID: "secret-1", Value: "old", ExpiresAt: null.TimeFrom(time.Now().Add(time.Hour))},
+		{UID: "secret-2", Value: "new"},
+		{UID: "secret-3", Value: "deleted", DeletedAt: null.TimeFrom(time.Now())},
+	}}
+
+	answer := func(secret string) string {
+		return hex.EncodeToString(challengeResponse(secret, "abc"))
+	}
+
+	require.True(t, answersChallenge([]byte(`{"challenge_response": "`+answer("new")+`"}`), "abc", endpoint))
+	require.True(t, answersChallenge([]byte(" "+answer("old")+"\n"), "abc", endpoint))
+	require.False(t, answersChallenge([]byte(answer("deleted")), "abc", endpoint))
+	require.False(t, answersChallenge([]byte(answer("other")), "abc", endpoint))
+	require.False(t, answersChallenge([]byte(`{"challenge": "abc"}`), "abc", endpoint))
+	require.False(t, answersChallenge([]byte("abc"), "abc", endpoint))
+	require.False(t, answersChallenge([]byte(""), "abc", endpoint))
+}
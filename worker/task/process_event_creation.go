@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"gopkg.in/guregu/null.v4"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy/internal/pkg/license"
@@ -24,6 +25,7 @@ import (
 	"github.com/frain-dev/convoy/queue"
 	"github.com/hibiken/asynq"
 	"github.com/oklog/ulid/v2"
+	"github.com/tidwall/gjson"
 )
 
 type CreateEventTaskParams struct {
@@ -38,6 +40,12 @@ type CreateEventTaskParams struct {
 	CustomHeaders  map[string]string `json:"custom_headers"`
 	IdempotencyKey string            `json:"idempotency_key"`
 	AcknowledgedAt time.Time         `json:"acknowledged_at,omitempty"`
+
+	SchemaStatus datastore.EventSchemaStatus `json:"schema_status"`
+
+	// IsDuplicate is set when the idempotency key was checked against a
+	// dedup window, the events table isn't searched for the key then.
+	IsDuplicate *bool `json:"is_duplicate,omitempty"`
 }
 
 type CreateEvent struct {
@@ -45,6 +53,10 @@ type CreateEvent struct {
 	Params             CreateEventTaskParams
 	Event              *datastore.Event
 	CreateSubscription bool
+
+	// DedupChecked is set when the event's idempotency key was checked
+	// against its source's dedup window.
+	DedupChecked bool `json:"dedup_checked,omitempty"`
 }
 
 type DefaultEventChannel struct {
@@ -104,16 +116,14 @@ func (d *DefaultEventChannel) CreateEvent(ctx context.Context, t *asynq.Task, ch
 			return nil, err
 		}
 
-		var isDuplicate bool
-		if len(event.IdempotencyKey) > 0 {
+		if len(event.IdempotencyKey) > 0 && !createEvent.DedupChecked {
 			events, err := args.eventRepo.FindEventsByIdempotencyKey(ctx, event.ProjectID, event.IdempotencyKey)
 			if err != nil {
 				return nil, &EndpointError{Err: err, delay: 10 * time.Second}
 			}
 
-			isDuplicate = len(events) > 0
+			event.IsDuplicateEvent = len(events) > 0
 		}
-		event.IsDuplicateEvent = isDuplicate
 
 		err = args.eventRepo.CreateEvent(ctx, event)
 		if err != nil {
@@ -251,6 +261,10 @@ func writeEventDeliveriesToQueue(ctx context.Context, subscriptions []datastore.
 			RetryLimit:      rc.RetryCount,
 		}
 
+		if s.Endpoint != nil && s.Endpoint.BodyEncoding == datastore.OriginalBodyEncoding {
+			metadata.OriginalBody = event.OriginalBody()
+		}
+
 		eventDelivery := &datastore.EventDelivery{
 			UID:            ulid.Make().String(),
 			SubscriptionID: s.UID,
@@ -267,6 +281,10 @@ func writeEventDeliveriesToQueue(ctx context.Context, subscriptions []datastore.
 			AcknowledgedAt: null.TimeFrom(time.Now()),
 		}
 
+		if s.Endpoint != nil && s.Endpoint.OrderedDelivery {
+			eventDelivery.OrderingKey = getOrderingKey(s.Endpoint.OrderingKey, event)
+		}
+
 		if s.Type == datastore.SubscriptionTypeCLI {
 			event.Endpoints = []string{}
 			eventDelivery.CLIMetadata = &datastore.CLIMetadata{
@@ -498,7 +516,9 @@ func buildEvent(ctx context.Context, eventRepo datastore.EventRepository, endpoi
 	eventParams *CreateEventTaskParams, project *datastore.Project,
 ) (*datastore.Event, error) {
 	var isDuplicate bool
-	if !util.IsStringEmpty(eventParams.IdempotencyKey) {
+	if eventParams.IsDuplicate != nil {
+		isDuplicate = *eventParams.IsDuplicate
+	} else if !util.IsStringEmpty(eventParams.IdempotencyKey) {
 		events, err := eventRepo.FindEventsByIdempotencyKey(ctx, project.UID, eventParams.IdempotencyKey)
 		if err != nil {
 			return nil, err
@@ -543,6 +563,7 @@ func buildEvent(ctx context.Context, eventRepo datastore.EventRepository, endpoi
 		Endpoints:        endpointIDs,
 		SourceID:         eventParams.SourceID,
 		ProjectID:        project.UID,
+		SchemaStatus:     eventParams.SchemaStatus,
 	}
 
 	if (project.Config == nil || project.Config.Strategy == nil) ||
@@ -589,3 +610,24 @@ func findEndpoints(ctx context.Context, endpointRepo datastore.EndpointRepositor
 
 	return endpoints, nil
 }
+
+// getOrderingKey resolves an endpoint ordering key against the event, header.
+// prefixed keys are read from the event headers, every other key is a path
+// into the event payload.
+func getOrderingKey(key string, event *datastore.Event) string {
+	if util.IsStringEmpty(key) {
+		return ""
+	}
+
+	if name, ok := strings.CutPrefix(key, "header."); ok {
+		for k, v := range event.Headers {
+			if strings.EqualFold(k, name) && len(v) > 0 {
+				return v[0]
+			}
+		}
+
+		return ""
+	}
+
+	return gjson.GetBytes(event.Data, key).String()
+}
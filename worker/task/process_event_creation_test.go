@@ -15,6 +15,7 @@ import (
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/hibiken/asynq"
 	"github.com/oklog/ulid/v2"
@@ -248,6 +249,55 @@ func TestProcessEventCreated(t *testing.T) {
 			wantErr: false,
 		},
 
+		{
+			name: "should_not_look_up_idempotency_key_checked_against_dedup_window",
+			event: &CreateEvent{
+				Event: &datastore.Event{
+					UID:              ulid.Make().String(),
+					EventType:        "*",
+					SourceID:         "source-id-1",
+					ProjectID:        "project-id-1",
+					Endpoints:        []string{"endpoint-id-1"},
+					Data:             []byte(`{}`),
+					IdempotencyKey:   "key-1",
+					IsDuplicateEvent: true,
+					CreatedAt:        time.Now(),
+					UpdatedAt:        time.Now(),
+				},
+				DedupChecked: true,
+			},
+			dbFn: func(args *args) {
+				project := &datastore.Project{
+					UID:  "project-id-1",
+					Type: datastore.IncomingProject,
+					Config: &datastore.ProjectConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       datastore.LinearStrategyProvider,
+							Duration:   10,
+							RetryCount: 3,
+						},
+					},
+				}
+
+				g, _ := args.projectRepo.(*mocks.MockProjectRepository)
+				g.EXPECT().FetchProjectByID(gomock.Any(), "project-id-1").Times(1).Return(
+					project,
+					nil,
+				)
+
+				e, _ := args.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, datastore.ErrEventNotFound)
+				e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, event *datastore.Event) error {
+					require.True(t, event.IsDuplicateEvent)
+					return nil
+				})
+
+				q, _ := args.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.MatchEventSubscriptionsProcessor, convoy.EventWorkflowQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			wantErr: false,
+		},
+
 		{
 			name: "should_process_replayed_event",
 			event: &CreateEvent{
@@ -760,3 +810,43 @@ func TestMatchSubscriptionsUsingFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOrderingKey(t *testing.T) {
+	event := &datastore.Event{
+		Data:    json.RawMessage(`{"data": {"account_id": "acc_1"}}`),
+		Headers: httpheader.HTTPHeader{"X-Account-Id": []string{"acc_2"}},
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{
+			name: "should_serialize_whole_endpoint",
+			key:  "",
+			want: "",
+		},
+		{
+			name: "should_read_key_from_payload",
+			key:  "data.account_id",
+			want: "acc_1",
+		},
+		{
+			name: "should_read_key_from_headers",
+			key:  "header.x-account-id",
+			want: "acc_2",
+		},
+		{
+			name: "should_return_empty_key_for_missing_field",
+			key:  "data.tenant_id",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getOrderingKey(tt.key, event))
+		})
+	}
+}
@@ -45,6 +45,12 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 				return
 			}
 
+			// batched deliveries wait on the event queue for their batch to fill up
+			var batchErr *BatchError
+			if errors.As(err, &batchErr) {
+				return
+			}
+
 			// set the error to nil, so it's removed from the event queue
 			err = nil
 
@@ -112,6 +118,26 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			return nil
 		}
 
+		// deliveries wait until the endpoint's url has been verified
+		if endpoint.AwaitingVerification() {
+			log.FromContext(ctx).Debugf("%s is waiting for %s to be verified", eventDelivery.UID, endpoint.Url)
+			delayDuration = verificationRetryDelay
+			return &VerificationError{Err: ErrEndpointNotVerified, delay: verificationRetryDelay}
+		}
+
+		if endpoint.OrderedDelivery {
+			blocked, err := eventDeliveryRepo.HasPendingPredecessor(ctx, project.UID, eventDelivery)
+			if err != nil {
+				return &DeliveryError{Err: err}
+			}
+
+			if blocked {
+				log.FromContext(ctx).Debugf("%s is waiting for earlier event deliveries to %s", eventDelivery.UID, endpoint.Url)
+				delayDuration = defaultDelay
+				return &OrderingError{Err: ErrDeliveryBlocked, delay: defaultDelay}
+			}
+		}
+
 		err = rateLimiter.AllowWithDuration(ctx, endpoint.UID, endpoint.RateLimit, int(endpoint.RateLimitDuration))
 		if err != nil {
 			log.FromContext(ctx).WithFields(map[string]interface{}{"event_delivery_id": data.EventDeliveryID}).
@@ -128,6 +154,16 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			}
 		}
 
+		if endpoint.BatchDelivery && endpoint.Status != datastore.InactiveEndpointStatus {
+			// the delivery was claimed by another batch, failed batches are
+			// retried from the retry queue so this job has nothing left to do.
+			if eventDelivery.Status != datastore.ScheduledEventStatus {
+				return nil
+			}
+
+			return sendEventDeliveryBatch(ctx, cfg, eventDelivery, endpoint, project, eventDeliveryRepo, attemptsRepo, q, dispatch, licenser, tracerBackend)
+		}
+
 		err = eventDeliveryRepo.UpdateStatusOfEventDelivery(ctx, project.UID, *eventDelivery, datastore.ProcessingEventStatus)
 		if err != nil {
 			return &DeliveryError{Err: err}
@@ -150,8 +186,22 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			return nil
 		}
 
-		sig := newSignature(endpoint, project, json.RawMessage(eventDelivery.Metadata.Raw))
-		header, err := sig.ComputeHeaderValue()
+		payload, isJSON, err := encodeDeliveryPayload(endpoint, eventDelivery)
+		if errors.Is(err, ErrUnsupportedFormPayload) {
+			log.FromContext(ctx).WithError(err).Errorf("%s failed, its payload cannot be encoded for %s", eventDelivery.UID, endpoint.Url)
+			err = failUnencodableDelivery(ctx, eventDeliveryRepo, eventDelivery, err)
+			if err != nil {
+				return &DeliveryError{Err: err}
+			}
+
+			return nil
+		}
+
+		if err != nil {
+			return &DeliveryError{Err: err}
+		}
+
+		signatureHeader, header, err := signEventDelivery(endpoint, project, eventDelivery, payload, isJSON)
 		if err != nil {
 			return &DeliveryError{Err: err}
 		}
@@ -182,7 +232,7 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 		} else {
 			httpDuration = time.Duration(endpoint.HttpTimeout) * time.Second
 		}
-		resp, err := dispatch.SendRequest(ctx, targetURL, string(convoy.HttpPost), sig.Payload, project.Config.Signature.Header.String(), header, int64(cfg.MaxResponseSize), eventDelivery.Headers, eventDelivery.IdempotencyKey, httpDuration)
+		resp, err := dispatch.SendRequest(withEndpointCredentials(ctx, endpoint), targetURL, endpoint.DeliveryMethod(), payload, deliveryContentType(endpoint, eventDelivery), signatureHeader, header, int64(cfg.MaxResponseSize), eventDelivery.Headers, eventDelivery.IdempotencyKey, httpDuration)
 
 		status := "-"
 		statusCode := 0
@@ -196,11 +246,12 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 		requestLogger := log.FromContext(ctx).WithFields(log.Fields{
 			"status":          status,
 			"uri":             targetURL,
-			"method":          convoy.HttpPost,
+			"method":          endpoint.DeliveryMethod(),
 			"duration":        duration,
 			"eventDeliveryID": eventDelivery.UID,
 		})
 
+		action := datastore.RetryStatusCodeAction
 		if err == nil && statusCode >= 200 && statusCode <= 299 {
 			requestLogger.Debugf("%s sent", eventDelivery.UID)
 			attemptStatus = true
@@ -217,14 +268,30 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			requestLogger.Errorf("%s", eventDelivery.UID)
 			done = false
 
-			eventDelivery.Status = datastore.RetryEventStatus
+			action = resolveStatusCodeAction(endpoint, project, resp)
+			switch action {
+			case datastore.DiscardStatusCodeAction:
+				eventDelivery.Status = datastore.DiscardedEventStatus
+				eventDelivery.Description = fmt.Sprintf("Endpoint responded with status code %d, event delivery discarded", statusCode)
+				log.FromContext(ctx).Errorf("%s discarded, endpoint responded with status code %d", eventDelivery.UID, statusCode)
+			case datastore.FailStatusCodeAction:
+				eventDelivery.Status = datastore.FailureEventStatus
+				eventDelivery.Description = fmt.Sprintf("Endpoint responded with non-retryable status code %d", statusCode)
+				log.FromContext(ctx).Errorf("%s failed, endpoint responded with non-retryable status code %d", eventDelivery.UID, statusCode)
+			default:
+				if retryAfter, ok := retryAfterDelay(resp, cfg.MaxRetrySeconds); ok {
+					delayDuration = retryAfter
+				}
+
+				eventDelivery.Status = datastore.RetryEventStatus
 
-			nextTime := time.Now().Add(delayDuration)
-			eventDelivery.Metadata.NextSendTime = nextTime
-			attempts := eventDelivery.Metadata.NumTrials + 1
+				nextTime := time.Now().Add(delayDuration)
+				eventDelivery.Metadata.NextSendTime = nextTime
+				attempts := eventDelivery.Metadata.NumTrials + 1
 
-			log.FromContext(ctx).Errorf("%s next retry time is %s (strategy = %s, delay = %d, attempts = %d/%d)\n", eventDelivery.UID,
-				nextTime.Format(time.ANSIC), eventDelivery.Metadata.Strategy, eventDelivery.Metadata.IntervalSeconds, attempts, eventDelivery.Metadata.RetryLimit)
+				log.FromContext(ctx).Errorf("%s next retry time is %s (strategy = %s, delay = %d, attempts = %d/%d)\n", eventDelivery.UID,
+					nextTime.Format(time.ANSIC), eventDelivery.Metadata.Strategy, eventDelivery.Metadata.IntervalSeconds, attempts, eventDelivery.Metadata.RetryLimit)
+			}
 		}
 		tracerBackend.Capture(project, targetURL, resp, duration)
 
@@ -261,16 +328,19 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 
 		eventDelivery.Metadata.NumTrials++
 
+		// deliveries failed by a status code policy won't be retried either
+		deadLettered := action == datastore.FailStatusCodeAction
 		if eventDelivery.Metadata.NumTrials >= eventDelivery.Metadata.RetryLimit {
 			if done {
 				if eventDelivery.Status != datastore.SuccessEventStatus {
 					log.FromContext(ctx).Error("an anomaly has occurred. retry limit exceeded, fan out is done but event status is not successful")
 					eventDelivery.Status = datastore.FailureEventStatus
 				}
-			} else {
+			} else if action == datastore.RetryStatusCodeAction {
 				log.FromContext(ctx).Errorf("%s retry limit exceeded ", eventDelivery.UID)
 				eventDelivery.Description = "Retry limit exceeded"
 				eventDelivery.Status = datastore.FailureEventStatus
+				deadLettered = true
 			}
 
 			if endpoint.Status != datastore.PendingEndpointStatus && project.Config.DisableEndpoint && !licenser.CircuitBreaking() {
@@ -305,7 +375,11 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			return &DeliveryError{Err: fmt.Errorf("%s, err: %s", ErrDeliveryAttemptFailed, err.Error())}
 		}
 
-		if !done && eventDelivery.Metadata.NumTrials < eventDelivery.Metadata.RetryLimit {
+		if deadLettered {
+			queueDeadLetter(ctx, q, eventDelivery, &attempt)
+		}
+
+		if !done && action == datastore.RetryStatusCodeAction && eventDelivery.Metadata.NumTrials < eventDelivery.Metadata.RetryLimit {
 			errS := "nil"
 			if err != nil {
 				errS = err.Error()
@@ -6,6 +6,8 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/internal/pkg/tracer"
 	"github.com/frain-dev/convoy/pkg/log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -30,6 +32,11 @@ import (
 )
 
 func TestProcessEventDelivery(t *testing.T) {
+	badRequestServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer badRequestServer.Close()
+
 	tt := []struct {
 		name          string
 		cfgPath       string
@@ -195,6 +202,239 @@ func TestProcessEventDelivery(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:          "Endpoint responds with non-retryable status code",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: nil,
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockEndpointRepository, o *mocks.MockProjectRepository, m *mocks.MockEventDeliveryRepository, q *mocks.MockQueuer, r *mocks.MockRateLimiter, d *mocks.MockDeliveryAttemptsRepository, l *mocks.MockLicenser) {
+				a.EXPECT().FindEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.Endpoint{
+						ProjectID:         "123",
+						Url:               badRequestServer.URL,
+						RateLimit:         10,
+						RateLimitDuration: 60,
+						Secrets: []datastore.Secret{
+							{Value: "secret"},
+						},
+						Status: datastore.ActiveEndpointStatus,
+						StatusCodePolicies: datastore.StatusCodePolicies{
+							{StatusCodes: []int{400}, Action: datastore.FailStatusCodeAction},
+						},
+					}, nil)
+
+				r.EXPECT().AllowWithDuration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+				m.EXPECT().
+					FindEventDeliveryByIDSlim(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`{"event": "invoice.completed"}`),
+							Raw:             `{"event": "invoice.completed"}`,
+							NumTrials:       0,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+						Status: datastore.ScheduledEventStatus,
+					}, nil).Times(1)
+
+				o.EXPECT().
+					FetchProjectByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Project{
+						LogoURL: "",
+						Config: &datastore.ProjectConfig{
+							Signature: &datastore.SignatureConfiguration{
+								Header: "X-Convoy-Signature",
+								Versions: []datastore.SignatureVersion{
+									{
+										UID:      "abc",
+										Hash:     "SHA256",
+										Encoding: datastore.HexEncoding,
+									},
+								},
+							},
+							SSL: &datastore.DefaultSSLConfig,
+							Strategy: &datastore.StrategyConfiguration{
+								Type:       datastore.LinearStrategyProvider,
+								Duration:   60,
+								RetryCount: 1,
+							},
+							RateLimit: &datastore.DefaultRateLimitConfig,
+						},
+					}, nil).Times(1)
+
+				m.EXPECT().
+					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+
+				d.EXPECT().CreateDeliveryAttempt(gomock.Any(), gomock.Any()).Times(1)
+
+				m.EXPECT().
+					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
+				l.EXPECT().UseForwardProxy().Times(1).Return(true)
+				l.EXPECT().IpRules().AnyTimes().Return(false)
+			},
+		},
+		{
+			name:          "Payload cannot be form encoded",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: nil,
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockEndpointRepository, o *mocks.MockProjectRepository, m *mocks.MockEventDeliveryRepository, q *mocks.MockQueuer, r *mocks.MockRateLimiter, d *mocks.MockDeliveryAttemptsRepository, l *mocks.MockLicenser) {
+				a.EXPECT().FindEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.Endpoint{
+						ProjectID:         "123",
+						RateLimit:         10,
+						RateLimitDuration: 60,
+						BodyEncoding:      datastore.FormBodyEncoding,
+						Secrets: []datastore.Secret{
+							{Value: "secret"},
+						},
+						Status: datastore.ActiveEndpointStatus,
+					}, nil)
+
+				r.EXPECT().AllowWithDuration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+				m.EXPECT().
+					FindEventDeliveryByIDSlim(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`[1, 2]`),
+							Raw:             `[1, 2]`,
+							NumTrials:       0,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+						Status: datastore.ScheduledEventStatus,
+					}, nil).Times(1)
+
+				o.EXPECT().
+					FetchProjectByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Project{
+						Config: &datastore.ProjectConfig{
+							SSL:       &datastore.DefaultSSLConfig,
+							RateLimit: &datastore.DefaultRateLimitConfig,
+						},
+					}, nil).Times(1)
+
+				m.EXPECT().
+					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any(), datastore.ProcessingEventStatus).
+					Return(nil).Times(1)
+
+				m.EXPECT().
+					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, eventDelivery *datastore.EventDelivery) error {
+						require.Equal(t, datastore.FailureEventStatus, eventDelivery.Status)
+						require.Contains(t, eventDelivery.Description, ErrUnsupportedFormPayload.Error())
+						return nil
+					}).Times(1)
+
+				l.EXPECT().UseForwardProxy().Times(1).Return(true)
+				l.EXPECT().IpRules().Times(2).Return(true)
+			},
+		},
+		{
+			name:          "Batched delivery was claimed by another batch",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: nil,
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockEndpointRepository, o *mocks.MockProjectRepository, m *mocks.MockEventDeliveryRepository, q *mocks.MockQueuer, r *mocks.MockRateLimiter, d *mocks.MockDeliveryAttemptsRepository, l *mocks.MockLicenser) {
+				a.EXPECT().FindEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.Endpoint{
+						ProjectID:         "123",
+						RateLimit:         10,
+						RateLimitDuration: 60,
+						BatchDelivery:     true,
+						Status:            datastore.ActiveEndpointStatus,
+					}, nil)
+
+				r.EXPECT().AllowWithDuration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+				m.EXPECT().
+					FindEventDeliveryByIDSlim(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`{"event": "invoice.completed"}`),
+							Raw:             `{"event": "invoice.completed"}`,
+							NumTrials:       1,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+						Status: datastore.RetryEventStatus,
+					}, nil).Times(1)
+
+				o.EXPECT().
+					FetchProjectByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Project{
+						Config: &datastore.ProjectConfig{
+							SSL:       &datastore.DefaultSSLConfig,
+							RateLimit: &datastore.DefaultRateLimitConfig,
+						},
+					}, nil).Times(1)
+
+				l.EXPECT().UseForwardProxy().Times(1).Return(true)
+				l.EXPECT().IpRules().Times(2).Return(true)
+			},
+		},
+		{
+			name:          "Endpoint is awaiting verification",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: nil,
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockEndpointRepository, o *mocks.MockProjectRepository, m *mocks.MockEventDeliveryRepository, q *mocks.MockQueuer, r *mocks.MockRateLimiter, d *mocks.MockDeliveryAttemptsRepository, l *mocks.MockLicenser) {
+				a.EXPECT().FindEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.Endpoint{
+						ProjectID:            "123",
+						Url:                  "https://example.com",
+						Status:               datastore.PendingEndpointStatus,
+						VerificationRequired: true,
+					}, nil)
+
+				// the delivery waits on the retry queue until the endpoint is verified
+				q.EXPECT().Write(convoy.RetryEventProcessor, convoy.RetryEventQueue, gomock.Any()).
+					DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+						require.Equal(t, verificationRetryDelay, job.Delay)
+						return nil
+					})
+
+				m.EXPECT().
+					FindEventDeliveryByIDSlim(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`{"event": "invoice.completed"}`),
+							Raw:             `{"event": "invoice.completed"}`,
+							NumTrials:       1,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+						Status: datastore.RetryEventStatus,
+					}, nil).Times(1)
+
+				o.EXPECT().
+					FetchProjectByID(gomock.Any(), gomock.Any()).
+					Return(&datastore.Project{
+						Config: &datastore.ProjectConfig{
+							SSL:       &datastore.DefaultSSLConfig,
+							RateLimit: &datastore.DefaultRateLimitConfig,
+						},
+					}, nil).Times(1)
+
+				l.EXPECT().UseForwardProxy().Times(1).Return(true)
+				l.EXPECT().IpRules().Times(2).Return(true)
+			},
+		},
 		{
 			name:          "Max retries reached - disabled endpoint - failed",
 			cfgPath:       "./testdata/Config/basic-convoy-disable-endpoint.json",
@@ -269,6 +509,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				l.EXPECT().UseForwardProxy().Times(1).Return(true)
 				l.EXPECT().IpRules().Times(3).Return(true)
 				l.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -359,6 +601,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				l.EXPECT().UseForwardProxy().Times(1).Return(true)
 				l.EXPECT().IpRules().Times(3).Return(true)
 				l.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -449,6 +693,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				l.EXPECT().UseForwardProxy().Times(1).Return(true)
 				l.EXPECT().IpRules().Times(3).Return(true)
 				l.EXPECT().CircuitBreaking().Times(1).Return(false)
@@ -541,6 +787,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				l.EXPECT().UseForwardProxy().Times(1).Return(true)
 				l.EXPECT().IpRules().Times(3).Return(true)
 				l.EXPECT().CircuitBreaking().Times(1).Return(false)
@@ -630,6 +878,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				l.EXPECT().UseForwardProxy().Times(1).Return(true)
 				l.EXPECT().IpRules().Times(3).Return(true)
 				l.EXPECT().CircuitBreaking().Times(1).Return(false)
@@ -719,6 +969,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				l.EXPECT().UseForwardProxy().Times(1).Return(true)
 				l.EXPECT().IpRules().Times(3).Return(true)
 				l.EXPECT().CircuitBreaking().Times(1).Return(false)
@@ -809,6 +1061,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				q.EXPECT().
 					Write(convoy.NotificationProcessor, convoy.DefaultQueue, gomock.Any()).
 					Return(nil).Times(1)
@@ -904,6 +1158,8 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				q.EXPECT().
 					Write(convoy.NotificationProcessor, convoy.DefaultQueue, gomock.Any()).
 					Return(nil).Times(1)
@@ -0,0 +1,70 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/keys"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+)
+
+type KeyRotation struct {
+	JobID string `json:"job_id"`
+}
+
+// ProcessKeyRotation re-encrypts credentials with the new encryption key.
+// The job saves its progress after every batch, so a retried task resumes
+// where the last attempt stopped.
+func ProcessKeyRotation(lo log.StdLogger, db database.Database, jobRepo datastore.JobRepository) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data KeyRotation
+
+		err := msgpack.DecodeMsgPack(t.Payload(), &data)
+		if err != nil {
+			return err
+		}
+
+		km, err := keys.Get()
+		if err != nil {
+			return err
+		}
+
+		err = keys.RunKeyRotation(ctx, lo, db, jobRepo, km, data.JobID)
+		if err != nil {
+			lo.WithError(err).Errorf("failed to rotate encryption key in job %s", data.JobID)
+
+			// retrying can't help until the rotation is started again
+			if errors.Is(err, keys.ErrKeyRotationStateLost) || errors.Is(err, keys.ErrKeyChangedInRotation) {
+				return fmt.Errorf("%w: %v", asynq.SkipRetry, err)
+			}
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// QueueKeyRotation queues the key rotation job, every call queues a new task
+// so a rotation that failed can be resumed.
+func QueueKeyRotation(q queue.Queuer, jobID string) error {
+	payload, err := msgpack.EncodeMsgPack(KeyRotation{JobID: jobID})
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      fmt.Sprintf("key-rotation:%s:%d", jobID, time.Now().UnixNano()),
+		Payload: payload,
+	}
+
+	return q.Write(convoy.KeyRotationProcessor, convoy.DefaultQueue, job)
+}
@@ -148,7 +148,7 @@ func sendUrlRequest(ctx context.Context, project *datastore.Project, metaEvent *
 
 	httpDuration := convoy.HTTP_TIMEOUT_IN_DURATION
 	start := time.Now()
-	resp, err := dispatch.SendRequest(ctx, url, string(convoy.HttpPost), sig.Payload, "X-Convoy-Signature", header, int64(cfg.MaxResponseSize), httpheader.HTTPHeader{}, dedup.GenerateChecksum(metaEvent.UID), httpDuration)
+	resp, err := dispatch.SendRequest(ctx, url, string(convoy.HttpPost), sig.Payload, "application/json", "X-Convoy-Signature", header, int64(cfg.MaxResponseSize), httpheader.HTTPHeader{}, dedup.GenerateChecksum(metaEvent.UID), httpDuration)
 	if err != nil {
 		return nil, err
 	}
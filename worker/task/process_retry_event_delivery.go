@@ -9,6 +9,7 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/license"
 	tracer2 "github.com/frain-dev/convoy/internal/pkg/tracer"
 	"github.com/frain-dev/convoy/pkg/circuit_breaker"
+	"net/http"
 	"time"
 
 	"github.com/frain-dev/convoy/internal/pkg/limiter"
@@ -37,6 +38,8 @@ import (
 var (
 	ErrDeliveryAttemptFailed = errors.New("error sending event")
 	ErrRateLimit             = errors.New("rate limit error")
+	ErrDeliveryBlocked       = errors.New("event delivery is blocked by earlier event deliveries")
+	ErrEndpointNotVerified   = errors.New("endpoint is awaiting verification")
 	defaultDelay             = 10 * time.Second
 	defaultEventDelay        = 120 * time.Second
 )
@@ -91,6 +94,24 @@ func ProcessRetryEventDelivery(endpointRepo datastore.EndpointRepository, eventD
 			return nil
 		}
 
+		// deliveries wait until the endpoint's url has been verified
+		if endpoint.AwaitingVerification() {
+			log.FromContext(ctx).Debugf("%s is waiting for %s to be verified", eventDelivery.UID, endpoint.Url)
+			return &VerificationError{Err: ErrEndpointNotVerified, delay: verificationRetryDelay}
+		}
+
+		if endpoint.OrderedDelivery {
+			blocked, err := eventDeliveryRepo.HasPendingPredecessor(ctx, project.UID, eventDelivery)
+			if err != nil {
+				return &EndpointError{Err: err, delay: defaultEventDelay}
+			}
+
+			if blocked {
+				log.FromContext(ctx).Debugf("%s is waiting for earlier event deliveries to %s", eventDelivery.UID, endpoint.Url)
+				return &OrderingError{Err: ErrDeliveryBlocked, delay: defaultDelay}
+			}
+		}
+
 		err = rateLimiter.AllowWithDuration(ctx, endpoint.UID, endpoint.RateLimit, int(endpoint.RateLimitDuration))
 		if err != nil {
 			log.FromContext(ctx).WithFields(map[string]interface{}{"event_delivery id": data.EventDeliveryID}).
@@ -147,8 +168,22 @@ func ProcessRetryEventDelivery(endpointRepo datastore.EndpointRepository, eventD
 			return nil
 		}
 
-		sig := newSignature(endpoint, project, json.RawMessage(eventDelivery.Metadata.Raw))
-		header, err := sig.ComputeHeaderValue()
+		payload, isJSON, err := encodeDeliveryPayload(endpoint, eventDelivery)
+		if errors.Is(err, ErrUnsupportedFormPayload) {
+			log.FromContext(ctx).WithError(err).Errorf("%s failed, its payload cannot be encoded for %s", eventDelivery.UID, endpoint.Url)
+			err = failUnencodableDelivery(ctx, eventDeliveryRepo, eventDelivery, err)
+			if err != nil {
+				return &EndpointError{Err: err, delay: defaultEventDelay}
+			}
+
+			return nil
+		}
+
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultEventDelay}
+		}
+
+		signatureHeader, header, err := signEventDelivery(endpoint, project, eventDelivery, payload, isJSON)
 		if err != nil {
 			return &EndpointError{Err: err, delay: defaultEventDelay}
 		}
@@ -179,7 +214,7 @@ func ProcessRetryEventDelivery(endpointRepo datastore.EndpointRepository, eventD
 		} else {
 			httpDuration = time.Duration(endpoint.HttpTimeout) * time.Second
 		}
-		resp, err := dispatch.SendRequest(ctx, targetURL, string(convoy.HttpPost), sig.Payload, project.Config.Signature.Header.String(), header, int64(cfg.MaxResponseSize), eventDelivery.Headers, eventDelivery.IdempotencyKey, httpDuration)
+		resp, err := dispatch.SendRequest(withEndpointCredentials(ctx, endpoint), targetURL, endpoint.DeliveryMethod(), payload, deliveryContentType(endpoint, eventDelivery), signatureHeader, header, int64(cfg.MaxResponseSize), eventDelivery.Headers, eventDelivery.IdempotencyKey, httpDuration)
 
 		status := "-"
 		statusCode := 0
@@ -193,11 +228,12 @@ func ProcessRetryEventDelivery(endpointRepo datastore.EndpointRepository, eventD
 		requestLogger := log.FromContext(ctx).WithFields(log.Fields{
 			"status":          status,
 			"uri":             targetURL,
-			"method":          convoy.HttpPost,
+			"method":          endpoint.DeliveryMethod(),
 			"duration":        duration,
 			"eventDeliveryID": eventDelivery.UID,
 		})
 
+		action := datastore.RetryStatusCodeAction
 		if err == nil && statusCode >= 200 && statusCode <= 299 {
 			requestLogger.Debugf("%s sent", eventDelivery.UID)
 			attemptStatus = true
@@ -209,14 +245,30 @@ func ProcessRetryEventDelivery(endpointRepo datastore.EndpointRepository, eventD
 			requestLogger.Errorf("%s", eventDelivery.UID)
 			done = false
 
-			eventDelivery.Status = datastore.RetryEventStatus
+			action = resolveStatusCodeAction(endpoint, project, resp)
+			switch action {
+			case datastore.DiscardStatusCodeAction:
+				eventDelivery.Status = datastore.DiscardedEventStatus
+				eventDelivery.Description = fmt.Sprintf("Endpoint responded with status code %d, event delivery discarded", statusCode)
+				log.FromContext(ctx).Errorf("%s discarded, endpoint responded with status code %d", eventDelivery.UID, statusCode)
+			case datastore.FailStatusCodeAction:
+				eventDelivery.Status = datastore.FailureEventStatus
+				eventDelivery.Description = fmt.Sprintf("Endpoint responded with non-retryable status code %d", statusCode)
+				log.FromContext(ctx).Errorf("%s failed, endpoint responded with non-retryable status code %d", eventDelivery.UID, statusCode)
+			default:
+				if retryAfter, ok := retryAfterDelay(resp, cfg.MaxRetrySeconds); ok {
+					delayDuration = retryAfter
+				}
 
-			nextTime := time.Now().Add(delayDuration)
-			eventDelivery.Metadata.NextSendTime = nextTime
-			attempts := eventDelivery.Metadata.NumTrials + 1
+				eventDelivery.Status = datastore.RetryEventStatus
 
-			log.FromContext(ctx).Errorf("%s next retry time is %s (strategy = %s, delay = %d, attempts = %d/%d)\n", eventDelivery.UID,
-				nextTime.Format(time.ANSIC), eventDelivery.Metadata.Strategy, eventDelivery.Metadata.IntervalSeconds, attempts, eventDelivery.Metadata.RetryLimit)
+				nextTime := time.Now().Add(delayDuration)
+				eventDelivery.Metadata.NextSendTime = nextTime
+				attempts := eventDelivery.Metadata.NumTrials + 1
+
+				log.FromContext(ctx).Errorf("%s next retry time is %s (strategy = %s, delay = %d, attempts = %d/%d)\n", eventDelivery.UID,
+					nextTime.Format(time.ANSIC), eventDelivery.Metadata.Strategy, eventDelivery.Metadata.IntervalSeconds, attempts, eventDelivery.Metadata.RetryLimit)
+			}
 		}
 		tracerBackend.Capture(project, targetURL, resp, duration)
 
@@ -253,16 +305,19 @@ func ProcessRetryEventDelivery(endpointRepo datastore.EndpointRepository, eventD
 
 		eventDelivery.Metadata.NumTrials++
 
+		// deliveries failed by a status code policy won't be retried either
+		deadLettered := action == datastore.FailStatusCodeAction
 		if eventDelivery.Metadata.NumTrials >= eventDelivery.Metadata.RetryLimit {
 			if done {
 				if eventDelivery.Status != datastore.SuccessEventStatus {
 					log.FromContext(ctx).Error("an anomaly has occurred. retry limit exceeded, fan out is done but event status is not successful")
 					eventDelivery.Status = datastore.FailureEventStatus
 				}
-			} else {
+			} else if action == datastore.RetryStatusCodeAction {
 				log.FromContext(ctx).Errorf("%s retry limit exceeded ", eventDelivery.UID)
 				eventDelivery.Description = "Retry limit exceeded"
 				eventDelivery.Status = datastore.FailureEventStatus
+				deadLettered = true
 			}
 
 			if endpoint.Status != datastore.PendingEndpointStatus && project.Config.DisableEndpoint && !licenser.CircuitBreaking() {
@@ -297,7 +352,11 @@ func ProcessRetryEventDelivery(endpointRepo datastore.EndpointRepository, eventD
 			return &EndpointError{Err: fmt.Errorf("%s, err: %s", ErrDeliveryAttemptFailed, err.Error()), delay: defaultEventDelay}
 		}
 
-		if !done && eventDelivery.Metadata.NumTrials < eventDelivery.Metadata.RetryLimit {
+		if deadLettered {
+			queueDeadLetter(ctx, q, eventDelivery, &attempt)
+		}
+
+		if !done && action == datastore.RetryStatusCodeAction && eventDelivery.Metadata.NumTrials < eventDelivery.Metadata.RetryLimit {
 			errS := "nil"
 			if err != nil {
 				errS = err.Error()
@@ -330,6 +389,68 @@ func newSignature(endpoint *datastore.Endpoint, g *datastore.Project, data json.
 	return s
 }
 
+// signEventDelivery returns the signature header and its value for the event delivery.
+// The standard webhooks id and timestamp headers are added to the event delivery headers.
+func signEventDelivery(endpoint *datastore.Endpoint, project *datastore.Project, eventDelivery *datastore.EventDelivery, payload json.RawMessage, isJSON bool) (string, string, error) {
+	if !project.Config.Signature.IsStandardWebhooks() {
+		sig := newSignature(endpoint, project, payload)
+		sig.Raw = !isJSON
+
+		header, err := sig.ComputeHeaderValue()
+		return project.Config.Signature.Header.String(), header, err
+	}
+
+	sw := &signature.StandardWebhooks{
+		ID:         eventDelivery.UID,
+		Payload:    payload,
+		Asymmetric: project.Config.Signature.Asymmetric,
+	}
+
+	for _, sc := range endpoint.Secrets {
+		if sc.DeletedAt.IsZero() {
+			sw.Secrets = append(sw.Secrets, sc.Value)
+		}
+	}
+
+	headers, err := sw.ComputeHeaders()
+	if err != nil {
+		return "", "", err
+	}
+
+	if eventDelivery.Headers == nil {
+		eventDelivery.Headers = httpheader.HTTPHeader{}
+	}
+	eventDelivery.Headers[signature.StandardWebhooksIDHeader] = []string{headers[signature.StandardWebhooksIDHeader]}
+	eventDelivery.Headers[signature.StandardWebhooksTimestampHeader] = []string{headers[signature.StandardWebhooksTimestampHeader]}
+
+	return signature.StandardWebhooksSignatureHeader, headers[signature.StandardWebhooksSignatureHeader], nil
+}
+
+// withEndpointCredentials makes the dispatcher present the endpoint's mutual tls
+// client certificate and authenticate with its oauth2 access token.
+func withEndpointCredentials(ctx context.Context, endpoint *datastore.Endpoint) context.Context {
+	if endpoint.MtlsClientCert != nil {
+		ctx = net.ContextWithClientCertificate(ctx, &net.ClientCertificate{
+			ClientCert: endpoint.MtlsClientCert.ClientCert,
+			ClientKey:  endpoint.MtlsClientCert.ClientKey,
+			CACert:     endpoint.MtlsClientCert.CACert,
+		})
+	}
+
+	auth := endpoint.Authentication
+	if auth != nil && auth.Type == datastore.OAuth2Authentication && auth.OAuth2 != nil {
+		ctx = net.ContextWithOAuth2(ctx, &net.OAuth2Config{
+			TokenURL:     auth.OAuth2.TokenURL,
+			ClientID:     auth.OAuth2.ClientID,
+			ClientSecret: auth.OAuth2.ClientSecret,
+			Scopes:       auth.OAuth2.Scopes,
+			Audience:     auth.OAuth2.Audience,
+		})
+	}
+
+	return ctx
+}
+
 func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.Endpoint, resp *net.Response, attemptStatus bool) datastore.DeliveryAttempt {
 	responseHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.ResponseHeader)
 	requestHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.RequestHeader)
@@ -355,3 +476,39 @@ func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.Endpoint,
 		UpdatedAt: time.Now(),
 	}
 }
+
+// resolveStatusCodeAction returns what should happen to an event delivery after
+// the endpoint failed to respond with a 2xx. Endpoint policies take precedence
+// over the project's retry strategy policies, deliveries are retried otherwise.
+func resolveStatusCodeAction(endpoint *datastore.Endpoint, project *datastore.Project, resp *net.Response) datastore.StatusCodeAction {
+	if resp == nil || resp.StatusCode == 0 {
+		return datastore.RetryStatusCodeAction
+	}
+
+	if action, ok := endpoint.StatusCodePolicies.Action(resp.StatusCode); ok {
+		return action
+	}
+
+	if project.Config != nil && project.Config.Strategy != nil {
+		if action, ok := project.Config.Strategy.StatusCodePolicies.Action(resp.StatusCode); ok {
+			return action
+		}
+	}
+
+	return datastore.RetryStatusCodeAction
+}
+
+// retryAfterDelay returns the delay an endpoint asked for in its Retry-After
+// header when it responded with 429 Too Many Requests or 503 Service Unavailable.
+func retryAfterDelay(resp *net.Response, maxRetrySeconds uint64) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return retrystrategies.RetryAfter(resp.ResponseHeader, maxRetrySeconds)
+	}
+
+	return 0, false
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/internal/pkg/tracer"
 	"github.com/frain-dev/convoy/pkg/log"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/frain-dev/convoy/internal/pkg/license"
@@ -74,6 +76,44 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 				licenser.EXPECT().IpRules().Times(2).Return(true)
 			},
 		},
+		{
+			name:          "Endpoint is awaiting verification",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: &VerificationError{Err: ErrEndpointNotVerified, delay: verificationRetryDelay},
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockEndpointRepository, o *mocks.MockProjectRepository, m *mocks.MockEventDeliveryRepository, q *mocks.MockQueuer, r *mocks.MockRateLimiter, d *mocks.MockDeliveryAttemptsRepository, l license.Licenser) {
+				m.EXPECT().
+					FindEventDeliveryByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						EndpointID:     "endpoint-id-1",
+						SubscriptionID: "sub-id-1",
+						ProjectID:      "project-id-1",
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`{"event": "invoice.completed"}`),
+							NumTrials:       1,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+						Status: datastore.RetryEventStatus,
+					}, nil).Times(1)
+
+				endpoint := &datastore.Endpoint{
+					UID:                  "endpoint-id-1",
+					Status:               datastore.PendingEndpointStatus,
+					VerificationRequired: true,
+				}
+				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).Times(1).Return(endpoint, nil)
+
+				project := &datastore.Project{UID: "project-id-1"}
+				o.EXPECT().FetchProjectByID(gomock.Any(), "project-id-1").Times(1).Return(project, nil)
+
+				licenser, _ := l.(*mocks.MockLicenser)
+				licenser.EXPECT().UseForwardProxy().Times(1).Return(true)
+				licenser.EXPECT().IpRules().Times(2).Return(true)
+			},
+		},
 		{
 			name:          "Endpoint is inactive",
 			cfgPath:       "./testdata/Config/basic-convoy.json",
@@ -114,6 +154,42 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 				licenser.EXPECT().IpRules().Times(2).Return(true)
 			},
 		},
+		{
+			name:          "Ordered delivery is blocked by an earlier delivery",
+			cfgPath:       "./testdata/Config/basic-convoy.json",
+			expectedError: &OrderingError{Err: ErrDeliveryBlocked, delay: defaultDelay},
+			msg: &datastore.EventDelivery{
+				UID: "",
+			},
+			dbFn: func(a *mocks.MockEndpointRepository, o *mocks.MockProjectRepository, m *mocks.MockEventDeliveryRepository, q *mocks.MockQueuer, r *mocks.MockRateLimiter, d *mocks.MockDeliveryAttemptsRepository, l license.Licenser) {
+				a.EXPECT().FindEndpointByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.Endpoint{
+						RateLimit:         10,
+						RateLimitDuration: 60,
+						Status:            datastore.ActiveEndpointStatus,
+						OrderedDelivery:   true,
+					}, nil)
+
+				o.EXPECT().FetchProjectByID(gomock.Any(), gomock.Any()).Return(&datastore.Project{Config: &datastore.DefaultProjectConfig}, nil)
+				m.EXPECT().
+					FindEventDeliveryByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						Status: datastore.RetryEventStatus,
+						Metadata: &datastore.Metadata{
+							Data:            []byte(`{"event": "invoice.completed"}`),
+							NumTrials:       0,
+							RetryLimit:      3,
+							IntervalSeconds: 20,
+						},
+					}, nil).Times(1)
+
+				m.EXPECT().HasPendingPredecessor(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+
+				licenser, _ := l.(*mocks.MockLicenser)
+				licenser.EXPECT().UseForwardProxy().Times(1).Return(true)
+				licenser.EXPECT().IpRules().Times(2).Return(true)
+			},
+		},
 		{
 			name:          "Endpoint does not respond with 2xx",
 			cfgPath:       "./testdata/Config/basic-convoy.json",
@@ -272,6 +348,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				licenser, _ := l.(*mocks.MockLicenser)
 				licenser.EXPECT().CircuitBreaking().Times(1).Return(false)
 				licenser.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -363,6 +441,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				licenser, _ := l.(*mocks.MockLicenser)
 				licenser.EXPECT().CircuitBreaking().Times(1).Return(false)
 				licenser.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -454,6 +534,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				licenser, _ := l.(*mocks.MockLicenser)
 				licenser.EXPECT().CircuitBreaking().Times(1).Return(false)
 				licenser.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -547,6 +629,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				licenser, _ := l.(*mocks.MockLicenser)
 				licenser.EXPECT().CircuitBreaking().Times(1).Return(false)
 				licenser.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -640,6 +724,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				licenser, _ := l.(*mocks.MockLicenser)
 				licenser.EXPECT().CircuitBreaking().Times(1).Return(false)
 				licenser.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -733,6 +819,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				licenser, _ := l.(*mocks.MockLicenser)
 				licenser.EXPECT().CircuitBreaking().Times(1).Return(false)
 				licenser.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -823,6 +911,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				licenser, _ := l.(*mocks.MockLicenser)
 				licenser.EXPECT().CircuitBreaking().Times(1).Return(false)
 				licenser.EXPECT().AdvancedEndpointMgmt().Times(1).Return(true)
@@ -914,6 +1004,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				q.EXPECT().
 					Write(convoy.NotificationProcessor, convoy.DefaultQueue, gomock.Any()).
 					Return(nil).Times(1)
@@ -1010,6 +1102,8 @@ func TestProcessRetryEventDelivery(t *testing.T) {
 					UpdateEventDeliveryMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 
+				q.EXPECT().Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, gomock.Any()).Times(1)
+
 				q.EXPECT().
 					Write(convoy.NotificationProcessor, convoy.DefaultQueue, gomock.Any()).
 					Return(nil).Times(1)
@@ -1198,3 +1292,141 @@ func TestProcessRetryEventDeliveryConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveStatusCodeAction(t *testing.T) {
+	project := &datastore.Project{
+		Config: &datastore.ProjectConfig{
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       datastore.LinearStrategyProvider,
+				Duration:   10,
+				RetryCount: 3,
+				StatusCodePolicies: datastore.StatusCodePolicies{
+					{StatusCodes: []int{410}, Action: datastore.DiscardStatusCodeAction},
+					{StatusCodes: []int{400, 422}, Action: datastore.FailStatusCodeAction},
+				},
+			},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		endpoint *datastore.Endpoint
+		resp     *net.Response
+		want     datastore.StatusCodeAction
+	}{
+		{
+			name:     "should_discard_using_project_policy",
+			endpoint: &datastore.Endpoint{},
+			resp:     &net.Response{StatusCode: 410},
+			want:     datastore.DiscardStatusCodeAction,
+		},
+		{
+			name:     "should_fail_using_project_policy",
+			endpoint: &datastore.Endpoint{},
+			resp:     &net.Response{StatusCode: 422},
+			want:     datastore.FailStatusCodeAction,
+		},
+		{
+			name: "should_prefer_endpoint_policy",
+			endpoint: &datastore.Endpoint{
+				StatusCodePolicies: datastore.StatusCodePolicies{
+					{StatusCodes: []int{422}, Action: datastore.RetryStatusCodeAction},
+				},
+			},
+			resp: &net.Response{StatusCode: 422},
+			want: datastore.RetryStatusCodeAction,
+		},
+		{
+			name:     "should_retry_unmatched_status_code",
+			endpoint: &datastore.Endpoint{},
+			resp:     &net.Response{StatusCode: 500},
+			want:     datastore.RetryStatusCodeAction,
+		},
+		{
+			name:     "should_retry_when_request_failed",
+			endpoint: &datastore.Endpoint{},
+			resp:     &net.Response{},
+			want:     datastore.RetryStatusCodeAction,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, resolveStatusCodeAction(tc.endpoint, project, tc.resp))
+		})
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "30")
+
+	tt := []struct {
+		name  string
+		resp  *net.Response
+		want  time.Duration
+		found bool
+	}{
+		{
+			name:  "should_honor_retry_after_on_429",
+			resp:  &net.Response{StatusCode: http.StatusTooManyRequests, ResponseHeader: header},
+			want:  30 * time.Second,
+			found: true,
+		},
+		{
+			name:  "should_honor_retry_after_on_503",
+			resp:  &net.Response{StatusCode: http.StatusServiceUnavailable, ResponseHeader: header},
+			want:  30 * time.Second,
+			found: true,
+		},
+		{
+			name: "should_ignore_retry_after_on_500",
+			resp: &net.Response{StatusCode: http.StatusInternalServerError, ResponseHeader: header},
+		},
+		{
+			name: "should_ignore_missing_header",
+			resp: &net.Response{StatusCode: http.StatusTooManyRequests, ResponseHeader: http.Header{}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			d, found := retryAfterDelay(tc.resp, 3600)
+			require.Equal(t, tc.found, found)
+			require.Equal(t, tc.want, d)
+		})
+	}
+}
+
+func TestSignEventDelivery(t *testing.T) {
+	endpoint := &datastore.Endpoint{
+		UID:     "endpoint-1",
+		Secrets: []datastore.Secret{{UID: "secret-1", Value: "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"}},
+	}
+	payload := json.RawMessage(`{"test": 2432232314}`)
+
+	t.Run("should_sign_with_convoy_scheme", func(t *testing.T) {
+		project := &datastore.Project{Config: &datastore.ProjectConfig{Signature: datastore.GetDefaultSignatureConfig()}}
+		eventDelivery := &datastore.EventDelivery{UID: "delivery-1"}
+
+		signatureHeader, header, err := signEventDelivery(endpoint, project, eventDelivery, payload, true)
+		require.NoError(t, err)
+		require.Equal(t, "X-Convoy-Signature", signatureHeader)
+		require.NotEmpty(t, header)
+		require.Nil(t, eventDelivery.Headers)
+	})
+
+	t.Run("should_sign_with_standard_webhooks_scheme", func(t *testing.T) {
+		project := &datastore.Project{Config: &datastore.ProjectConfig{
+			Signature: &datastore.SignatureConfiguration{Scheme: datastore.StandardWebhooksSignatureScheme},
+		}}
+		eventDelivery := &datastore.EventDelivery{UID: "delivery-1"}
+
+		signatureHeader, header, err := signEventDelivery(endpoint, project, eventDelivery, payload, true)
+		require.NoError(t, err)
+		require.Equal(t, "webhook-signature", signatureHeader)
+		require.True(t, strings.HasPrefix(header, "v1,"))
+		require.Equal(t, []string{"delivery-1"}, eventDelivery.Headers["webhook-id"])
+		require.Len(t, eventDelivery.Headers["webhook-timestamp"], 1)
+	})
+}
@@ -0,0 +1,79 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+)
+
+// scheduleTolerance absorbs clock drift between the api and worker
+// instances when checking if a scheduled event is due.
+const scheduleTolerance = time.Second
+
+// ProcessScheduledEvent dispatches a scheduled event to the create event queue
+// once it is due. Jobs for events that are no longer scheduled or that have been
+// moved to a later time are dropped.
+func ProcessScheduledEvent(scheduledEventRepo datastore.ScheduledEventRepository, q queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data ScheduledEvent
+
+		err := msgpack.DecodeMsgPack(t.Payload(), &data)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		scheduledEvent, err := scheduledEventRepo.FindScheduledEventByID(ctx, data.ProjectID, data.ScheduledEventID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrScheduledEventNotFound) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		if scheduledEvent.Status != datastore.ScheduleActiveStatus {
+			return nil
+		}
+
+		if scheduledEvent.DeliverAt.After(time.Now().Add(scheduleTolerance)) {
+			return nil
+		}
+
+		// the event is marked as dispatched before it is queued, the update only
+		// succeeds while it is still scheduled, so a cancelled event is never queued.
+		err = scheduledEventRepo.UpdateScheduledEventStatus(ctx, scheduledEvent.ProjectID, scheduledEvent.UID, datastore.ScheduleDispatchedStatus)
+		if err != nil {
+			if errors.Is(err, postgres.ErrScheduledEventNotUpdated) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		job := &queue.Job{
+			ID:      scheduledEvent.JobID(),
+			Payload: scheduledEvent.Payload,
+			Delay:   0,
+		}
+
+		err = q.Write(scheduledEvent.Kind.TaskName(), convoy.CreateEventQueue, job)
+		if err != nil {
+			restoreErr := scheduledEventRepo.RestoreScheduledEvent(ctx, scheduledEvent.ProjectID, scheduledEvent.UID)
+			if restoreErr != nil {
+				log.FromContext(ctx).WithError(restoreErr).Errorf("failed to restore scheduled event %s after it could not be queued", scheduledEvent.UID)
+			}
+
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessScheduledEvent(t *testing.T) {
+	errQueueFailed := errors.New("failed to queue")
+
+	tests := []struct {
+		name    string
+		dbFn    func(*mocks.MockScheduledEventRepository, *mocks.MockQueuer)
+		wantErr error
+	}{
+		{
+			name: "should_dispatch_due_event",
+			dbFn: func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindScheduledEventByID(gomock.Any(), "project-1", "scheduled-1").Times(1).Return(&datastore.ScheduledEvent{
+					UID:       "scheduled-1",
+					ProjectID: "project-1",
+					EventID:   "event-1",
+					Kind:      datastore.BroadcastScheduledEvent,
+					Payload:   []byte("payload"),
+					Status:    datastore.ScheduleActiveStatus,
+					DeliverAt: time.Now(),
+				}, nil)
+
+				q.EXPECT().Write(convoy.CreateBroadcastEventProcessor, convoy.CreateEventQueue, &queue.Job{
+					ID:      "broadcast:project-1:event-1",
+					Payload: []byte("payload"),
+				}).Times(1).Return(nil)
+
+				s.EXPECT().UpdateScheduledEventStatus(gomock.Any(), "project-1", "scheduled-1", datastore.ScheduleDispatchedStatus).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_not_queue_event_cancelled_while_dispatching",
+			dbFn: func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindScheduledEventByID(gomock.Any(), "project-1", "scheduled-1").Times(1).Return(&datastore.ScheduledEvent{
+					UID:       "scheduled-1",
+					ProjectID: "project-1",
+					Status:    datastore.ScheduleActiveStatus,
+					DeliverAt: time.Now(),
+				}, nil)
+
+				s.EXPECT().UpdateScheduledEventStatus(gomock.Any(), "project-1", "scheduled-1", datastore.ScheduleDispatchedStatus).Times(1).Return(postgres.ErrScheduledEventNotUpdated)
+			},
+		},
+		{
+			name: "should_restore_event_that_could_not_be_queued",
+			dbFn: func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindScheduledEventByID(gomock.Any(), "project-1", "scheduled-1").Times(1).Return(&datastore.ScheduledEvent{
+					UID:       "scheduled-1",
+					ProjectID: "project-1",
+					EventID:   "event-1",
+					Kind:      datastore.BroadcastScheduledEvent,
+					Status:    datastore.ScheduleActiveStatus,
+					DeliverAt: time.Now(),
+				}, nil)
+
+				s.EXPECT().UpdateScheduledEventStatus(gomock.Any(), "project-1", "scheduled-1", datastore.ScheduleDispatchedStatus).Times(1).Return(nil)
+				q.EXPECT().Write(convoy.CreateBroadcastEventProcessor, convoy.CreateEventQueue, gomock.Any()).Times(1).Return(errQueueFailed)
+				s.EXPECT().RestoreScheduledEvent(gomock.Any(), "project-1", "scheduled-1").Times(1).Return(nil)
+			},
+			wantErr: &EndpointError{Err: errQueueFailed, delay: defaultDelay},
+		},
+		{
+			name: "should_drop_cancelled_event",
+			dbFn: func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindScheduledEventByID(gomock.Any(), "project-1", "scheduled-1").Times(1).Return(&datastore.ScheduledEvent{
+					UID:       "scheduled-1",
+					ProjectID: "project-1",
+					Status:    datastore.ScheduleCancelledStatus,
+					DeliverAt: time.Now(),
+				}, nil)
+			},
+		},
+		{
+			name: "should_drop_rescheduled_event",
+			dbFn: func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindScheduledEventByID(gomock.Any(), "project-1", "scheduled-1").Times(1).Return(&datastore.ScheduledEvent{
+					UID:       "scheduled-1",
+					ProjectID: "project-1",
+					Status:    datastore.ScheduleActiveStatus,
+					DeliverAt: time.Now().Add(time.Hour),
+				}, nil)
+			},
+		},
+		{
+			name: "should_drop_missing_event",
+			dbFn: func(s *mocks.MockScheduledEventRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindScheduledEventByID(gomock.Any(), "project-1", "scheduled-1").Times(1).Return(nil, datastore.ErrScheduledEventNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			scheduledEventRepo := mocks.NewMockScheduledEventRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(scheduledEventRepo, q)
+			}
+
+			buf, err := msgpack.EncodeMsgPack(ScheduledEvent{ScheduledEventID: "scheduled-1", ProjectID: "project-1"})
+			require.NoError(t, err)
+
+			task := asynq.NewTask(string(convoy.ScheduledEventProcessor), buf, asynq.Queue(string(convoy.CreateEventQueue)))
+			err = ProcessScheduledEvent(scheduledEventRepo, q)(context.Background(), task)
+
+			require.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -54,6 +54,15 @@ func GetRetryDelay(n int, err error, t *asynq.Task) time.Duration {
 	if circuitBreakerError, ok := err.(*CircuitBreakerError); ok {
 		return circuitBreakerError.Delay()
 	}
+	if orderingError, ok := err.(*OrderingError); ok {
+		return orderingError.Delay()
+	}
+	if batchError, ok := err.(*BatchError); ok {
+		return batchError.Delay()
+	}
+	if verificationError, ok := err.(*VerificationError); ok {
+		return verificationError.Delay()
+	}
 
 	return asynq.DefaultRetryDelayFunc(n, err, t)
 }
@@ -67,6 +76,36 @@ type EventDelivery struct {
 	ProjectID       string
 }
 
+type ScheduledEvent struct {
+	ScheduledEventID string
+	ProjectID        string
+}
+
+type DeadLetter struct {
+	EventDeliveryID string
+	ProjectID       string
+	Reason          string
+	ResponseStatus  string
+	ResponseBody    string
+	ResponseError   string
+}
+
+type EndpointVerification struct {
+	EndpointID string
+	ProjectID  string
+	Attempt    int
+}
+
+type EndpointProbe struct {
+	EndpointID string
+	ProjectID  string
+}
+
+type RestApiSourcePoll struct {
+	SourceID  string
+	ProjectID string
+}
+
 type EventDeliveryConfig struct {
 	project      *datastore.Project
 	subscription *datastore.Subscription
@@ -118,3 +157,48 @@ func (e *CircuitBreakerError) Error() string {
 func (e *CircuitBreakerError) Delay() time.Duration {
 	return e.delay
 }
+
+// OrderingError is returned when an event delivery to an ordered endpoint
+// is blocked by deliveries created before it.
+type OrderingError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *OrderingError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OrderingError) Delay() time.Duration {
+	return e.delay
+}
+
+// BatchError is returned when an event delivery to a batching endpoint
+// is waiting for its batch to fill up.
+type BatchError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BatchError) Delay() time.Duration {
+	return e.delay
+}
+
+// VerificationError is returned when an event delivery's endpoint is still
+// awaiting verification of its url.
+type VerificationError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *VerificationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *VerificationError) Delay() time.Duration {
+	return e.delay
+}